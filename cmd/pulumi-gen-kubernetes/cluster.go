@@ -0,0 +1,113 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/gen"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// writeClusterClient generates an SDK for the extension APIs of the cluster of the context
+// `kubeContext` (or the current context) of the kubeconfig file `kubeconfig` (or of those `kubectl`
+// would use): the kinds of its CustomResourceDefinitions, and those of its aggregated APIs, i.e.,
+// of the APIServices served by a service of the cluster rather than by the API server itself.
+func writeClusterClient(
+	language string, data map[string]interface{}, templateDir, outdir, kubeconfig,
+	kubeContext string,
+) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfig
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	conf, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, overrides).ClientConfig()
+	if err != nil {
+		log.Fatalf("Failed to load kubeconfig: %v", err)
+	}
+	disco, pool, err := client.NewClients(conf)
+	if err != nil {
+		log.Fatalf("Failed to create clients for the cluster: %v", err)
+	}
+
+	crds := listClusterObjects(disco, pool, "apiextensions.k8s.io", "CustomResourceDefinition")
+	apiGroups := sets.NewString()
+	apiServices := listClusterObjects(disco, pool, "apiregistration.k8s.io", "APIService")
+	for _, apiService := range apiServices {
+		_, aggregated, _ := unstructured.NestedMap(apiService.Object, "spec", "service")
+		if aggregated {
+			group, _, _ := unstructured.NestedString(apiService.Object, "spec", "group")
+			apiGroups.Insert(group)
+		}
+	}
+
+	// The cluster's OpenAPI spec defines the kinds of its aggregated APIs.
+	spec, err := disco.RESTClient().Get().
+		AbsPath("/openapi/v2").
+		SetHeader("Accept", "application/json").
+		Do().Raw()
+	if err != nil {
+		log.Fatalf("Failed to get the OpenAPI spec of the cluster: %v", err)
+	}
+	clusterData := map[string]interface{}{}
+	err = json.Unmarshal(spec, &clusterData)
+	if err != nil {
+		log.Fatalf("Failed to parse the OpenAPI spec of the cluster: %v", err)
+	}
+
+	files, err := gen.ClusterClient(language, data, clusterData, crds, apiGroups.List(), templateDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeFiles(files, outdir)
+	fmt.Println(outdir)
+}
+
+// listClusterObjects returns the objects of `kind`, of the API `group`, on the cluster, listed with
+// the newest version of the group the cluster serves (each of which serves every object).
+func listClusterObjects(
+	disco discovery.CachedDiscoveryInterface, pool dynamic.ClientPool, group, kind string,
+) []*unstructured.Unstructured {
+	for _, version := range []string{"v1", "v1beta1"} {
+		gv := schema.GroupVersion{Group: group, Version: version}
+		if _, err := disco.ServerResourcesForGroupVersion(gv.String()); err != nil {
+			continue
+		}
+		objClient, err := client.FromGVK(pool, disco, gv.WithKind(kind), "")
+		if err != nil {
+			log.Fatalf("Failed to create a client for %s: %v", kind, err)
+		}
+		list, err := objClient.List(metav1.ListOptions{})
+		if err != nil {
+			log.Fatalf("Failed to list %s objects: %v", kind, err)
+		}
+
+		objs := []*unstructured.Unstructured{}
+		for i := range list.(*unstructured.UnstructuredList).Items {
+			objs = append(objs, &list.(*unstructured.UnstructuredList).Items[i])
+		}
+		return objs
+	}
+	return nil
+}
@@ -16,20 +16,49 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 
+	"github.com/ghodss/yaml"
 	"github.com/pulumi/pulumi-kubernetes/pkg/gen"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+var yamlSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// schemaFile matches the names of the specs of Kubernetes releases in the schemas directory (e.g.,
+// `swagger-v1.16.json`), capturing their minor versions.
+var schemaFile = regexp.MustCompile(`^swagger-v1\.([0-9]+)\.json$`)
+
 func main() {
-	if len(os.Args) < 4 {
-		log.Fatal("Usage: gen <swagger-file> <template-dir> <out-dir>")
+	schemaDir := flag.String("schemas", "",
+		"directory of the OpenAPI specs of past Kubernetes releases, from which generated kinds "+
+			"are tagged with the versions that introduce and remove them")
+	cluster := flag.Bool("cluster", false,
+		"generate an SDK for the CustomResourceDefinitions and aggregated APIs of a cluster, "+
+			"rather than for Kubernetes")
+	kubeconfig := flag.String("kubeconfig", "",
+		"kubeconfig file of the cluster (by default, the one kubectl uses)")
+	kubeContext := flag.String("context", "",
+		"kubeconfig context of the cluster (by default, the current context)")
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 4 || (*cluster && len(args) > 4) {
+		log.Fatal("Usage: gen [-schemas <schema-dir>] <language> <swagger-file> <template-dir> " +
+			"<out-dir> [<crd-file>...]\n" +
+			"       gen -cluster [-kubeconfig <file>] [-context <name>] [-schemas <schema-dir>] " +
+			"<language> <swagger-file> <template-dir> <out-dir>")
 	}
 
-	swagger, err := ioutil.ReadFile(os.Args[1])
+	language := args[0]
+
+	swagger, err := ioutil.ReadFile(args[1])
 	if err != nil {
 		panic(err)
 	}
@@ -39,14 +68,41 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	if *schemaDir != "" {
+		gen.AddVersionHistory(data, readSchemas(*schemaDir))
+	}
 
-	templateDir := os.Args[2]
+	templateDir := args[2]
+	outdir := args[3]
+	if *cluster {
+		writeClusterClient(language, data, templateDir, outdir, *kubeconfig, *kubeContext)
+		return
+	}
+	if crdFiles := args[4:]; len(crdFiles) > 0 {
+		writeCRDClient(language, data, templateDir, outdir, crdFiles)
+		return
+	}
+
+	switch language {
+	case "nodejs":
+		writeNodeJSClient(data, templateDir, outdir)
+	case "python":
+		writePythonClient(data, templateDir, outdir)
+	case "dotnet":
+		writeDotnetClient(data, templateDir, outdir)
+	case "go":
+		writeGoClient(data, templateDir, outdir)
+	default:
+		log.Fatalf("Unrecognized language '%s'", language)
+	}
+}
+
+func writeNodeJSClient(data map[string]interface{}, templateDir, outdir string) {
 	inputAPIts, ouputAPIts, providerts, helmts, packagejson, err := gen.NodeJSClient(data, templateDir)
 	if err != nil {
 		panic(err)
 	}
 
-	outdir := os.Args[3]
 	err = os.MkdirAll(outdir, 0700)
 	if err != nil {
 		panic(err)
@@ -85,3 +141,109 @@ func main() {
 	fmt.Printf("%s/package.json\n", outdir)
 	fmt.Println(err)
 }
+
+func writePythonClient(data map[string]interface{}, templateDir, outdir string) {
+	files, err := gen.PythonClient(data, templateDir)
+	if err != nil {
+		panic(err)
+	}
+	writeFiles(files, outdir)
+	fmt.Printf("%s/setup.py\n", outdir)
+}
+
+func writeDotnetClient(data map[string]interface{}, templateDir, outdir string) {
+	files, err := gen.DotnetClient(data, templateDir)
+	if err != nil {
+		panic(err)
+	}
+	writeFiles(files, outdir)
+	fmt.Printf("%s/Pulumi.Kubernetes.csproj\n", outdir)
+}
+
+func writeGoClient(data map[string]interface{}, templateDir, outdir string) {
+	files, err := gen.GoClient(data, templateDir)
+	if err != nil {
+		panic(err)
+	}
+	writeFiles(files, outdir)
+	fmt.Printf("%s/kubernetes\n", outdir)
+}
+
+// writeCRDClient writes an SDK of the kinds of custom resources defined by the
+// CustomResourceDefinitions in the YAML files `crdFiles`.
+func writeCRDClient(
+	language string, data map[string]interface{}, templateDir, outdir string, crdFiles []string,
+) {
+	var objs []*unstructured.Unstructured
+	for _, crdFile := range crdFiles {
+		text, err := ioutil.ReadFile(crdFile)
+		if err != nil {
+			panic(err)
+		}
+		for _, doc := range yamlSeparator.Split(string(text), -1) {
+			obj := map[string]interface{}{}
+			err = yaml.Unmarshal([]byte(doc), &obj)
+			if err != nil {
+				log.Fatalf("Failed to parse %s: %v", crdFile, err)
+			}
+			if len(obj) > 0 {
+				objs = append(objs, &unstructured.Unstructured{Object: obj})
+			}
+		}
+	}
+
+	files, err := gen.CRDClient(language, data, objs, templateDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	writeFiles(files, outdir)
+	fmt.Println(outdir)
+}
+
+// writeFiles writes the generated `files`, keyed by their paths relative to `outdir`.
+func writeFiles(files map[string]string, outdir string) {
+	for path, contents := range files {
+		path = filepath.Join(outdir, path)
+		err := os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			panic(err)
+		}
+
+		err = ioutil.WriteFile(path, []byte(contents), 0777)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// readSchemas returns the specs of the Kubernetes releases in `schemaDir`, by minor version.
+func readSchemas(schemaDir string) map[int]map[string]interface{} {
+	entries, err := ioutil.ReadDir(schemaDir)
+	if err != nil {
+		panic(err)
+	}
+
+	schemas := map[int]map[string]interface{}{}
+	for _, entry := range entries {
+		match := schemaFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		minor, err := strconv.Atoi(match[1])
+		if err != nil {
+			panic(err)
+		}
+
+		swagger, err := ioutil.ReadFile(filepath.Join(schemaDir, entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		schema := map[string]interface{}{}
+		err = json.Unmarshal(swagger, &schema)
+		if err != nil {
+			panic(err)
+		}
+		schemas[minor] = schema
+	}
+	return schemas
+}
@@ -0,0 +1,89 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// pulumi-kubernetes-agent is a small, authenticated reverse proxy that runs inside a cluster, and
+// forwards requests from the Kubernetes provider to the API server using its own ServiceAccount
+// credentials. See `pkg/client/agent.go`.
+package main
+
+import (
+	"crypto/subtle"
+	"flag"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"k8s.io/client-go/rest"
+)
+
+func main() {
+	listen := flag.String("listen", ":8443", "address to listen on")
+	certFile := flag.String("tls-cert", "", "serving certificate")
+	keyFile := flag.String("tls-key", "", "serving key")
+	insecure := flag.Bool("insecure", false,
+		"serve plain HTTP; the agent token is then sent in the clear, so use only for testing")
+	flag.Parse()
+
+	if (*certFile == "" || *keyFile == "") && !*insecure {
+		glog.Fatal("-tls-cert and -tls-key must be set (or -insecure, to serve plain HTTP)")
+	}
+
+	token := os.Getenv("PULUMI_AGENT_TOKEN")
+	if token == "" {
+		glog.Fatal("PULUMI_AGENT_TOKEN must be set")
+	}
+
+	conf, err := rest.InClusterConfig()
+	if err != nil {
+		glog.Fatalf("Unable to read in-cluster config: %v", err)
+	}
+	target, err := url.Parse(conf.Host)
+	if err != nil {
+		glog.Fatalf("Invalid API server address %q: %v", conf.Host, err)
+	}
+	transport, err := rest.TransportFor(conf)
+	if err != nil {
+		glog.Fatalf("Unable to configure API server transport: %v", err)
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = transport
+	// Flush periodically, so that watch events are streamed back to the provider promptly.
+	proxy.FlushInterval = 100 * time.Millisecond
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		given := req.Header.Get(client.AgentTokenHeader)
+		if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			http.Error(w, "invalid agent token", http.StatusUnauthorized)
+			return
+		}
+		// Never forward the provider's own credentials; the transport adds the agent's.
+		req.Header.Del(client.AgentTokenHeader)
+		req.Header.Del("Authorization")
+		proxy.ServeHTTP(w, req)
+	})
+
+	glog.Infof("Proxying requests on %s to %s", *listen, conf.Host)
+	if !*insecure {
+		err = http.ListenAndServeTLS(*listen, *certFile, *keyFile, handler)
+	} else {
+		glog.Warning("Serving plain HTTP; the agent token is not protected in transit")
+		err = http.ListenAndServe(*listen, handler)
+	}
+	glog.Fatal(err)
+}
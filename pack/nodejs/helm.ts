@@ -6,24 +6,64 @@ import * as pulumi from "@pulumi/pulumi";
 import * as shell from "shell-quote";
 import * as tmp from "tmp";
 import * as path from "./path";
+import * as placeholder from "./placeholder";
+import * as wave from "./wave";
 
 export namespace v2 {
-    export interface ChartOpts {
+    export interface BaseChartOpts {
+        namespace?: string;
+        // Values that override those of the chart's `values.yaml`, and of `valueFiles`. Any value
+        // can be an Input, e.g., an Output of another resource, or secret config, which remains
+        // secret in the resources the chart renders (see `placeholder.ts`).
+        values?: pulumi.Inputs;
+        // Paths of YAML files of values, which override those of the chart's `values.yaml`, and
+        // each other, in order (equivalent to `--values`).
+        valueFiles?: string[];
+        // How to handle the objects the chart annotates as Helm hooks. Defaults to "ordered".
+        hooks?: HookMode;
+        transformations?: k8s.yaml.Transformation[];
+    }
+
+    // HookMode is how a Chart handles the objects annotated as Helm hooks (`helm.sh/hook`):
+    //
+    //   * "ordered": the hooks Helm runs on install and upgrade are deployed in the order it runs
+    //     them: `pre-install` and `pre-upgrade` hooks before the rest of the chart, `post-install`
+    //     and `post-upgrade` hooks after it, each ordered by `helm.sh/hook-weight` (see `wave.ts`).
+    //     Other hooks (e.g., `test`, or `pre-delete`) are omitted, since nothing would run them.
+    //   * "include": hooks are deployed as ordinary resources, without their hook annotations.
+    //   * "skip": hooks are omitted.
+    //
+    // Either way, hooks that are deployed are managed like any other resource; in particular, they
+    // are not deleted according to `helm.sh/hook-delete-policy`.
+    export type HookMode = "ordered" | "include" | "skip";
+
+    // ChartOpts specifies a chart to fetch from a chart repository.
+    export interface ChartOpts extends BaseChartOpts {
         repo: string;
         chart: string;
         version: string;
 
-        namespace?: string;
-        values?: any;
-        transformations?: ((o: any) => void)[];
+        // Additional options to fetch the chart with (e.g., credentials of the repository).
         fetchOpts?: FetchOpts;
     }
 
+    // LocalChartOpts specifies a chart in a local directory (e.g., one checked in alongside the
+    // program), which is rendered as is.
+    export interface LocalChartOpts extends BaseChartOpts {
+        path: string;
+    }
+
+    function isChartOpts(o: any): o is ChartOpts {
+        return "chart" in o;
+    }
+
     // Chart is a component representing a collection of resources described by an arbitrary Helm
     // Chart. The Chart can be fetched from any source that is accessible to the `helm` command
-    // line. Values in the `values.yml` file can be overridden using `ChartOpts.values` (equivalent
-    // to `--set` or having multiple `values.yml` files). Objects can be tranformed arbitrarily by
-    // supplying callbacks to `ChartOpts.transformations`.
+    // line, or read from a local directory. Each object the Chart renders is managed as a resource
+    // of its own, which is awaited and diffed like any other. Values in the `values.yml` file can
+    // be overridden using `ChartOpts.values` (equivalent to `--set` or having multiple
+    // `values.yml` files). Objects can be tranformed arbitrarily by supplying callbacks to
+    // `ChartOpts.transformations`.
     //
     // `Chart` does not use Tiller. The Chart specified is copied and expanded locally; any values
     // that would be retrieved in-cluster would be assigned fake values, and none of Tiller's
@@ -33,7 +73,10 @@ export namespace v2 {
     // unlike a "normal" Pulumi program, updating a ConfigMap does not trigger a cascading update
     // among Deployments that reference it.
     export class Chart extends k8s.yaml.CollectionComponentResource {
-        constructor(releaseName: string, config: ChartOpts, opts?: pulumi.ComponentResourceOptions) {
+        constructor(
+            releaseName: string, config: ChartOpts | LocalChartOpts,
+            opts?: pulumi.ComponentResourceOptions,
+        ) {
             super("kubernetes:helm.sh/v2:Chart", releaseName, config, opts);
 
             // Create temporary directories and files to hold chart data and override values.
@@ -41,12 +84,20 @@ export namespace v2 {
             const chartDir = tmp.dirSync({unsafeCleanup: true});
 
             try {
-                // Fetch chart.
-                fetch(`${config.repo}/${config.chart}`,
-                    {destination: chartDir.name, version: config.version});
+                // Fetch chart, unless it is local.
+                let chart: string;
+                if (isChartOpts(config)) {
+                    fetch(`${config.repo}/${config.chart}`,
+                        {...config.fetchOpts, destination: chartDir.name, version: config.version});
+                    chart = `${shell.quote([chartDir.name])}/${shell.quote([config.chart])}`;
+                } else {
+                    chart = path.quotePath(config.path);
+                }
 
                 // Write overrides file.
-                const data = JSON.stringify(config.values || {}, undefined, "  ");
+                const placeholders = new placeholder.Placeholders();
+                const data = JSON.stringify(
+                    placeholders.replace(config.values || {}), undefined, "  ");
                 fs.writeFileSync(overrides.name, data);
 
                 // Does not require Tiller. From the `helm template` documentation:
@@ -57,16 +108,19 @@ export namespace v2 {
                 // > looked up or retrieved in-cluster will be faked locally. Additionally, none
                 // > of the server-side testing of chart validity (e.g. whether an API is supported)
                 // > is done.
-                const chart = `${shell.quote([chartDir.name])}/${shell.quote([config.chart])}`;
                 const release = shell.quote([releaseName]);
-                const values = shell.quote([overrides.name]);
+                const values = valuesFlags(config.valueFiles, overrides.name);
                 const namespaceArg = config.namespace ? `--namespace ${shell.quote([config.namespace])}` : "";
                 const yamlStream = execSync(
-                    `helm template ${chart} --name ${release} --values ${values} ${namespaceArg}`
+                    `helm template ${chart} --name ${release} ${values} ${namespaceArg}`
                 ).toString();
                 this.resources = k8s.yaml.parse({
                     yaml: [yamlStream],
-                    transformations: config.transformations || [],
+                    transformations: [
+                        placeholders.substitute,
+                        hookTransformation(config.hooks),
+                        ...(config.transformations || []),
+                    ],
                 }, { parent: this });
             } catch (e) {
                 // Shed stack trace, only emit the error.
@@ -78,6 +132,141 @@ export namespace v2 {
             }
         }
     }
+
+    // hookTransformation returns the transformation that handles hooks as `mode` specifies.
+    export function hookTransformation(mode: HookMode = "ordered"): k8s.yaml.Transformation {
+        return (obj: any) => {
+            const hooks = wave.hooks(obj);
+            if (hooks.length === 0) {
+                return true;
+            }
+            switch (mode) {
+                case "skip":
+                    return false;
+                case "include":
+                    for (const key of wave.hookAnnotations) {
+                        delete obj.metadata.annotations[key];
+                    }
+                    return true;
+                default:
+                    return hooks.some(h => wave.installHooks.indexOf(h) >= 0);
+            }
+        };
+    }
+
+    // valuesFlags returns the `--values` flags for `valueFiles`, followed by `overrides`, which
+    // takes precedence over them.
+    export function valuesFlags(valueFiles: string[] | undefined, overrides: string): string {
+        const files = [...(valueFiles || []), overrides];
+        return files.map(f => `--values ${path.quotePath(f)}`).join(" ");
+    }
+}
+
+export namespace v3 {
+    // ChartOpts specifies a Helm 3 chart to fetch. `chart` is either the name of a chart in `repo`,
+    // a reference to a chart in a repository added with `helm repo add` (e.g., "stable/redis"), or
+    // the reference of a chart in an OCI registry (e.g., "oci://registry.example.com/charts/redis").
+    export interface ChartOpts extends v2.BaseChartOpts {
+        chart: string;
+        // The URL of the chart repository (e.g., "https://charts.bitnami.com/bitnami").
+        repo?: string;
+        // The version of the chart. Without this, the latest version is used.
+        version?: string;
+
+        // Additional options to fetch the chart with (e.g., credentials of the repository). Only
+        // the options `helm template` supports (i.e., not `destination`, `untar`, `untardir`,
+        // `home`, or `prov`) apply.
+        fetchOpts?: FetchOpts;
+    }
+
+    // LocalChartOpts specifies a Helm 3 chart in a local directory. The dependencies its
+    // `Chart.yaml` declares are fetched before it is rendered.
+    export interface LocalChartOpts extends v2.BaseChartOpts {
+        path: string;
+    }
+
+    function isChartOpts(o: any): o is ChartOpts {
+        return "chart" in o;
+    }
+
+    // Chart is a component representing a collection of resources described by a Helm 3 chart
+    // (i.e., a chart whose `Chart.yaml` has `apiVersion: v2`, including charts that depend on
+    // library charts). Like `v2.Chart`, it renders the chart locally with `helm template`, which
+    // requires the Helm 3 CLI, and manages each object it renders as a resource of its own. Helm 3
+    // has no Tiller, so nothing is installed in the cluster; unlike `v2.Chart`, the CRDs in the
+    // `crds/` directory of the chart are included.
+    export class Chart extends k8s.yaml.CollectionComponentResource {
+        constructor(
+            releaseName: string, config: ChartOpts | LocalChartOpts,
+            opts?: pulumi.ComponentResourceOptions,
+        ) {
+            super("kubernetes:helm.sh/v3:Chart", releaseName, config, opts);
+
+            // Create a temporary file to hold override values.
+            const overrides = tmp.fileSync({postfix: ".yaml"});
+
+            try {
+                const flags: string[] = [];
+                let chart: string;
+                if (isChartOpts(config)) {
+                    chart = shell.quote([config.chart]);
+                    if (config.repo !== undefined)    { flags.push(`--repo ${shell.quote([config.repo])}`);       }
+                    if (config.version !== undefined) { flags.push(`--version ${shell.quote([config.version])}`); }
+                    flags.push(...templateFetchFlags(config.fetchOpts));
+                } else {
+                    chart = path.quotePath(config.path);
+                    execSync(`helm dependency build ${chart}`);
+                }
+
+                // Write overrides file.
+                const placeholders = new placeholder.Placeholders();
+                const data = JSON.stringify(
+                    placeholders.replace(config.values || {}), undefined, "  ");
+                fs.writeFileSync(overrides.name, data);
+                flags.push(v2.valuesFlags(config.valueFiles, overrides.name));
+
+                if (config.namespace !== undefined) {
+                    flags.push(`--namespace ${shell.quote([config.namespace])}`);
+                }
+
+                const release = shell.quote([releaseName]);
+                const yamlStream = execSync(
+                    `helm template ${release} ${chart} --include-crds ${flags.join(" ")}`
+                ).toString();
+                this.resources = k8s.yaml.parse({
+                    yaml: [yamlStream],
+                    transformations: [
+                        placeholders.substitute,
+                        v2.hookTransformation(config.hooks),
+                        ...(config.transformations || []),
+                    ],
+                }, { parent: this });
+            } catch (e) {
+                // Shed stack trace, only emit the error.
+                throw new pulumi.RunError(e.toString());
+            } finally {
+                // Clean up temporary files.
+                overrides.removeCallback()
+            }
+        }
+    }
+
+    // templateFetchFlags returns the flags of `helm template` that correspond to `opts`.
+    function templateFetchFlags(opts?: FetchOpts): string[] {
+        const flags: string[] = [];
+        if (opts === undefined) {
+            return flags;
+        }
+        if (opts.caFile !== undefined)   { flags.push(`--ca-file ${path.quotePath(opts.caFile)}`);     }
+        if (opts.certFile !== undefined) { flags.push(`--cert-file ${path.quotePath(opts.certFile)}`); }
+        if (opts.keyFile !== undefined)  { flags.push(`--key-file ${path.quotePath(opts.keyFile)}`);   }
+        if (opts.keyring !== undefined)  { flags.push(`--keyring ${path.quotePath(opts.keyring)}`);    }
+        if (opts.password !== undefined) { flags.push(`--password ${shell.quote([opts.password])}`);  }
+        if (opts.username !== undefined) { flags.push(`--username ${shell.quote([opts.username])}`);  }
+        if (opts.devel === true)         { flags.push(`--devel`);                                     }
+        if (opts.verify === true)        { flags.push(`--verify`);                                    }
+        return flags;
+    }
 }
 
 export interface FetchOpts {
@@ -1,3 +1,4 @@
 export * from "./provider";
 import * as helm from "./helm";
-export { helm };
+import * as kustomize from "./kustomize";
+export { helm, kustomize };
@@ -0,0 +1,38 @@
+import { execSync } from "child_process";
+
+import * as k8s from "./index";
+import * as pulumi from "@pulumi/pulumi";
+import * as path from "./path";
+
+export interface DirectoryOpts {
+    // The directory that contains the `kustomization.yaml` to build. This is either a local path,
+    // or a git URL in any form `kustomize build` accepts (e.g.,
+    // "https://github.com/kubernetes-sigs/kustomize//examples/helloWorld?ref=v3.3.1").
+    directory: string;
+
+    transformations?: k8s.yaml.Transformation[];
+}
+
+// Directory is a component representing the collection of resources built by kustomize from a
+// kustomization (e.g., an overlay of an existing base), so that existing kustomizations can be
+// deployed with Pulumi without being rewritten. Each object kustomize builds is managed as a
+// resource of its own, which is awaited and diffed like any other, and objects can be transformed
+// arbitrarily by supplying callbacks to `DirectoryOpts.transformations`.
+//
+// The kustomization is built locally with the `kustomize` command line, which must be installed.
+export class Directory extends k8s.yaml.CollectionComponentResource {
+    constructor(name: string, config: DirectoryOpts, opts?: pulumi.ComponentResourceOptions) {
+        super("kubernetes:kustomize:Directory", name, config, opts);
+
+        try {
+            const yamlStream = execSync(`kustomize build ${path.quotePath(config.directory)}`).toString();
+            this.resources = k8s.yaml.parse({
+                yaml: [yamlStream],
+                transformations: config.transformations || [],
+            }, { parent: this });
+        } catch (e) {
+            // Shed stack trace, only emit the error.
+            throw new pulumi.RunError(e.toString());
+        }
+    }
+}
@@ -0,0 +1,88 @@
+import * as crypto from "crypto";
+import * as pulumi from "@pulumi/pulumi";
+
+// Chart values that are Inputs.
+//
+// The values of a Helm chart can be Inputs: Outputs of other resources, or secret config, e.g.,
+//
+//     values: {auth: {password: config.requireSecret("redisPassword")}}
+//
+// Charts are rendered synchronously, before such values are known, so each one is rendered as a
+// unique placeholder string, for which the value is substituted in the objects the chart renders.
+// The resources of those objects then depend on the value like on any other Input, and a secret
+// value stays secret: it is encrypted in state, and redacted in diffs.
+//
+// Templates can use a placeholder as is, quote it, or base64 encode it (e.g., `b64enc`, as for the
+// data of a Secret), but can't otherwise compute with it (e.g., hash it with `sha256sum`, or test
+// its value); where a placeholder the templates transformed can be recognized, it is an error.
+// Values that are plain (i.e., not Outputs or Promises) are rendered as usual.
+
+export class Placeholders {
+    private readonly prefix = `__pulumi_${crypto.randomBytes(8).toString("hex")}_`;
+    private readonly pattern = new RegExp(`${this.prefix}\\d+__`, "g");
+    private readonly inputs = new Map<string, pulumi.Input<any>>();
+    private readonly encoded = new Map<string, pulumi.Input<any>>();
+
+    // replace returns a copy of `values` in which each Output or Promise is replaced by a
+    // placeholder.
+    public replace(values: any): any {
+        if (pulumi.Output.isInstance(values) || values instanceof Promise) {
+            const placeholder = `${this.prefix}${this.inputs.size}__`;
+            this.inputs.set(placeholder, values);
+            this.encoded.set(Buffer.from(placeholder).toString("base64"), values);
+            return placeholder;
+        }
+        if (Array.isArray(values)) {
+            return values.map(v => this.replace(v));
+        }
+        if (values !== null && typeof values === "object") {
+            const replaced: any = {};
+            for (const key of Object.keys(values)) {
+                replaced[key] = this.replace(values[key]);
+            }
+            return replaced;
+        }
+        return values;
+    }
+
+    // substitute is a transformation that substitutes the values for the placeholders in `obj`.
+    public readonly substitute = (obj: any): void => {
+        this.substituteValue(obj);
+    }
+
+    private substituteValue(v: any): any {
+        if (typeof v === "string") {
+            return this.substituteString(v);
+        }
+        if (v !== null && typeof v === "object" && !pulumi.Output.isInstance(v)) {
+            for (const key of Object.keys(v)) {
+                v[key] = this.substituteValue(v[key]);
+            }
+        }
+        return v;
+    }
+
+    private substituteString(s: string): any {
+        if (this.inputs.has(s)) {
+            return this.inputs.get(s);
+        }
+        if (this.encoded.has(s)) {
+            return pulumi.output(this.encoded.get(s)).apply(
+                value => Buffer.from(String(value)).toString("base64"));
+        }
+
+        const placeholders = s.match(this.pattern) || [];
+        if (s.replace(this.pattern, "").toLowerCase().indexOf(this.prefix) >= 0) {
+            throw new Error(
+                `a chart template transformed a value that is an Output (in "${s}"), which ` +
+                `isn't supported; pass the value as a plain value instead`);
+        }
+        if (placeholders.length == 0) {
+            return s;
+        }
+        return pulumi.all(placeholders.map(p => this.inputs.get(p))).apply(values => {
+            let i = 0;
+            return s.replace(this.pattern, () => String(values[i++]));
+        });
+    }
+}
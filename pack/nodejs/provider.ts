@@ -8,17 +8,39 @@ import * as inputApi from "./types/input";
 import * as outputApi from "./types/output";
 import * as jsyaml from "js-yaml";
 import * as glob from "glob";
+import * as remote from "./remote";
+import * as transform from "./transform";
+import * as wave from "./wave";
 
 export namespace yaml {
+    /**
+     * A Transformation is applied to each object of a ConfigFile, ConfigGroup, Chart, or kustomize
+     * Directory before it is registered as a resource, e.g., to set its namespace, add labels, or
+     * patch its images. It modifies the object in place, and may return `false` to omit the object
+     * altogether (see `transform.ts`).
+     */
+    export type Transformation = transform.Transformation;
+
     export interface ConfigGroupOpts {
         files?: string[] | string;
+        // The sha256 checksums ("sha256:<hex>") that pin the contents of remote `files`, by URL.
+        checksums?: {[url: string]: string};
         yaml?: string[] | string;
-        transformations?: ((o: any) => void)[];
+        objs?: any[] | any;
+        transformations?: Transformation[];
     }
 
     export interface ConfigOpts {
         objs: any[];
-        transformations?: ((o: any) => void)[];
+        transformations?: Transformation[];
+    }
+
+    export interface ConfigFileOpts {
+        // The path of the YAML file to read. Defaults to the name of the `ConfigFile`.
+        file?: string;
+        // The sha256 checksum ("sha256:<hex>") that pins the contents of a remote `file`.
+        checksum?: string;
+        transformations?: Transformation[];
     }
 
     export function parse(
@@ -26,24 +48,19 @@ export namespace yaml {
     ): {[key: string]: pulumi.CustomResource} {
         let resources: {[key: string]: pulumi.CustomResource} = {};
 
+        // Collect the objects of each file and YAML text, and the objects given directly.
+        const docs: {file?: string, objs: any[]}[] = [];
         if (config.files !== undefined) {
             let files: string[] = [];
-            if (typeof config.files === 'string') {
-                files = glob.sync(config.files);
-            } else {
-                for (const file of config.files) {
-                    files.push(...glob.sync(file));
-                }
+            const patterns = typeof config.files === 'string' ? [config.files] : config.files;
+            for (const pattern of patterns) {
+                files.push(...(remote.isURL(pattern) ? [pattern] : glob.sync(pattern)));
             }
 
+            const checksums = config.checksums || {};
             for (const file of files) {
-                const text = fs.readFileSync(file).toString();
-                const objs = jsyaml.safeLoadAll(text);
-                const cf = new ConfigFile(file,
-                    {objs: objs, transformations: config.transformations}, opts);
-                if (cf != null) {
-                    resources = {...resources, ...cf.resources}
-                }
+                const text = readFile(file, checksums[file]);
+                docs.push({file: file, objs: jsyaml.safeLoadAll(text)});
             }
         }
 
@@ -56,13 +73,26 @@ export namespace yaml {
             }
 
             for (const text of yamlTexts) {
-                const objs = jsyaml.safeLoadAll(text);
-                const docResources = parseYamlDocument(
-                    {objs: objs, transformations: config.transformations}, opts);
-                resources = {...resources, ...docResources};
+                docs.push({objs: jsyaml.safeLoadAll(text)});
             }
         }
 
+        if (config.objs !== undefined) {
+            docs.push({objs: Array.isArray(config.objs) ? config.objs : [config.objs]});
+        }
+
+        // Parse the documents that define CRDs first, so that the custom resources in the others
+        // can depend on them (see `parseYamlDocument`).
+        const definesKinds = (doc: {objs: any[]}) =>
+            doc.objs.some(obj => wave.definedKind(obj) !== undefined);
+        for (const doc of [...docs.filter(definesKinds), ...docs.filter(d => !definesKinds(d))]) {
+            const docConfig = {objs: doc.objs, transformations: config.transformations};
+            const docResources = doc.file !== undefined
+                ? new ConfigFile(doc.file, docConfig, opts).resources
+                : parseYamlDocument(docConfig, opts);
+            resources = {...resources, ...docResources};
+        }
+
         return resources;
     }
 
@@ -752,8 +782,17 @@ export namespace yaml {
      *   3. Using a literal string containing YAML, or a list of such strings:
      *        a. `{yaml: "(LITERAL YAML HERE)"}`
      *        b. `{yaml: ["(LITERAL YAML HERE)", "(MORE YAML)"]}`
-     *   4. Any combination of files, patterns, or YAML strings:
-     *        a. `{files: "foo.yaml", yaml: "(LITERAL YAML HERE)"}`
+     *   4. Using an object, or a list of objects, that is already parsed:
+     *        a. `{objs: {apiVersion: "v1", kind: "Namespace", metadata: {name: "foo"}}}`
+     *        b. `{objs: [namespace, deployment]}`
+     *   5. Using the URL of a remote file, optionally pinned by checksum (see `remote.ts`):
+     *        a. `{files: "https://example.com/install.yaml"}`
+     *        b. `{files: url, checksums: {[url]: "sha256:(HEX DIGEST)"}}`
+     *   6. Any combination of files, patterns, URLs, YAML strings, or objects:
+     *        a. `{files: "foo.yaml", yaml: "(LITERAL YAML HERE)", objs: [namespace]}`
+     *
+     * Each object is managed as a resource of its own. Use `getResource` to look one up, e.g., to
+     * depend on it: `group.getResource("apps/v1/Deployment", "default", "nginx")`.
      */
     export class ConfigGroup extends CollectionComponentResource {
         constructor(name: string, config: ConfigGroupOpts, opts?: pulumi.ComponentResourceOptions) {
@@ -762,22 +801,89 @@ export namespace yaml {
         }
     }
 
+    /**
+     * ConfigFile is a component representing the objects in a YAML file, e.g., one written for
+     * `kubectl apply -f`, which may contain several documents. Each object is managed as a resource
+     * of its own, which is awaited and diffed like any other. For example:
+     *
+     *     new k8s.yaml.ConfigFile("guestbook", {file: "guestbook.yaml"});
+     *
+     * The file can also be the URL of a remote manifest, which may be pinned by its checksum:
+     *
+     *     new k8s.yaml.ConfigFile("operator", {
+     *         file: "https://example.com/releases/v1.0.0/install.yaml",
+     *         checksum: "sha256:(HEX DIGEST)",
+     *     });
+     *
+     * The objects can also be given directly, as `{objs: [...]}`.
+     */
     export class ConfigFile extends CollectionComponentResource {
-        constructor(name: string, config: ConfigOpts, opts?: pulumi.ComponentResourceOptions) {
+        constructor(
+            name: string, config?: ConfigFileOpts | ConfigOpts, opts?: pulumi.ComponentResourceOptions,
+        ) {
             super("kubernetes:yaml:ConfigFile", name, config, opts);
-            this.resources = parseYamlDocument(config, {parent: this});
+            if (config !== undefined && "objs" in config) {
+                this.resources = parseYamlDocument(config, {parent: this});
+                return;
+            }
+
+            const fileOpts = <ConfigFileOpts>(config || {});
+            const text = readFile(fileOpts.file || name, fileOpts.checksum);
+            this.resources = parseYamlDocument({
+                objs: jsyaml.safeLoadAll(text),
+                transformations: fileOpts.transformations,
+            }, {parent: this});
         }
     }
 
+    // readFile returns the contents of a YAML file, which may be local or remote.
+    function readFile(file: string, checksum?: string): string {
+        return remote.isURL(file) ? remote.read(file, checksum) : fs.readFileSync(file).toString();
+    }
+
+    // customResourceDefinitions are the CRD resources created so far, by the kind they define.
+    const customResourceDefinitions: {[groupKind: string]: pulumi.Resource} = {};
+
+    // parseYamlDocument creates a resource for each object in the document. Namespaces and CRDs are
+    // deployed first, admission webhook configurations last, and objects annotated with Helm hook
+    // weights or Argo CD sync waves in that order: every resource in a wave depends on all the
+    // resources of the preceding wave (see `wave.ts`). Custom resources also depend on the CRD of
+    // their kind, if it was created earlier in the program, e.g., by another document.
     function parseYamlDocument(
         config: ConfigOpts, opts?: pulumi.CustomResourceOptions,
     ):  {[key: string]: pulumi.CustomResource} {
         let resources: {[key: string]: pulumi.CustomResource} = {};
 
-        for (const obj of config.objs) {
-            const fileObject = parseYamlObject(obj, config.transformations, opts);
-            if (fileObject != null) {
-                resources[fileObject.name] = fileObject.resource;
+        // Transform objects first, so that transformations can set ordering annotations.
+        const objs = transform.transform(config.objs, config.transformations);
+
+        const dependsOn: pulumi.Resource[] = (opts && opts.dependsOn)
+            ? (<pulumi.Resource[]>[]).concat(<any>opts.dependsOn)
+            : [];
+        let previousWave: pulumi.Resource[] = [];
+        for (const waveObjs of wave.groupByWave(objs)) {
+            const waveOpts = previousWave.length > 0
+                ? {...opts, dependsOn: [...dependsOn, ...previousWave]}
+                : opts;
+            const currentWave: pulumi.Resource[] = [];
+            for (const obj of waveObjs) {
+                const crd = customResourceDefinitions[wave.groupKind(obj)];
+                const objOpts = crd !== undefined
+                    ? {...waveOpts, dependsOn: [...dependsOn, ...previousWave, crd]}
+                    : waveOpts;
+                const fileObject = parseYamlObject(obj, undefined, objOpts);
+                if (fileObject != null) {
+                    resources[fileObject.name] = fileObject.resource;
+                    currentWave.push(fileObject.resource);
+
+                    const kind = wave.definedKind(obj);
+                    if (kind !== undefined) {
+                        customResourceDefinitions[kind] = fileObject.resource;
+                    }
+                }
+            }
+            if (currentWave.length > 0) {
+                previousWave = currentWave;
             }
         }
 
@@ -785,14 +891,16 @@ export namespace yaml {
     }
 
     function parseYamlObject(
-        obj: any, transformations?: ((o: any) => void)[], opts?: pulumi.CustomResourceOptions,
+        obj: any, transformations?: Transformation[], opts?: pulumi.CustomResourceOptions,
     ): {name: string, resource: pulumi.CustomResource} | null {
         if (obj == null || Object.keys(obj).length == 0) {
             return null;
         }
 
         for (const t of transformations || []) {
-            t(obj);
+            if (t(obj) === false) {
+                return null;
+            }
         }
 
         const kind = obj["kind"];
@@ -1636,10 +1744,24 @@ export class Provider extends pulumi.ProviderResource {
      */
     constructor(name: string, args: ProviderArgs, opts?: pulumi.ResourceOptions) {
         let inputs: pulumi.Inputs = {
+            "agent": args ? args.agent : undefined,
             "cluster": args ? args.cluster : undefined,
+            "compactStateThreshold": args ? args.compactStateThreshold : undefined,
             "context": args ? args.context : undefined,
+            "deletionPropagationPolicy": args ? args.deletionPropagationPolicy : undefined,
+            "enableDryRun": args ? args.enableDryRun : undefined,
+            "enableServerSideApply": args ? args.enableServerSideApply : undefined,
+            "endpointSettleSeconds": args ? args.endpointSettleSeconds : undefined,
+            "fieldManager": args ? args.fieldManager : undefined,
             "kubeconfig": args ? args.kubeconfig : undefined,
             "namespace": args ? args.namespace : undefined,
+            "offlineKubernetesVersion": args ? args.offlineKubernetesVersion : undefined,
+            "plaintextSecretData": args ? args.plaintextSecretData : undefined,
+            "renderYamlDiff": args ? args.renderYamlDiff : undefined,
+            "renderYamlToDirectory": args ? args.renderYamlToDirectory : undefined,
+            "retryPolicy": args ? args.retryPolicy : undefined,
+            "suppressAwait": args ? args.suppressAwait : undefined,
+            "timeouts": args ? args.timeouts : undefined,
         };
         super("kubernetes", name, inputs, opts);
     }
@@ -1650,21 +1772,230 @@ export class Provider extends pulumi.ProviderResource {
  */
 export interface ProviderArgs {
     /**
-     * If present, the name of the kubeconfig cluster to use.
+     * If present, all requests to the cluster are sent through an in-cluster agent
+     * (`pulumi-kubernetes-agent`) instead of directly to the API server. Use this to manage clusters
+     * whose API servers aren't reachable from where Pulumi runs.
+     */
+    readonly agent?: pulumi.Input<ProviderAgent>;
+    /**
+     * If present, the name of the kubeconfig cluster to use, overriding the cluster of the selected
+     * context.
      */
     readonly cluster?: pulumi.Input<string>;
     /**
-     * If present, the name of the kubeconfig context to use.
+     * If present, live objects larger than this many bytes (e.g., very large ConfigMaps) are stored
+     * in the checkpoint as a content hash rather than in full. Drift is still detected on refresh by
+     * comparing hashes. Note that the outputs of such resources will not include their contents.
+     */
+    readonly compactStateThreshold?: pulumi.Input<number>;
+    /**
+     * If present, the name of the kubeconfig context to use, rather than the current context.
+     * Use this to target several clusters in one program, with one provider each.
      */
     readonly context?: pulumi.Input<string>;
     /**
-     * The contents of a kubeconfig file. If this is set, this config will be used instead of $KUBECONFIG.
+     * If present, overrides how the deletion of objects propagates to their dependents (e.g., the
+     * ReplicaSets and Pods of a Deployment): "Foreground" (the default) waits for the dependents to
+     * be deleted, "Background" lets the garbage collector delete them after the object is gone, and
+     * "Orphan" leaves them behind. The `pulumi.com/deletionPropagationPolicy` annotation still takes
+     * precedence for individual objects.
+     */
+    readonly deletionPropagationPolicy?: pulumi.Input<string>;
+    /**
+     * If true, previews render a YAML diff of each updated object (as with `renderYamlDiff`)
+     * against the object computed by a server-side dry run of the update, so that they show
+     * exactly what the API server, including its admission webhooks, would store. If the dry run
+     * fails (e.g., because a webhook would reject the update), the preview reports why. Requires
+     * Kubernetes 1.13 or later.
+     */
+    readonly enableDryRun?: pulumi.Input<boolean>;
+    /**
+     * If true, objects are created and updated with server-side apply, rather than with
+     * client-side patches. The API server then tracks which fields Pulumi manages, removes fields
+     * Pulumi stops setting, and reports an error naming the other manager (e.g., `kubectl`) when
+     * Pulumi would overwrite a field set by someone else. Requires Kubernetes 1.16 or later.
+     */
+    readonly enableServerSideApply?: pulumi.Input<boolean>;
+    /**
+     * If present, overrides how many seconds to wait after the last change to the endpoints of a
+     * Service before considering them settled (10 by default). The
+     * `pulumi.com/endpointSettleSeconds` annotation still takes precedence for individual Services.
+     */
+    readonly endpointSettleSeconds?: pulumi.Input<number>;
+    /**
+     * If present, the name of the field manager to which the changes Pulumi makes to objects are
+     * attributed in their `managedFields` ("pulumi-kubernetes" by default), so that stacks or tools
+     * sharing an object can be told apart, and server-side apply conflicts name the stack at fault.
+     */
+    readonly fieldManager?: pulumi.Input<string>;
+    /**
+     * The contents of a kubeconfig file, or its path. If this is set, this config will be used
+     * instead of $KUBECONFIG. If no kubeconfig is set or found, and the provider runs in a pod, the
+     * pod's service account is used.
      */
     readonly kubeconfig?: pulumi.Input<string>;
     /**
-     * If present, the namespace scope to use.
+     * If present, the default namespace of namespaced objects that don't specify
+     * `metadata.namespace`. Objects are otherwise created in the "default" namespace.
      */
     readonly namespace?: pulumi.Input<string>;
+    /**
+     * If present, a Kubernetes version (e.g., "1.16") whose OpenAPI schema, bundled with the
+     * provider, is used to validate and diff objects without contacting the cluster, e.g., for
+     * previews in CI. Deployments fail in this mode.
+     */
+    readonly offlineKubernetesVersion?: pulumi.Input<string>;
+    /**
+     * If true, the `data` and `stringData` of Secrets are stored in state in plaintext, and shown in
+     * diffs. By default, they are marked as secret, so that they're encrypted in state, and masked in
+     * the CLI and in rendered YAML diffs.
+     */
+    readonly plaintextSecretData?: pulumi.Input<boolean>;
+    /**
+     * If true, previews also report a unified YAML diff of each changed object against its live
+     * state, similar to `kubectl diff`. Fields left unchanged by the update keep their live
+     * (server-defaulted) values in the rendered diff.
+     */
+    readonly renderYamlDiff?: pulumi.Input<boolean>;
+    /**
+     * If present, the directory to which the manifests of objects are written, instead of deploying
+     * them to a cluster, e.g., for a GitOps tool to apply. CustomResourceDefinitions are written to
+     * `0-crd/`, and other objects to `1-manifest/`. Objects are validated against the bundled schema
+     * of `offlineKubernetesVersion`, which defaults to the newest bundled version.
+     */
+    readonly renderYamlToDirectory?: pulumi.Input<string>;
+    /**
+     * If present, overrides how requests to the API server are retried when they fail transiently.
+     */
+    readonly retryPolicy?: pulumi.Input<ProviderRetryPolicy>;
+    /**
+     * If true, objects are considered ready as soon as the API server accepts them, as if every
+     * object had the `pulumi.com/skipAwait` annotation. Useful for pipelines that only need objects
+     * to be accepted, not to become ready.
+     */
+    readonly suppressAwait?: pulumi.Input<boolean>;
+    /**
+     * If present, overrides how long to wait for objects of each kind to become ready, keyed by
+     * `apiVersion/kind`, e.g., `{ "v1/Service": "5m", "apps/v1/Deployment": "15m" }`. The
+     * `pulumi.com/timeoutSeconds` annotation still takes precedence for individual objects.
+     */
+    readonly timeouts?: pulumi.Input<{[apiVersionKind: string]: pulumi.Input<string>}>;
+}
+
+/**
+ * The set of arguments for `renderManifest`.
+ */
+export interface RenderManifestArgs {
+    /**
+     * The objects to render, e.g., the `getInputs()` of some resources. Each must specify
+     * `apiVersion`, `kind`, and `metadata.name`.
+     */
+    readonly objects?: pulumi.Input<any>[];
+    /**
+     * The resources to render, e.g., `Object.values(chart.resources)`. Rendered in addition to
+     * `objects`.
+     */
+    readonly resources?: pulumi.CustomResource[];
+    /**
+     * If true, render the objects as they currently exist in the cluster, rather than as declared
+     * in the program.
+     */
+    readonly live?: boolean;
+}
+
+/**
+ * The result of `renderManifest`.
+ */
+export interface RenderManifestResult {
+    /**
+     * The rendered objects, as a multi-document YAML string.
+     */
+    readonly manifest: string;
+    /**
+     * The normalized objects, i.e., without status or server-maintained metadata.
+     */
+    readonly objects: any[];
+}
+
+/**
+ * Renders the fully-rendered, normalized manifest of some managed resources (e.g., all the
+ * resources of a chart or ConfigGroup), for example so that it can be exported as a stack output
+ * for auditing, GitOps mirrors, or policy scanning.
+ */
+export function renderManifest(
+    args: RenderManifestArgs, opts?: pulumi.InvokeOptions,
+): Promise<RenderManifestResult> {
+    const objects: pulumi.Input<any>[] = [...(args.objects || [])];
+    for (const resource of args.resources || []) {
+        const inputs = (<any>resource).getInputs;
+        if (typeof inputs === "function") {
+            objects.push(inputs.call(resource));
+        }
+    }
+    return pulumi.runtime.invoke("kubernetes:kubernetes:renderManifest", {
+        "objects": objects,
+        "live": args.live,
+    }, opts);
+}
+
+/**
+ * Specifies how to reach an in-cluster agent, which forwards requests to the API server using its
+ * own ServiceAccount credentials.
+ */
+export interface ProviderAgent {
+    /**
+     * The address at which the agent is reachable, e.g., "https://localhost:8443".
+     */
+    readonly url: pulumi.Input<string>;
+    /**
+     * The shared secret the agent was deployed with (its `PULUMI_AGENT_TOKEN`).
+     */
+    readonly token: pulumi.Input<string>;
+    /**
+     * The PEM-encoded certificate authority for the agent's serving certificate. If not set, the
+     * system roots are used.
+     */
+    readonly caCert?: pulumi.Input<string>;
+    /**
+     * If true, the agent's serving certificate is not verified.
+     */
+    readonly insecure?: pulumi.Input<boolean>;
+}
+
+/**
+ * Specifies how requests to the API server are retried when they fail transiently. Requests that
+ * never reach the API server are always retried; requests that fail with one of
+ * `retryableStatusCodes` are retried only if they are idempotent.
+ */
+export interface ProviderRetryPolicy {
+    /**
+     * The maximum number of times a request is attempted, including the first. Defaults to 5.
+     */
+    readonly maxAttempts?: pulumi.Input<number>;
+    /**
+     * The delay before the first retry, e.g., "500ms". Doubles on each retry. Defaults to "500ms".
+     */
+    readonly initialBackoff?: pulumi.Input<string>;
+    /**
+     * The maximum delay between retries, e.g., "10s". Defaults to "10s".
+     */
+    readonly maxBackoff?: pulumi.Input<string>;
+    /**
+     * HTTP status codes considered transient. Defaults to [502, 503, 504].
+     */
+    readonly retryableStatusCodes?: pulumi.Input<pulumi.Input<number>[]>;
+}
+
+/**
+ * withAliases returns `opts`, with the given resource types added to its aliases, so that a
+ * resource that moves between apiVersions of the same kind is updated in place rather than
+ * replaced.
+ */
+function withAliases(
+    opts: pulumi.CustomResourceOptions | undefined, types: string[],
+): pulumi.CustomResourceOptions {
+    const aliases = types.map(type => ({ type: type }));
+    return { ...opts, aliases: [...((opts && opts.aliases) || []), ...aliases] };
 }
 
 export namespace admissionregistration {
@@ -1725,6 +2056,65 @@ export namespace admissionregistration {
       }
     }
 
+    /**
+     * InitializerConfigurationPatch manages only the specified fields of an existing InitializerConfiguration that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the InitializerConfigurationPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class InitializerConfigurationPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Initializers is a list of resources and their default initializers Order-sensitive. When
+       * merging multiple InitializerConfigurations, we sort the initializers from different
+       * InitializerConfigurations by the name of the InitializerConfigurations; the order of the
+       * initializers from the same InitializerConfiguration is preserved.
+       */
+      public readonly initializers: pulumi.Output<outputApi.admissionregistration.v1alpha1.Initializer[]>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+
+      public getInputs(): Partial<inputApi.admissionregistration.v1alpha1.InitializerConfiguration> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.admissionregistration.v1alpha1.InitializerConfiguration>;
+
+      /**
+      * Create a admissionregistration.v1alpha1.InitializerConfigurationPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.admissionregistration.v1alpha1.InitializerConfiguration>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "admissionregistration.k8s.io/v1alpha1";
+          inputs["initializers"] = args.initializers;
+          inputs["kind"] = "InitializerConfiguration";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:admissionregistration.k8s.io/v1alpha1:InitializerConfigurationPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * InitializerConfigurationList is a list of InitializerConfiguration.
      */
@@ -1835,6 +2225,62 @@ export namespace admissionregistration {
       }
     }
 
+    /**
+     * MutatingWebhookConfigurationPatch manages only the specified fields of an existing MutatingWebhookConfiguration that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the MutatingWebhookConfigurationPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class MutatingWebhookConfigurationPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Webhooks is a list of webhooks and the affected resources and operations.
+       */
+      public readonly webhooks: pulumi.Output<outputApi.admissionregistration.v1beta1.Webhook[]>;
+
+
+      public getInputs(): Partial<inputApi.admissionregistration.v1beta1.MutatingWebhookConfiguration> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.admissionregistration.v1beta1.MutatingWebhookConfiguration>;
+
+      /**
+      * Create a admissionregistration.v1beta1.MutatingWebhookConfigurationPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.admissionregistration.v1beta1.MutatingWebhookConfiguration>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "admissionregistration.k8s.io/v1beta1";
+          inputs["kind"] = "MutatingWebhookConfiguration";
+          inputs["metadata"] = args.metadata;
+          inputs["webhooks"] = args.webhooks;
+          super("kubernetes:admissionregistration.k8s.io/v1beta1:MutatingWebhookConfigurationPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * MutatingWebhookConfigurationList is a list of MutatingWebhookConfiguration.
      */
@@ -1942,6 +2388,62 @@ export namespace admissionregistration {
       }
     }
 
+    /**
+     * ValidatingWebhookConfigurationPatch manages only the specified fields of an existing ValidatingWebhookConfiguration that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ValidatingWebhookConfigurationPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ValidatingWebhookConfigurationPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Webhooks is a list of webhooks and the affected resources and operations.
+       */
+      public readonly webhooks: pulumi.Output<outputApi.admissionregistration.v1beta1.Webhook[]>;
+
+
+      public getInputs(): Partial<inputApi.admissionregistration.v1beta1.ValidatingWebhookConfiguration> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.admissionregistration.v1beta1.ValidatingWebhookConfiguration>;
+
+      /**
+      * Create a admissionregistration.v1beta1.ValidatingWebhookConfigurationPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.admissionregistration.v1beta1.ValidatingWebhookConfiguration>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "admissionregistration.k8s.io/v1beta1";
+          inputs["kind"] = "ValidatingWebhookConfiguration";
+          inputs["metadata"] = args.metadata;
+          inputs["webhooks"] = args.webhooks;
+          super("kubernetes:admissionregistration.k8s.io/v1beta1:ValidatingWebhookConfigurationPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ValidatingWebhookConfigurationList is a list of ValidatingWebhookConfiguration.
      */
@@ -2059,9 +2561,12 @@ export namespace apiextensions {
     }
 
     /**
-     * CustomResourceDefinitionList is a list of CustomResourceDefinition objects.
+     * CustomResourceDefinitionPatch manages only the specified fields of an existing CustomResourceDefinition that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CustomResourceDefinitionPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class CustomResourceDefinitionList extends pulumi.CustomResource {
+    export class CustomResourceDefinitionPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -2070,11 +2575,6 @@ export namespace apiextensions {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
-      /**
-       * Items individual CustomResourceDefinitions
-       */
-      public readonly items: pulumi.Output<outputApi.apiextensions.v1beta1.CustomResourceDefinition[]>;
-
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -2084,7 +2584,68 @@ export namespace apiextensions {
       public readonly kind: pulumi.Output<string>;
 
       
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec describes how the user wants the resources to appear
+       */
+      public readonly spec: pulumi.Output<outputApi.apiextensions.v1beta1.CustomResourceDefinitionSpec>;
+
+      /**
+       * Status indicates the actual state of the CustomResourceDefinition
+       */
+      public readonly status: pulumi.Output<outputApi.apiextensions.v1beta1.CustomResourceDefinitionStatus>;
+
+
+      public getInputs(): Partial<inputApi.apiextensions.v1beta1.CustomResourceDefinition> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apiextensions.v1beta1.CustomResourceDefinition>;
+
+      /**
+      * Create a apiextensions.v1beta1.CustomResourceDefinitionPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apiextensions.v1beta1.CustomResourceDefinition>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apiextensions.k8s.io/v1beta1";
+          inputs["kind"] = "CustomResourceDefinition";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apiextensions.k8s.io/v1beta1:CustomResourceDefinitionPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * CustomResourceDefinitionList is a list of CustomResourceDefinition objects.
+     */
+    export class CustomResourceDefinitionList extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Items individual CustomResourceDefinitions
+       */
+      public readonly items: pulumi.Output<outputApi.apiextensions.v1beta1.CustomResourceDefinition[]>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
 
 
       public getInputs(): inputApi.apiextensions.v1beta1.CustomResourceDefinitionList { return this.__inputs; }
@@ -2170,6 +2731,65 @@ export namespace apiregistration {
       }
     }
 
+    /**
+     * APIServicePatch manages only the specified fields of an existing APIService that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the APIServicePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class APIServicePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec contains information for locating and communicating with a server
+       */
+      public readonly spec: pulumi.Output<outputApi.apiregistration.v1beta1.APIServiceSpec>;
+
+      /**
+       * Status contains derived information about an API server
+       */
+      public readonly status: pulumi.Output<outputApi.apiregistration.v1beta1.APIServiceStatus>;
+
+
+      public getInputs(): Partial<inputApi.apiregistration.v1beta1.APIService> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apiregistration.v1beta1.APIService>;
+
+      /**
+      * Create a apiregistration.v1beta1.APIServicePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apiregistration.v1beta1.APIService>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apiregistration/v1beta1";
+          inputs["kind"] = "APIService";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apiregistration/v1beta1:APIServicePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * APIServiceList is a list of APIService objects.
      */
@@ -2285,11 +2905,74 @@ export namespace apps {
           inputs["kind"] = "ControllerRevision";
           inputs["metadata"] = args.metadata;
           inputs["revision"] = args.revision;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta1:ControllerRevision", "kubernetes:apps/v1beta2:ControllerRevision"]);
           super("kubernetes:apps/v1:ControllerRevision", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ControllerRevisionPatch manages only the specified fields of an existing ControllerRevision that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ControllerRevisionPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ControllerRevisionPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Data is the serialized representation of the state.
+       */
+      public readonly data: pulumi.Output<outputApi.pkg.runtime.RawExtension>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Revision indicates the revision of the state represented by Data.
+       */
+      public readonly revision: pulumi.Output<number>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1.ControllerRevision> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1.ControllerRevision>;
+
+      /**
+      * Create a apps.v1.ControllerRevisionPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1.ControllerRevision>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1";
+          inputs["data"] = args.data;
+          inputs["kind"] = "ControllerRevision";
+          inputs["metadata"] = args.metadata;
+          inputs["revision"] = args.revision;
+          super("kubernetes:apps/v1:ControllerRevisionPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ControllerRevisionList is a resource containing a list of ControllerRevision objects.
      */
@@ -2399,11 +3082,77 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta2:DaemonSet", "kubernetes:extensions/v1beta1:DaemonSet"]);
           super("kubernetes:apps/v1:DaemonSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * DaemonSetPatch manages only the specified fields of an existing DaemonSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DaemonSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DaemonSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The desired behavior of this daemon set. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1.DaemonSetSpec>;
+
+      /**
+       * The current status of this daemon set. This data may be out of date by some window of time.
+       * Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1.DaemonSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1.DaemonSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1.DaemonSet>;
+
+      /**
+      * Create a apps.v1.DaemonSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1.DaemonSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1";
+          inputs["kind"] = "DaemonSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1:DaemonSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DaemonSetList is a collection of daemon sets.
      */
@@ -2510,15 +3259,19 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta1:Deployment", "kubernetes:apps/v1beta2:Deployment", "kubernetes:extensions/v1beta1:Deployment"]);
           super("kubernetes:apps/v1:Deployment", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * DeploymentList is a list of Deployments.
+     * DeploymentPatch manages only the specified fields of an existing Deployment that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class DeploymentList extends pulumi.CustomResource {
+    export class DeploymentPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -2527,11 +3280,6 @@ export namespace apps {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
-      /**
-       * Items is the list of Deployments.
-       */
-      public readonly items: pulumi.Output<outputApi.apps.v1.Deployment[]>;
-
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -2541,36 +3289,47 @@ export namespace apps {
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * Standard list metadata.
+       * Standard object metadata.
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
+      /**
+       * Specification of the desired behavior of the Deployment.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1.DeploymentSpec>;
+
+      /**
+       * Most recently observed status of the Deployment.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1.DeploymentStatus>;
 
-      public getInputs(): inputApi.apps.v1.DeploymentList { return this.__inputs; }
-      private readonly __inputs: inputApi.apps.v1.DeploymentList;
+
+      public getInputs(): Partial<inputApi.apps.v1.Deployment> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1.Deployment>;
 
       /**
-      * Create a apps.v1.DeploymentList resource with the given unique name, arguments, and options.
+      * Create a apps.v1.DeploymentPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.apps.v1.DeploymentList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.apps.v1.Deployment>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "apps/v1";
-          inputs["items"] = args.items;
-          inputs["kind"] = "DeploymentList";
+          inputs["kind"] = "Deployment";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:apps/v1:DeploymentList", name, inputs, opts);
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1:DeploymentPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * ReplicaSet ensures that a specified number of pod replicas are running at any given time.
+     * DeploymentList is a list of Deployments.
      */
-    export class ReplicaSet extends pulumi.CustomResource {
+    export class DeploymentList extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -2579,6 +3338,11 @@ export namespace apps {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
+      /**
+       * Items is the list of Deployments.
+       */
+      public readonly items: pulumi.Output<outputApi.apps.v1.Deployment[]>;
+
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -2588,13 +3352,60 @@ export namespace apps {
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * If the Labels of a ReplicaSet are empty, they are defaulted to be the same as the Pod(s)
-       * that the ReplicaSet manages. Standard object's metadata. More info:
-       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       * Standard list metadata.
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
 
-      /**
+
+      public getInputs(): inputApi.apps.v1.DeploymentList { return this.__inputs; }
+      private readonly __inputs: inputApi.apps.v1.DeploymentList;
+
+      /**
+      * Create a apps.v1.DeploymentList resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.apps.v1.DeploymentList, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1";
+          inputs["items"] = args.items;
+          inputs["kind"] = "DeploymentList";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:apps/v1:DeploymentList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * ReplicaSet ensures that a specified number of pod replicas are running at any given time.
+     */
+    export class ReplicaSet extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * If the Labels of a ReplicaSet are empty, they are defaulted to be the same as the Pod(s)
+       * that the ReplicaSet manages. Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
        * Spec defines the specification of the desired behavior of the ReplicaSet. More info:
        * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
        */
@@ -2625,11 +3436,78 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta2:ReplicaSet", "kubernetes:extensions/v1beta1:ReplicaSet"]);
           super("kubernetes:apps/v1:ReplicaSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ReplicaSetPatch manages only the specified fields of an existing ReplicaSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ReplicaSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ReplicaSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * If the Labels of a ReplicaSet are empty, they are defaulted to be the same as the Pod(s)
+       * that the ReplicaSet manages. Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the specification of the desired behavior of the ReplicaSet. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1.ReplicaSetSpec>;
+
+      /**
+       * Status is the most recently observed status of the ReplicaSet. This data may be out of date
+       * by some window of time. Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1.ReplicaSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1.ReplicaSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1.ReplicaSet>;
+
+      /**
+      * Create a apps.v1.ReplicaSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1.ReplicaSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1";
+          inputs["kind"] = "ReplicaSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1:ReplicaSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ReplicaSetList is a collection of ReplicaSets.
      */
@@ -2740,11 +3618,72 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta1:StatefulSet", "kubernetes:apps/v1beta2:StatefulSet"]);
           super("kubernetes:apps/v1:StatefulSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * StatefulSetPatch manages only the specified fields of an existing StatefulSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StatefulSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class StatefulSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the desired identities of pods in this set.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1.StatefulSetSpec>;
+
+      /**
+       * Status is the current status of Pods in this StatefulSet. This data may be out of date by
+       * some window of time.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1.StatefulSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1.StatefulSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1.StatefulSet>;
+
+      /**
+      * Create a apps.v1.StatefulSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1.StatefulSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1";
+          inputs["kind"] = "StatefulSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1:StatefulSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * StatefulSetList is a collection of StatefulSets.
      */
@@ -2859,11 +3798,74 @@ export namespace apps {
           inputs["kind"] = "ControllerRevision";
           inputs["metadata"] = args.metadata;
           inputs["revision"] = args.revision;
+          opts = withAliases(opts, ["kubernetes:apps/v1:ControllerRevision", "kubernetes:apps/v1beta2:ControllerRevision"]);
           super("kubernetes:apps/v1beta1:ControllerRevision", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ControllerRevisionPatch manages only the specified fields of an existing ControllerRevision that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ControllerRevisionPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ControllerRevisionPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Data is the serialized representation of the state.
+       */
+      public readonly data: pulumi.Output<outputApi.pkg.runtime.RawExtension>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Revision indicates the revision of the state represented by Data.
+       */
+      public readonly revision: pulumi.Output<number>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta1.ControllerRevision> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta1.ControllerRevision>;
+
+      /**
+      * Create a apps.v1beta1.ControllerRevisionPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta1.ControllerRevision>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta1";
+          inputs["data"] = args.data;
+          inputs["kind"] = "ControllerRevision";
+          inputs["metadata"] = args.metadata;
+          inputs["revision"] = args.revision;
+          super("kubernetes:apps/v1beta1:ControllerRevisionPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ControllerRevisionList is a resource containing a list of ControllerRevision objects.
      */
@@ -2971,11 +3973,73 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:Deployment", "kubernetes:apps/v1beta2:Deployment", "kubernetes:extensions/v1beta1:Deployment"]);
           super("kubernetes:apps/v1beta1:Deployment", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * DeploymentPatch manages only the specified fields of an existing Deployment that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeploymentPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of the Deployment.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta1.DeploymentSpec>;
+
+      /**
+       * Most recently observed status of the Deployment.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta1.DeploymentStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta1.Deployment> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta1.Deployment>;
+
+      /**
+      * Create a apps.v1beta1.DeploymentPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta1.Deployment>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta1";
+          inputs["kind"] = "Deployment";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta1:DeploymentPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DeploymentList is a list of Deployments.
      */
@@ -3087,7 +4151,68 @@ export namespace apps {
     }
 
     /**
-     * Scale represents a scaling request for a resource.
+     * DeploymentRollbackPatch manages only the specified fields of an existing DeploymentRollback that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentRollbackPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeploymentRollbackPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Required: This must match the Name of a deployment.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * The config of this deployment rollback.
+       */
+      public readonly rollbackTo: pulumi.Output<outputApi.apps.v1beta1.RollbackConfig>;
+
+      /**
+       * The annotations to be updated to a deployment
+       */
+      public readonly updatedAnnotations: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta1.DeploymentRollback> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta1.DeploymentRollback>;
+
+      /**
+      * Create a apps.v1beta1.DeploymentRollbackPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta1.DeploymentRollback>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta1";
+          inputs["kind"] = "DeploymentRollback";
+          inputs["name"] = args.name;
+          inputs["rollbackTo"] = args.rollbackTo;
+          inputs["updatedAnnotations"] = args.updatedAnnotations;
+          super("kubernetes:apps/v1beta1:DeploymentRollbackPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * Scale represents a scaling request for a resource.
      */
     export class Scale extends pulumi.CustomResource {
       /**
@@ -3143,11 +4268,77 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta2:Scale"]);
           super("kubernetes:apps/v1beta1:Scale", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ScalePatch manages only the specified fields of an existing Scale that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ScalePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ScalePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * defines the behavior of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta1.ScaleSpec>;
+
+      /**
+       * current status of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       * Read-only.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta1.ScaleStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta1.Scale> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta1.Scale>;
+
+      /**
+      * Create a apps.v1beta1.ScalePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta1.Scale>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta1";
+          inputs["kind"] = "Scale";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta1:ScalePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DEPRECATED - This group version of StatefulSet is deprecated by apps/v1beta2/StatefulSet. See
      * the release notes for more information. StatefulSet represents a set of pods with consistent
@@ -3206,11 +4397,72 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:StatefulSet", "kubernetes:apps/v1beta2:StatefulSet"]);
           super("kubernetes:apps/v1beta1:StatefulSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * StatefulSetPatch manages only the specified fields of an existing StatefulSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StatefulSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class StatefulSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the desired identities of pods in this set.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta1.StatefulSetSpec>;
+
+      /**
+       * Status is the current status of Pods in this StatefulSet. This data may be out of date by
+       * some window of time.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta1.StatefulSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta1.StatefulSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta1.StatefulSet>;
+
+      /**
+      * Create a apps.v1beta1.StatefulSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta1.StatefulSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta1";
+          inputs["kind"] = "StatefulSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta1:StatefulSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * StatefulSetList is a collection of StatefulSets.
      */
@@ -3325,11 +4577,74 @@ export namespace apps {
           inputs["kind"] = "ControllerRevision";
           inputs["metadata"] = args.metadata;
           inputs["revision"] = args.revision;
+          opts = withAliases(opts, ["kubernetes:apps/v1:ControllerRevision", "kubernetes:apps/v1beta1:ControllerRevision"]);
           super("kubernetes:apps/v1beta2:ControllerRevision", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ControllerRevisionPatch manages only the specified fields of an existing ControllerRevision that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ControllerRevisionPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ControllerRevisionPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Data is the serialized representation of the state.
+       */
+      public readonly data: pulumi.Output<outputApi.pkg.runtime.RawExtension>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Revision indicates the revision of the state represented by Data.
+       */
+      public readonly revision: pulumi.Output<number>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta2.ControllerRevision> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.ControllerRevision>;
+
+      /**
+      * Create a apps.v1beta2.ControllerRevisionPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.ControllerRevision>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["data"] = args.data;
+          inputs["kind"] = "ControllerRevision";
+          inputs["metadata"] = args.metadata;
+          inputs["revision"] = args.revision;
+          super("kubernetes:apps/v1beta2:ControllerRevisionPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ControllerRevisionList is a resource containing a list of ControllerRevision objects.
      */
@@ -3440,15 +4755,19 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:DaemonSet", "kubernetes:extensions/v1beta1:DaemonSet"]);
           super("kubernetes:apps/v1beta2:DaemonSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * DaemonSetList is a collection of daemon sets.
+     * DaemonSetPatch manages only the specified fields of an existing DaemonSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DaemonSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class DaemonSetList extends pulumi.CustomResource {
+    export class DaemonSetPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3457,11 +4776,6 @@ export namespace apps {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
-      /**
-       * A list of daemon sets.
-       */
-      public readonly items: pulumi.Output<outputApi.apps.v1beta2.DaemonSet[]>;
-
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -3471,39 +4785,51 @@ export namespace apps {
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * Standard list metadata. More info:
+       * Standard object's metadata. More info:
        * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
+      /**
+       * The desired behavior of this daemon set. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.DaemonSetSpec>;
+
+      /**
+       * The current status of this daemon set. This data may be out of date by some window of time.
+       * Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.DaemonSetStatus>;
 
-      public getInputs(): inputApi.apps.v1beta2.DaemonSetList { return this.__inputs; }
-      private readonly __inputs: inputApi.apps.v1beta2.DaemonSetList;
+
+      public getInputs(): Partial<inputApi.apps.v1beta2.DaemonSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.DaemonSet>;
 
       /**
-      * Create a apps.v1beta2.DaemonSetList resource with the given unique name, arguments, and options.
+      * Create a apps.v1beta2.DaemonSetPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.apps.v1beta2.DaemonSetList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.DaemonSet>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "apps/v1beta2";
-          inputs["items"] = args.items;
-          inputs["kind"] = "DaemonSetList";
+          inputs["kind"] = "DaemonSet";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:apps/v1beta2:DaemonSetList", name, inputs, opts);
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta2:DaemonSetPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * DEPRECATED - This group version of Deployment is deprecated by apps/v1/Deployment. See the
-     * release notes for more information. Deployment enables declarative updates for Pods and
-     * ReplicaSets.
+     * DaemonSetList is a collection of daemon sets.
      */
-    export class Deployment extends pulumi.CustomResource {
+    export class DaemonSetList extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3512,6 +4838,11 @@ export namespace apps {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
+      /**
+       * A list of daemon sets.
+       */
+      public readonly items: pulumi.Output<outputApi.apps.v1beta2.DaemonSet[]>;
+
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -3521,9 +4852,59 @@ export namespace apps {
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * Standard object metadata.
+       * Standard list metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+
+
+      public getInputs(): inputApi.apps.v1beta2.DaemonSetList { return this.__inputs; }
+      private readonly __inputs: inputApi.apps.v1beta2.DaemonSetList;
+
+      /**
+      * Create a apps.v1beta2.DaemonSetList resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.apps.v1beta2.DaemonSetList, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["items"] = args.items;
+          inputs["kind"] = "DaemonSetList";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:apps/v1beta2:DaemonSetList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * DEPRECATED - This group version of Deployment is deprecated by apps/v1/Deployment. See the
+     * release notes for more information. Deployment enables declarative updates for Pods and
+     * ReplicaSets.
+     */
+    export class Deployment extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
        * Specification of the desired behavior of the Deployment.
@@ -3553,11 +4934,73 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:Deployment", "kubernetes:apps/v1beta1:Deployment", "kubernetes:extensions/v1beta1:Deployment"]);
           super("kubernetes:apps/v1beta2:Deployment", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * DeploymentPatch manages only the specified fields of an existing Deployment that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeploymentPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of the Deployment.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.DeploymentSpec>;
+
+      /**
+       * Most recently observed status of the Deployment.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.DeploymentStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta2.Deployment> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.Deployment>;
+
+      /**
+      * Create a apps.v1beta2.DeploymentPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.Deployment>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["kind"] = "Deployment";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta2:DeploymentPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DeploymentList is a list of Deployments.
      */
@@ -3670,11 +5113,78 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:ReplicaSet", "kubernetes:extensions/v1beta1:ReplicaSet"]);
           super("kubernetes:apps/v1beta2:ReplicaSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ReplicaSetPatch manages only the specified fields of an existing ReplicaSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ReplicaSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ReplicaSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * If the Labels of a ReplicaSet are empty, they are defaulted to be the same as the Pod(s)
+       * that the ReplicaSet manages. Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the specification of the desired behavior of the ReplicaSet. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.ReplicaSetSpec>;
+
+      /**
+       * Status is the most recently observed status of the ReplicaSet. This data may be out of date
+       * by some window of time. Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.ReplicaSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.apps.v1beta2.ReplicaSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.ReplicaSet>;
+
+      /**
+      * Create a apps.v1beta2.ReplicaSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.ReplicaSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["kind"] = "ReplicaSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:apps/v1beta2:ReplicaSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ReplicaSetList is a collection of ReplicaSets.
      */
@@ -3786,21 +5296,19 @@ export namespace apps {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1beta1:Scale"]);
           super("kubernetes:apps/v1beta2:Scale", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * DEPRECATED - This group version of StatefulSet is deprecated by apps/v1/StatefulSet. See the
-     * release notes for more information. StatefulSet represents a set of pods with consistent
-     * identities. Identities are defined as:
-     *  - Network: A single stable DNS and hostname.
-     *  - Storage: As many VolumeClaims as requested.
-     * The StatefulSet guarantees that a given network identity will always map to the same storage
-     * identity.
+     * ScalePatch manages only the specified fields of an existing Scale that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ScalePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class StatefulSet extends pulumi.CustomResource {
+    export class ScalePatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3817,47 +5325,58 @@ export namespace apps {
        */
       public readonly kind: pulumi.Output<string>;
 
-      
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec defines the desired identities of pods in this set.
+       * defines the behavior of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
        */
-      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.StatefulSetSpec>;
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.ScaleSpec>;
 
       /**
-       * Status is the current status of Pods in this StatefulSet. This data may be out of date by
-       * some window of time.
+       * current status of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       * Read-only.
        */
-      public readonly status: pulumi.Output<outputApi.apps.v1beta2.StatefulSetStatus>;
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.ScaleStatus>;
 
 
-      public getInputs(): inputApi.apps.v1beta2.StatefulSet { return this.__inputs; }
-      private readonly __inputs: inputApi.apps.v1beta2.StatefulSet;
+      public getInputs(): Partial<inputApi.apps.v1beta2.Scale> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.Scale>;
 
       /**
-      * Create a apps.v1beta2.StatefulSet resource with the given unique name, arguments, and options.
+      * Create a apps.v1beta2.ScalePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.apps.v1beta2.StatefulSet, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.Scale>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "apps/v1beta2";
-          inputs["kind"] = "StatefulSet";
+          inputs["kind"] = "Scale";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:apps/v1beta2:StatefulSet", name, inputs, opts);
+          super("kubernetes:apps/v1beta2:ScalePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * StatefulSetList is a collection of StatefulSets.
+     * DEPRECATED - This group version of StatefulSet is deprecated by apps/v1/StatefulSet. See the
+     * release notes for more information. StatefulSet represents a set of pods with consistent
+     * identities. Identities are defined as:
+     *  - Network: A single stable DNS and hostname.
+     *  - Storage: As many VolumeClaims as requested.
+     * The StatefulSet guarantees that a given network identity will always map to the same storage
+     * identity.
      */
-    export class StatefulSetList extends pulumi.CustomResource {
+    export class StatefulSet extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3866,9 +5385,6 @@ export namespace apps {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
-      
-      public readonly items: pulumi.Output<outputApi.apps.v1beta2.StatefulSet[]>;
-
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -3878,41 +5394,50 @@ export namespace apps {
       public readonly kind: pulumi.Output<string>;
 
       
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
+      /**
+       * Spec defines the desired identities of pods in this set.
+       */
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.StatefulSetSpec>;
 
-      public getInputs(): inputApi.apps.v1beta2.StatefulSetList { return this.__inputs; }
-      private readonly __inputs: inputApi.apps.v1beta2.StatefulSetList;
+      /**
+       * Status is the current status of Pods in this StatefulSet. This data may be out of date by
+       * some window of time.
+       */
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.StatefulSetStatus>;
+
+
+      public getInputs(): inputApi.apps.v1beta2.StatefulSet { return this.__inputs; }
+      private readonly __inputs: inputApi.apps.v1beta2.StatefulSet;
 
       /**
-      * Create a apps.v1beta2.StatefulSetList resource with the given unique name, arguments, and options.
+      * Create a apps.v1beta2.StatefulSet resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.apps.v1beta2.StatefulSetList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.apps.v1beta2.StatefulSet, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "apps/v1beta2";
-          inputs["items"] = args.items;
-          inputs["kind"] = "StatefulSetList";
+          inputs["kind"] = "StatefulSet";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:apps/v1beta2:StatefulSetList", name, inputs, opts);
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:StatefulSet", "kubernetes:apps/v1beta1:StatefulSet"]);
+          super("kubernetes:apps/v1beta2:StatefulSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
-  }
-
-}
-
-export namespace authentication {
-  export namespace v1 {
     /**
-     * TokenReview attempts to authenticate a token to a known user. Note: TokenReview requests may
-     * be cached by the webhook token authenticator plugin in the kube-apiserver.
+     * StatefulSetPatch manages only the specified fields of an existing StatefulSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StatefulSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class TokenReview extends pulumi.CustomResource {
+    export class StatefulSetPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3933,46 +5458,43 @@ export namespace authentication {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated
+       * Spec defines the desired identities of pods in this set.
        */
-      public readonly spec: pulumi.Output<outputApi.authentication.v1.TokenReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.apps.v1beta2.StatefulSetSpec>;
 
       /**
-       * Status is filled in by the server and indicates whether the request can be authenticated.
+       * Status is the current status of Pods in this StatefulSet. This data may be out of date by
+       * some window of time.
        */
-      public readonly status: pulumi.Output<outputApi.authentication.v1.TokenReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.apps.v1beta2.StatefulSetStatus>;
 
 
-      public getInputs(): inputApi.authentication.v1.TokenReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authentication.v1.TokenReview;
+      public getInputs(): Partial<inputApi.apps.v1beta2.StatefulSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.apps.v1beta2.StatefulSet>;
 
       /**
-      * Create a authentication.v1.TokenReview resource with the given unique name, arguments, and options.
+      * Create a apps.v1beta2.StatefulSetPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authentication.v1.TokenReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.apps.v1beta2.StatefulSet>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authentication.k8s.io/v1";
-          inputs["kind"] = "TokenReview";
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["kind"] = "StatefulSet";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authentication.k8s.io/v1:TokenReview", name, inputs, opts);
+          super("kubernetes:apps/v1beta2:StatefulSetPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
-  }
-
-  export namespace v1beta1 {
     /**
-     * TokenReview attempts to authenticate a token to a known user. Note: TokenReview requests may
-     * be cached by the webhook token authenticator plugin in the kube-apiserver.
+     * StatefulSetList is a collection of StatefulSets.
      */
-    export class TokenReview extends pulumi.CustomResource {
+    export class StatefulSetList extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -3981,6 +5503,9 @@ export namespace authentication {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
+      
+      public readonly items: pulumi.Output<outputApi.apps.v1beta2.StatefulSet[]>;
+
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -3990,37 +5515,26 @@ export namespace authentication {
       public readonly kind: pulumi.Output<string>;
 
       
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
-
-      /**
-       * Spec holds information about the request being evaluated
-       */
-      public readonly spec: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewSpec>;
-
-      /**
-       * Status is filled in by the server and indicates whether the request can be authenticated.
-       */
-      public readonly status: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewStatus>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
 
 
-      public getInputs(): inputApi.authentication.v1beta1.TokenReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authentication.v1beta1.TokenReview;
+      public getInputs(): inputApi.apps.v1beta2.StatefulSetList { return this.__inputs; }
+      private readonly __inputs: inputApi.apps.v1beta2.StatefulSetList;
 
       /**
-      * Create a authentication.v1beta1.TokenReview resource with the given unique name, arguments, and options.
+      * Create a apps.v1beta2.StatefulSetList resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authentication.v1beta1.TokenReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.apps.v1beta2.StatefulSetList, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authentication.k8s.io/v1beta1";
-          inputs["kind"] = "TokenReview";
+          inputs["apiVersion"] = "apps/v1beta2";
+          inputs["items"] = args.items;
+          inputs["kind"] = "StatefulSetList";
           inputs["metadata"] = args.metadata;
-          inputs["spec"] = args.spec;
-          inputs["status"] = args.status;
-          super("kubernetes:authentication.k8s.io/v1beta1:TokenReview", name, inputs, opts);
+          super("kubernetes:apps/v1beta2:StatefulSetList", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -4029,14 +5543,13 @@ export namespace authentication {
 
 }
 
-export namespace authorization {
+export namespace authentication {
   export namespace v1 {
     /**
-     * LocalSubjectAccessReview checks whether or not a user or group can perform an action in a
-     * given namespace. Having a namespace scoped resource makes it much easier to grant namespace
-     * scoped policy that includes permissions checking.
+     * TokenReview attempts to authenticate a token to a known user. Note: TokenReview requests may
+     * be cached by the webhook token authenticator plugin in the kube-apiserver.
      */
-    export class LocalSubjectAccessReview extends pulumi.CustomResource {
+    export class TokenReview extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4057,45 +5570,46 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated.  spec.namespace must be equal to
-       * the namespace you made the request against.  If empty, it is defaulted.
+       * Spec holds information about the request being evaluated
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authentication.v1.TokenReviewSpec>;
 
       /**
-       * Status is filled in by the server and indicates whether the request is allowed or not
+       * Status is filled in by the server and indicates whether the request can be authenticated.
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authentication.v1.TokenReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1.LocalSubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1.LocalSubjectAccessReview;
+      public getInputs(): inputApi.authentication.v1.TokenReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authentication.v1.TokenReview;
 
       /**
-      * Create a authorization.v1.LocalSubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authentication.v1.TokenReview resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1.LocalSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.authentication.v1.TokenReview, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1";
-          inputs["kind"] = "LocalSubjectAccessReview";
+          inputs["apiVersion"] = "authentication.k8s.io/v1";
+          inputs["kind"] = "TokenReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1:LocalSubjectAccessReview", name, inputs, opts);
+          opts = withAliases(opts, ["kubernetes:authentication.k8s.io/v1beta1:TokenReview"]);
+          super("kubernetes:authentication.k8s.io/v1:TokenReview", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * SelfSubjectAccessReview checks whether or the current user can perform an action.  Not
-     * filling in a spec.namespace means "in all namespaces".  Self is a special case, because users
-     * should always be able to check whether they can perform an action
+     * TokenReviewPatch manages only the specified fields of an existing TokenReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the TokenReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class SelfSubjectAccessReview extends pulumi.CustomResource {
+    export class TokenReviewPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4116,49 +5630,46 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated.  user and groups must be empty
+       * Spec holds information about the request being evaluated
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authentication.v1.TokenReviewSpec>;
 
       /**
-       * Status is filled in by the server and indicates whether the request is allowed or not
+       * Status is filled in by the server and indicates whether the request can be authenticated.
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authentication.v1.TokenReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1.SelfSubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1.SelfSubjectAccessReview;
+      public getInputs(): Partial<inputApi.authentication.v1.TokenReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authentication.v1.TokenReview>;
 
       /**
-      * Create a authorization.v1.SelfSubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authentication.v1.TokenReviewPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1.SelfSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.authentication.v1.TokenReview>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1";
-          inputs["kind"] = "SelfSubjectAccessReview";
+          inputs["apiVersion"] = "authentication.k8s.io/v1";
+          inputs["kind"] = "TokenReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1:SelfSubjectAccessReview", name, inputs, opts);
+          super("kubernetes:authentication.k8s.io/v1:TokenReviewPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+  }
+
+  export namespace v1beta1 {
     /**
-     * SelfSubjectRulesReview enumerates the set of actions the current user can perform within a
-     * namespace. The returned list of actions may be incomplete depending on the server's
-     * authorization mode, and any errors experienced during the evaluation. SelfSubjectRulesReview
-     * should be used by UIs to show/hide actions, or to quickly let an end user reason about their
-     * permissions. It should NOT Be used by external systems to drive authorization decisions as
-     * this raises confused deputy, cache lifetime/revocation, and correctness concerns.
-     * SubjectAccessReview, and LocalAccessReview are the correct way to defer authorization
-     * decisions to the API server.
+     * TokenReview attempts to authenticate a token to a known user. Note: TokenReview requests may
+     * be cached by the webhook token authenticator plugin in the kube-apiserver.
      */
-    export class SelfSubjectRulesReview extends pulumi.CustomResource {
+    export class TokenReview extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4179,42 +5690,46 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated.
+       * Spec holds information about the request being evaluated
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectRulesReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewSpec>;
 
       /**
-       * Status is filled in by the server and indicates the set of actions a user can perform.
+       * Status is filled in by the server and indicates whether the request can be authenticated.
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectRulesReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1.SelfSubjectRulesReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1.SelfSubjectRulesReview;
+      public getInputs(): inputApi.authentication.v1beta1.TokenReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authentication.v1beta1.TokenReview;
 
       /**
-      * Create a authorization.v1.SelfSubjectRulesReview resource with the given unique name, arguments, and options.
+      * Create a authentication.v1beta1.TokenReview resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1.SelfSubjectRulesReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.authentication.v1beta1.TokenReview, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1";
-          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["apiVersion"] = "authentication.k8s.io/v1beta1";
+          inputs["kind"] = "TokenReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1:SelfSubjectRulesReview", name, inputs, opts);
+          opts = withAliases(opts, ["kubernetes:authentication.k8s.io/v1:TokenReview"]);
+          super("kubernetes:authentication.k8s.io/v1beta1:TokenReview", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * SubjectAccessReview checks whether or not a user or group can perform an action.
+     * TokenReviewPatch manages only the specified fields of an existing TokenReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the TokenReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class SubjectAccessReview extends pulumi.CustomResource {
+    export class TokenReviewPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4237,39 +5752,42 @@ export namespace authorization {
       /**
        * Spec holds information about the request being evaluated
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewSpec>;
 
       /**
-       * Status is filled in by the server and indicates whether the request is allowed or not
+       * Status is filled in by the server and indicates whether the request can be authenticated.
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authentication.v1beta1.TokenReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1.SubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1.SubjectAccessReview;
+      public getInputs(): Partial<inputApi.authentication.v1beta1.TokenReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authentication.v1beta1.TokenReview>;
 
       /**
-      * Create a authorization.v1.SubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authentication.v1beta1.TokenReviewPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1.SubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.authentication.v1beta1.TokenReview>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1";
-          inputs["kind"] = "SubjectAccessReview";
+          inputs["apiVersion"] = "authentication.k8s.io/v1beta1";
+          inputs["kind"] = "TokenReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1:SubjectAccessReview", name, inputs, opts);
+          super("kubernetes:authentication.k8s.io/v1beta1:TokenReviewPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
   }
 
-  export namespace v1beta1 {
+}
+
+export namespace authorization {
+  export namespace v1 {
     /**
      * LocalSubjectAccessReview checks whether or not a user or group can perform an action in a
      * given namespace. Having a namespace scoped resource makes it much easier to grant namespace
@@ -4299,42 +5817,44 @@ export namespace authorization {
        * Spec holds information about the request being evaluated.  spec.namespace must be equal to
        * the namespace you made the request against.  If empty, it is defaulted.
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
 
       /**
        * Status is filled in by the server and indicates whether the request is allowed or not
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1beta1.LocalSubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1beta1.LocalSubjectAccessReview;
+      public getInputs(): inputApi.authorization.v1.LocalSubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1.LocalSubjectAccessReview;
 
       /**
-      * Create a authorization.v1beta1.LocalSubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authorization.v1.LocalSubjectAccessReview resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1beta1.LocalSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.authorization.v1.LocalSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
           inputs["kind"] = "LocalSubjectAccessReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1beta1:LocalSubjectAccessReview", name, inputs, opts);
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1beta1:LocalSubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1:LocalSubjectAccessReview", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * SelfSubjectAccessReview checks whether or the current user can perform an action.  Not
-     * filling in a spec.namespace means "in all namespaces".  Self is a special case, because users
-     * should always be able to check whether they can perform an action
+     * LocalSubjectAccessReviewPatch manages only the specified fields of an existing LocalSubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the LocalSubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class SelfSubjectAccessReview extends pulumi.CustomResource {
+    export class LocalSubjectAccessReviewPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4355,49 +5875,45 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated.  user and groups must be empty
+       * Spec holds information about the request being evaluated.  spec.namespace must be equal to
+       * the namespace you made the request against.  If empty, it is defaulted.
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
 
       /**
        * Status is filled in by the server and indicates whether the request is allowed or not
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1beta1.SelfSubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1beta1.SelfSubjectAccessReview;
+      public getInputs(): Partial<inputApi.authorization.v1.LocalSubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1.LocalSubjectAccessReview>;
 
       /**
-      * Create a authorization.v1beta1.SelfSubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authorization.v1.LocalSubjectAccessReviewPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1beta1.SelfSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.authorization.v1.LocalSubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
-          inputs["kind"] = "SelfSubjectAccessReview";
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "LocalSubjectAccessReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectAccessReview", name, inputs, opts);
+          super("kubernetes:authorization.k8s.io/v1:LocalSubjectAccessReviewPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * SelfSubjectRulesReview enumerates the set of actions the current user can perform within a
-     * namespace. The returned list of actions may be incomplete depending on the server's
-     * authorization mode, and any errors experienced during the evaluation. SelfSubjectRulesReview
-     * should be used by UIs to show/hide actions, or to quickly let an end user reason about their
-     * permissions. It should NOT Be used by external systems to drive authorization decisions as
-     * this raises confused deputy, cache lifetime/revocation, and correctness concerns.
-     * SubjectAccessReview, and LocalAccessReview are the correct way to defer authorization
-     * decisions to the API server.
+     * SelfSubjectAccessReview checks whether or the current user can perform an action.  Not
+     * filling in a spec.namespace means "in all namespaces".  Self is a special case, because users
+     * should always be able to check whether they can perform an action
      */
-    export class SelfSubjectRulesReview extends pulumi.CustomResource {
+    export class SelfSubjectAccessReview extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4418,42 +5934,46 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated.
+       * Spec holds information about the request being evaluated.  user and groups must be empty
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectRulesReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectAccessReviewSpec>;
 
       /**
-       * Status is filled in by the server and indicates the set of actions a user can perform.
+       * Status is filled in by the server and indicates whether the request is allowed or not
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectRulesReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1beta1.SelfSubjectRulesReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1beta1.SelfSubjectRulesReview;
+      public getInputs(): inputApi.authorization.v1.SelfSubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1.SelfSubjectAccessReview;
 
       /**
-      * Create a authorization.v1beta1.SelfSubjectRulesReview resource with the given unique name, arguments, and options.
+      * Create a authorization.v1.SelfSubjectAccessReview resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1beta1.SelfSubjectRulesReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.authorization.v1.SelfSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
-          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SelfSubjectAccessReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectRulesReview", name, inputs, opts);
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1beta1:SelfSubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1:SelfSubjectAccessReview", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * SubjectAccessReview checks whether or not a user or group can perform an action.
+     * SelfSubjectAccessReviewPatch manages only the specified fields of an existing SelfSubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SelfSubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class SubjectAccessReview extends pulumi.CustomResource {
+    export class SelfSubjectAccessReviewPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -4474,51 +5994,815 @@ export namespace authorization {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Spec holds information about the request being evaluated
+       * Spec holds information about the request being evaluated.  user and groups must be empty
        */
-      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectAccessReviewSpec>;
 
       /**
        * Status is filled in by the server and indicates whether the request is allowed or not
        */
-      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
 
 
-      public getInputs(): inputApi.authorization.v1beta1.SubjectAccessReview { return this.__inputs; }
-      private readonly __inputs: inputApi.authorization.v1beta1.SubjectAccessReview;
+      public getInputs(): Partial<inputApi.authorization.v1.SelfSubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1.SelfSubjectAccessReview>;
 
       /**
-      * Create a authorization.v1beta1.SubjectAccessReview resource with the given unique name, arguments, and options.
+      * Create a authorization.v1.SelfSubjectAccessReviewPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.authorization.v1beta1.SubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.authorization.v1.SelfSubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
-          inputs["kind"] = "SubjectAccessReview";
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SelfSubjectAccessReview";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:authorization.k8s.io/v1beta1:SubjectAccessReview", name, inputs, opts);
+          super("kubernetes:authorization.k8s.io/v1:SelfSubjectAccessReviewPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
-  }
-
-}
-
-export namespace autoscaling {
-  export namespace v1 {
     /**
-     * CrossVersionObjectReference contains enough information to let you identify the referred
-     * resource.
-     */
-    export class CrossVersionObjectReference extends pulumi.CustomResource {
-      /**
-       * API version of the referent
+     * SelfSubjectRulesReview enumerates the set of actions the current user can perform within a
+     * namespace. The returned list of actions may be incomplete depending on the server's
+     * authorization mode, and any errors experienced during the evaluation. SelfSubjectRulesReview
+     * should be used by UIs to show/hide actions, or to quickly let an end user reason about their
+     * permissions. It should NOT Be used by external systems to drive authorization decisions as
+     * this raises confused deputy, cache lifetime/revocation, and correctness concerns.
+     * SubjectAccessReview, and LocalAccessReview are the correct way to defer authorization
+     * decisions to the API server.
+     */
+    export class SelfSubjectRulesReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectRulesReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates the set of actions a user can perform.
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectRulesReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1.SelfSubjectRulesReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1.SelfSubjectRulesReview;
+
+      /**
+      * Create a authorization.v1.SelfSubjectRulesReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1.SelfSubjectRulesReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1beta1:SelfSubjectRulesReview"]);
+          super("kubernetes:authorization.k8s.io/v1:SelfSubjectRulesReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SelfSubjectRulesReviewPatch manages only the specified fields of an existing SelfSubjectRulesReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SelfSubjectRulesReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SelfSubjectRulesReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SelfSubjectRulesReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates the set of actions a user can perform.
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectRulesReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1.SelfSubjectRulesReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1.SelfSubjectRulesReview>;
+
+      /**
+      * Create a authorization.v1.SelfSubjectRulesReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1.SelfSubjectRulesReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1:SelfSubjectRulesReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SubjectAccessReview checks whether or not a user or group can perform an action.
+     */
+    export class SubjectAccessReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1.SubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1.SubjectAccessReview;
+
+      /**
+      * Create a authorization.v1.SubjectAccessReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1.SubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1beta1:SubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1:SubjectAccessReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SubjectAccessReviewPatch manages only the specified fields of an existing SubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SubjectAccessReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1.SubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1.SubjectAccessReview>;
+
+      /**
+      * Create a authorization.v1.SubjectAccessReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1.SubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1";
+          inputs["kind"] = "SubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1:SubjectAccessReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+  }
+
+  export namespace v1beta1 {
+    /**
+     * LocalSubjectAccessReview checks whether or not a user or group can perform an action in a
+     * given namespace. Having a namespace scoped resource makes it much easier to grant namespace
+     * scoped policy that includes permissions checking.
+     */
+    export class LocalSubjectAccessReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.  spec.namespace must be equal to
+       * the namespace you made the request against.  If empty, it is defaulted.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1beta1.LocalSubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1beta1.LocalSubjectAccessReview;
+
+      /**
+      * Create a authorization.v1beta1.LocalSubjectAccessReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1beta1.LocalSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "LocalSubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1:LocalSubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1beta1:LocalSubjectAccessReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * LocalSubjectAccessReviewPatch manages only the specified fields of an existing LocalSubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the LocalSubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class LocalSubjectAccessReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.  spec.namespace must be equal to
+       * the namespace you made the request against.  If empty, it is defaulted.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1beta1.LocalSubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1beta1.LocalSubjectAccessReview>;
+
+      /**
+      * Create a authorization.v1beta1.LocalSubjectAccessReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1beta1.LocalSubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "LocalSubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1beta1:LocalSubjectAccessReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SelfSubjectAccessReview checks whether or the current user can perform an action.  Not
+     * filling in a spec.namespace means "in all namespaces".  Self is a special case, because users
+     * should always be able to check whether they can perform an action
+     */
+    export class SelfSubjectAccessReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.  user and groups must be empty
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1beta1.SelfSubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1beta1.SelfSubjectAccessReview;
+
+      /**
+      * Create a authorization.v1beta1.SelfSubjectAccessReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1beta1.SelfSubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SelfSubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1:SelfSubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectAccessReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SelfSubjectAccessReviewPatch manages only the specified fields of an existing SelfSubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SelfSubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SelfSubjectAccessReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.  user and groups must be empty
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1beta1.SelfSubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1beta1.SelfSubjectAccessReview>;
+
+      /**
+      * Create a authorization.v1beta1.SelfSubjectAccessReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1beta1.SelfSubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SelfSubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectAccessReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SelfSubjectRulesReview enumerates the set of actions the current user can perform within a
+     * namespace. The returned list of actions may be incomplete depending on the server's
+     * authorization mode, and any errors experienced during the evaluation. SelfSubjectRulesReview
+     * should be used by UIs to show/hide actions, or to quickly let an end user reason about their
+     * permissions. It should NOT Be used by external systems to drive authorization decisions as
+     * this raises confused deputy, cache lifetime/revocation, and correctness concerns.
+     * SubjectAccessReview, and LocalAccessReview are the correct way to defer authorization
+     * decisions to the API server.
+     */
+    export class SelfSubjectRulesReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectRulesReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates the set of actions a user can perform.
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectRulesReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1beta1.SelfSubjectRulesReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1beta1.SelfSubjectRulesReview;
+
+      /**
+      * Create a authorization.v1beta1.SelfSubjectRulesReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1beta1.SelfSubjectRulesReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1:SelfSubjectRulesReview"]);
+          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectRulesReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SelfSubjectRulesReviewPatch manages only the specified fields of an existing SelfSubjectRulesReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SelfSubjectRulesReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SelfSubjectRulesReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated.
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SelfSubjectRulesReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates the set of actions a user can perform.
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectRulesReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1beta1.SelfSubjectRulesReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1beta1.SelfSubjectRulesReview>;
+
+      /**
+      * Create a authorization.v1beta1.SelfSubjectRulesReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1beta1.SelfSubjectRulesReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SelfSubjectRulesReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1beta1:SelfSubjectRulesReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SubjectAccessReview checks whether or not a user or group can perform an action.
+     */
+    export class SubjectAccessReview extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): inputApi.authorization.v1beta1.SubjectAccessReview { return this.__inputs; }
+      private readonly __inputs: inputApi.authorization.v1beta1.SubjectAccessReview;
+
+      /**
+      * Create a authorization.v1beta1.SubjectAccessReview resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.authorization.v1beta1.SubjectAccessReview, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:authorization.k8s.io/v1:SubjectAccessReview"]);
+          super("kubernetes:authorization.k8s.io/v1beta1:SubjectAccessReview", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SubjectAccessReviewPatch manages only the specified fields of an existing SubjectAccessReview that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SubjectAccessReviewPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SubjectAccessReviewPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec holds information about the request being evaluated
+       */
+      public readonly spec: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewSpec>;
+
+      /**
+       * Status is filled in by the server and indicates whether the request is allowed or not
+       */
+      public readonly status: pulumi.Output<outputApi.authorization.v1beta1.SubjectAccessReviewStatus>;
+
+
+      public getInputs(): Partial<inputApi.authorization.v1beta1.SubjectAccessReview> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.authorization.v1beta1.SubjectAccessReview>;
+
+      /**
+      * Create a authorization.v1beta1.SubjectAccessReviewPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.authorization.v1beta1.SubjectAccessReview>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "authorization.k8s.io/v1beta1";
+          inputs["kind"] = "SubjectAccessReview";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:authorization.k8s.io/v1beta1:SubjectAccessReviewPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+  }
+
+}
+
+export namespace autoscaling {
+  export namespace v1 {
+    /**
+     * CrossVersionObjectReference contains enough information to let you identify the referred
+     * resource.
+     */
+    export class CrossVersionObjectReference extends pulumi.CustomResource {
+      /**
+       * API version of the referent
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind of the referent; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds"
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
+       */
+      public readonly name: pulumi.Output<string>;
+
+
+      public getInputs(): inputApi.autoscaling.v1.CrossVersionObjectReference { return this.__inputs; }
+      private readonly __inputs: inputApi.autoscaling.v1.CrossVersionObjectReference;
+
+      /**
+      * Create a autoscaling.v1.CrossVersionObjectReference resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.autoscaling.v1.CrossVersionObjectReference, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "autoscaling/v1";
+          inputs["kind"] = "CrossVersionObjectReference";
+          inputs["name"] = args.name;
+          opts = withAliases(opts, ["kubernetes:autoscaling/v2beta1:CrossVersionObjectReference"]);
+          super("kubernetes:autoscaling/v1:CrossVersionObjectReference", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * CrossVersionObjectReferencePatch manages only the specified fields of an existing CrossVersionObjectReference that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CrossVersionObjectReferencePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class CrossVersionObjectReferencePatch extends pulumi.CustomResource {
+      /**
+       * API version of the referent
        */
       public readonly apiVersion: pulumi.Output<string>;
 
@@ -4534,22 +6818,22 @@ export namespace autoscaling {
       public readonly name: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.autoscaling.v1.CrossVersionObjectReference { return this.__inputs; }
-      private readonly __inputs: inputApi.autoscaling.v1.CrossVersionObjectReference;
+      public getInputs(): Partial<inputApi.autoscaling.v1.CrossVersionObjectReference> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.autoscaling.v1.CrossVersionObjectReference>;
 
       /**
-      * Create a autoscaling.v1.CrossVersionObjectReference resource with the given unique name, arguments, and options.
+      * Create a autoscaling.v1.CrossVersionObjectReferencePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.autoscaling.v1.CrossVersionObjectReference, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.autoscaling.v1.CrossVersionObjectReference>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "autoscaling/v1";
           inputs["kind"] = "CrossVersionObjectReference";
           inputs["name"] = args.name;
-          super("kubernetes:autoscaling/v1:CrossVersionObjectReference", name, inputs, opts);
+          super("kubernetes:autoscaling/v1:CrossVersionObjectReferencePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -4609,11 +6893,75 @@ export namespace autoscaling {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:autoscaling/v2beta1:HorizontalPodAutoscaler"]);
           super("kubernetes:autoscaling/v1:HorizontalPodAutoscaler", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * HorizontalPodAutoscalerPatch manages only the specified fields of an existing HorizontalPodAutoscaler that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the HorizontalPodAutoscalerPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class HorizontalPodAutoscalerPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * behaviour of autoscaler. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       */
+      public readonly spec: pulumi.Output<outputApi.autoscaling.v1.HorizontalPodAutoscalerSpec>;
+
+      /**
+       * current information about the autoscaler.
+       */
+      public readonly status: pulumi.Output<outputApi.autoscaling.v1.HorizontalPodAutoscalerStatus>;
+
+
+      public getInputs(): Partial<inputApi.autoscaling.v1.HorizontalPodAutoscaler> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.autoscaling.v1.HorizontalPodAutoscaler>;
+
+      /**
+      * Create a autoscaling.v1.HorizontalPodAutoscalerPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.autoscaling.v1.HorizontalPodAutoscaler>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "autoscaling/v1";
+          inputs["kind"] = "HorizontalPodAutoscaler";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:autoscaling/v1:HorizontalPodAutoscalerPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * list of horizontal pod autoscaler objects.
      */
@@ -4728,6 +7076,71 @@ export namespace autoscaling {
       }
     }
 
+    /**
+     * ScalePatch manages only the specified fields of an existing Scale that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ScalePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ScalePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * defines the behavior of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       */
+      public readonly spec: pulumi.Output<outputApi.autoscaling.v1.ScaleSpec>;
+
+      /**
+       * current status of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       * Read-only.
+       */
+      public readonly status: pulumi.Output<outputApi.autoscaling.v1.ScaleStatus>;
+
+
+      public getInputs(): Partial<inputApi.autoscaling.v1.Scale> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.autoscaling.v1.Scale>;
+
+      /**
+      * Create a autoscaling.v1.ScalePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.autoscaling.v1.Scale>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "autoscaling/v1";
+          inputs["kind"] = "Scale";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:autoscaling/v1:ScalePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
   }
 
   export namespace v2beta1 {
@@ -4768,11 +7181,56 @@ export namespace autoscaling {
           inputs["apiVersion"] = "autoscaling/v2beta1";
           inputs["kind"] = "CrossVersionObjectReference";
           inputs["name"] = args.name;
+          opts = withAliases(opts, ["kubernetes:autoscaling/v1:CrossVersionObjectReference"]);
           super("kubernetes:autoscaling/v2beta1:CrossVersionObjectReference", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * CrossVersionObjectReferencePatch manages only the specified fields of an existing CrossVersionObjectReference that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CrossVersionObjectReferencePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class CrossVersionObjectReferencePatch extends pulumi.CustomResource {
+      /**
+       * API version of the referent
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind of the referent; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds"
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
+       */
+      public readonly name: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.autoscaling.v2beta1.CrossVersionObjectReference> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.autoscaling.v2beta1.CrossVersionObjectReference>;
+
+      /**
+      * Create a autoscaling.v2beta1.CrossVersionObjectReferencePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.autoscaling.v2beta1.CrossVersionObjectReference>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "autoscaling/v2beta1";
+          inputs["kind"] = "CrossVersionObjectReference";
+          inputs["name"] = args.name;
+          super("kubernetes:autoscaling/v2beta1:CrossVersionObjectReferencePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * HorizontalPodAutoscaler is the configuration for a horizontal pod autoscaler, which
      * automatically manages the replica count of any resource implementing the scale subresource
@@ -4830,11 +7288,75 @@ export namespace autoscaling {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:autoscaling/v1:HorizontalPodAutoscaler"]);
           super("kubernetes:autoscaling/v2beta1:HorizontalPodAutoscaler", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * HorizontalPodAutoscalerPatch manages only the specified fields of an existing HorizontalPodAutoscaler that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the HorizontalPodAutoscalerPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class HorizontalPodAutoscalerPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * metadata is the standard object metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * spec is the specification for the behaviour of the autoscaler. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       */
+      public readonly spec: pulumi.Output<outputApi.autoscaling.v2beta1.HorizontalPodAutoscalerSpec>;
+
+      /**
+       * status is the current information about the autoscaler.
+       */
+      public readonly status: pulumi.Output<outputApi.autoscaling.v2beta1.HorizontalPodAutoscalerStatus>;
+
+
+      public getInputs(): Partial<inputApi.autoscaling.v2beta1.HorizontalPodAutoscaler> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.autoscaling.v2beta1.HorizontalPodAutoscaler>;
+
+      /**
+      * Create a autoscaling.v2beta1.HorizontalPodAutoscalerPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.autoscaling.v2beta1.HorizontalPodAutoscaler>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "autoscaling/v2beta1";
+          inputs["kind"] = "HorizontalPodAutoscaler";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:autoscaling/v2beta1:HorizontalPodAutoscalerPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * HorizontalPodAutoscaler is a list of horizontal pod autoscaler objects.
      */
@@ -4936,20 +7458,84 @@ export namespace batch {
       private readonly __inputs: inputApi.batch.v1.Job;
 
       /**
-      * Create a batch.v1.Job resource with the given unique name, arguments, and options.
+      * Create a batch.v1.Job resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.batch.v1.Job, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "batch/v1";
+          inputs["kind"] = "Job";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:batch/v1:Job", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * JobPatch manages only the specified fields of an existing Job that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the JobPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class JobPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of a job. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.batch.v1.JobSpec>;
+
+      /**
+       * Current status of a job. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.batch.v1.JobStatus>;
+
+
+      public getInputs(): Partial<inputApi.batch.v1.Job> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.batch.v1.Job>;
+
+      /**
+      * Create a batch.v1.JobPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.batch.v1.Job, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.batch.v1.Job>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "batch/v1";
           inputs["kind"] = "Job";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:batch/v1:Job", name, inputs, opts);
+          super("kubernetes:batch/v1:JobPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -5066,11 +7652,76 @@ export namespace batch {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:batch/v2alpha1:CronJob"]);
           super("kubernetes:batch/v1beta1:CronJob", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * CronJobPatch manages only the specified fields of an existing CronJob that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CronJobPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class CronJobPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of a cron job, including the schedule. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.batch.v1beta1.CronJobSpec>;
+
+      /**
+       * Current status of a cron job. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.batch.v1beta1.CronJobStatus>;
+
+
+      public getInputs(): Partial<inputApi.batch.v1beta1.CronJob> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.batch.v1beta1.CronJob>;
+
+      /**
+      * Create a batch.v1beta1.CronJobPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.batch.v1beta1.CronJob>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "batch/v1beta1";
+          inputs["kind"] = "CronJob";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:batch/v1beta1:CronJobPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * CronJobList is a collection of cron jobs.
      */
@@ -5183,11 +7834,76 @@ export namespace batch {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:batch/v1beta1:CronJob"]);
           super("kubernetes:batch/v2alpha1:CronJob", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * CronJobPatch manages only the specified fields of an existing CronJob that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CronJobPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class CronJobPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of a cron job, including the schedule. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.batch.v2alpha1.CronJobSpec>;
+
+      /**
+       * Current status of a cron job. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.batch.v2alpha1.CronJobStatus>;
+
+
+      public getInputs(): Partial<inputApi.batch.v2alpha1.CronJob> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.batch.v2alpha1.CronJob>;
+
+      /**
+      * Create a batch.v2alpha1.CronJobPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.batch.v2alpha1.CronJob>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "batch/v2alpha1";
+          inputs["kind"] = "CronJob";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:batch/v2alpha1:CronJobPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * CronJobList is a collection of cron jobs.
      */
@@ -5303,6 +8019,65 @@ export namespace certificates {
       }
     }
 
+    /**
+     * CertificateSigningRequestPatch manages only the specified fields of an existing CertificateSigningRequest that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the CertificateSigningRequestPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class CertificateSigningRequestPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The certificate request itself and any additional information.
+       */
+      public readonly spec: pulumi.Output<outputApi.certificates.v1beta1.CertificateSigningRequestSpec>;
+
+      /**
+       * Derived information about the request.
+       */
+      public readonly status: pulumi.Output<outputApi.certificates.v1beta1.CertificateSigningRequestStatus>;
+
+
+      public getInputs(): Partial<inputApi.certificates.v1beta1.CertificateSigningRequest> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.certificates.v1beta1.CertificateSigningRequest>;
+
+      /**
+      * Create a certificates.v1beta1.CertificateSigningRequestPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.certificates.v1beta1.CertificateSigningRequest>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "certificates.k8s.io/v1beta1";
+          inputs["kind"] = "CertificateSigningRequest";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:certificates.k8s.io/v1beta1:CertificateSigningRequestPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     
     export class CertificateSigningRequestList extends pulumi.CustomResource {
       /**
@@ -5359,7 +8134,116 @@ export namespace core {
      * Binding ties one object to another; for example, a pod is bound to a node by a scheduler.
      * Deprecated in 1.7, please use the bindings subresource of pods instead.
      */
-    export class Binding extends pulumi.CustomResource {
+    export class Binding extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The target object that you want to bind to the standard object.
+       */
+      public readonly target: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+
+      public getInputs(): inputApi.core.v1.Binding { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.Binding;
+
+      /**
+      * Create a core.v1.Binding resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.Binding, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Binding";
+          inputs["metadata"] = args.metadata;
+          inputs["target"] = args.target;
+          super("kubernetes:core/v1:Binding", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * BindingPatch manages only the specified fields of an existing Binding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the BindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class BindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The target object that you want to bind to the standard object.
+       */
+      public readonly target: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.Binding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Binding>;
+
+      /**
+      * Create a core.v1.BindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.Binding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Binding";
+          inputs["metadata"] = args.metadata;
+          inputs["target"] = args.target;
+          super("kubernetes:core/v1:BindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * ComponentStatus (and ComponentStatusList) holds the cluster validation info.
+     */
+    export class ComponentStatus extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -5368,6 +8252,11 @@ export namespace core {
        */
       public readonly apiVersion: pulumi.Output<string>;
 
+      /**
+       * List of component conditions observed
+       */
+      public readonly conditions: pulumi.Output<outputApi.core.v1.ComponentCondition[]>;
+
       /**
        * Kind is a string value representing the REST resource this object represents. Servers may
        * infer this from the endpoint the client submits requests to. Cannot be updated. In
@@ -5382,37 +8271,35 @@ export namespace core {
        */
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
-      /**
-       * The target object that you want to bind to the standard object.
-       */
-      public readonly target: pulumi.Output<outputApi.core.v1.ObjectReference>;
-
 
-      public getInputs(): inputApi.core.v1.Binding { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.Binding;
+      public getInputs(): inputApi.core.v1.ComponentStatus { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.ComponentStatus;
 
       /**
-      * Create a core.v1.Binding resource with the given unique name, arguments, and options.
+      * Create a core.v1.ComponentStatus resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.Binding, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.core.v1.ComponentStatus, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
-          inputs["kind"] = "Binding";
+          inputs["conditions"] = args.conditions;
+          inputs["kind"] = "ComponentStatus";
           inputs["metadata"] = args.metadata;
-          inputs["target"] = args.target;
-          super("kubernetes:core/v1:Binding", name, inputs, opts);
+          super("kubernetes:core/v1:ComponentStatus", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * ComponentStatus (and ComponentStatusList) holds the cluster validation info.
+     * ComponentStatusPatch manages only the specified fields of an existing ComponentStatus that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ComponentStatusPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class ComponentStatus extends pulumi.CustomResource {
+    export class ComponentStatusPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -5441,23 +8328,23 @@ export namespace core {
       public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
 
-      public getInputs(): inputApi.core.v1.ComponentStatus { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.ComponentStatus;
+      public getInputs(): Partial<inputApi.core.v1.ComponentStatus> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ComponentStatus>;
 
       /**
-      * Create a core.v1.ComponentStatus resource with the given unique name, arguments, and options.
+      * Create a core.v1.ComponentStatusPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.ComponentStatus, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.core.v1.ComponentStatus>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
           inputs["conditions"] = args.conditions;
           inputs["kind"] = "ComponentStatus";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:core/v1:ComponentStatus", name, inputs, opts);
+          super("kubernetes:core/v1:ComponentStatusPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -5569,6 +8456,63 @@ export namespace core {
       }
     }
 
+    /**
+     * ConfigMapPatch manages only the specified fields of an existing ConfigMap that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ConfigMapPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ConfigMapPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Data contains the configuration data. Each key must consist of alphanumeric characters,
+       * '-', '_' or '.'.
+       */
+      public readonly data: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.ConfigMap> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ConfigMap>;
+
+      /**
+      * Create a core.v1.ConfigMapPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.ConfigMap>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["data"] = args.data;
+          inputs["kind"] = "ConfigMap";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:core/v1:ConfigMapPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ConfigMapList is a resource containing a list of ConfigMap objects.
      */
@@ -5690,63 +8634,259 @@ export namespace core {
       }
     }
 
+    /**
+     * EndpointsPatch manages only the specified fields of an existing Endpoints that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the EndpointsPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class EndpointsPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The set of all endpoints is the union of all subsets. Addresses are placed into subsets
+       * according to the IPs they share. A single address with multiple ports, some of which are
+       * ready and some of which are not (because they come from different containers) will result
+       * in the address being displayed in different subsets for the different ports. No address
+       * will appear in both Addresses and NotReadyAddresses in the same subset. Sets of addresses
+       * and ports that comprise a service.
+       */
+      public readonly subsets: pulumi.Output<outputApi.core.v1.EndpointSubset[]>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.Endpoints> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Endpoints>;
+
+      /**
+      * Create a core.v1.EndpointsPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.Endpoints>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Endpoints";
+          inputs["metadata"] = args.metadata;
+          inputs["subsets"] = args.subsets;
+          super("kubernetes:core/v1:EndpointsPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * EndpointsList is a list of endpoints.
      */
     export class EndpointsList extends pulumi.CustomResource {
       /**
-       * APIVersion defines the versioned schema of this representation of an object. Servers should
-       * convert recognized schemas to the latest internal value, and may reject unrecognized
-       * values. More info:
-       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * List of endpoints.
+       */
+      public readonly items: pulumi.Output<outputApi.core.v1.Endpoints[]>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard list metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+
+
+      public getInputs(): inputApi.core.v1.EndpointsList { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.EndpointsList;
+
+      /**
+      * Create a core.v1.EndpointsList resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.EndpointsList, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["items"] = args.items;
+          inputs["kind"] = "EndpointsList";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:core/v1:EndpointsList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * Event is a report of an event somewhere in the cluster.
+     */
+    export class Event extends pulumi.CustomResource {
+      /**
+       * What action was taken/failed regarding to the Regarding object.
+       */
+      public readonly action: pulumi.Output<string>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * The number of times this event has occurred.
+       */
+      public readonly count: pulumi.Output<number>;
+
+      /**
+       * Time when this Event was first observed.
+       */
+      public readonly eventTime: pulumi.Output<string>;
+
+      /**
+       * The time at which the event was first recorded. (Time of server receipt is in TypeMeta.)
+       */
+      public readonly firstTimestamp: pulumi.Output<string>;
+
+      /**
+       * The object that this event is about.
+       */
+      public readonly involvedObject: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * The time at which the most recent occurrence of this event was recorded.
+       */
+      public readonly lastTimestamp: pulumi.Output<string>;
+
+      /**
+       * A human-readable description of the status of this operation.
+       */
+      public readonly message: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * This should be a short, machine understandable string that gives the reason for the
+       * transition into the object's current status.
+       */
+      public readonly reason: pulumi.Output<string>;
+
+      /**
+       * Optional secondary object for more complex actions.
+       */
+      public readonly related: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+      /**
+       * Name of the controller that emitted this Event, e.g. `kubernetes.io/kubelet`.
+       */
+      public readonly reportingComponent: pulumi.Output<string>;
+
+      /**
+       * ID of the controller instance, e.g. `kubelet-xyzf`.
        */
-      public readonly apiVersion: pulumi.Output<string>;
+      public readonly reportingInstance: pulumi.Output<string>;
 
       /**
-       * List of endpoints.
+       * Data about the Event series this event represents or nil if it's a singleton Event.
        */
-      public readonly items: pulumi.Output<outputApi.core.v1.Endpoints[]>;
+      public readonly series: pulumi.Output<outputApi.core.v1.EventSeries>;
 
       /**
-       * Kind is a string value representing the REST resource this object represents. Servers may
-       * infer this from the endpoint the client submits requests to. Cannot be updated. In
-       * CamelCase. More info:
-       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       * The component reporting this event. Should be a short machine understandable string.
        */
-      public readonly kind: pulumi.Output<string>;
+      public readonly source: pulumi.Output<outputApi.core.v1.EventSource>;
 
       /**
-       * Standard list metadata. More info:
-       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       * Type of this event (Normal, Warning), new types could be added in the future
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly type: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.core.v1.EndpointsList { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.EndpointsList;
+      public getInputs(): inputApi.core.v1.Event { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.Event;
 
       /**
-      * Create a core.v1.EndpointsList resource with the given unique name, arguments, and options.
+      * Create a core.v1.Event resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.EndpointsList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.core.v1.Event, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
+          inputs["action"] = args.action;
           inputs["apiVersion"] = "v1";
-          inputs["items"] = args.items;
-          inputs["kind"] = "EndpointsList";
+          inputs["count"] = args.count;
+          inputs["eventTime"] = args.eventTime;
+          inputs["firstTimestamp"] = args.firstTimestamp;
+          inputs["involvedObject"] = args.involvedObject;
+          inputs["kind"] = "Event";
+          inputs["lastTimestamp"] = args.lastTimestamp;
+          inputs["message"] = args.message;
           inputs["metadata"] = args.metadata;
-          super("kubernetes:core/v1:EndpointsList", name, inputs, opts);
+          inputs["reason"] = args.reason;
+          inputs["related"] = args.related;
+          inputs["reportingComponent"] = args.reportingComponent;
+          inputs["reportingInstance"] = args.reportingInstance;
+          inputs["series"] = args.series;
+          inputs["source"] = args.source;
+          inputs["type"] = args.type;
+          super("kubernetes:core/v1:Event", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * Event is a report of an event somewhere in the cluster.
+     * EventPatch manages only the specified fields of an existing Event that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the EventPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class Event extends pulumi.CustomResource {
+    export class EventPatch extends pulumi.CustomResource {
       /**
        * What action was taken/failed regarding to the Regarding object.
        */
@@ -5841,17 +8981,17 @@ export namespace core {
       public readonly type: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.core.v1.Event { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.Event;
+      public getInputs(): Partial<inputApi.core.v1.Event> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Event>;
 
       /**
-      * Create a core.v1.Event resource with the given unique name, arguments, and options.
+      * Create a core.v1.EventPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.Event, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.core.v1.Event>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["action"] = args.action;
           inputs["apiVersion"] = "v1";
@@ -5870,7 +9010,7 @@ export namespace core {
           inputs["series"] = args.series;
           inputs["source"] = args.source;
           inputs["type"] = args.type;
-          super("kubernetes:core/v1:Event", name, inputs, opts);
+          super("kubernetes:core/v1:EventPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -5982,6 +9122,63 @@ export namespace core {
       }
     }
 
+    /**
+     * LimitRangePatch manages only the specified fields of an existing LimitRange that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the LimitRangePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class LimitRangePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the limits enforced. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.LimitRangeSpec>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.LimitRange> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.LimitRange>;
+
+      /**
+      * Create a core.v1.LimitRangePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.LimitRange>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "LimitRange";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          super("kubernetes:core/v1:LimitRangePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * LimitRangeList is a list of LimitRange items.
      */
@@ -6097,6 +9294,70 @@ export namespace core {
       }
     }
 
+    /**
+     * NamespacePatch manages only the specified fields of an existing Namespace that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the NamespacePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class NamespacePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the behavior of the Namespace. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.NamespaceSpec>;
+
+      /**
+       * Status describes the current status of a Namespace. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.NamespaceStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.Namespace> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Namespace>;
+
+      /**
+      * Create a core.v1.NamespacePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.Namespace>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Namespace";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:NamespacePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * NamespaceList is a list of Namespaces.
      */
@@ -6201,23 +9462,134 @@ export namespace core {
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.Node, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.core.v1.Node, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Node";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:Node", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * NodePatch manages only the specified fields of an existing Node that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the NodePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class NodePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the behavior of a node.
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.NodeSpec>;
+
+      /**
+       * Most recently observed status of the node. Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.NodeStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.Node> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Node>;
+
+      /**
+      * Create a core.v1.NodePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.Node>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Node";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:NodePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * NodeConfigSource specifies a source of node configuration. Exactly one subfield (excluding
+     * metadata) must be non-nil.
+     */
+    export class NodeConfigSource extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      
+      public readonly configMapRef: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+
+      public getInputs(): inputApi.core.v1.NodeConfigSource { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.NodeConfigSource;
+
+      /**
+      * Create a core.v1.NodeConfigSource resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.NodeConfigSource, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
-          inputs["kind"] = "Node";
-          inputs["metadata"] = args.metadata;
-          inputs["spec"] = args.spec;
-          inputs["status"] = args.status;
-          super("kubernetes:core/v1:Node", name, inputs, opts);
+          inputs["configMapRef"] = args.configMapRef;
+          inputs["kind"] = "NodeConfigSource";
+          super("kubernetes:core/v1:NodeConfigSource", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * NodeConfigSource specifies a source of node configuration. Exactly one subfield (excluding
-     * metadata) must be non-nil.
+     * NodeConfigSourcePatch manages only the specified fields of an existing NodeConfigSource that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the NodeConfigSourcePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class NodeConfigSource extends pulumi.CustomResource {
+    export class NodeConfigSourcePatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -6238,22 +9610,22 @@ export namespace core {
       public readonly kind: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.core.v1.NodeConfigSource { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.NodeConfigSource;
+      public getInputs(): Partial<inputApi.core.v1.NodeConfigSource> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.NodeConfigSource>;
 
       /**
-      * Create a core.v1.NodeConfigSource resource with the given unique name, arguments, and options.
+      * Create a core.v1.NodeConfigSourcePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.NodeConfigSource, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.core.v1.NodeConfigSource>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
           inputs["configMapRef"] = args.configMapRef;
           inputs["kind"] = "NodeConfigSource";
-          super("kubernetes:core/v1:NodeConfigSource", name, inputs, opts);
+          super("kubernetes:core/v1:NodeConfigSourcePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -6386,6 +9758,84 @@ export namespace core {
       }
     }
 
+    /**
+     * ObjectReferencePatch manages only the specified fields of an existing ObjectReference that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ObjectReferencePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ObjectReferencePatch extends pulumi.CustomResource {
+      /**
+       * API version of the referent.
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * If referring to a piece of an object instead of an entire object, this string should
+       * contain a valid JSON/Go field access statement, such as
+       * desiredState.manifest.containers[2]. For example, if the object reference is to a container
+       * within a pod, this would take on a value like: "spec.containers{name}" (where "name" refers
+       * to the name of the container that triggered the event) or if no container name is specified
+       * "spec.containers[2]" (container with index 2 in this pod). This syntax is chosen only to
+       * have some well-defined way of referencing a part of an object.
+       */
+      public readonly fieldPath: pulumi.Output<string>;
+
+      /**
+       * Kind of the referent. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the referent. More info:
+       * https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * Namespace of the referent. More info:
+       * https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/
+       */
+      public readonly namespace: pulumi.Output<string>;
+
+      /**
+       * Specific resourceVersion to which this reference is made, if any. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#concurrency-control-and-consistency
+       */
+      public readonly resourceVersion: pulumi.Output<string>;
+
+      /**
+       * UID of the referent. More info:
+       * https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#uids
+       */
+      public readonly uid: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.ObjectReference> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ObjectReference>;
+
+      /**
+      * Create a core.v1.ObjectReferencePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.ObjectReference>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "core/v1";
+          inputs["fieldPath"] = args.fieldPath;
+          inputs["kind"] = "ObjectReference";
+          inputs["name"] = args.name;
+          inputs["namespace"] = args.namespace;
+          inputs["resourceVersion"] = args.resourceVersion;
+          inputs["uid"] = args.uid;
+          super("kubernetes:core/v1:ObjectReferencePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PersistentVolume (PV) is a storage resource provisioned by an administrator. It is analogous
      * to a node. More info: https://kubernetes.io/docs/concepts/storage/persistent-volumes
@@ -6450,6 +9900,72 @@ export namespace core {
       }
     }
 
+    /**
+     * PersistentVolumePatch manages only the specified fields of an existing PersistentVolume that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PersistentVolumePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PersistentVolumePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines a specification of a persistent volume owned by the cluster. Provisioned by an
+       * administrator. More info:
+       * https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistent-volumes
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.PersistentVolumeSpec>;
+
+      /**
+       * Status represents the current information/status for the persistent volume. Populated by
+       * the system. Read-only. More info:
+       * https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistent-volumes
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.PersistentVolumeStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.PersistentVolume> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.PersistentVolume>;
+
+      /**
+      * Create a core.v1.PersistentVolumePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.PersistentVolume>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "PersistentVolume";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:PersistentVolumePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PersistentVolumeClaim is a user's request for and claim to a persistent volume
      */
@@ -6512,6 +10028,71 @@ export namespace core {
       }
     }
 
+    /**
+     * PersistentVolumeClaimPatch manages only the specified fields of an existing PersistentVolumeClaim that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PersistentVolumeClaimPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PersistentVolumeClaimPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the desired characteristics of a volume requested by a pod author. More info:
+       * https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.PersistentVolumeClaimSpec>;
+
+      /**
+       * Status represents the current information/status of a persistent volume claim. Read-only.
+       * More info:
+       * https://kubernetes.io/docs/concepts/storage/persistent-volumes#persistentvolumeclaims
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.PersistentVolumeClaimStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.PersistentVolumeClaim> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.PersistentVolumeClaim>;
+
+      /**
+      * Create a core.v1.PersistentVolumeClaimPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.PersistentVolumeClaim>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "PersistentVolumeClaim";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:PersistentVolumeClaimPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PersistentVolumeClaimList is a list of PersistentVolumeClaim items.
      */
@@ -6590,41 +10171,106 @@ export namespace core {
        * CamelCase. More info:
        * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
        */
-      public readonly kind: pulumi.Output<string>;
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard list metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+
+
+      public getInputs(): inputApi.core.v1.PersistentVolumeList { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.PersistentVolumeList;
+
+      /**
+      * Create a core.v1.PersistentVolumeList resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.PersistentVolumeList, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["items"] = args.items;
+          inputs["kind"] = "PersistentVolumeList";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:core/v1:PersistentVolumeList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * Pod is a collection of containers that can run on a host. This resource is created by clients
+     * and scheduled onto hosts.
+     */
+    export class Pod extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of the pod. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.PodSpec>;
 
       /**
-       * Standard list metadata. More info:
-       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       * Most recently observed status of the pod. This data may not be up to date. Populated by the
+       * system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly status: pulumi.Output<outputApi.core.v1.PodStatus>;
 
 
-      public getInputs(): inputApi.core.v1.PersistentVolumeList { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.PersistentVolumeList;
+      public getInputs(): inputApi.core.v1.Pod { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.Pod;
 
       /**
-      * Create a core.v1.PersistentVolumeList resource with the given unique name, arguments, and options.
+      * Create a core.v1.Pod resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.PersistentVolumeList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.core.v1.Pod, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
-          inputs["items"] = args.items;
-          inputs["kind"] = "PersistentVolumeList";
+          inputs["kind"] = "Pod";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:core/v1:PersistentVolumeList", name, inputs, opts);
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:Pod", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * Pod is a collection of containers that can run on a host. This resource is created by clients
-     * and scheduled onto hosts.
+     * PodPatch manages only the specified fields of an existing Pod that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PodPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class Pod extends pulumi.CustomResource {
+    export class PodPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -6661,24 +10307,24 @@ export namespace core {
       public readonly status: pulumi.Output<outputApi.core.v1.PodStatus>;
 
 
-      public getInputs(): inputApi.core.v1.Pod { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.Pod;
+      public getInputs(): Partial<inputApi.core.v1.Pod> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Pod>;
 
       /**
-      * Create a core.v1.Pod resource with the given unique name, arguments, and options.
+      * Create a core.v1.PodPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.Pod, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.core.v1.Pod>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
           inputs["kind"] = "Pod";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:core/v1:Pod", name, inputs, opts);
+          super("kubernetes:core/v1:PodPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -6790,6 +10436,63 @@ export namespace core {
       }
     }
 
+    /**
+     * PodTemplatePatch manages only the specified fields of an existing PodTemplate that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PodTemplatePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PodTemplatePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Template defines the pods that will be created from this pod template.
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly template: pulumi.Output<outputApi.core.v1.PodTemplateSpec>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.PodTemplate> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.PodTemplate>;
+
+      /**
+      * Create a core.v1.PodTemplatePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.PodTemplate>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "PodTemplate";
+          inputs["metadata"] = args.metadata;
+          inputs["template"] = args.template;
+          super("kubernetes:core/v1:PodTemplatePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PodTemplateList is a list of PodTemplates.
      */
@@ -6906,6 +10609,72 @@ export namespace core {
       }
     }
 
+    /**
+     * ReplicationControllerPatch manages only the specified fields of an existing ReplicationController that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ReplicationControllerPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ReplicationControllerPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * If the Labels of a ReplicationController are empty, they are defaulted to be the same as
+       * the Pod(s) that the replication controller manages. Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the specification of the desired behavior of the replication controller. More
+       * info: https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.ReplicationControllerSpec>;
+
+      /**
+       * Status is the most recently observed status of the replication controller. This data may be
+       * out of date by some window of time. Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.ReplicationControllerStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.ReplicationController> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ReplicationController>;
+
+      /**
+      * Create a core.v1.ReplicationControllerPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.ReplicationController>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "ReplicationController";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:ReplicationControllerPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ReplicationControllerList is a collection of replication controllers.
      */
@@ -7021,6 +10790,70 @@ export namespace core {
       }
     }
 
+    /**
+     * ResourceQuotaPatch manages only the specified fields of an existing ResourceQuota that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ResourceQuotaPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ResourceQuotaPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the desired quota.
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.ResourceQuotaSpec>;
+
+      /**
+       * Status defines the actual enforced quota and its current usage.
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.ResourceQuotaStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.ResourceQuota> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ResourceQuota>;
+
+      /**
+      * Create a core.v1.ResourceQuotaPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.ResourceQuota>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "ResourceQuota";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:ResourceQuotaPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ResourceQuotaList is a list of ResourceQuota items.
      */
@@ -7070,16 +10903,89 @@ export namespace core {
           inputs["items"] = args.items;
           inputs["kind"] = "ResourceQuotaList";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:core/v1:ResourceQuotaList", name, inputs, opts);
+          super("kubernetes:core/v1:ResourceQuotaList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * Secret holds secret data of a certain type. The total bytes of the values in the Data field
+     * must be less than MaxSecretSize bytes.
+     */
+    export class Secret extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Data contains the secret data. Each key must consist of alphanumeric characters, '-', '_'
+       * or '.'. The serialized form of the secret data is a base64 encoded string, representing the
+       * arbitrary (possibly non-string) data value here. Described in
+       * https://tools.ietf.org/html/rfc4648#section-4
+       */
+      public readonly data: pulumi.Output<object>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * stringData allows specifying non-binary secret data in string form. It is provided as a
+       * write-only convenience method. All keys and values are merged into the data field on write,
+       * overwriting any existing values. It is never output when reading from the API.
+       */
+      public readonly stringData: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+      /**
+       * Used to facilitate programmatic handling of secret data.
+       */
+      public readonly type: pulumi.Output<string>;
+
+
+      public getInputs(): inputApi.core.v1.Secret { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.Secret;
+
+      /**
+      * Create a core.v1.Secret resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.Secret, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["data"] = args.data;
+          inputs["kind"] = "Secret";
+          inputs["metadata"] = args.metadata;
+          inputs["stringData"] = args.stringData;
+          inputs["type"] = args.type;
+          super("kubernetes:core/v1:Secret", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * Secret holds secret data of a certain type. The total bytes of the values in the Data field
-     * must be less than MaxSecretSize bytes.
+     * SecretPatch manages only the specified fields of an existing Secret that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SecretPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class Secret extends pulumi.CustomResource {
+    export class SecretPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -7123,17 +11029,17 @@ export namespace core {
       public readonly type: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.core.v1.Secret { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.Secret;
+      public getInputs(): Partial<inputApi.core.v1.Secret> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Secret>;
 
       /**
-      * Create a core.v1.Secret resource with the given unique name, arguments, and options.
+      * Create a core.v1.SecretPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.Secret, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.core.v1.Secret>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "v1";
           inputs["data"] = args.data;
@@ -7141,7 +11047,7 @@ export namespace core {
           inputs["metadata"] = args.metadata;
           inputs["stringData"] = args.stringData;
           inputs["type"] = args.type;
-          super("kubernetes:core/v1:Secret", name, inputs, opts);
+          super("kubernetes:core/v1:SecretPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -7263,6 +11169,70 @@ export namespace core {
       }
     }
 
+    /**
+     * ServicePatch manages only the specified fields of an existing Service that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ServicePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ServicePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the behavior of a service.
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.core.v1.ServiceSpec>;
+
+      /**
+       * Most recently observed status of the service. Populated by the system. Read-only. More
+       * info: https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.core.v1.ServiceStatus>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.Service> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.Service>;
+
+      /**
+      * Create a core.v1.ServicePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.Service>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "Service";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:ServicePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ServiceAccount binds together: * a name, understood by users, and perhaps by peripheral
      * systems, for an identity * a principal that can be authenticated and authorized * a set of
@@ -7336,6 +11306,80 @@ export namespace core {
       }
     }
 
+    /**
+     * ServiceAccountPatch manages only the specified fields of an existing ServiceAccount that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ServiceAccountPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ServiceAccountPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * AutomountServiceAccountToken indicates whether pods running as this service account should
+       * have an API token automatically mounted. Can be overridden at the pod level.
+       */
+      public readonly automountServiceAccountToken: pulumi.Output<boolean>;
+
+      /**
+       * ImagePullSecrets is a list of references to secrets in the same namespace to use for
+       * pulling any images in pods that reference this ServiceAccount. ImagePullSecrets are
+       * distinct from Secrets because Secrets can be mounted in the pod, but ImagePullSecrets are
+       * only accessed by the kubelet. More info:
+       * https://kubernetes.io/docs/concepts/containers/images/#specifying-imagepullsecrets-on-a-pod
+       */
+      public readonly imagePullSecrets: pulumi.Output<outputApi.core.v1.LocalObjectReference[]>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Secrets is the list of secrets allowed to be used by pods running using this
+       * ServiceAccount. More info: https://kubernetes.io/docs/concepts/configuration/secret
+       */
+      public readonly secrets: pulumi.Output<outputApi.core.v1.ObjectReference[]>;
+
+
+      public getInputs(): Partial<inputApi.core.v1.ServiceAccount> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.core.v1.ServiceAccount>;
+
+      /**
+      * Create a core.v1.ServiceAccountPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.core.v1.ServiceAccount>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["automountServiceAccountToken"] = args.automountServiceAccountToken;
+          inputs["imagePullSecrets"] = args.imagePullSecrets;
+          inputs["kind"] = "ServiceAccount";
+          inputs["metadata"] = args.metadata;
+          inputs["secrets"] = args.secrets;
+          super("kubernetes:core/v1:ServiceAccountPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ServiceAccountList is a list of ServiceAccount objects
      */
@@ -7419,41 +11463,176 @@ export namespace core {
        * Standard list metadata. More info:
        * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
        */
-      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+
+
+      public getInputs(): inputApi.core.v1.ServiceList { return this.__inputs; }
+      private readonly __inputs: inputApi.core.v1.ServiceList;
+
+      /**
+      * Create a core.v1.ServiceList resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.core.v1.ServiceList, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["items"] = args.items;
+          inputs["kind"] = "ServiceList";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:core/v1:ServiceList", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+  }
+
+}
+
+export namespace events {
+  export namespace v1beta1 {
+    /**
+     * Event is a report of an event somewhere in the cluster. It generally denotes some state
+     * change in the system.
+     */
+    export class Event extends pulumi.CustomResource {
+      /**
+       * What action was taken/failed regarding to the regarding object.
+       */
+      public readonly action: pulumi.Output<string>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Deprecated field assuring backward compatibility with core.v1 Event type
+       */
+      public readonly deprecatedCount: pulumi.Output<number>;
+
+      /**
+       * Deprecated field assuring backward compatibility with core.v1 Event type
+       */
+      public readonly deprecatedFirstTimestamp: pulumi.Output<string>;
+
+      /**
+       * Deprecated field assuring backward compatibility with core.v1 Event type
+       */
+      public readonly deprecatedLastTimestamp: pulumi.Output<string>;
+
+      /**
+       * Deprecated field assuring backward compatibility with core.v1 Event type
+       */
+      public readonly deprecatedSource: pulumi.Output<outputApi.core.v1.EventSource>;
+
+      /**
+       * Required. Time when this Event was first observed.
+       */
+      public readonly eventTime: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Optional. A human-readable description of the status of this operation. Maximal length of
+       * the note is 1kB, but libraries should be prepared to handle values up to 64kB.
+       */
+      public readonly note: pulumi.Output<string>;
+
+      /**
+       * Why the action was taken.
+       */
+      public readonly reason: pulumi.Output<string>;
+
+      /**
+       * The object this Event is about. In most cases it's an Object reporting controller
+       * implements. E.g. ReplicaSetController implements ReplicaSets and this event is emitted
+       * because it acts on some changes in a ReplicaSet object.
+       */
+      public readonly regarding: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+      /**
+       * Optional secondary object for more complex actions. E.g. when regarding object triggers a
+       * creation or deletion of related object.
+       */
+      public readonly related: pulumi.Output<outputApi.core.v1.ObjectReference>;
+
+      /**
+       * Name of the controller that emitted this Event, e.g. `kubernetes.io/kubelet`.
+       */
+      public readonly reportingController: pulumi.Output<string>;
+
+      /**
+       * ID of the controller instance, e.g. `kubelet-xyzf`.
+       */
+      public readonly reportingInstance: pulumi.Output<string>;
+
+      /**
+       * Data about the Event series this event represents or nil if it's a singleton Event.
+       */
+      public readonly series: pulumi.Output<outputApi.events.v1beta1.EventSeries>;
+
+      /**
+       * Type of this event (Normal, Warning), new types could be added in the future.
+       */
+      public readonly type: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.core.v1.ServiceList { return this.__inputs; }
-      private readonly __inputs: inputApi.core.v1.ServiceList;
+      public getInputs(): inputApi.events.v1beta1.Event { return this.__inputs; }
+      private readonly __inputs: inputApi.events.v1beta1.Event;
 
       /**
-      * Create a core.v1.ServiceList resource with the given unique name, arguments, and options.
+      * Create a events.v1beta1.Event resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.core.v1.ServiceList, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.events.v1beta1.Event, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "v1";
-          inputs["items"] = args.items;
-          inputs["kind"] = "ServiceList";
+          inputs["action"] = args.action;
+          inputs["apiVersion"] = "events.k8s.io/v1beta1";
+          inputs["deprecatedCount"] = args.deprecatedCount;
+          inputs["deprecatedFirstTimestamp"] = args.deprecatedFirstTimestamp;
+          inputs["deprecatedLastTimestamp"] = args.deprecatedLastTimestamp;
+          inputs["deprecatedSource"] = args.deprecatedSource;
+          inputs["eventTime"] = args.eventTime;
+          inputs["kind"] = "Event";
           inputs["metadata"] = args.metadata;
-          super("kubernetes:core/v1:ServiceList", name, inputs, opts);
+          inputs["note"] = args.note;
+          inputs["reason"] = args.reason;
+          inputs["regarding"] = args.regarding;
+          inputs["related"] = args.related;
+          inputs["reportingController"] = args.reportingController;
+          inputs["reportingInstance"] = args.reportingInstance;
+          inputs["series"] = args.series;
+          inputs["type"] = args.type;
+          super("kubernetes:events.k8s.io/v1beta1:Event", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
-  }
-
-}
-
-export namespace events {
-  export namespace v1beta1 {
     /**
-     * Event is a report of an event somewhere in the cluster. It generally denotes some state
-     * change in the system.
+     * EventPatch manages only the specified fields of an existing Event that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the EventPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class Event extends pulumi.CustomResource {
+    export class EventPatch extends pulumi.CustomResource {
       /**
        * What action was taken/failed regarding to the regarding object.
        */
@@ -7548,17 +11727,17 @@ export namespace events {
       public readonly type: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.events.v1beta1.Event { return this.__inputs; }
-      private readonly __inputs: inputApi.events.v1beta1.Event;
+      public getInputs(): Partial<inputApi.events.v1beta1.Event> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.events.v1beta1.Event>;
 
       /**
-      * Create a events.v1beta1.Event resource with the given unique name, arguments, and options.
+      * Create a events.v1beta1.EventPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.events.v1beta1.Event, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.events.v1beta1.Event>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["action"] = args.action;
           inputs["apiVersion"] = "events.k8s.io/v1beta1";
@@ -7577,7 +11756,7 @@ export namespace events {
           inputs["reportingInstance"] = args.reportingInstance;
           inputs["series"] = args.series;
           inputs["type"] = args.type;
-          super("kubernetes:events.k8s.io/v1beta1:Event", name, inputs, opts);
+          super("kubernetes:events.k8s.io/v1beta1:EventPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -7699,11 +11878,77 @@ export namespace extensions {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:DaemonSet", "kubernetes:apps/v1beta2:DaemonSet"]);
           super("kubernetes:extensions/v1beta1:DaemonSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * DaemonSetPatch manages only the specified fields of an existing DaemonSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DaemonSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DaemonSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The desired behavior of this daemon set. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.DaemonSetSpec>;
+
+      /**
+       * The current status of this daemon set. This data may be out of date by some window of time.
+       * Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.extensions.v1beta1.DaemonSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.DaemonSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.DaemonSet>;
+
+      /**
+      * Create a extensions.v1beta1.DaemonSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.DaemonSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "DaemonSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:extensions/v1beta1:DaemonSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DaemonSetList is a collection of daemon sets.
      */
@@ -7812,11 +12057,73 @@ export namespace extensions {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:Deployment", "kubernetes:apps/v1beta1:Deployment", "kubernetes:apps/v1beta2:Deployment"]);
           super("kubernetes:extensions/v1beta1:Deployment", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * DeploymentPatch manages only the specified fields of an existing Deployment that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeploymentPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of the Deployment.
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.DeploymentSpec>;
+
+      /**
+       * Most recently observed status of the Deployment.
+       */
+      public readonly status: pulumi.Output<outputApi.extensions.v1beta1.DeploymentStatus>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.Deployment> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.Deployment>;
+
+      /**
+      * Create a extensions.v1beta1.DeploymentPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.Deployment>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "Deployment";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:extensions/v1beta1:DeploymentPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DeploymentList is a list of Deployments.
      */
@@ -7870,9 +12177,130 @@ export namespace extensions {
     }
 
     /**
-     * DEPRECATED. DeploymentRollback stores the information required to rollback a deployment.
+     * DEPRECATED. DeploymentRollback stores the information required to rollback a deployment.
+     */
+    export class DeploymentRollback extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Required: This must match the Name of a deployment.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * The config of this deployment rollback.
+       */
+      public readonly rollbackTo: pulumi.Output<outputApi.extensions.v1beta1.RollbackConfig>;
+
+      /**
+       * The annotations to be updated to a deployment
+       */
+      public readonly updatedAnnotations: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+
+      public getInputs(): inputApi.extensions.v1beta1.DeploymentRollback { return this.__inputs; }
+      private readonly __inputs: inputApi.extensions.v1beta1.DeploymentRollback;
+
+      /**
+      * Create a extensions.v1beta1.DeploymentRollback resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.extensions.v1beta1.DeploymentRollback, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "DeploymentRollback";
+          inputs["name"] = args.name;
+          inputs["rollbackTo"] = args.rollbackTo;
+          inputs["updatedAnnotations"] = args.updatedAnnotations;
+          super("kubernetes:extensions/v1beta1:DeploymentRollback", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * DeploymentRollbackPatch manages only the specified fields of an existing DeploymentRollback that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeploymentRollbackPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeploymentRollbackPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Required: This must match the Name of a deployment.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * The config of this deployment rollback.
+       */
+      public readonly rollbackTo: pulumi.Output<outputApi.extensions.v1beta1.RollbackConfig>;
+
+      /**
+       * The annotations to be updated to a deployment
+       */
+      public readonly updatedAnnotations: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.DeploymentRollback> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.DeploymentRollback>;
+
+      /**
+      * Create a extensions.v1beta1.DeploymentRollbackPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.DeploymentRollback>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "DeploymentRollback";
+          inputs["name"] = args.name;
+          inputs["rollbackTo"] = args.rollbackTo;
+          inputs["updatedAnnotations"] = args.updatedAnnotations;
+          super("kubernetes:extensions/v1beta1:DeploymentRollbackPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * Ingress is a collection of rules that allow inbound connections to reach the endpoints
+     * defined by a backend. An Ingress can be configured to give services externally-reachable
+     * urls, load balance traffic, terminate SSL, offer name based virtual hosting etc.
      */
-    export class DeploymentRollback extends pulumi.CustomResource {
+    export class Ingress extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -7890,49 +12318,53 @@ export namespace extensions {
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * Required: This must match the Name of a deployment.
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
        */
-      public readonly name: pulumi.Output<string>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * The config of this deployment rollback.
+       * Spec is the desired state of the Ingress. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
        */
-      public readonly rollbackTo: pulumi.Output<outputApi.extensions.v1beta1.RollbackConfig>;
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.IngressSpec>;
 
       /**
-       * The annotations to be updated to a deployment
+       * Status is the current state of the Ingress. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
        */
-      public readonly updatedAnnotations: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+      public readonly status: pulumi.Output<outputApi.extensions.v1beta1.IngressStatus>;
 
 
-      public getInputs(): inputApi.extensions.v1beta1.DeploymentRollback { return this.__inputs; }
-      private readonly __inputs: inputApi.extensions.v1beta1.DeploymentRollback;
+      public getInputs(): inputApi.extensions.v1beta1.Ingress { return this.__inputs; }
+      private readonly __inputs: inputApi.extensions.v1beta1.Ingress;
 
       /**
-      * Create a extensions.v1beta1.DeploymentRollback resource with the given unique name, arguments, and options.
+      * Create a extensions.v1beta1.Ingress resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.extensions.v1beta1.DeploymentRollback, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.extensions.v1beta1.Ingress, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "extensions/v1beta1";
-          inputs["kind"] = "DeploymentRollback";
-          inputs["name"] = args.name;
-          inputs["rollbackTo"] = args.rollbackTo;
-          inputs["updatedAnnotations"] = args.updatedAnnotations;
-          super("kubernetes:extensions/v1beta1:DeploymentRollback", name, inputs, opts);
+          inputs["kind"] = "Ingress";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:extensions/v1beta1:Ingress", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * Ingress is a collection of rules that allow inbound connections to reach the endpoints
-     * defined by a backend. An Ingress can be configured to give services externally-reachable
-     * urls, load balance traffic, terminate SSL, offer name based virtual hosting etc.
+     * IngressPatch manages only the specified fields of an existing Ingress that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the IngressPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class Ingress extends pulumi.CustomResource {
+    export class IngressPatch extends pulumi.CustomResource {
       /**
        * APIVersion defines the versioned schema of this representation of an object. Servers should
        * convert recognized schemas to the latest internal value, and may reject unrecognized
@@ -7968,24 +12400,24 @@ export namespace extensions {
       public readonly status: pulumi.Output<outputApi.extensions.v1beta1.IngressStatus>;
 
 
-      public getInputs(): inputApi.extensions.v1beta1.Ingress { return this.__inputs; }
-      private readonly __inputs: inputApi.extensions.v1beta1.Ingress;
+      public getInputs(): Partial<inputApi.extensions.v1beta1.Ingress> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.Ingress>;
 
       /**
-      * Create a extensions.v1beta1.Ingress resource with the given unique name, arguments, and options.
+      * Create a extensions.v1beta1.IngressPatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.extensions.v1beta1.Ingress, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.Ingress>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "extensions/v1beta1";
           inputs["kind"] = "Ingress";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:extensions/v1beta1:Ingress", name, inputs, opts);
+          super("kubernetes:extensions/v1beta1:IngressPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -8093,11 +12525,68 @@ export namespace extensions {
           inputs["kind"] = "NetworkPolicy";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
+          opts = withAliases(opts, ["kubernetes:networking.k8s.io/v1:NetworkPolicy"]);
           super("kubernetes:extensions/v1beta1:NetworkPolicy", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * NetworkPolicyPatch manages only the specified fields of an existing NetworkPolicy that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the NetworkPolicyPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class NetworkPolicyPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior for this NetworkPolicy.
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.NetworkPolicySpec>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.NetworkPolicy> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.NetworkPolicy>;
+
+      /**
+      * Create a extensions.v1beta1.NetworkPolicyPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.NetworkPolicy>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "NetworkPolicy";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          super("kubernetes:extensions/v1beta1:NetworkPolicyPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DEPRECATED 1.9 - This group version of NetworkPolicyList is deprecated by
      * networking/v1/NetworkPolicyList. Network Policy List is a list of NetworkPolicy objects.
@@ -8206,6 +12695,62 @@ export namespace extensions {
       }
     }
 
+    /**
+     * PodSecurityPolicyPatch manages only the specified fields of an existing PodSecurityPolicy that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PodSecurityPolicyPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PodSecurityPolicyPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * spec defines the policy enforced.
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.PodSecurityPolicySpec>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.PodSecurityPolicy> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.PodSecurityPolicy>;
+
+      /**
+      * Create a extensions.v1beta1.PodSecurityPolicyPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.PodSecurityPolicy>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "PodSecurityPolicy";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          super("kubernetes:extensions/v1beta1:PodSecurityPolicyPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * Pod Security Policy List is a list of PodSecurityPolicy objects.
      */
@@ -8319,11 +12864,78 @@ export namespace extensions {
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
+          opts = withAliases(opts, ["kubernetes:apps/v1:ReplicaSet", "kubernetes:apps/v1beta2:ReplicaSet"]);
           super("kubernetes:extensions/v1beta1:ReplicaSet", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ReplicaSetPatch manages only the specified fields of an existing ReplicaSet that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ReplicaSetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ReplicaSetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * If the Labels of a ReplicaSet are empty, they are defaulted to be the same as the Pod(s)
+       * that the ReplicaSet manages. Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Spec defines the specification of the desired behavior of the ReplicaSet. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.ReplicaSetSpec>;
+
+      /**
+       * Status is the most recently observed status of the ReplicaSet. This data may be out of date
+       * by some window of time. Populated by the system. Read-only. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<outputApi.extensions.v1beta1.ReplicaSetStatus>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.ReplicaSet> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.ReplicaSet>;
+
+      /**
+      * Create a extensions.v1beta1.ReplicaSetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.ReplicaSet>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "ReplicaSet";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:extensions/v1beta1:ReplicaSetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ReplicaSetList is a collection of ReplicaSets.
      */
@@ -8418,24 +13030,89 @@ export namespace extensions {
       public readonly status: pulumi.Output<outputApi.extensions.v1beta1.ScaleStatus>;
 
 
-      public getInputs(): inputApi.extensions.v1beta1.Scale { return this.__inputs; }
-      private readonly __inputs: inputApi.extensions.v1beta1.Scale;
+      public getInputs(): inputApi.extensions.v1beta1.Scale { return this.__inputs; }
+      private readonly __inputs: inputApi.extensions.v1beta1.Scale;
+
+      /**
+      * Create a extensions.v1beta1.Scale resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.extensions.v1beta1.Scale, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "extensions/v1beta1";
+          inputs["kind"] = "Scale";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:extensions/v1beta1:Scale", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * ScalePatch manages only the specified fields of an existing Scale that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ScalePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ScalePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata; More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * defines the behavior of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       */
+      public readonly spec: pulumi.Output<outputApi.extensions.v1beta1.ScaleSpec>;
+
+      /**
+       * current status of the scale. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status.
+       * Read-only.
+       */
+      public readonly status: pulumi.Output<outputApi.extensions.v1beta1.ScaleStatus>;
+
+
+      public getInputs(): Partial<inputApi.extensions.v1beta1.Scale> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.extensions.v1beta1.Scale>;
 
       /**
-      * Create a extensions.v1beta1.Scale resource with the given unique name, arguments, and options.
+      * Create a extensions.v1beta1.ScalePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.extensions.v1beta1.Scale, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.extensions.v1beta1.Scale>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "extensions/v1beta1";
           inputs["kind"] = "Scale";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
           inputs["status"] = args.status;
-          super("kubernetes:extensions/v1beta1:Scale", name, inputs, opts);
+          super("kubernetes:extensions/v1beta1:ScalePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -8517,6 +13194,80 @@ export namespace meta {
       }
     }
 
+    /**
+     * APIGroupPatch manages only the specified fields of an existing APIGroup that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the APIGroupPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class APIGroupPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * name is the name of the group.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * preferredVersion is the version preferred by the API server, which probably is the storage
+       * version.
+       */
+      public readonly preferredVersion: pulumi.Output<outputApi.meta.v1.GroupVersionForDiscovery>;
+
+      /**
+       * a map of client CIDR to server address that is serving this group. This is to help clients
+       * reach servers in the most network-efficient way possible. Clients can use the appropriate
+       * server address as per the CIDR that they match. In case of multiple matches, clients should
+       * use the longest matching CIDR. The server returns only those CIDRs that it thinks that the
+       * client can match. For example: the master will return an internal IP CIDR only, if the
+       * client reaches the server using an internal IP. Server looks at X-Forwarded-For header or
+       * X-Real-Ip header or request.RemoteAddr (in that order) to get the client IP.
+       */
+      public readonly serverAddressByClientCIDRs: pulumi.Output<outputApi.meta.v1.ServerAddressByClientCIDR[]>;
+
+      /**
+       * versions are the versions supported in this group.
+       */
+      public readonly versions: pulumi.Output<outputApi.meta.v1.GroupVersionForDiscovery[]>;
+
+
+      public getInputs(): Partial<inputApi.meta.v1.APIGroup> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.meta.v1.APIGroup>;
+
+      /**
+      * Create a meta.v1.APIGroupPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.meta.v1.APIGroup>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "APIGroup";
+          inputs["name"] = args.name;
+          inputs["preferredVersion"] = args.preferredVersion;
+          inputs["serverAddressByClientCIDRs"] = args.serverAddressByClientCIDRs;
+          inputs["versions"] = args.versions;
+          super("kubernetes:core/v1:APIGroupPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * APIGroupList is a list of APIGroup, to allow clients to discover the API at /apis.
      */
@@ -8675,6 +13426,67 @@ export namespace meta {
       }
     }
 
+    /**
+     * APIVersionsPatch manages only the specified fields of an existing APIVersions that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the APIVersionsPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class APIVersionsPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * a map of client CIDR to server address that is serving this group. This is to help clients
+       * reach servers in the most network-efficient way possible. Clients can use the appropriate
+       * server address as per the CIDR that they match. In case of multiple matches, clients should
+       * use the longest matching CIDR. The server returns only those CIDRs that it thinks that the
+       * client can match. For example: the master will return an internal IP CIDR only, if the
+       * client reaches the server using an internal IP. Server looks at X-Forwarded-For header or
+       * X-Real-Ip header or request.RemoteAddr (in that order) to get the client IP.
+       */
+      public readonly serverAddressByClientCIDRs: pulumi.Output<outputApi.meta.v1.ServerAddressByClientCIDR[]>;
+
+      /**
+       * versions are the api versions that are available.
+       */
+      public readonly versions: pulumi.Output<string[]>;
+
+
+      public getInputs(): Partial<inputApi.meta.v1.APIVersions> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.meta.v1.APIVersions>;
+
+      /**
+      * Create a meta.v1.APIVersionsPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.meta.v1.APIVersions>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["kind"] = "APIVersions";
+          inputs["serverAddressByClientCIDRs"] = args.serverAddressByClientCIDRs;
+          inputs["versions"] = args.versions;
+          super("kubernetes:core/v1:APIVersionsPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * DeleteOptions may be provided when deleting an API object.
      */
@@ -8712,50 +13524,194 @@ export namespace meta {
       public readonly orphanDependents: pulumi.Output<boolean>;
 
       /**
-       * Must be fulfilled before a deletion is carried out. If not possible, a 409 Conflict status
-       * will be returned.
+       * Must be fulfilled before a deletion is carried out. If not possible, a 409 Conflict status
+       * will be returned.
+       */
+      public readonly preconditions: pulumi.Output<outputApi.meta.v1.Preconditions>;
+
+      /**
+       * Whether and how garbage collection will be performed. Either this field or OrphanDependents
+       * may be set, but not both. The default policy is decided by the existing finalizer set in
+       * the metadata.finalizers and the resource-specific default policy. Acceptable values are:
+       * 'Orphan' - orphan the dependents; 'Background' - allow the garbage collector to delete the
+       * dependents in the background; 'Foreground' - a cascading policy that deletes all dependents
+       * in the foreground.
+       */
+      public readonly propagationPolicy: pulumi.Output<string>;
+
+
+      public getInputs(): inputApi.meta.v1.DeleteOptions { return this.__inputs; }
+      private readonly __inputs: inputApi.meta.v1.DeleteOptions;
+
+      /**
+      * Create a meta.v1.DeleteOptions resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.meta.v1.DeleteOptions, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["gracePeriodSeconds"] = args.gracePeriodSeconds;
+          inputs["kind"] = "DeleteOptions";
+          inputs["orphanDependents"] = args.orphanDependents;
+          inputs["preconditions"] = args.preconditions;
+          inputs["propagationPolicy"] = args.propagationPolicy;
+          super("kubernetes:core/v1:DeleteOptions", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * DeleteOptionsPatch manages only the specified fields of an existing DeleteOptions that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the DeleteOptionsPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class DeleteOptionsPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * The duration in seconds before the object should be deleted. Value must be non-negative
+       * integer. The value zero indicates delete immediately. If this value is nil, the default
+       * grace period for the specified type will be used. Defaults to a per object value if not
+       * specified. zero means delete immediately.
+       */
+      public readonly gracePeriodSeconds: pulumi.Output<number>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Deprecated: please use the PropagationPolicy, this field will be deprecated in 1.7. Should
+       * the dependent objects be orphaned. If true/false, the "orphan" finalizer will be added
+       * to/removed from the object's finalizers list. Either this field or PropagationPolicy may be
+       * set, but not both.
+       */
+      public readonly orphanDependents: pulumi.Output<boolean>;
+
+      /**
+       * Must be fulfilled before a deletion is carried out. If not possible, a 409 Conflict status
+       * will be returned.
+       */
+      public readonly preconditions: pulumi.Output<outputApi.meta.v1.Preconditions>;
+
+      /**
+       * Whether and how garbage collection will be performed. Either this field or OrphanDependents
+       * may be set, but not both. The default policy is decided by the existing finalizer set in
+       * the metadata.finalizers and the resource-specific default policy. Acceptable values are:
+       * 'Orphan' - orphan the dependents; 'Background' - allow the garbage collector to delete the
+       * dependents in the background; 'Foreground' - a cascading policy that deletes all dependents
+       * in the foreground.
+       */
+      public readonly propagationPolicy: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.meta.v1.DeleteOptions> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.meta.v1.DeleteOptions>;
+
+      /**
+      * Create a meta.v1.DeleteOptionsPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.meta.v1.DeleteOptions>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["gracePeriodSeconds"] = args.gracePeriodSeconds;
+          inputs["kind"] = "DeleteOptions";
+          inputs["orphanDependents"] = args.orphanDependents;
+          inputs["preconditions"] = args.preconditions;
+          inputs["propagationPolicy"] = args.propagationPolicy;
+          super("kubernetes:core/v1:DeleteOptionsPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * OwnerReference contains enough information to let you identify an owning object. Currently,
+     * an owning object must be in the same namespace, so there is no namespace field.
+     */
+    export class OwnerReference extends pulumi.CustomResource {
+      /**
+       * API version of the referent.
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * If true, AND if the owner has the "foregroundDeletion" finalizer, then the owner cannot be
+       * deleted from the key-value store until this reference is removed. Defaults to false. To set
+       * this field, a user needs "delete" permission of the owner, otherwise 422 (Unprocessable
+       * Entity) will be returned.
+       */
+      public readonly blockOwnerDeletion: pulumi.Output<boolean>;
+
+      /**
+       * If true, this reference points to the managing controller.
+       */
+      public readonly controller: pulumi.Output<boolean>;
+
+      /**
+       * Kind of the referent. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#names
        */
-      public readonly preconditions: pulumi.Output<outputApi.meta.v1.Preconditions>;
+      public readonly name: pulumi.Output<string>;
 
       /**
-       * Whether and how garbage collection will be performed. Either this field or OrphanDependents
-       * may be set, but not both. The default policy is decided by the existing finalizer set in
-       * the metadata.finalizers and the resource-specific default policy. Acceptable values are:
-       * 'Orphan' - orphan the dependents; 'Background' - allow the garbage collector to delete the
-       * dependents in the background; 'Foreground' - a cascading policy that deletes all dependents
-       * in the foreground.
+       * UID of the referent. More info: http://kubernetes.io/docs/user-guide/identifiers#uids
        */
-      public readonly propagationPolicy: pulumi.Output<string>;
+      public readonly uid: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.meta.v1.DeleteOptions { return this.__inputs; }
-      private readonly __inputs: inputApi.meta.v1.DeleteOptions;
+      public getInputs(): inputApi.meta.v1.OwnerReference { return this.__inputs; }
+      private readonly __inputs: inputApi.meta.v1.OwnerReference;
 
       /**
-      * Create a meta.v1.DeleteOptions resource with the given unique name, arguments, and options.
+      * Create a meta.v1.OwnerReference resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.meta.v1.DeleteOptions, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.meta.v1.OwnerReference, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "v1";
-          inputs["gracePeriodSeconds"] = args.gracePeriodSeconds;
-          inputs["kind"] = "DeleteOptions";
-          inputs["orphanDependents"] = args.orphanDependents;
-          inputs["preconditions"] = args.preconditions;
-          inputs["propagationPolicy"] = args.propagationPolicy;
-          super("kubernetes:core/v1:DeleteOptions", name, inputs, opts);
+          inputs["apiVersion"] = "meta/v1";
+          inputs["blockOwnerDeletion"] = args.blockOwnerDeletion;
+          inputs["controller"] = args.controller;
+          inputs["kind"] = "OwnerReference";
+          inputs["name"] = args.name;
+          inputs["uid"] = args.uid;
+          super("kubernetes:meta/v1:OwnerReference", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
     /**
-     * OwnerReference contains enough information to let you identify an owning object. Currently,
-     * an owning object must be in the same namespace, so there is no namespace field.
+     * OwnerReferencePatch manages only the specified fields of an existing OwnerReference that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the OwnerReferencePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class OwnerReference extends pulumi.CustomResource {
+    export class OwnerReferencePatch extends pulumi.CustomResource {
       /**
        * API version of the referent.
        */
@@ -8791,17 +13747,17 @@ export namespace meta {
       public readonly uid: pulumi.Output<string>;
 
 
-      public getInputs(): inputApi.meta.v1.OwnerReference { return this.__inputs; }
-      private readonly __inputs: inputApi.meta.v1.OwnerReference;
+      public getInputs(): Partial<inputApi.meta.v1.OwnerReference> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.meta.v1.OwnerReference>;
 
       /**
-      * Create a meta.v1.OwnerReference resource with the given unique name, arguments, and options.
+      * Create a meta.v1.OwnerReferencePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.meta.v1.OwnerReference, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.meta.v1.OwnerReference>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["apiVersion"] = "meta/v1";
           inputs["blockOwnerDeletion"] = args.blockOwnerDeletion;
@@ -8809,7 +13765,7 @@ export namespace meta {
           inputs["kind"] = "OwnerReference";
           inputs["name"] = args.name;
           inputs["uid"] = args.uid;
-          super("kubernetes:meta/v1:OwnerReference", name, inputs, opts);
+          super("kubernetes:meta/v1:OwnerReferencePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -8896,6 +13852,91 @@ export namespace meta {
       }
     }
 
+    /**
+     * StatusPatch manages only the specified fields of an existing Status that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StatusPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class StatusPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Suggested HTTP return code for this status, 0 if not set.
+       */
+      public readonly code: pulumi.Output<number>;
+
+      /**
+       * Extended data associated with the reason.  Each reason may define its own extended details.
+       * This field is optional and the data returned is not guaranteed to conform to any schema
+       * except that defined by the reason type.
+       */
+      public readonly details: pulumi.Output<outputApi.meta.v1.StatusDetails>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * A human-readable description of the status of this operation.
+       */
+      public readonly message: pulumi.Output<string>;
+
+      /**
+       * Standard list metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ListMeta>;
+
+      /**
+       * A machine-readable description of why this operation is in the "Failure" status. If this
+       * value is empty there is no information available. A Reason clarifies an HTTP status code
+       * but does not override it.
+       */
+      public readonly reason: pulumi.Output<string>;
+
+      /**
+       * Status of the operation. One of: "Success" or "Failure". More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#spec-and-status
+       */
+      public readonly status: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.meta.v1.Status> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.meta.v1.Status>;
+
+      /**
+      * Create a meta.v1.StatusPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.meta.v1.Status>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "v1";
+          inputs["code"] = args.code;
+          inputs["details"] = args.details;
+          inputs["kind"] = "Status";
+          inputs["message"] = args.message;
+          inputs["metadata"] = args.metadata;
+          inputs["reason"] = args.reason;
+          inputs["status"] = args.status;
+          super("kubernetes:core/v1:StatusPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
   }
 
 }
@@ -8950,11 +13991,68 @@ export namespace networking {
           inputs["kind"] = "NetworkPolicy";
           inputs["metadata"] = args.metadata;
           inputs["spec"] = args.spec;
+          opts = withAliases(opts, ["kubernetes:extensions/v1beta1:NetworkPolicy"]);
           super("kubernetes:networking.k8s.io/v1:NetworkPolicy", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * NetworkPolicyPatch manages only the specified fields of an existing NetworkPolicy that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the NetworkPolicyPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class NetworkPolicyPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior for this NetworkPolicy.
+       */
+      public readonly spec: pulumi.Output<outputApi.networking.v1.NetworkPolicySpec>;
+
+
+      public getInputs(): Partial<inputApi.networking.v1.NetworkPolicy> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.networking.v1.NetworkPolicy>;
+
+      /**
+      * Create a networking.v1.NetworkPolicyPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.networking.v1.NetworkPolicy>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "networking.k8s.io/v1";
+          inputs["kind"] = "NetworkPolicy";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          super("kubernetes:networking.k8s.io/v1:NetworkPolicyPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * NetworkPolicyList is a list of NetworkPolicy objects.
      */
@@ -9068,6 +14166,61 @@ export namespace policy {
       }
     }
 
+    /**
+     * EvictionPatch manages only the specified fields of an existing Eviction that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the EvictionPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class EvictionPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * DeleteOptions may be provided
+       */
+      public readonly deleteOptions: pulumi.Output<outputApi.meta.v1.DeleteOptions>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * ObjectMeta describes the pod that is being evicted.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+
+      public getInputs(): Partial<inputApi.policy.v1beta1.Eviction> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.policy.v1beta1.Eviction>;
+
+      /**
+      * Create a policy.v1beta1.EvictionPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.policy.v1beta1.Eviction>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "policy/v1beta1";
+          inputs["deleteOptions"] = args.deleteOptions;
+          inputs["kind"] = "Eviction";
+          inputs["metadata"] = args.metadata;
+          super("kubernetes:policy/v1beta1:EvictionPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PodDisruptionBudget is an object to define the max disruption that can be caused to a
      * collection of pods
@@ -9125,6 +14278,65 @@ export namespace policy {
       }
     }
 
+    /**
+     * PodDisruptionBudgetPatch manages only the specified fields of an existing PodDisruptionBudget that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PodDisruptionBudgetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PodDisruptionBudgetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired behavior of the PodDisruptionBudget.
+       */
+      public readonly spec: pulumi.Output<outputApi.policy.v1beta1.PodDisruptionBudgetSpec>;
+
+      /**
+       * Most recently observed status of the PodDisruptionBudget.
+       */
+      public readonly status: pulumi.Output<outputApi.policy.v1beta1.PodDisruptionBudgetStatus>;
+
+
+      public getInputs(): Partial<inputApi.policy.v1beta1.PodDisruptionBudget> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.policy.v1beta1.PodDisruptionBudget>;
+
+      /**
+      * Create a policy.v1beta1.PodDisruptionBudgetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.policy.v1beta1.PodDisruptionBudget>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "policy/v1beta1";
+          inputs["kind"] = "PodDisruptionBudget";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:policy/v1beta1:PodDisruptionBudgetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PodDisruptionBudgetList is a collection of PodDisruptionBudgets.
      */
@@ -9235,11 +14447,75 @@ export namespace rbac {
           inputs["kind"] = "ClusterRole";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRole", "kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRole"]);
           super("kubernetes:rbac.authorization.k8s.io/v1:ClusterRole", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ClusterRolePatch manages only the specified fields of an existing ClusterRole that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ClusterRolePatch extends pulumi.CustomResource {
+      /**
+       * AggregationRule is an optional field that describes how to build the Rules for this
+       * ClusterRole. If AggregationRule is set, then the Rules are controller managed and direct
+       * changes to Rules will be stomped by the controller.
+       */
+      public readonly aggregationRule: pulumi.Output<outputApi.rbac.v1.AggregationRule>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Rules holds all the PolicyRules for this ClusterRole
+       */
+      public readonly rules: pulumi.Output<outputApi.rbac.v1.PolicyRule[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1.ClusterRole> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1.ClusterRole>;
+
+      /**
+      * Create a rbac.v1.ClusterRolePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1.ClusterRole>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["aggregationRule"] = args.aggregationRule;
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1";
+          inputs["kind"] = "ClusterRole";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          super("kubernetes:rbac.authorization.k8s.io/v1:ClusterRolePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ClusterRoleBinding references a ClusterRole, but not contain it.  It can reference a
      * ClusterRole in the global namespace, and adds who information via Subject.
@@ -9295,11 +14571,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRoleBinding", "kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1:ClusterRoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ClusterRoleBindingPatch manages only the specified fields of an existing ClusterRoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ClusterRoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can only reference a ClusterRole in the global namespace. If the RoleRef cannot be
+       * resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1.ClusterRoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1.ClusterRoleBinding>;
+
+      /**
+      * Create a rbac.v1.ClusterRoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1.ClusterRoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1";
+          inputs["kind"] = "ClusterRoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1:ClusterRoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ClusterRoleBindingList is a collection of ClusterRoleBindings
      */
@@ -9452,11 +14791,67 @@ export namespace rbac {
           inputs["kind"] = "Role";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1alpha1:Role", "kubernetes:rbac.authorization.k8s.io/v1beta1:Role"]);
           super("kubernetes:rbac.authorization.k8s.io/v1:Role", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RolePatch manages only the specified fields of an existing Role that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RolePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Rules holds all the PolicyRules for this Role
+       */
+      public readonly rules: pulumi.Output<outputApi.rbac.v1.PolicyRule[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1.Role> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1.Role>;
+
+      /**
+      * Create a rbac.v1.RolePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1.Role>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1";
+          inputs["kind"] = "Role";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          super("kubernetes:rbac.authorization.k8s.io/v1:RolePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBinding references a role, but does not contain it.  It can reference a Role in the same
      * namespace or a ClusterRole in the global namespace. It adds who information via Subjects and
@@ -9514,11 +14909,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1alpha1:RoleBinding", "kubernetes:rbac.authorization.k8s.io/v1beta1:RoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1:RoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RoleBindingPatch manages only the specified fields of an existing RoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can reference a Role in the current namespace or a ClusterRole in the global
+       * namespace. If the RoleRef cannot be resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1.RoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1.RoleBinding>;
+
+      /**
+      * Create a rbac.v1.RoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1.RoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1";
+          inputs["kind"] = "RoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1:RoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBindingList is a collection of RoleBindings
      */
@@ -9665,24 +15123,88 @@ export namespace rbac {
       public readonly rules: pulumi.Output<outputApi.rbac.v1alpha1.PolicyRule[]>;
 
 
-      public getInputs(): inputApi.rbac.v1alpha1.ClusterRole { return this.__inputs; }
-      private readonly __inputs: inputApi.rbac.v1alpha1.ClusterRole;
+      public getInputs(): inputApi.rbac.v1alpha1.ClusterRole { return this.__inputs; }
+      private readonly __inputs: inputApi.rbac.v1alpha1.ClusterRole;
+
+      /**
+      * Create a rbac.v1alpha1.ClusterRole resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.rbac.v1alpha1.ClusterRole, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["aggregationRule"] = args.aggregationRule;
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1alpha1";
+          inputs["kind"] = "ClusterRole";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:ClusterRole", "kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRole"]);
+          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRole", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * ClusterRolePatch manages only the specified fields of an existing ClusterRole that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ClusterRolePatch extends pulumi.CustomResource {
+      /**
+       * AggregationRule is an optional field that describes how to build the Rules for this
+       * ClusterRole. If AggregationRule is set, then the Rules are controller managed and direct
+       * changes to Rules will be stomped by the controller.
+       */
+      public readonly aggregationRule: pulumi.Output<outputApi.rbac.v1alpha1.AggregationRule>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Rules holds all the PolicyRules for this ClusterRole
+       */
+      public readonly rules: pulumi.Output<outputApi.rbac.v1alpha1.PolicyRule[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1alpha1.ClusterRole> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1alpha1.ClusterRole>;
 
       /**
-      * Create a rbac.v1alpha1.ClusterRole resource with the given unique name, arguments, and options.
+      * Create a rbac.v1alpha1.ClusterRolePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.rbac.v1alpha1.ClusterRole, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.rbac.v1alpha1.ClusterRole>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["aggregationRule"] = args.aggregationRule;
           inputs["apiVersion"] = "rbac.authorization.k8s.io/v1alpha1";
           inputs["kind"] = "ClusterRole";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
-          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRole", name, inputs, opts);
+          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRolePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -9742,11 +15264,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:ClusterRoleBinding", "kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ClusterRoleBindingPatch manages only the specified fields of an existing ClusterRoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ClusterRoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can only reference a ClusterRole in the global namespace. If the RoleRef cannot be
+       * resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1alpha1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1alpha1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1alpha1.ClusterRoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1alpha1.ClusterRoleBinding>;
+
+      /**
+      * Create a rbac.v1alpha1.ClusterRoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1alpha1.ClusterRoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1alpha1";
+          inputs["kind"] = "ClusterRoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ClusterRoleBindingList is a collection of ClusterRoleBindings
      */
@@ -9899,11 +15484,67 @@ export namespace rbac {
           inputs["kind"] = "Role";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:Role", "kubernetes:rbac.authorization.k8s.io/v1beta1:Role"]);
           super("kubernetes:rbac.authorization.k8s.io/v1alpha1:Role", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RolePatch manages only the specified fields of an existing Role that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RolePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Rules holds all the PolicyRules for this Role
+       */
+      public readonly rules: pulumi.Output<outputApi.rbac.v1alpha1.PolicyRule[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1alpha1.Role> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1alpha1.Role>;
+
+      /**
+      * Create a rbac.v1alpha1.RolePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1alpha1.Role>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1alpha1";
+          inputs["kind"] = "Role";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:RolePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBinding references a role, but does not contain it.  It can reference a Role in the same
      * namespace or a ClusterRole in the global namespace. It adds who information via Subjects and
@@ -9961,11 +15602,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:RoleBinding", "kubernetes:rbac.authorization.k8s.io/v1beta1:RoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1alpha1:RoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RoleBindingPatch manages only the specified fields of an existing RoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can reference a Role in the current namespace or a ClusterRole in the global
+       * namespace. If the RoleRef cannot be resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1alpha1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1alpha1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1alpha1.RoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1alpha1.RoleBinding>;
+
+      /**
+      * Create a rbac.v1alpha1.RoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1alpha1.RoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1alpha1";
+          inputs["kind"] = "RoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1alpha1:RoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBindingList is a collection of RoleBindings
      */
@@ -10084,53 +15788,171 @@ export namespace rbac {
       public readonly apiVersion: pulumi.Output<string>;
 
       /**
-       * Kind of object being referenced. Values defined by this API group are "User", "Group", and
-       * "ServiceAccount". If the Authorizer does not recognized the kind value, the Authorizer
-       * should report an error.
+       * Kind of object being referenced. Values defined by this API group are "User", "Group", and
+       * "ServiceAccount". If the Authorizer does not recognized the kind value, the Authorizer
+       * should report an error.
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the object being referenced.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * Namespace of the referenced object.  If the object kind is non-namespace, such as "User" or
+       * "Group", and this value is not empty the Authorizer should report an error.
+       */
+      public readonly namespace: pulumi.Output<string>;
+
+
+      public getInputs(): inputApi.rbac.v1alpha1.Subject { return this.__inputs; }
+      private readonly __inputs: inputApi.rbac.v1alpha1.Subject;
+
+      /**
+      * Create a rbac.v1alpha1.Subject resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The arguments to use to populate this resource's properties.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: inputApi.rbac.v1alpha1.Subject, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac/v1alpha1";
+          inputs["kind"] = "Subject";
+          inputs["name"] = args.name;
+          inputs["namespace"] = args.namespace;
+          super("kubernetes:rbac/v1alpha1:Subject", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * SubjectPatch manages only the specified fields of an existing Subject that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the SubjectPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class SubjectPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion holds the API group and version of the referenced subject. Defaults to "v1" for
+       * ServiceAccount subjects. Defaults to "rbac.authorization.k8s.io/v1alpha1" for User and
+       * Group subjects.
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind of object being referenced. Values defined by this API group are "User", "Group", and
+       * "ServiceAccount". If the Authorizer does not recognized the kind value, the Authorizer
+       * should report an error.
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Name of the object being referenced.
+       */
+      public readonly name: pulumi.Output<string>;
+
+      /**
+       * Namespace of the referenced object.  If the object kind is non-namespace, such as "User" or
+       * "Group", and this value is not empty the Authorizer should report an error.
+       */
+      public readonly namespace: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1alpha1.Subject> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1alpha1.Subject>;
+
+      /**
+      * Create a rbac.v1alpha1.SubjectPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1alpha1.Subject>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac/v1alpha1";
+          inputs["kind"] = "Subject";
+          inputs["name"] = args.name;
+          inputs["namespace"] = args.namespace;
+          super("kubernetes:rbac/v1alpha1:SubjectPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+  }
+
+  export namespace v1beta1 {
+    /**
+     * ClusterRole is a cluster level, logical grouping of PolicyRules that can be referenced as a
+     * unit by a RoleBinding or ClusterRoleBinding.
+     */
+    export class ClusterRole extends pulumi.CustomResource {
+      /**
+       * AggregationRule is an optional field that describes how to build the Rules for this
+       * ClusterRole. If AggregationRule is set, then the Rules are controller managed and direct
+       * changes to Rules will be stomped by the controller.
+       */
+      public readonly aggregationRule: pulumi.Output<outputApi.rbac.v1beta1.AggregationRule>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
        */
       public readonly kind: pulumi.Output<string>;
 
       /**
-       * Name of the object being referenced.
+       * Standard object's metadata.
        */
-      public readonly name: pulumi.Output<string>;
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
 
       /**
-       * Namespace of the referenced object.  If the object kind is non-namespace, such as "User" or
-       * "Group", and this value is not empty the Authorizer should report an error.
+       * Rules holds all the PolicyRules for this ClusterRole
        */
-      public readonly namespace: pulumi.Output<string>;
+      public readonly rules: pulumi.Output<outputApi.rbac.v1beta1.PolicyRule[]>;
 
 
-      public getInputs(): inputApi.rbac.v1alpha1.Subject { return this.__inputs; }
-      private readonly __inputs: inputApi.rbac.v1alpha1.Subject;
+      public getInputs(): inputApi.rbac.v1beta1.ClusterRole { return this.__inputs; }
+      private readonly __inputs: inputApi.rbac.v1beta1.ClusterRole;
 
       /**
-      * Create a rbac.v1alpha1.Subject resource with the given unique name, arguments, and options.
+      * Create a rbac.v1beta1.ClusterRole resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
       * @param args The arguments to use to populate this resource's properties.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.rbac.v1alpha1.Subject, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: inputApi.rbac.v1beta1.ClusterRole, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
-          inputs["apiVersion"] = "rbac/v1alpha1";
-          inputs["kind"] = "Subject";
-          inputs["name"] = args.name;
-          inputs["namespace"] = args.namespace;
-          super("kubernetes:rbac/v1alpha1:Subject", name, inputs, opts);
+          inputs["aggregationRule"] = args.aggregationRule;
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1beta1";
+          inputs["kind"] = "ClusterRole";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:ClusterRole", "kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRole"]);
+          super("kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRole", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
-  }
-
-  export namespace v1beta1 {
     /**
-     * ClusterRole is a cluster level, logical grouping of PolicyRules that can be referenced as a
-     * unit by a RoleBinding or ClusterRoleBinding.
+     * ClusterRolePatch manages only the specified fields of an existing ClusterRole that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
      */
-    export class ClusterRole extends pulumi.CustomResource {
+    export class ClusterRolePatch extends pulumi.CustomResource {
       /**
        * AggregationRule is an optional field that describes how to build the Rules for this
        * ClusterRole. If AggregationRule is set, then the Rules are controller managed and direct
@@ -10165,24 +15987,24 @@ export namespace rbac {
       public readonly rules: pulumi.Output<outputApi.rbac.v1beta1.PolicyRule[]>;
 
 
-      public getInputs(): inputApi.rbac.v1beta1.ClusterRole { return this.__inputs; }
-      private readonly __inputs: inputApi.rbac.v1beta1.ClusterRole;
+      public getInputs(): Partial<inputApi.rbac.v1beta1.ClusterRole> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1beta1.ClusterRole>;
 
       /**
-      * Create a rbac.v1beta1.ClusterRole resource with the given unique name, arguments, and options.
+      * Create a rbac.v1beta1.ClusterRolePatch resource with the given unique name, arguments, and options.
       *
       * @param name The _unique_ name of the resource.
-      * @param args The arguments to use to populate this resource's properties.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
       * @param opts A bag of options that control this resource's behavior.
       */
-      constructor(name: string, args: inputApi.rbac.v1beta1.ClusterRole, opts?: pulumi.CustomResourceOptions) {
+      constructor(name: string, args: Partial<inputApi.rbac.v1beta1.ClusterRole>, opts?: pulumi.CustomResourceOptions) {
           let inputs: pulumi.Inputs = {};
           inputs["aggregationRule"] = args.aggregationRule;
           inputs["apiVersion"] = "rbac.authorization.k8s.io/v1beta1";
           inputs["kind"] = "ClusterRole";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
-          super("kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRole", name, inputs, opts);
+          super("kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRolePatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -10242,11 +16064,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:ClusterRoleBinding", "kubernetes:rbac.authorization.k8s.io/v1alpha1:ClusterRoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * ClusterRoleBindingPatch manages only the specified fields of an existing ClusterRoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the ClusterRoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class ClusterRoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can only reference a ClusterRole in the global namespace. If the RoleRef cannot be
+       * resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1beta1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1beta1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1beta1.ClusterRoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1beta1.ClusterRoleBinding>;
+
+      /**
+      * Create a rbac.v1beta1.ClusterRoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1beta1.ClusterRoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1beta1";
+          inputs["kind"] = "ClusterRoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1beta1:ClusterRoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * ClusterRoleBindingList is a collection of ClusterRoleBindings
      */
@@ -10399,11 +16284,67 @@ export namespace rbac {
           inputs["kind"] = "Role";
           inputs["metadata"] = args.metadata;
           inputs["rules"] = args.rules;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:Role", "kubernetes:rbac.authorization.k8s.io/v1alpha1:Role"]);
           super("kubernetes:rbac.authorization.k8s.io/v1beta1:Role", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RolePatch manages only the specified fields of an existing Role that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RolePatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RolePatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Rules holds all the PolicyRules for this Role
+       */
+      public readonly rules: pulumi.Output<outputApi.rbac.v1beta1.PolicyRule[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1beta1.Role> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1beta1.Role>;
+
+      /**
+      * Create a rbac.v1beta1.RolePatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1beta1.Role>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1beta1";
+          inputs["kind"] = "Role";
+          inputs["metadata"] = args.metadata;
+          inputs["rules"] = args.rules;
+          super("kubernetes:rbac.authorization.k8s.io/v1beta1:RolePatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBinding references a role, but does not contain it.  It can reference a Role in the same
      * namespace or a ClusterRole in the global namespace. It adds who information via Subjects and
@@ -10461,11 +16402,74 @@ export namespace rbac {
           inputs["metadata"] = args.metadata;
           inputs["roleRef"] = args.roleRef;
           inputs["subjects"] = args.subjects;
+          opts = withAliases(opts, ["kubernetes:rbac.authorization.k8s.io/v1:RoleBinding", "kubernetes:rbac.authorization.k8s.io/v1alpha1:RoleBinding"]);
           super("kubernetes:rbac.authorization.k8s.io/v1beta1:RoleBinding", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * RoleBindingPatch manages only the specified fields of an existing RoleBinding that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the RoleBindingPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class RoleBindingPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata.
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * RoleRef can reference a Role in the current namespace or a ClusterRole in the global
+       * namespace. If the RoleRef cannot be resolved, the Authorizer must return an error.
+       */
+      public readonly roleRef: pulumi.Output<outputApi.rbac.v1beta1.RoleRef>;
+
+      /**
+       * Subjects holds references to the objects the role applies to.
+       */
+      public readonly subjects: pulumi.Output<outputApi.rbac.v1beta1.Subject[]>;
+
+
+      public getInputs(): Partial<inputApi.rbac.v1beta1.RoleBinding> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.rbac.v1beta1.RoleBinding>;
+
+      /**
+      * Create a rbac.v1beta1.RoleBindingPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.rbac.v1beta1.RoleBinding>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "rbac.authorization.k8s.io/v1beta1";
+          inputs["kind"] = "RoleBinding";
+          inputs["metadata"] = args.metadata;
+          inputs["roleRef"] = args.roleRef;
+          inputs["subjects"] = args.subjects;
+          super("kubernetes:rbac.authorization.k8s.io/v1beta1:RoleBindingPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * RoleBindingList is a collection of RoleBindings
      */
@@ -10640,7 +16644,78 @@ export namespace scheduling {
           inputs["kind"] = "PriorityClass";
           inputs["metadata"] = args.metadata;
           inputs["value"] = args.value;
-          super("kubernetes:scheduling.k8s.io/v1alpha1:PriorityClass", name, inputs, opts);
+          super("kubernetes:scheduling.k8s.io/v1alpha1:PriorityClass", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
+    /**
+     * PriorityClassPatch manages only the specified fields of an existing PriorityClass that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PriorityClassPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PriorityClassPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * description is an arbitrary string that usually provides guidelines on when this priority
+       * class should be used.
+       */
+      public readonly description: pulumi.Output<string>;
+
+      /**
+       * globalDefault specifies whether this PriorityClass should be considered as the default
+       * priority for pods that do not have any priority class.
+       */
+      public readonly globalDefault: pulumi.Output<boolean>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * The value of this priority class. This is the actual priority that pods receive when they
+       * have the name of this class in their pod spec.
+       */
+      public readonly value: pulumi.Output<number>;
+
+
+      public getInputs(): Partial<inputApi.scheduling.v1alpha1.PriorityClass> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.scheduling.v1alpha1.PriorityClass>;
+
+      /**
+      * Create a scheduling.v1alpha1.PriorityClassPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.scheduling.v1alpha1.PriorityClass>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "scheduling.k8s.io/v1alpha1";
+          inputs["description"] = args.description;
+          inputs["globalDefault"] = args.globalDefault;
+          inputs["kind"] = "PriorityClass";
+          inputs["metadata"] = args.metadata;
+          inputs["value"] = args.value;
+          super("kubernetes:scheduling.k8s.io/v1alpha1:PriorityClassPatch", name, inputs, opts);
           this.__inputs = args;
       }
     }
@@ -10752,6 +16827,57 @@ export namespace settings {
       }
     }
 
+    /**
+     * PodPresetPatch manages only the specified fields of an existing PodPreset that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the PodPresetPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class PodPresetPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      
+      public readonly spec: pulumi.Output<outputApi.settings.v1alpha1.PodPresetSpec>;
+
+
+      public getInputs(): Partial<inputApi.settings.v1alpha1.PodPreset> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.settings.v1alpha1.PodPreset>;
+
+      /**
+      * Create a settings.v1alpha1.PodPresetPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.settings.v1alpha1.PodPreset>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "settings.k8s.io/v1alpha1";
+          inputs["kind"] = "PodPreset";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          super("kubernetes:settings.k8s.io/v1alpha1:PodPresetPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * PodPresetList is a list of PodPreset objects.
      */
@@ -10899,11 +17025,104 @@ export namespace storage {
           inputs["provisioner"] = args.provisioner;
           inputs["reclaimPolicy"] = args.reclaimPolicy;
           inputs["volumeBindingMode"] = args.volumeBindingMode;
+          opts = withAliases(opts, ["kubernetes:storage.k8s.io/v1beta1:StorageClass"]);
           super("kubernetes:storage.k8s.io/v1:StorageClass", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * StorageClassPatch manages only the specified fields of an existing StorageClass that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StorageClassPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class StorageClassPatch extends pulumi.CustomResource {
+      /**
+       * AllowVolumeExpansion shows whether the storage class allow volume expand
+       */
+      public readonly allowVolumeExpansion: pulumi.Output<boolean>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Dynamically provisioned PersistentVolumes of this storage class are created with these
+       * mountOptions, e.g. ["ro", "soft"]. Not validated - mount of the PVs will simply fail if one
+       * is invalid.
+       */
+      public readonly mountOptions: pulumi.Output<string[]>;
+
+      /**
+       * Parameters holds the parameters for the provisioner that should create volumes of this
+       * storage class.
+       */
+      public readonly parameters: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+      /**
+       * Provisioner indicates the type of the provisioner.
+       */
+      public readonly provisioner: pulumi.Output<string>;
+
+      /**
+       * Dynamically provisioned PersistentVolumes of this storage class are created with this
+       * reclaimPolicy. Defaults to Delete.
+       */
+      public readonly reclaimPolicy: pulumi.Output<string>;
+
+      /**
+       * VolumeBindingMode indicates how PersistentVolumeClaims should be provisioned and bound.
+       * When unset, VolumeBindingImmediate is used. This field is alpha-level and is only honored
+       * by servers that enable the VolumeScheduling feature.
+       */
+      public readonly volumeBindingMode: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.storage.v1.StorageClass> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.storage.v1.StorageClass>;
+
+      /**
+      * Create a storage.v1.StorageClassPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.storage.v1.StorageClass>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["allowVolumeExpansion"] = args.allowVolumeExpansion;
+          inputs["apiVersion"] = "storage.k8s.io/v1";
+          inputs["kind"] = "StorageClass";
+          inputs["metadata"] = args.metadata;
+          inputs["mountOptions"] = args.mountOptions;
+          inputs["parameters"] = args.parameters;
+          inputs["provisioner"] = args.provisioner;
+          inputs["reclaimPolicy"] = args.reclaimPolicy;
+          inputs["volumeBindingMode"] = args.volumeBindingMode;
+          super("kubernetes:storage.k8s.io/v1:StorageClassPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * StorageClassList is a collection of storage classes.
      */
@@ -11024,6 +17243,70 @@ export namespace storage {
       }
     }
 
+    /**
+     * VolumeAttachmentPatch manages only the specified fields of an existing VolumeAttachment that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the VolumeAttachmentPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class VolumeAttachmentPatch extends pulumi.CustomResource {
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Specification of the desired attach/detach volume behavior. Populated by the Kubernetes
+       * system.
+       */
+      public readonly spec: pulumi.Output<outputApi.storage.v1alpha1.VolumeAttachmentSpec>;
+
+      /**
+       * Status of the VolumeAttachment request. Populated by the entity completing the attach or
+       * detach operation, i.e. the external-attacher.
+       */
+      public readonly status: pulumi.Output<outputApi.storage.v1alpha1.VolumeAttachmentStatus>;
+
+
+      public getInputs(): Partial<inputApi.storage.v1alpha1.VolumeAttachment> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.storage.v1alpha1.VolumeAttachment>;
+
+      /**
+      * Create a storage.v1alpha1.VolumeAttachmentPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.storage.v1alpha1.VolumeAttachment>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["apiVersion"] = "storage.k8s.io/v1alpha1";
+          inputs["kind"] = "VolumeAttachment";
+          inputs["metadata"] = args.metadata;
+          inputs["spec"] = args.spec;
+          inputs["status"] = args.status;
+          super("kubernetes:storage.k8s.io/v1alpha1:VolumeAttachmentPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * VolumeAttachmentList is a collection of VolumeAttachment objects.
      */
@@ -11168,11 +17451,104 @@ export namespace storage {
           inputs["provisioner"] = args.provisioner;
           inputs["reclaimPolicy"] = args.reclaimPolicy;
           inputs["volumeBindingMode"] = args.volumeBindingMode;
+          opts = withAliases(opts, ["kubernetes:storage.k8s.io/v1:StorageClass"]);
           super("kubernetes:storage.k8s.io/v1beta1:StorageClass", name, inputs, opts);
           this.__inputs = args;
       }
     }
 
+    /**
+     * StorageClassPatch manages only the specified fields of an existing StorageClass that is otherwise
+     * owned by someone else (e.g., an object created by the cluster or by another tool). The fields
+     * are applied with server-side apply, and deleting the StorageClassPatch relinquishes them rather
+     * than deleting the object. Requires Kubernetes 1.16 or later.
+     */
+    export class StorageClassPatch extends pulumi.CustomResource {
+      /**
+       * AllowVolumeExpansion shows whether the storage class allow volume expand
+       */
+      public readonly allowVolumeExpansion: pulumi.Output<boolean>;
+
+      /**
+       * APIVersion defines the versioned schema of this representation of an object. Servers should
+       * convert recognized schemas to the latest internal value, and may reject unrecognized
+       * values. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#resources
+       */
+      public readonly apiVersion: pulumi.Output<string>;
+
+      /**
+       * Kind is a string value representing the REST resource this object represents. Servers may
+       * infer this from the endpoint the client submits requests to. Cannot be updated. In
+       * CamelCase. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds
+       */
+      public readonly kind: pulumi.Output<string>;
+
+      /**
+       * Standard object's metadata. More info:
+       * https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+       */
+      public readonly metadata: pulumi.Output<outputApi.meta.v1.ObjectMeta>;
+
+      /**
+       * Dynamically provisioned PersistentVolumes of this storage class are created with these
+       * mountOptions, e.g. ["ro", "soft"]. Not validated - mount of the PVs will simply fail if one
+       * is invalid.
+       */
+      public readonly mountOptions: pulumi.Output<string[]>;
+
+      /**
+       * Parameters holds the parameters for the provisioner that should create volumes of this
+       * storage class.
+       */
+      public readonly parameters: pulumi.Output<{[key: string]: pulumi.Output<string>}>;
+
+      /**
+       * Provisioner indicates the type of the provisioner.
+       */
+      public readonly provisioner: pulumi.Output<string>;
+
+      /**
+       * Dynamically provisioned PersistentVolumes of this storage class are created with this
+       * reclaimPolicy. Defaults to Delete.
+       */
+      public readonly reclaimPolicy: pulumi.Output<string>;
+
+      /**
+       * VolumeBindingMode indicates how PersistentVolumeClaims should be provisioned and bound.
+       * When unset, VolumeBindingImmediate is used. This field is alpha-level and is only honored
+       * by servers that enable the VolumeScheduling feature.
+       */
+      public readonly volumeBindingMode: pulumi.Output<string>;
+
+
+      public getInputs(): Partial<inputApi.storage.v1beta1.StorageClass> { return this.__inputs; }
+      private readonly __inputs: Partial<inputApi.storage.v1beta1.StorageClass>;
+
+      /**
+      * Create a storage.v1beta1.StorageClassPatch resource with the given unique name, arguments, and options.
+      *
+      * @param name The _unique_ name of the resource.
+      * @param args The fields to apply. `metadata.name` must identify the object to patch.
+      * @param opts A bag of options that control this resource's behavior.
+      */
+      constructor(name: string, args: Partial<inputApi.storage.v1beta1.StorageClass>, opts?: pulumi.CustomResourceOptions) {
+          let inputs: pulumi.Inputs = {};
+          inputs["allowVolumeExpansion"] = args.allowVolumeExpansion;
+          inputs["apiVersion"] = "storage.k8s.io/v1beta1";
+          inputs["kind"] = "StorageClass";
+          inputs["metadata"] = args.metadata;
+          inputs["mountOptions"] = args.mountOptions;
+          inputs["parameters"] = args.parameters;
+          inputs["provisioner"] = args.provisioner;
+          inputs["reclaimPolicy"] = args.reclaimPolicy;
+          inputs["volumeBindingMode"] = args.volumeBindingMode;
+          super("kubernetes:storage.k8s.io/v1beta1:StorageClassPatch", name, inputs, opts);
+          this.__inputs = args;
+      }
+    }
+
     /**
      * StorageClassList is a collection of storage classes.
      */
@@ -0,0 +1,105 @@
+import { execFileSync } from "child_process";
+import * as crypto from "crypto";
+import * as fs from "fs";
+import * as os from "os";
+import * as path from "path";
+
+// Remote manifests.
+//
+// `yaml.ConfigFile` and `yaml.ConfigGroup` accept http(s) URLs in place of file names, e.g., for
+// the install manifests that operators publish with their releases. Each manifest is downloaded
+// when the program runs, and saved to an on-disk cache (`~/.pulumi/kubernetes/manifests`, or
+// `$PULUMI_KUBERNETES_MANIFEST_CACHE`).
+//
+// A manifest can be pinned with the sha256 checksum of its contents (as "sha256:<hex>"). A pinned
+// manifest is read from the cache if it is there (so that, e.g., air-gapped builds can use a cache
+// populated in advance), and is otherwise downloaded and verified: if its contents don't match the
+// checksum, the program fails, rather than deploying something other than what was reviewed.
+// Manifests that aren't pinned are downloaded every time.
+
+const maxRedirects = 10;
+const maxManifestSize = 256 * 1024 * 1024;
+
+// isURL returns true if `file` is the URL of a remote manifest, rather than a path.
+export function isURL(file: string): boolean {
+    return /^https?:\/\//.test(file);
+}
+
+// read returns the contents of the remote manifest at `url`, verified against `checksum`, if any.
+export function read(url: string, checksum?: string): string {
+    const key = sha256(checksum !== undefined ? `${url}\n${checksum}` : url);
+    const cacheFile = path.join(cacheDir(), `${key}.yaml`);
+    if (checksum !== undefined && fs.existsSync(cacheFile)) {
+        const cached = fs.readFileSync(cacheFile);
+        if (matches(cached, checksum)) {
+            return cached.toString();
+        }
+    }
+
+    const data = download(url);
+    verify(url, data, checksum);
+    mkdirp(path.dirname(cacheFile));
+    fs.writeFileSync(cacheFile, data);
+    return data.toString();
+}
+
+// verify throws if `data`, the contents of `url`, doesn't match `checksum`.
+export function verify(url: string, data: Buffer, checksum?: string) {
+    if (checksum !== undefined && !matches(data, checksum)) {
+        throw new Error(
+            `checksum mismatch for ${url}: expected ${checksum}, got sha256:${sha256(data)}; ` +
+            `if the manifest was updated intentionally, update its checksum`);
+    }
+}
+
+export function cacheDir(): string {
+    return process.env["PULUMI_KUBERNETES_MANIFEST_CACHE"] ||
+        path.join(os.homedir(), ".pulumi", "kubernetes", "manifests");
+}
+
+function matches(data: Buffer, checksum: string): boolean {
+    if (!/^sha256:[0-9a-fA-F]{64}$/.test(checksum)) {
+        throw new Error(`invalid checksum "${checksum}": expected "sha256:" and 64 hex digits`);
+    }
+    return sha256(data) === checksum.slice("sha256:".length).toLowerCase();
+}
+
+function mkdirp(dir: string) {
+    if (!fs.existsSync(dir)) {
+        mkdirp(path.dirname(dir));
+        fs.mkdirSync(dir);
+    }
+}
+
+function sha256(data: string | Buffer): string {
+    return crypto.createHash("sha256").update(data).digest("hex");
+}
+
+// download fetches `url`. Resources are registered synchronously, so it is fetched by a child
+// process.
+function download(url: string): Buffer {
+    const script = `
+        const get = (u, redirects) => require(u.split(":")[0]).get(u, res => {
+            const redirect = res.statusCode >= 300 && res.statusCode < 400 && res.headers.location;
+            if (redirect && redirects > 0) {
+                res.resume();
+                return get(new URL(res.headers.location, u).toString(), redirects - 1);
+            }
+            if (res.statusCode !== 200) {
+                console.error(\`GET \${u} returned \${res.statusCode}\`);
+                process.exit(1);
+            }
+            res.pipe(process.stdout);
+        }).on("error", err => {
+            console.error(err.message);
+            process.exit(1);
+        });
+        get(process.argv[1], ${maxRedirects});`;
+    try {
+        return execFileSync(process.execPath, ["-e", script, url], {
+            maxBuffer: maxManifestSize, stdio: ["ignore", "pipe", "pipe"],
+        });
+    } catch (e) {
+        throw new Error(`unable to download ${url}: ${e.stderr ? e.stderr.toString().trim() : e}`);
+    }
+}
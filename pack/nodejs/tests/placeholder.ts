@@ -0,0 +1,25 @@
+import * as assert from "assert";
+import * as placeholder from "../placeholder";
+
+describe("placeholder.Placeholders", () => {
+    it("replaces Inputs, and leaves plain values as they are", () => {
+        const placeholders = new placeholder.Placeholders();
+        const password = Promise.resolve("hunter2");
+        const values = placeholders.replace({replicas: 3, auth: {password: password}, tags: ["a"]});
+        assert.equal(values.replicas, 3);
+        assert.deepEqual(values.tags, ["a"]);
+        assert.equal(typeof values.auth.password, "string");
+        assert.notEqual(values.auth.password, "hunter2");
+
+        const obj = {data: {password: values.auth.password, user: "admin"}};
+        placeholders.substitute(obj);
+        assert.strictEqual(obj.data.password, password);
+        assert.equal(obj.data.user, "admin");
+    });
+    it("rejects placeholders that templates transformed", () => {
+        const placeholders = new placeholder.Placeholders();
+        const values = placeholders.replace({password: Promise.resolve("hunter2")});
+        const obj = {data: {password: values.password.toUpperCase()}};
+        assert.throws(() => placeholders.substitute(obj), /transformed a value that is an Output/);
+    });
+});
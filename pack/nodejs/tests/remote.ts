@@ -0,0 +1,34 @@
+import * as assert from "assert";
+import * as crypto from "crypto";
+import * as fs from "fs";
+import * as os from "os";
+import * as path from "path";
+import * as remote from "../remote";
+
+const manifest = "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: operators\n";
+const checksum = `sha256:${crypto.createHash("sha256").update(manifest).digest("hex")}`;
+
+describe("remote", () => {
+    it("recognizes URLs", () => {
+        assert.ok(remote.isURL("https://example.com/install.yaml"));
+        assert.ok(!remote.isURL("manifests/install.yaml"));
+    });
+    it("rejects contents that don't match the checksum", () => {
+        const url = "https://example.com/install.yaml";
+        remote.verify(url, Buffer.from(manifest), checksum);
+        assert.throws(() => remote.verify(url, Buffer.from(manifest + "# changed\n"), checksum),
+            /checksum mismatch for https:\/\/example.com\/install.yaml/);
+    });
+    it("reads pinned manifests from the cache", () => {
+        const dir = fs.mkdtempSync(path.join(os.tmpdir(), "manifests-"));
+        process.env["PULUMI_KUBERNETES_MANIFEST_CACHE"] = dir;
+        try {
+            const url = "https://example.invalid/install.yaml";
+            const key = crypto.createHash("sha256").update(`${url}\n${checksum}`).digest("hex");
+            fs.writeFileSync(path.join(dir, `${key}.yaml`), manifest);
+            assert.equal(remote.read(url, checksum), manifest);
+        } finally {
+            delete process.env["PULUMI_KUBERNETES_MANIFEST_CACHE"];
+        }
+    });
+});
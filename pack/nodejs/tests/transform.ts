@@ -0,0 +1,27 @@
+import * as assert from "assert";
+import * as transform from "../transform";
+
+function obj(kind: string, name: string): any {
+    return {apiVersion: "v1", kind: kind, metadata: {name: name}};
+}
+
+describe("transform.transform", () => {
+    it("applies transformations in order", () => {
+        const objs = transform.transform([obj("ConfigMap", "a")], [
+            o => { o.metadata.namespace = "prod"; },
+            o => { o.metadata.name = `${o.metadata.namespace}-${o.metadata.name}`; },
+        ]);
+        assert.deepEqual(objs.map(o => o.metadata.name), ["prod-a"]);
+    });
+    it("omits objects for which a transformation returns false", () => {
+        const objs = transform.transform([obj("ConfigMap", "a"), obj("Secret", "b")], [
+            o => o.kind !== "Secret",
+        ]);
+        assert.deepEqual(objs.map(o => o.metadata.name), ["a"]);
+    });
+    it("flattens lists and skips empty documents", () => {
+        const list = {apiVersion: "v1", kind: "List", items: [obj("ConfigMap", "a"), obj("Service", "b")]};
+        const objs = transform.transform([null, {}, list, obj("ConfigMap", "c")]);
+        assert.deepEqual(objs.map(o => o.metadata.name), ["a", "b", "c"]);
+    });
+});
@@ -0,0 +1,71 @@
+import * as assert from "assert";
+import * as wave from "../wave";
+
+function obj(name: string, annotations?: {[key: string]: string}, kind?: string): any {
+    return {apiVersion: "v1", kind: kind || "ConfigMap", metadata: {name: name, annotations: annotations}};
+}
+
+describe("wave.groupByWave", () => {
+    it("keeps unannotated objects in a single wave", () => {
+        const waves = wave.groupByWave([obj("a"), obj("b")]);
+        assert.deepEqual(waves.map(w => w.map(o => o.metadata.name)), [["a", "b"]]);
+    });
+    it("orders objects by sync wave", () => {
+        const waves = wave.groupByWave([
+            obj("late", {"argocd.argoproj.io/sync-wave": "5"}),
+            obj("default"),
+            obj("early", {"argocd.argoproj.io/sync-wave": "-1"}),
+        ]);
+        assert.deepEqual(waves.map(w => w.map(o => o.metadata.name)), [["early"], ["default"], ["late"]]);
+    });
+    it("orders Helm hooks around the release, by hook weight", () => {
+        const waves = wave.groupByWave([
+            obj("post", {"helm.sh/hook": "post-install"}),
+            obj("release"),
+            obj("pre-heavy", {"helm.sh/hook": "pre-install,pre-upgrade", "helm.sh/hook-weight": "10"}),
+            obj("pre-light", {"helm.sh/hook": "pre-install", "helm.sh/hook-weight": "-5"}),
+        ]);
+        assert.deepEqual(
+            waves.map(w => w.map(o => o.metadata.name)),
+            [["pre-light"], ["pre-heavy"], ["release"], ["post"]]);
+    });
+    it("deploys namespaces and CRDs first, and webhooks last", () => {
+        const waves = wave.groupByWave([
+            obj("webhook", undefined, "ValidatingWebhookConfiguration"),
+            obj("config"),
+            obj("crd", undefined, "CustomResourceDefinition"),
+            obj("ns", undefined, "Namespace"),
+            obj("hook", {"helm.sh/hook": "pre-install"}),
+        ]);
+        assert.deepEqual(
+            waves.map(w => w.map(o => o.metadata.name)),
+            [["crd", "ns"], ["hook"], ["config"], ["webhook"]]);
+    });
+    it("ignores weights that aren't integers", () => {
+        assert.equal(wave.weight(obj("a", {"helm.sh/hook-weight": "heavy"})), 0);
+    });
+});
+
+describe("wave.hooks", () => {
+    it("lists the hooks of an object", () => {
+        assert.deepEqual(wave.hooks(obj("a")), []);
+        assert.deepEqual(
+            wave.hooks(obj("b", {"helm.sh/hook": "pre-install, pre-upgrade"})),
+            ["pre-install", "pre-upgrade"]);
+    });
+});
+
+describe("wave.definedKind", () => {
+    it("matches CRDs with the custom resources of their kind", () => {
+        const crd = {
+            apiVersion: "apiextensions.k8s.io/v1beta1",
+            kind: "CustomResourceDefinition",
+            metadata: {name: "crontabs.stable.example.com"},
+            spec: {group: "stable.example.com", names: {kind: "CronTab", plural: "crontabs"}},
+        };
+        const cr = {apiVersion: "stable.example.com/v1", kind: "CronTab", metadata: {name: "a"}};
+        assert.equal(wave.definedKind(crd), wave.groupKind(cr));
+        assert.equal(wave.definedKind(cr), undefined);
+        assert.equal(wave.groupKind(obj("a")), "/ConfigMap");
+    });
+});
@@ -0,0 +1,51 @@
+// Transformations of the objects of collections of manifests (i.e., of `yaml.ConfigFile`,
+// `yaml.ConfigGroup`, `helm.v2.Chart`, `helm.v3.Chart`, and `kustomize.Directory`).
+//
+// A transformation is applied to each object before it is registered as a resource, e.g., to set
+// its namespace, add labels, or patch its images. It modifies the object in place, and may return
+// `false` to omit the object altogether. Lists (e.g., the `v1/List` that `kubectl get -o yaml`
+// emits) are flattened first, so that transformations see (and resources are registered for) the
+// objects they contain.
+
+export type Transformation = (obj: any) => void | boolean;
+
+// transform flattens `objs`, and applies `transformations` to each object, in order. Returns the
+// objects that weren't omitted.
+export function transform(objs: any[], transformations?: Transformation[]): any[] {
+    const transformed: any[] = [];
+    for (const obj of flatten(objs)) {
+        let omit = false;
+        for (const t of transformations || []) {
+            if (t(obj) === false) {
+                omit = true;
+                break;
+            }
+        }
+        if (!omit && !isEmpty(obj)) {
+            transformed.push(obj);
+        }
+    }
+    return transformed;
+}
+
+function flatten(objs: any[]): any[] {
+    const flattened: any[] = [];
+    for (const obj of objs) {
+        if (isEmpty(obj)) {
+            continue;
+        } else if (isList(obj)) {
+            flattened.push(...flatten(obj.items));
+        } else {
+            flattened.push(obj);
+        }
+    }
+    return flattened;
+}
+
+function isEmpty(obj: any): boolean {
+    return obj == null || Object.keys(obj).length == 0;
+}
+
+function isList(obj: any): boolean {
+    return typeof obj.kind === "string" && obj.kind.endsWith("List") && Array.isArray(obj.items);
+}
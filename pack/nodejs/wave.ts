@@ -0,0 +1,120 @@
+// Deployment ordering for collections of manifests (e.g., Helm charts, or manifests originating
+// from GitOps tools), computed from the annotations those tools use to encode it:
+//
+//   * Helm hooks: `helm.sh/hook: pre-install` (or `pre-upgrade`) objects are deployed before the
+//     rest of the chart, and `post-install` (or `post-upgrade`) objects after it. Within each group,
+//     objects are ordered by `helm.sh/hook-weight`.
+//   * Argo CD sync waves: objects are ordered by `argocd.argoproj.io/sync-wave`.
+//
+// Regardless of annotations, objects are also ordered by what their kind implies they depend on:
+// Namespaces and CustomResourceDefinitions are deployed before everything else, since the objects
+// that live in them (or are instances of them) can't be created until they exist; and admission
+// webhook configurations are deployed after everything else, since until the services that back
+// them are running, they'd reject the creation of the very objects that start those services.
+//
+// Objects in each wave are deployed only after every object in the preceding wave. In addition,
+// custom resources depend on the CustomResourceDefinitions of their kinds, even when those are
+// defined in another document (e.g., a chart's `crds/`) or component (see `definedKind`).
+
+const hookAnnotation = "helm.sh/hook";
+const hookWeightAnnotation = "helm.sh/hook-weight";
+const hookDeletePolicyAnnotation = "helm.sh/hook-delete-policy";
+const syncWaveAnnotation = "argocd.argoproj.io/sync-wave";
+
+const firstKinds = ["Namespace", "CustomResourceDefinition"];
+const lastKinds = ["MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"];
+
+// kindRank returns -1 for objects whose kind others depend on, 1 for objects whose kind depends on
+// others, and 0 otherwise.
+export function kindRank(obj: any): number {
+    const kind = obj && obj.kind;
+    if (firstKinds.indexOf(kind) >= 0) {
+        return -1;
+    }
+    if (lastKinds.indexOf(kind) >= 0) {
+        return 1;
+    }
+    return 0;
+}
+
+// hookAnnotations are the annotations with which Helm manages hooks.
+export const hookAnnotations = [hookAnnotation, hookWeightAnnotation, hookDeletePolicyAnnotation];
+
+// installHooks are the hooks Helm runs when it installs or upgrades a release.
+export const installHooks = ["pre-install", "pre-upgrade", "post-install", "post-upgrade"];
+
+// hooks returns the Helm hooks an object is, if any.
+export function hooks(obj: any): string[] {
+    return String(annotation(obj, hookAnnotation) || "")
+        .split(",")
+        .map(h => h.trim())
+        .filter(h => h !== "");
+}
+
+// hookPhase returns -1 for objects that Helm deploys before a release, 1 for objects it deploys
+// after, and 0 otherwise.
+export function hookPhase(obj: any): number {
+    const objHooks = hooks(obj);
+    if (objHooks.some(h => h === "pre-install" || h === "pre-upgrade")) {
+        return -1;
+    }
+    if (objHooks.some(h => h === "post-install" || h === "post-upgrade")) {
+        return 1;
+    }
+    return 0;
+}
+
+// weight returns the sync wave or hook weight of an object, or 0 if it has neither.
+export function weight(obj: any): number {
+    let value = annotation(obj, syncWaveAnnotation);
+    if (value === undefined) {
+        value = annotation(obj, hookWeightAnnotation);
+    }
+    const parsed = parseInt(value, 10);
+    return isNaN(parsed) ? 0 : parsed;
+}
+
+// groupByWave partitions objects into waves, in deployment order. Objects keep their relative
+// order within a wave.
+export function groupByWave(objs: any[]): any[][] {
+    const waves: {[key: string]: {rank: number, phase: number, weight: number, objs: any[]}} = {};
+    for (const obj of objs) {
+        const rank = kindRank(obj);
+        const phase = hookPhase(obj);
+        const w = weight(obj);
+        const key = `${rank}/${phase}/${w}`;
+        if (waves[key] === undefined) {
+            waves[key] = {rank: rank, phase: phase, weight: w, objs: []};
+        }
+        waves[key].objs.push(obj);
+    }
+
+    return Object.keys(waves)
+        .map(key => waves[key])
+        .sort((a, b) =>
+            a.rank !== b.rank ? a.rank - b.rank
+            : a.phase !== b.phase ? a.phase - b.phase
+            : a.weight - b.weight)
+        .map(wave => wave.objs);
+}
+
+// groupKind returns the group and kind of an object, as "group/Kind".
+export function groupKind(obj: any): string {
+    const apiVersion = String((obj && obj.apiVersion) || "");
+    const group = apiVersion.indexOf("/") >= 0 ? apiVersion.split("/")[0] : "";
+    return `${group}/${obj && obj.kind}`;
+}
+
+// definedKind returns the kind a CustomResourceDefinition defines, as "group/Kind", or undefined
+// for other objects.
+export function definedKind(obj: any): string | undefined {
+    if (!obj || obj.kind !== "CustomResourceDefinition" || !obj.spec || !obj.spec.names) {
+        return undefined;
+    }
+    return `${obj.spec.group}/${obj.spec.names.kind}`;
+}
+
+function annotation(obj: any, key: string): any {
+    const annotations = (obj && obj.metadata && obj.metadata.annotations) || {};
+    return annotations[key];
+}
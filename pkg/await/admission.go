@@ -0,0 +1,110 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// Admission webhook retries.
+//
+// When a program installs a controller that registers an admission webhook (e.g., cert-manager, or
+// Istio's sidecar injector), and then creates objects the webhook intercepts, the webhook is
+// typically registered before the Pods that serve it are ready. Until they are, the API server
+// rejects every matching request with an error like `failed calling admission webhook ...: dial
+// tcp ...: connect: connection refused`. Since this resolves itself within moments, creates and
+// updates that fail this way are retried with exponential backoff, up to `maxAdmissionRetries`
+// times, rather than failing the whole deployment on the first attempt.
+
+// --------------------------------------------------------------------------
+
+var (
+	maxAdmissionRetries        = 6
+	initialAdmissionRetryWait  = 2 * time.Second
+	maxAdmissionRetryWait      = 30 * time.Second
+	webhookUnavailableMessages = []string{
+		"connection refused",
+		"no endpoints available",
+		"no such host",
+		"connection reset by peer",
+		"EOF",
+		"context deadline exceeded",
+		"Client.Timeout exceeded",
+	}
+)
+
+// retryAdmission issues a create or update of `obj` with `op`, retrying while the API server can't
+// reach the admission webhooks the request has to pass.
+func retryAdmission(
+	ctx context.Context, host *provider.HostClient, urn resource.URN,
+	obj *unstructured.Unstructured, op func() error,
+) error {
+	wait := initialAdmissionRetryWait
+	for retry := 1; ; retry++ {
+		err := op()
+		if err == nil || !isWebhookUnavailable(err) || retry > maxAdmissionRetries {
+			return err
+		}
+
+		glog.V(3).Infof("Retrying request for '%s' (retry %d of %d) after error: %v",
+			obj.GetName(), retry, maxAdmissionRetries, err)
+		if host != nil {
+			_ = host.Log(ctx, diag.Info, urn, fmt.Sprintf(
+				"Admission webhook is unavailable; retrying in %v", wait))
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		wait *= 2
+		if wait > maxAdmissionRetryWait {
+			wait = maxAdmissionRetryWait
+		}
+	}
+}
+
+// isWebhookUnavailable returns true if `err` means the API server rejected a request because it
+// could not call an admission webhook, rather than because the webhook denied it.
+func isWebhookUnavailable(err error) bool {
+	if !errors.IsInternalError(err) {
+		return false
+	}
+	message := err.Error()
+	if !strings.Contains(message, "failed calling admission webhook") &&
+		!strings.Contains(message, "failed calling webhook") {
+		return false
+	}
+	for _, unavailable := range webhookUnavailableMessages {
+		if strings.Contains(message, unavailable) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package await
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_IsWebhookUnavailable(t *testing.T) {
+	tests := []struct {
+		description string
+		err         error
+		unavailable bool
+	}{
+		{
+			description: "Webhook refused the connection",
+			err: errors.NewInternalError(fmt.Errorf(`failed calling admission webhook ` +
+				`"webhook.cert-manager.io": Post https://cert-manager-webhook.cert-manager.svc:443/` +
+				`mutate: dial tcp 10.0.0.1:443: connect: connection refused`)),
+			unavailable: true,
+		},
+		{
+			description: "Webhook closed the connection",
+			err: errors.NewInternalError(fmt.Errorf(`failed calling webhook ` +
+				`"sidecar-injector.istio.io": Post https://istio-sidecar-injector.istio-system.svc:443/` +
+				`inject: EOF`)),
+			unavailable: true,
+		},
+		{
+			description: "Webhook denied the request",
+			err: errors.NewForbidden(schema.GroupResource{Resource: "pods"}, "foo",
+				fmt.Errorf(`admission webhook "validate.example.com" denied the request`)),
+		},
+		{
+			description: "Unrelated internal error",
+			err:         errors.NewInternalError(fmt.Errorf("etcdserver: request timed out")),
+		},
+		{
+			description: "Connection refused by the API server",
+			err:         fmt.Errorf("dial tcp 10.0.0.1:443: connect: connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.unavailable, isWebhookUnavailable(test.err), test.description)
+	}
+}
+
+func withFastAdmissionRetries(f func()) {
+	maxRetries, initialWait := maxAdmissionRetries, initialAdmissionRetryWait
+	maxAdmissionRetries, initialAdmissionRetryWait = 2, time.Millisecond
+	defer func() {
+		maxAdmissionRetries, initialAdmissionRetryWait = maxRetries, initialWait
+	}()
+	f()
+}
+
+func Test_RetryAdmission(t *testing.T) {
+	unavailable := errors.NewInternalError(fmt.Errorf(
+		`failed calling admission webhook "webhook.example.com": Post https://webhook: EOF`))
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetName("foo")
+
+	withFastAdmissionRetries(func() {
+		// The request succeeds once the webhook is up.
+		attempts := 0
+		err := retryAdmission(context.Background(), nil, "", obj, func() error {
+			attempts++
+			if attempts < 3 {
+				return unavailable
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+
+		// Retries are bounded.
+		attempts = 0
+		err = retryAdmission(context.Background(), nil, "", obj, func() error {
+			attempts++
+			return unavailable
+		})
+		assert.Equal(t, unavailable, err)
+		assert.Equal(t, 3, attempts)
+
+		// Other errors fail immediately.
+		attempts = 0
+		invalid := errors.NewBadRequest("spec.replicas: Invalid value")
+		err = retryAdmission(context.Background(), nil, "", obj, func() error {
+			attempts++
+			return invalid
+		})
+		assert.Equal(t, invalid, err)
+		assert.Equal(t, 1, attempts)
+	})
+}
@@ -0,0 +1,260 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// User-facing annotations.
+//
+// Annotations with the `pulumi.com/` prefix are generally reserved for the provider's own
+// bookkeeping, and are rejected by `Check`. The annotations below are the exceptions: users set them
+// on their objects to control how the provider creates, updates, and awaits them.
+
+// --------------------------------------------------------------------------
+
+const (
+	// AnnotationMigrateStoredVersions, when set to "true" on a CustomResourceDefinition, causes an
+	// update that removes versions from `spec.versions` to first migrate all stored custom resources
+	// to the new storage version, and then prune `status.storedVersions`.
+	AnnotationMigrateStoredVersions = "pulumi.com/migrateStoredVersions"
+
+	// AnnotationRolloutGate, when set on a Deployment to a count or percentage of replicas (e.g.,
+	// "25%"), causes the await to succeed as soon as that many Pods of the new revision are ready,
+	// rather than waiting for the rollout to complete.
+	AnnotationRolloutGate = "pulumi.com/rolloutGate"
+
+	// AnnotationRolloutGatePause, when set to "true" alongside `pulumi.com/rolloutGate`, pauses the
+	// Deployment once the gate is reached, so that the rollout stops at the canary Pods. Setting
+	// `spec.paused` to `false` resumes it.
+	AnnotationRolloutGatePause = "pulumi.com/rolloutGatePause"
+
+	// AnnotationTimeoutSeconds, when set to a positive integer, overrides the time the provider waits
+	// for the object to become ready after it is created or updated (e.g., "1200" for a cloud load
+	// balancer that is slow to provision, or "60" for a CI cluster that should fail fast).
+	AnnotationTimeoutSeconds = "pulumi.com/timeoutSeconds"
+
+	// AnnotationSkipAwait, when set to "true", causes the provider to consider the object ready as
+	// soon as the API server accepts it, without waiting for it to become ready (e.g., for a Service
+	// that intentionally targets no Pods, or for objects created while bootstrapping a cluster).
+	AnnotationSkipAwait = "pulumi.com/skipAwait"
+
+	// AnnotationFailIfPaused, when set to "true" on a Deployment whose `spec.paused` is true, causes
+	// the await to fail immediately, since the rollout will not progress. By default, the await
+	// succeeds immediately instead, with an informational message.
+	AnnotationFailIfPaused = "pulumi.com/failIfPaused"
+
+	// AnnotationEndpointSettleSeconds, when set on a Service to a non-negative integer, overrides
+	// how long the provider waits after the last change to the Service's endpoints before
+	// considering them settled (e.g., "0" for a Service whose endpoints are known to be stable).
+	AnnotationEndpointSettleSeconds = "pulumi.com/endpointSettleSeconds"
+
+	// AnnotationDeletionPropagationPolicy, when set to "Foreground", "Background", or "Orphan",
+	// overrides how the deletion of the object propagates to its dependents (e.g., the ReplicaSets
+	// and Pods of a Deployment). With "Foreground", the default, the delete completes only once the
+	// dependents are gone; with "Background", the garbage collector removes them after the object is
+	// gone; and with "Orphan", they are left behind.
+	AnnotationDeletionPropagationPolicy = "pulumi.com/deletionPropagationPolicy"
+
+	// AnnotationForceDelete, when set to "true" on a Namespace, allows it to be deleted even though
+	// objects managed by the same stack still live in it (and will be deleted along with it).
+	AnnotationForceDelete = "pulumi.com/forceDelete"
+)
+
+// UserAnnotations is the set of `pulumi.com/` annotations users are allowed to set.
+var UserAnnotations = map[string]bool{
+	AnnotationMigrateStoredVersions:     true,
+	AnnotationRolloutGate:               true,
+	AnnotationRolloutGatePause:          true,
+	AnnotationTimeoutSeconds:            true,
+	AnnotationSkipAwait:                 true,
+	AnnotationFailIfPaused:              true,
+	AnnotationEndpointSettleSeconds:     true,
+	AnnotationDeletionPropagationPolicy: true,
+	AnnotationForceDelete:               true,
+}
+
+func annotationIsTrue(obj interface{ GetAnnotations() map[string]string }, key string) bool {
+	return obj.GetAnnotations()[key] == "true"
+}
+
+// skipAwait returns true if the user asked us not to wait for `obj` to become ready, either with its
+// `pulumi.com/skipAwait` annotation, or for every object with `opts`.
+func skipAwait(obj interface{ GetAnnotations() map[string]string }, opts Options) bool {
+	return opts.SuppressAwait || annotationIsTrue(obj, AnnotationSkipAwait)
+}
+
+// TimeoutSeconds returns the timeout requested by the `pulumi.com/timeoutSeconds` annotation of
+// `obj`, or 0 if there is none. It is an error for the annotation to be anything but a positive
+// integer.
+func TimeoutSeconds(obj interface{ GetAnnotations() map[string]string }) (time.Duration, error) {
+	value, exists := obj.GetAnnotations()[AnnotationTimeoutSeconds]
+	if !exists {
+		return 0, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0, fmt.Errorf("annotation '%s' must be a positive integer number of seconds, got %q",
+			AnnotationTimeoutSeconds, value)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// EndpointSettleSeconds returns the settle period requested by the
+// `pulumi.com/endpointSettleSeconds` annotation of `obj`, and whether there is one. It is an error
+// for the annotation to be anything but a non-negative integer.
+func EndpointSettleSeconds(
+	obj interface{ GetAnnotations() map[string]string },
+) (time.Duration, bool, error) {
+	value, exists := obj.GetAnnotations()[AnnotationEndpointSettleSeconds]
+	if !exists {
+		return 0, false, nil
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false, fmt.Errorf(
+			"annotation '%s' must be a non-negative integer number of seconds, got %q",
+			AnnotationEndpointSettleSeconds, value)
+	}
+	return time.Duration(seconds) * time.Second, true, nil
+}
+
+// ParsePropagationPolicy parses a deletion propagation policy, i.e., "Foreground", "Background", or
+// "Orphan".
+func ParsePropagationPolicy(value string) (metav1.DeletionPropagation, error) {
+	switch policy := metav1.DeletionPropagation(value); policy {
+	case metav1.DeletePropagationForeground, metav1.DeletePropagationBackground,
+		metav1.DeletePropagationOrphan:
+		return policy, nil
+	default:
+		return "", fmt.Errorf(
+			"deletion propagation policy must be one of %q, %q, or %q, got %q",
+			metav1.DeletePropagationForeground, metav1.DeletePropagationBackground,
+			metav1.DeletePropagationOrphan, value)
+	}
+}
+
+// DeletionPropagationPolicy returns the propagation policy with which to delete `obj`: the one
+// requested by its `pulumi.com/deletionPropagationPolicy` annotation, if any, or else the one
+// configured by `opts`. It is an error for the annotation not to name a policy.
+func DeletionPropagationPolicy(
+	obj interface{ GetAnnotations() map[string]string }, opts Options,
+) (metav1.DeletionPropagation, error) {
+	value, exists := obj.GetAnnotations()[AnnotationDeletionPropagationPolicy]
+	if !exists {
+		return opts.PropagationPolicyOrDefault(), nil
+	}
+	policy, err := ParsePropagationPolicy(value)
+	if err != nil {
+		return "", fmt.Errorf("annotation '%s' is invalid: %v", AnnotationDeletionPropagationPolicy,
+			err)
+	}
+	return policy, nil
+}
+
+// Options configures how the provider waits for objects to become ready. It is set by the
+// provider's config, and applies to every object the provider manages.
+type Options struct {
+	// Timeouts overrides the await timeouts of individual kinds (the provider's `timeouts` config).
+	Timeouts Timeouts
+
+	// EndpointSettlePeriod, if positive, overrides how long to wait after the last change to the
+	// endpoints of a Service before considering them settled (the provider's
+	// `endpointSettleSeconds` config).
+	EndpointSettlePeriod time.Duration
+
+	// ServerSideApply, if true, causes objects to be created and updated with server-side apply,
+	// rather than with create requests and client-side patches (the provider's
+	// `enableServerSideApply` config).
+	ServerSideApply bool
+
+	// SuppressAwait, if true, causes every object to be considered ready as soon as the API server
+	// accepts it, as if it had the `pulumi.com/skipAwait` annotation (the provider's `suppressAwait`
+	// config).
+	SuppressAwait bool
+
+	// FieldManager, if set, overrides the field manager on whose behalf objects are server-side
+	// applied (the provider's `fieldManager` config).
+	FieldManager string
+
+	// PropagationPolicy, if set, overrides how the deletion of objects propagates to their
+	// dependents (the provider's `deletionPropagationPolicy` config). The
+	// `pulumi.com/deletionPropagationPolicy` annotation still takes precedence.
+	PropagationPolicy metav1.DeletionPropagation
+}
+
+// FieldManagerOrDefault returns the field manager on whose behalf objects are server-side applied.
+func (o Options) FieldManagerOrDefault() string {
+	if o.FieldManager == "" {
+		return FieldManager
+	}
+	return o.FieldManager
+}
+
+// PropagationPolicyOrDefault returns the propagation policy with which objects are deleted, unless
+// their annotations say otherwise.
+func (o Options) PropagationPolicyOrDefault() metav1.DeletionPropagation {
+	if o.PropagationPolicy == "" {
+		return metav1.DeletePropagationForeground
+	}
+	return o.PropagationPolicy
+}
+
+// Timeouts maps the `apiVersion/kind` of objects (e.g., "apps/v1/Deployment", or "v1/Service" for
+// the core group) to how long the provider waits for them to become ready, overriding the default
+// of their awaiter. It is set by the provider's `timeouts` config, so that the waits of a whole
+// cluster can be tuned at once, and is in turn overridden for individual objects by the
+// `pulumi.com/timeoutSeconds` annotation.
+type Timeouts map[string]time.Duration
+
+// ParseTimeouts parses the provider's `timeouts` config: a JSON object mapping `apiVersion/kind` to
+// a positive duration, e.g., `{"v1/Service": "5m", "apps/v1/Deployment": "15m"}`.
+func ParseTimeouts(text string) (Timeouts, error) {
+	var raw map[string]string
+	if err := json.Unmarshal([]byte(text), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse timeouts: %v", err)
+	}
+
+	timeouts := Timeouts{}
+	for id, value := range raw {
+		if strings.Count(id, "/") < 1 {
+			return nil, fmt.Errorf("timeouts must be keyed by apiVersion/kind (e.g., %q), got %q",
+				"apps/v1/Deployment", id)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("timeout for %q must be a positive duration (e.g., %q), got %q",
+				id, "10m", value)
+		}
+		timeouts[id] = timeout
+	}
+	return timeouts, nil
+}
+
+// forObject returns the timeout configured for the kind of `obj`, if any.
+func (t Timeouts) forObject(obj *unstructured.Unstructured) (time.Duration, bool) {
+	timeout, exists := t[fmt.Sprintf("%s/%s", obj.GetAPIVersion(), obj.GetKind())]
+	return timeout, exists
+}
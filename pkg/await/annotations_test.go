@@ -0,0 +1,178 @@
+package await
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_TimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		timeout     time.Duration
+		isValid     bool
+	}{
+		{"No annotation", nil, 0, true},
+		{"Positive integer", map[string]string{AnnotationTimeoutSeconds: "1200"}, 20 * time.Minute, true},
+		{"Zero", map[string]string{AnnotationTimeoutSeconds: "0"}, 0, false},
+		{"Negative integer", map[string]string{AnnotationTimeoutSeconds: "-5"}, 0, false},
+		{"Duration string", map[string]string{AnnotationTimeoutSeconds: "10m"}, 0, false},
+	}
+
+	for _, test := range tests {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetAnnotations(test.annotations)
+
+		timeout, err := TimeoutSeconds(obj)
+		assert.Equal(t, test.timeout, timeout, test.description)
+		assert.Equal(t, test.isValid, err == nil, test.description)
+	}
+}
+
+func Test_EndpointSettleSeconds(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		period      time.Duration
+		exists      bool
+		isValid     bool
+	}{
+		{"No annotation", nil, 0, false, true},
+		{"Positive integer", map[string]string{AnnotationEndpointSettleSeconds: "3"}, 3 * time.Second,
+			true, true},
+		{"Zero", map[string]string{AnnotationEndpointSettleSeconds: "0"}, 0, true, true},
+		{"Negative integer", map[string]string{AnnotationEndpointSettleSeconds: "-5"}, 0, false, false},
+		{"Duration string", map[string]string{AnnotationEndpointSettleSeconds: "3s"}, 0, false, false},
+	}
+
+	for _, test := range tests {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetAnnotations(test.annotations)
+
+		period, exists, err := EndpointSettleSeconds(obj)
+		assert.Equal(t, test.period, period, test.description)
+		assert.Equal(t, test.exists, exists, test.description)
+		assert.Equal(t, test.isValid, err == nil, test.description)
+	}
+}
+
+func Test_CreateAwaitConfig_Timeout(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	c := mockAwaitConfig(obj)
+	assert.Equal(t, 5*time.Minute, c.timeout(5*time.Minute), "Default timeout")
+
+	obj.SetAnnotations(map[string]string{AnnotationTimeoutSeconds: "30"})
+	assert.Equal(t, 30*time.Second, c.timeout(5*time.Minute), "Annotated timeout")
+
+	obj.SetAnnotations(map[string]string{AnnotationTimeoutSeconds: "soon"})
+	assert.Equal(t, 5*time.Minute, c.timeout(5*time.Minute), "Invalid annotation")
+}
+
+func Test_CreateAwaitConfig_ProviderTimeout(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+	}}
+	c := mockAwaitConfig(obj)
+	c.options.Timeouts = Timeouts{"apps/v1/Deployment": 15 * time.Minute, "v1/Service": time.Minute}
+	assert.Equal(t, 15*time.Minute, c.timeout(5*time.Minute), "Configured timeout for kind")
+
+	obj.SetAnnotations(map[string]string{AnnotationTimeoutSeconds: "30"})
+	assert.Equal(t, 30*time.Second, c.timeout(5*time.Minute), "Annotation overrides config")
+
+	obj.SetAnnotations(nil)
+	obj.SetAPIVersion("extensions/v1beta1")
+	assert.Equal(t, 5*time.Minute, c.timeout(5*time.Minute), "No timeout configured for kind")
+}
+
+func Test_ParseTimeouts(t *testing.T) {
+	tests := []struct {
+		description string
+		text        string
+		expected    Timeouts
+		isValid     bool
+	}{
+		{
+			description: "Timeouts should parse",
+			text:        `{"v1/Service": "5m", "apps/v1/Deployment": "1h30m"}`,
+			expected:    Timeouts{"v1/Service": 5 * time.Minute, "apps/v1/Deployment": 90 * time.Minute},
+			isValid:     true,
+		},
+		{
+			description: "Timeouts must be keyed by apiVersion/kind",
+			text:        `{"Service": "5m"}`,
+		},
+		{
+			description: "Timeouts must be durations",
+			text:        `{"v1/Service": "300"}`,
+		},
+		{
+			description: "Timeouts must be positive",
+			text:        `{"v1/Service": "-5m"}`,
+		},
+		{
+			description: "Timeouts must be an object",
+			text:        `["5m"]`,
+		},
+	}
+
+	for _, test := range tests {
+		timeouts, err := ParseTimeouts(test.text)
+		assert.Equal(t, test.isValid, err == nil, test.description)
+		if test.isValid {
+			assert.Equal(t, test.expected, timeouts, test.description)
+		}
+	}
+}
+
+func Test_SkipAwait(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		opts        Options
+		skip        bool
+	}{
+		{"No annotation", nil, Options{}, false},
+		{"Annotation is true", map[string]string{AnnotationSkipAwait: "true"}, Options{}, true},
+		{"Annotation is false", map[string]string{AnnotationSkipAwait: "false"}, Options{}, false},
+		{"Awaits are suppressed", nil, Options{SuppressAwait: true}, true},
+	}
+
+	for _, test := range tests {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetAnnotations(test.annotations)
+		assert.Equal(t, test.skip, skipAwait(obj, test.opts), test.description)
+	}
+}
+
+func Test_DeletionPropagationPolicy(t *testing.T) {
+	tests := []struct {
+		description string
+		annotations map[string]string
+		opts        Options
+		policy      metav1.DeletionPropagation
+		isValid     bool
+	}{
+		{"No annotation", nil, Options{}, metav1.DeletePropagationForeground, true},
+		{"Provider default", nil, Options{PropagationPolicy: metav1.DeletePropagationBackground},
+			metav1.DeletePropagationBackground, true},
+		{"Annotation overrides provider default",
+			map[string]string{AnnotationDeletionPropagationPolicy: "Orphan"},
+			Options{PropagationPolicy: metav1.DeletePropagationBackground},
+			metav1.DeletePropagationOrphan, true},
+		{"Unknown policy", map[string]string{AnnotationDeletionPropagationPolicy: "orphan"},
+			Options{}, "", false},
+	}
+
+	for _, test := range tests {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetAnnotations(test.annotations)
+		policy, err := DeletionPropagationPolicy(obj, test.opts)
+		assert.Equal(t, test.isValid, err == nil, test.description)
+		assert.Equal(t, test.policy, policy, test.description)
+	}
+}
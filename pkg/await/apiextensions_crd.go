@@ -0,0 +1,143 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi-kubernetes/pkg/watcher"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for apiextensions.k8s.io/v1beta1/CustomResourceDefinition and
+// apiextensions.k8s.io/v1/CustomResourceDefinition.
+//
+// A CRD is accepted by the API server immediately, but its custom resources can't be created until
+// the API server has checked that its names don't conflict with any other CRD, and started serving
+// its REST endpoints. Programs usually create a CRD and its custom resources together, so we wait
+// for both steps, as reported in `.status.conditions`:
+//
+//   1. `NamesAccepted` is `True`. If it is `False` (e.g., `NameConflict`), the CRD will never be
+//      served, so we fail immediately.
+//   2. `Established` is `True`.
+//
+// Once the CRD is established, we invalidate the discovery cache, so that the custom resources
+// that follow it can be resolved to their new endpoints.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definitions/
+
+// ------------------------------------------------------------------------------------------------
+
+const crdEstablishedTimeout = 5 * time.Minute
+
+// crdConditionErrors returns a message for each of the CRD's conditions that is not yet `True`, and
+// whether any of them is fatal, i.e., will never become `True` without a change to the CRD.
+func crdConditionErrors(crd *unstructured.Unstructured) (messages []string, fatal bool) {
+	rawConditions, _ := openapi.Pluck(crd.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+
+	pending := map[string]string{
+		"NamesAccepted": "CustomResourceDefinition names have not been accepted",
+		"Established":   "CustomResourceDefinition has not been established",
+	}
+	for _, conditionType := range []string{"NamesAccepted", "Established"} {
+		condition, found := findCondition(conditions, conditionType)
+		if !found {
+			messages = append(messages, pending[conditionType])
+			continue
+		}
+		if condition["status"] == trueStatus {
+			continue
+		}
+
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		messages = append(messages, fmt.Sprintf("%s: [%s] %s", pending[conditionType], reason, message))
+		fatal = fatal || (conditionType == "NamesAccepted" && condition["status"] == "False")
+	}
+	return messages, fatal
+}
+
+func untilCRDEstablished(c createAwaitConfig) error {
+	var lastErrors []string
+	var fatal bool
+	established := func(crd *unstructured.Unstructured, err error) error {
+		if err != nil {
+			return err
+		}
+		lastErrors, fatal = crdConditionErrors(crd)
+		if fatal {
+			return &initializationError{subErrors: lastErrors, object: crd}
+		}
+		if len(lastErrors) > 0 {
+			glog.V(3).Infof("CustomResourceDefinition '%s' is not yet established: %v",
+				crd.GetName(), lastErrors)
+			return watcher.RetryableError(fmt.Errorf("%s", strings.Join(lastErrors, "; ")))
+		}
+		return nil
+	}
+
+	name := c.currentInputs.GetName()
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(established, c.timeout(crdEstablishedTimeout))
+	if err == nil {
+		// The CRD's custom resources are now served; make sure we can find them.
+		if cached, isCached := c.disco.(discovery.CachedDiscoveryInterface); isCached {
+			cached.Invalidate()
+		}
+		return nil
+	}
+	if fatal || len(lastErrors) == 0 {
+		return err
+	}
+	if c.ctx.Err() != nil {
+		return &cancellationError{objectName: name, subErrors: lastErrors}
+	}
+	return &timeoutError{objectName: name, subErrors: lastErrors}
+}
+
+func untilCRDEstablishedUpdated(u updateAwaitConfig) error {
+	return untilCRDEstablished(u.createAwaitConfig)
+}
+
+func readCRDEstablished(c createAwaitConfig) error {
+	crd, err := c.clientForResource.Get(c.currentInputs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the resource as having been deleted.
+		return err
+	}
+
+	if messages, _ := crdConditionErrors(crd); len(messages) > 0 {
+		return &initializationError{subErrors: messages, object: crd}
+	}
+	return nil
+}
+
+var crdAwaiter = awaitSpec{
+	awaitCreation: untilCRDEstablished,
+	awaitUpdate:   untilCRDEstablishedUpdated,
+	awaitRead:     readCRDEstablished,
+}
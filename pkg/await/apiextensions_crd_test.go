@@ -0,0 +1,70 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Apiextensions_CRDConditionErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		conditions  string
+		errors      []string
+		fatal       bool
+	}{
+		{
+			description: "Should succeed when names are accepted and CRD is established",
+			conditions: `[
+				{"type": "NamesAccepted", "status": "True", "reason": "NoConflicts"},
+				{"type": "Established", "status": "True", "reason": "InitialNamesAccepted"}
+			]`,
+		},
+		{
+			description: "Should wait if controller has not yet reported conditions",
+			conditions:  `[]`,
+			errors: []string{
+				"CustomResourceDefinition names have not been accepted",
+				"CustomResourceDefinition has not been established",
+			},
+		},
+		{
+			description: "Should wait until CRD is established",
+			conditions: `[
+				{"type": "NamesAccepted", "status": "True", "reason": "NoConflicts"},
+				{"type": "Established", "status": "False", "reason": "Installing",
+				 "message": "the initial names have not yet been accepted"}
+			]`,
+			errors: []string{"CustomResourceDefinition has not been established: [Installing] " +
+				"the initial names have not yet been accepted"},
+		},
+		{
+			description: "Should fail immediately on a name conflict",
+			conditions: `[
+				{"type": "NamesAccepted", "status": "False", "reason": "NameConflict",
+				 "message": "\"foos\" is already in use"},
+				{"type": "Established", "status": "False", "reason": "NotAccepted",
+				 "message": "not all names are accepted"}
+			]`,
+			errors: []string{
+				`CustomResourceDefinition names have not been accepted: [NameConflict] "foos" is already in use`,
+				"CustomResourceDefinition has not been established: [NotAccepted] not all names are accepted",
+			},
+			fatal: true,
+		},
+	}
+
+	for _, test := range tests {
+		crd, err := decodeUnstructured(`{
+			"apiVersion": "apiextensions.k8s.io/v1beta1",
+			"kind": "CustomResourceDefinition",
+			"metadata": {"name": "foos.example.com"},
+			"status": {"conditions": ` + test.conditions + `}
+		}`)
+		assert.NoError(t, err, test.description)
+
+		errors, fatal := crdConditionErrors(crd)
+		assert.Equal(t, test.errors, errors, test.description)
+		assert.Equal(t, test.fatal, fatal, test.description)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi-kubernetes/pkg/watcher"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for apiregistration.k8s.io/v1/APIService and
+// apiregistration.k8s.io/v1beta1/APIService.
+//
+// An APIService registers an API group/version with the aggregation layer. For an aggregated API
+// (e.g., `metrics.k8s.io`, served by metrics-server), the API server proxies requests for the
+// group/version to a Service in the cluster, so the API is not usable until that Service's Pods are
+// up and have passed the API server's discovery check. Programs usually install the aggregated API
+// server and the resources that use it together, so we wait for the `Available` condition to be
+// `True`.
+//
+// While the API server behind the APIService is starting, `Available` is routinely `False` (e.g.,
+// `MissingEndpoints` or `FailedDiscoveryCheck`), so we keep waiting until the timeout rather than
+// failing immediately.
+//
+// Once the APIService is available, we invalidate the discovery cache, so that the resources that
+// follow it can be resolved to their new endpoints.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/tasks/extend-kubernetes/configure-aggregation-layer/
+
+// ------------------------------------------------------------------------------------------------
+
+const apiServiceAvailableTimeout = 5 * time.Minute
+
+// apiServiceAvailableError returns a message describing why the APIService is not yet available, or
+// "" if it is.
+func apiServiceAvailableError(apiService *unstructured.Unstructured) string {
+	rawConditions, _ := openapi.Pluck(apiService.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+
+	condition, found := findCondition(conditions, "Available")
+	if !found {
+		return "APIService has not yet been checked for availability"
+	}
+	if condition["status"] == trueStatus {
+		return ""
+	}
+
+	reason, _ := condition["reason"].(string)
+	message, _ := condition["message"].(string)
+	return fmt.Sprintf("APIService is not available: [%s] %s", reason, message)
+}
+
+func untilAPIServiceAvailable(c createAwaitConfig) error {
+	var lastError string
+	available := func(apiService *unstructured.Unstructured, err error) error {
+		if err != nil {
+			return err
+		}
+		lastError = apiServiceAvailableError(apiService)
+		if lastError != "" {
+			glog.V(3).Infof("APIService '%s' is not yet available: %s", apiService.GetName(), lastError)
+			return watcher.RetryableError(fmt.Errorf("%s", lastError))
+		}
+		return nil
+	}
+
+	name := c.currentInputs.GetName()
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(available, c.timeout(apiServiceAvailableTimeout))
+	if err == nil {
+		// The aggregated API is now served; make sure we can find its resources.
+		if cached, isCached := c.disco.(discovery.CachedDiscoveryInterface); isCached {
+			cached.Invalidate()
+		}
+		return nil
+	}
+	if lastError == "" {
+		return err
+	}
+	if c.ctx.Err() != nil {
+		return &cancellationError{objectName: name, subErrors: []string{lastError}}
+	}
+	return &timeoutError{objectName: name, subErrors: []string{lastError}}
+}
+
+func untilAPIServiceAvailableUpdated(u updateAwaitConfig) error {
+	return untilAPIServiceAvailable(u.createAwaitConfig)
+}
+
+func readAPIServiceAvailable(c createAwaitConfig) error {
+	apiService, err := c.clientForResource.Get(c.currentInputs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the resource as having been deleted.
+		return err
+	}
+
+	if message := apiServiceAvailableError(apiService); message != "" {
+		return &initializationError{subErrors: []string{message}, object: apiService}
+	}
+	return nil
+}
+
+var apiServiceAwaiter = awaitSpec{
+	awaitCreation: untilAPIServiceAvailable,
+	awaitUpdate:   untilAPIServiceAvailableUpdated,
+	awaitRead:     readAPIServiceAvailable,
+}
@@ -0,0 +1,45 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Apiregistration_APIServiceAvailableError(t *testing.T) {
+	tests := []struct {
+		description string
+		conditions  string
+		expected    string
+	}{
+		{
+			description: "Should succeed when APIService is available",
+			conditions:  `[{"type": "Available", "status": "True", "reason": "Passed"}]`,
+		},
+		{
+			description: "Should wait if availability has not yet been checked",
+			conditions:  `[]`,
+			expected:    "APIService has not yet been checked for availability",
+		},
+		{
+			description: "Should wait while the API server behind the APIService is starting",
+			conditions: `[
+				{"type": "Available", "status": "False", "reason": "MissingEndpoints",
+				 "message": "endpoints for service/metrics-server in \"kube-system\" have no addresses"}
+			]`,
+			expected: "APIService is not available: [MissingEndpoints] endpoints for " +
+				"service/metrics-server in \"kube-system\" have no addresses",
+		},
+	}
+
+	for _, test := range tests {
+		apiService, err := decodeUnstructured(`{
+			"apiVersion": "apiregistration.k8s.io/v1",
+			"kind": "APIService",
+			"metadata": {"name": "v1beta1.metrics.k8s.io"},
+			"status": {"conditions": ` + test.conditions + `}
+		}`)
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.expected, apiServiceAvailableError(apiService), test.description)
+	}
+}
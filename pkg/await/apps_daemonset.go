@@ -0,0 +1,447 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for extensions/v1beta1/DaemonSet, apps/v1beta2/DaemonSet, and apps/v1/DaemonSet.
+//
+// A DaemonSet runs one Pod on each (eligible) node of the cluster. The DaemonSet controller reports
+// its progress in `.status`:
+//
+//   * `desiredNumberScheduled` is the number of nodes that should be running the Pod.
+//   * `numberReady` is the number of those nodes on which the Pod is ready.
+//   * `updatedNumberScheduled` is the number of nodes running the Pod of the current template.
+//
+// The success conditions are:
+//
+//   1. `.status.observedGeneration` is at least `.metadata.generation`, i.e., the DaemonSet
+//      controller has seen the current spec.
+//   2. `.status.numberReady` is equal to `.status.desiredNumberScheduled`.
+//   3. On update, if the update strategy is `RollingUpdate`, `.status.updatedNumberScheduled` is
+//      equal to `.status.desiredNumberScheduled`. The controller only ever takes down
+//      `maxUnavailable` nodes' Pods at a time, so during an update we accept up to `maxUnavailable`
+//      Pods that are not yet ready, the same tolerance the user asked the controller to apply.
+//
+// A DaemonSet whose node selector matches no nodes (`desiredNumberScheduled` is 0) is considered
+// ready, since there is nothing to wait for, but we warn about it, because it's usually a mistake.
+//
+// Pods that can't be scheduled onto their node (e.g., because the node is out of resources) are
+// reported periodically as warnings, along with the node they were meant for, as are the
+// DaemonSet's own warning events (e.g., `FailedPlacement` from older controllers, which schedule
+// Pods themselves).
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/workloads/controllers/daemonset/
+//   * https://kubernetes.io/docs/tasks/manage-daemon/update-daemon-set/
+
+// ------------------------------------------------------------------------------------------------
+
+type daemonsetInitAwaiter struct {
+	config                 updateAwaitConfig
+	daemonset              *unstructured.Unstructured
+	generation             int64
+	observedGeneration     int64
+	desiredNumberScheduled int64
+	numberReady            int64
+	updatedNumberScheduled int64
+	warnedNoNodes          bool
+
+	daemonsetErrors map[string]string
+
+	pods map[string]*unstructured.Unstructured
+}
+
+func makeDaemonSetInitAwaiter(c updateAwaitConfig) *daemonsetInitAwaiter {
+	return &daemonsetInitAwaiter{
+		config:    c,
+		daemonset: c.currentInputs,
+
+		daemonsetErrors: map[string]string{},
+
+		pods: map[string]*unstructured.Unstructured{},
+	}
+}
+
+func (dsa *daemonsetInitAwaiter) Await() error {
+	podClient, err := dsa.makePodClient()
+	if err != nil {
+		return err
+	}
+
+	// Create DaemonSet watcher.
+	daemonsetWatcher, err := watchWithReconnect(dsa.config.clientForResource,
+		nameListOptions(dsa.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for DaemonSet object '%s'",
+			dsa.config.currentInputs.GetName())
+	}
+	defer daemonsetWatcher.Stop()
+
+	// Create Pod watcher.
+	podWatcher, err := watchWithReconnect(podClient, selectorListOptions(dsa.config.currentInputs))
+	if err != nil {
+		return errors.Wrapf(err,
+			"Could not create watcher for Pods objects associated with DaemonSet '%s'",
+			dsa.config.currentInputs.GetName())
+	}
+	defer podWatcher.Stop()
+
+	period := time.NewTicker(10 * time.Second)
+	defer period.Stop()
+
+	return dsa.await(daemonsetWatcher, podWatcher,
+		client.ThrottledAfter(dsa.config.timeout(5*time.Minute)), period.C)
+}
+
+func (dsa *daemonsetInitAwaiter) Read() error {
+	podClient, err := dsa.makePodClient()
+	if err != nil {
+		return err
+	}
+
+	daemonset, err := dsa.config.clientForResource.Get(dsa.config.currentInputs.GetName(),
+		metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the DaemonSet as having been deleted.
+		return err
+	}
+
+	podList, err := podClient.List(selectorListOptions(daemonset))
+	if err != nil {
+		glog.V(3).Infof("Error retrieving Pod list for DaemonSet '%s': %v",
+			daemonset.GetName(), err)
+		podList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
+	}
+
+	return dsa.read(daemonset, podList.(*unstructured.UnstructuredList))
+}
+
+func (dsa *daemonsetInitAwaiter) read(
+	daemonset *unstructured.Unstructured, pods *unstructured.UnstructuredList,
+) error {
+	dsa.processDaemonSetEvent(watchAddedEvent(daemonset))
+
+	err := pods.EachListItem(func(pod runtime.Object) error {
+		dsa.processPodEvent(watchAddedEvent(pod.(*unstructured.Unstructured)))
+		return nil
+	})
+	if err != nil {
+		glog.V(3).Infof("Error iterating over Pod list for DaemonSet '%s': %v",
+			daemonset.GetName(), err)
+	}
+
+	if dsa.succeeded() {
+		return nil
+	}
+
+	return &initializationError{
+		subErrors: dsa.errorMessages(),
+		object:    daemonset,
+	}
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (dsa *daemonsetInitAwaiter) await(
+	daemonsetWatcher, podWatcher watch.Interface, timeout, period <-chan time.Time,
+) error {
+	inputName := dsa.config.currentInputs.GetName()
+	for {
+		if dsa.succeeded() {
+			if dsa.desiredNumberScheduled == 0 && !dsa.warnedNoNodes {
+				dsa.warnedNoNodes = true
+				dsa.warn(fmt.Sprintf(
+					"DaemonSet '%s' is not scheduled on any nodes; check its node selector and tolerations",
+					inputName))
+			}
+			return nil
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-dsa.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  dsa.errorMessages(),
+			}
+		case <-timeout:
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  dsa.errorMessages(),
+			}
+		case <-period:
+			for _, message := range dsa.schedulingErrors() {
+				dsa.warn(message)
+			}
+			for _, message := range dsa.eventWarnings() {
+				dsa.warn(message)
+			}
+		case event := <-daemonsetWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			dsa.processDaemonSetEvent(event)
+		case event := <-podWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			dsa.processPodEvent(event)
+		}
+	}
+}
+
+func (dsa *daemonsetInitAwaiter) succeeded() bool {
+	if dsa.generation == 0 || dsa.observedGeneration < dsa.generation {
+		return false
+	}
+
+	// Creation: every node must be running a ready Pod.
+	if dsa.config.lastInputs == nil {
+		return dsa.numberReady >= dsa.desiredNumberScheduled
+	}
+
+	// Update: every node must be running the new template, but we tolerate as many unready Pods as
+	// the controller itself is allowed to take down.
+	if !dsa.isRollingUpdate() {
+		return dsa.numberReady >= dsa.desiredNumberScheduled
+	}
+	return dsa.updatedNumberScheduled >= dsa.desiredNumberScheduled &&
+		dsa.numberReady >= dsa.desiredNumberScheduled-dsa.maxUnavailable()
+}
+
+func (dsa *daemonsetInitAwaiter) processDaemonSetEvent(event watch.Event) {
+	daemonset, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("DaemonSet watch received unknown object type '%s'",
+			reflect.TypeOf(daemonset))
+		return
+	}
+
+	// Do nothing if this is not the DaemonSet we're waiting for.
+	if daemonset.GetName() != dsa.config.currentInputs.GetName() {
+		return
+	}
+
+	// Start over, prove that rollout is complete.
+	dsa.daemonsetErrors = map[string]string{}
+	dsa.observedGeneration = 0
+
+	// Mark the rollout as incomplete if it's deleted.
+	if event.Type == watch.Deleted {
+		return
+	}
+
+	dsa.daemonset = daemonset
+	dsa.generation = daemonset.GetGeneration()
+
+	rawStatus, hasStatus := openapi.Pluck(daemonset.Object, "status")
+	status, isMap := rawStatus.(map[string]interface{})
+	if !hasStatus || !isMap {
+		// DaemonSet controller has not yet seen this DaemonSet. Do nothing.
+		return
+	}
+	dsa.observedGeneration, _ = int64Value(status["observedGeneration"])
+	dsa.desiredNumberScheduled, _ = int64Value(status["desiredNumberScheduled"])
+	dsa.numberReady, _ = int64Value(status["numberReady"])
+	dsa.updatedNumberScheduled, _ = int64Value(status["updatedNumberScheduled"])
+
+	conditions, _ := status["conditions"].([]interface{})
+	for _, rawCondition := range conditions {
+		condition, isMap := rawCondition.(map[string]interface{})
+		if !isMap || condition["status"] == trueStatus {
+			continue
+		}
+		errorFromCondition(dsa.daemonsetErrors, condition)
+	}
+}
+
+func (dsa *daemonsetInitAwaiter) processPodEvent(event watch.Event) {
+	pod, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Pod watch received unknown object type '%s'",
+			reflect.TypeOf(pod))
+		return
+	}
+
+	// Check whether this Pod was created by our DaemonSet.
+	if !isOwnedBy(pod, dsa.config.currentInputs) {
+		return
+	}
+
+	// If Pod was deleted, remove it from our aggregated checkers.
+	if event.Type == watch.Deleted {
+		delete(dsa.pods, pod.GetName())
+		return
+	}
+	dsa.pods[pod.GetName()] = pod
+}
+
+func (dsa *daemonsetInitAwaiter) isRollingUpdate() bool {
+	strategy, _ := openapi.Pluck(dsa.daemonset.Object, "spec", "updateStrategy", "type")
+	// NOTE: `RollingUpdate` is the default strategy in apps/v1beta2 and apps/v1. The
+	// extensions/v1beta1 default is `OnDelete`, but the API server fills it in, so we'll see it on
+	// the live object.
+	return strategy == nil || strategy == "RollingUpdate"
+}
+
+// maxUnavailable returns the number of Pods the DaemonSet controller may take down at once during
+// a rolling update. As in the controller, percentages are of the desired number of Pods, rounded
+// up, and the default is 1.
+func (dsa *daemonsetInitAwaiter) maxUnavailable() int64 {
+	rawMaxUnavailable, exists := openapi.Pluck(
+		dsa.daemonset.Object, "spec", "updateStrategy", "rollingUpdate", "maxUnavailable")
+	if !exists {
+		return 1
+	}
+
+	var maxUnavailable intstr.IntOrString
+	if percent, isString := rawMaxUnavailable.(string); isString {
+		maxUnavailable = intstr.FromString(percent)
+	} else if count, isNumber := int64Value(rawMaxUnavailable); isNumber {
+		maxUnavailable = intstr.FromInt(int(count))
+	} else {
+		return 1
+	}
+
+	value, err := intstr.GetValueFromIntOrPercent(&maxUnavailable, int(dsa.desiredNumberScheduled), true)
+	if err != nil {
+		glog.V(3).Infof("DaemonSet '%s' has invalid maxUnavailable '%v': %v",
+			dsa.config.currentInputs.GetName(), rawMaxUnavailable, err)
+		return 1
+	}
+	return int64(value)
+}
+
+// schedulingErrors describes each of the DaemonSet's Pods that could not be scheduled onto its
+// node.
+func (dsa *daemonsetInitAwaiter) schedulingErrors() []string {
+	names := []string{}
+	for name := range dsa.pods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := []string{}
+	for _, name := range names {
+		pod := dsa.pods[name]
+		checker := makePodChecker()
+		checker.check(pod)
+
+		reasons := []string{}
+		for reason := range checker.podScheduledErrors {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		for _, reason := range reasons {
+			messages = append(messages, fmt.Sprintf("Pod '%s' could not be scheduled on node '%s': [%s] %s",
+				name, podNodeName(pod), reason, checker.podScheduledErrors[reason]))
+		}
+	}
+	return messages
+}
+
+// eventWarnings returns the messages of the DaemonSet's recent warning events, e.g., the
+// `FailedPlacement` events reported when a node can't fit the DaemonSet's Pod.
+func (dsa *daemonsetInitAwaiter) eventWarnings() []string {
+	if dsa.config.pool == nil {
+		return nil
+	}
+
+	name := dsa.config.currentInputs.GetName()
+	clientForEvents, err := dsa.config.eventClient()
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for DaemonSet '%s': %v", name, err)
+		return nil
+	}
+	warnings, err := getLastWarningsForObject(clientForEvents,
+		dsa.config.currentInputs.GetNamespace(), name, "DaemonSet", 10)
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for DaemonSet '%s': %v", name, err)
+		return nil
+	}
+
+	messages := []string{}
+	for _, warning := range warnings {
+		messages = append(messages, fmt.Sprintf("[%s] %s", warning.Reason, warning.Message))
+	}
+	return messages
+}
+
+// podNodeName returns the name of the node a DaemonSet Pod is meant to run on. Older DaemonSet
+// controllers set `.spec.nodeName` directly; newer ones leave scheduling to the scheduler, and pin
+// the Pod to its node with a node affinity on `metadata.name`.
+func podNodeName(pod *unstructured.Unstructured) string {
+	if nodeName, _ := openapi.Pluck(pod.Object, "spec", "nodeName"); nodeName != nil && nodeName != "" {
+		return fmt.Sprint(nodeName)
+	}
+
+	rawTerms, _ := openapi.Pluck(pod.Object, "spec", "affinity", "nodeAffinity",
+		"requiredDuringSchedulingIgnoredDuringExecution", "nodeSelectorTerms")
+	terms, _ := rawTerms.([]interface{})
+	for _, rawTerm := range terms {
+		term, _ := rawTerm.(map[string]interface{})
+		fields, _ := term["matchFields"].([]interface{})
+		for _, rawField := range fields {
+			field, _ := rawField.(map[string]interface{})
+			values, _ := field["values"].([]interface{})
+			if field["key"] == "metadata.name" && len(values) == 1 {
+				return fmt.Sprint(values[0])
+			}
+		}
+	}
+	return "<unknown>"
+}
+
+func (dsa *daemonsetInitAwaiter) warn(message string) {
+	if dsa.config.host != nil {
+		_ = dsa.config.host.Log(dsa.config.ctx, diag.Warning, dsa.config.urn, message)
+	}
+}
+
+func (dsa *daemonsetInitAwaiter) errorMessages() []string {
+	messages := []string{}
+	for _, message := range dsa.daemonsetErrors {
+		messages = append(messages, message)
+	}
+	if dsa.generation != 0 {
+		messages = append(messages, fmt.Sprintf("%d/%d Pods ready, %d/%d Pods updated",
+			dsa.numberReady, dsa.desiredNumberScheduled,
+			dsa.updatedNumberScheduled, dsa.desiredNumberScheduled))
+	}
+	return append(messages, dsa.schedulingErrors()...)
+}
+
+func (dsa *daemonsetInitAwaiter) makePodClient() (dynamic.ResourceInterface, error) {
+	podClient, err := client.FromGVK(dsa.config.pool, dsa.config.disco,
+		schema.GroupVersionKind{
+			Group:   "",
+			Version: "v1",
+			Kind:    "Pod",
+		}, dsa.config.currentInputs.GetNamespace())
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"Could not make client to watch Pods associated with DaemonSet '%s'",
+			dsa.config.currentInputs.GetName())
+	}
+	return podClient, nil
+}
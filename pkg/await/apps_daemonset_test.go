@@ -0,0 +1,220 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const daemonsetInputName = "fluentd"
+
+func Test_Apps_DaemonSet(t *testing.T) {
+	tests := []struct {
+		description   string
+		lastInputs    *unstructured.Unstructured
+		do            func(daemonsets, pods chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when all Pods are ready",
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(1, 1, 3, 0, 0, ""))
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(1, 1, 3, 3, 3, ""))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail if no Pods are ready",
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(1, 1, 3, 0, 3, ""))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: daemonsetInputName,
+				subErrors:  []string{"0/3 Pods ready, 3/3 Pods updated"}},
+		},
+		{
+			description: "Should wait for the controller to observe the current generation",
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(2, 1, 3, 3, 3, ""))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: daemonsetInputName,
+				subErrors:  []string{"3/3 Pods ready, 3/3 Pods updated"}},
+		},
+		{
+			description: "Should succeed if no nodes are eligible",
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(1, 1, 0, 0, 0, ""))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "[Update] Should tolerate maxUnavailable unready Pods",
+			lastInputs:  daemonsetInput(),
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(2, 2, 4, 3, 4, "25%"))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "[Update] Should fail if more than maxUnavailable Pods are unready",
+			lastInputs:  daemonsetInput(),
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(2, 2, 4, 2, 4, "25%"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: daemonsetInputName,
+				subErrors:  []string{"2/4 Pods ready, 4/4 Pods updated"}},
+		},
+		{
+			description: "[Update] Should fail if not all Pods are updated",
+			lastInputs:  daemonsetInput(),
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(2, 2, 4, 4, 3, "25%"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: daemonsetInputName,
+				subErrors:  []string{"4/4 Pods ready, 3/4 Pods updated"}},
+		},
+		{
+			description: "Should report Pods that could not be scheduled on their node",
+			do: func(daemonsets, pods chan watch.Event, timeout chan time.Time) {
+				daemonsets <- watchAddedEvent(daemonsetWithStatus(1, 1, 2, 1, 2, ""))
+				pods <- watchAddedEvent(daemonsetUnschedulablePod("fluentd-x7k2p", "node-2"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: daemonsetInputName,
+				subErrors: []string{
+					"1/2 Pods ready, 2/2 Pods updated",
+					"Pod 'fluentd-x7k2p' could not be scheduled on node 'node-2': [Unschedulable] " +
+						"0/3 nodes are available: 1 Insufficient memory, 2 node(s) didn't match node selector.",
+				}},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeDaemonSetInitAwaiter(
+			updateAwaitConfig{
+				createAwaitConfig: mockAwaitConfig(daemonsetInput()),
+				lastInputs:        test.lastInputs,
+			})
+		daemonsets := make(chan watch.Event)
+		pods := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go test.do(daemonsets, pods, timeout)
+
+		err := awaiter.await(&chanWatcher{results: daemonsets}, &chanWatcher{results: pods}, timeout,
+			period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Apps_DaemonSet_PodNodeName(t *testing.T) {
+	pod := daemonsetUnschedulablePod("fluentd-x7k2p", "node-2")
+	assert.Equal(t, "node-2", podNodeName(pod))
+
+	unstructured.SetNestedField(pod.Object, "node-1", "spec", "nodeName")
+	assert.Equal(t, "node-1", podNodeName(pod))
+
+	unstructured.RemoveNestedField(pod.Object, "spec")
+	assert.Equal(t, "<unknown>", podNodeName(pod))
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func daemonsetInput() *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "apps/v1",
+		"kind": "DaemonSet",
+		"metadata": {"name": "%s", "namespace": "%s"},
+		"spec": {"selector": {"matchLabels": {"app": "fluentd"}}}
+	}`, daemonsetInputName, inputNamespace))
+}
+
+func daemonsetWithStatus(
+	generation, observedGeneration, desired, ready, updated int, maxUnavailable string,
+) *unstructured.Unstructured {
+	rollingUpdate := "{}"
+	if maxUnavailable != "" {
+		rollingUpdate = fmt.Sprintf(`{"maxUnavailable": "%s"}`, maxUnavailable)
+	}
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "apps/v1",
+		"kind": "DaemonSet",
+		"metadata": {"name": "%s", "namespace": "%s", "generation": %d},
+		"spec": {
+			"selector": {"matchLabels": {"app": "fluentd"}},
+			"updateStrategy": {"type": "RollingUpdate", "rollingUpdate": %s}
+		},
+		"status": {
+			"observedGeneration": %d,
+			"desiredNumberScheduled": %d,
+			"currentNumberScheduled": %d,
+			"numberReady": %d,
+			"updatedNumberScheduled": %d
+		}
+	}`, daemonsetInputName, inputNamespace, generation, rollingUpdate, observedGeneration, desired,
+		desired, ready, updated))
+}
+
+func daemonsetUnschedulablePod(name, nodeName string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "%s",
+			"namespace": "%s",
+			"ownerReferences": [{
+				"apiVersion": "apps/v1", "kind": "DaemonSet", "name": "%s",
+				"uid": "0b2e3c5e-c3a4-11e8-a6c1-025000000001", "controller": true
+			}]
+		},
+		"spec": {
+			"affinity": {"nodeAffinity": {"requiredDuringSchedulingIgnoredDuringExecution": {
+				"nodeSelectorTerms": [{"matchFields": [
+					{"key": "metadata.name", "operator": "In", "values": ["%s"]}
+				]}]
+			}}}
+		},
+		"status": {
+			"phase": "Pending",
+			"conditions": [{
+				"type": "PodScheduled",
+				"status": "False",
+				"reason": "Unschedulable",
+				"message": "0/3 nodes are available: 1 Insufficient memory, 2 node(s) didn't match node selector."
+			}]
+		}
+	}`, name, inputNamespace, daemonsetInputName, nodeName))
+}
@@ -15,6 +15,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 )
@@ -66,7 +68,8 @@ import (
 //   3. A timeout channel, which fires after some minutes.
 //   4. A cancellation channel, with which the user can signal cancellation (e.g., using SIGINT).
 //   5. A period channel, which is used to signal when we should display an aggregated report of
-//      Pod errors we know about.
+//      Pod errors we know about, along with the progress of the rollout (e.g., "3/10 updated
+//      replicas available"), so that the user can see whether a long rollout is moving or stalled.
 //
 // The `deploymentInitAwaiter` will synchronously process events from the union of all these channels.
 // Any time the success conditions described above a reached, we will terminate the awaiter.
@@ -74,6 +77,16 @@ import (
 // The opportunity to display intermediate results will typically appear after a container in the
 // Pod fails, (e.g., volume fails to mount, image fails to pull, exited with code 1, etc.).
 //
+// Canary-style rollouts are supported with the `pulumi.com/rolloutGate` annotation. If it is set
+// (to a count or percentage of the desired replicas), the awaiter succeeds as soon as that many Pods
+// of the new revision are ready, rather than waiting for the rollout to complete; if
+// `pulumi.com/rolloutGatePause` is also "true", the Deployment is then paused, so the rollout
+// stops at the canary Pods.
+//
+// A paused Deployment (i.e., `spec.paused` is true) never makes progress, so there is nothing to
+// wait for. By default we succeed immediately, noting that the Deployment is paused; if the
+// `pulumi.com/failIfPaused` annotation is "true", we fail immediately instead.
+//
 //
 // x-refs:
 //   * https://kubernetes.io/docs/concepts/workloads/controllers/deployment/
@@ -92,6 +105,17 @@ type deploymentInitAwaiter struct {
 	updatedReplicaSetReady bool
 	currentGeneration      string
 
+	// rolloutGate is the value of the `pulumi.com/rolloutGate` annotation, if any, and
+	// rolloutGateReached is true once enough Pods of the new revision are ready to satisfy it.
+	rolloutGate        *intstr.IntOrString
+	rolloutGateReached bool
+
+	// paused is true if the Deployment's rollout is paused, i.e., `spec.paused` is true.
+	paused bool
+
+	// deployment is the last observed state of the Deployment, used to report rollout progress.
+	deployment *unstructured.Unstructured
+
 	deploymentErrors map[string]string
 
 	replicaSets map[string]*unstructured.Unstructured
@@ -111,9 +135,22 @@ func makeDeploymentInitAwaiter(c updateAwaitConfig) *deploymentInitAwaiter {
 
 		pods:        map[string]*unstructured.Unstructured{},
 		replicaSets: map[string]*unstructured.Unstructured{},
+
+		rolloutGate: rolloutGateFor(c.currentInputs),
 	}
 }
 
+// rolloutGateFor returns the rollout gate requested by the `pulumi.com/rolloutGate` annotation, or
+// nil if there is none.
+func rolloutGateFor(deployment *unstructured.Unstructured) *intstr.IntOrString {
+	value, exists := deployment.GetAnnotations()[AnnotationRolloutGate]
+	if !exists || value == "" {
+		return nil
+	}
+	gate := intstr.Parse(value)
+	return &gate
+}
+
 func (dia *deploymentInitAwaiter) Await() error {
 	//
 	// We succeed when only when all of the following are true:
@@ -138,7 +175,8 @@ func (dia *deploymentInitAwaiter) Await() error {
 	}
 
 	// Create Deployment watcher.
-	deploymentWatcher, err := dia.config.clientForResource.Watch(metav1.ListOptions{})
+	deploymentWatcher, err := watchWithReconnect(dia.config.clientForResource,
+		nameListOptions(dia.config.currentInputs.GetName()))
 	if err != nil {
 		return errors.Wrapf(err, "Could set up watch for Deployment object '%s'",
 			dia.config.currentInputs.GetName())
@@ -146,7 +184,8 @@ func (dia *deploymentInitAwaiter) Await() error {
 	defer deploymentWatcher.Stop()
 
 	// Create ReplicaSet watcher.
-	replicaSetWatcher, err := replicaSetClient.Watch(metav1.ListOptions{})
+	replicaSetWatcher, err := watchWithReconnect(replicaSetClient,
+		selectorListOptions(dia.config.currentInputs))
 	if err != nil {
 		return errors.Wrapf(err,
 			"Could not create watcher for ReplicaSet objects associated with Deployment '%s'",
@@ -155,7 +194,7 @@ func (dia *deploymentInitAwaiter) Await() error {
 	defer replicaSetWatcher.Stop()
 
 	// Create Pod watcher.
-	podWatcher, err := podClient.Watch(metav1.ListOptions{})
+	podWatcher, err := watchWithReconnect(podClient, selectorListOptions(dia.config.currentInputs))
 	if err != nil {
 		return errors.Wrapf(err,
 			"Could not create watcher for Pods objects associated with Deployment '%s'",
@@ -166,7 +205,39 @@ func (dia *deploymentInitAwaiter) Await() error {
 	period := time.NewTicker(10 * time.Second)
 	defer period.Stop()
 
-	return dia.await(deploymentWatcher, replicaSetWatcher, podWatcher, time.After(5*time.Minute), period.C)
+	err = dia.await(deploymentWatcher, replicaSetWatcher, podWatcher,
+		client.ThrottledAfter(dia.config.timeout(5*time.Minute)), period.C)
+	if awaitFailed(err) {
+		reportPodLogs(dia.config.createAwaitConfig, podValues(dia.pods))
+	}
+	if err != nil {
+		return err
+	}
+
+	if dia.rolloutGateReached {
+		return dia.pauseAtRolloutGate()
+	}
+	return nil
+}
+
+// pauseAtRolloutGate pauses the Deployment if requested, once its rollout gate has been reached.
+func (dia *deploymentInitAwaiter) pauseAtRolloutGate() error {
+	name := dia.config.currentInputs.GetName()
+	message := fmt.Sprintf("✅ Deployment '%s' reached rollout gate of %s", name, dia.rolloutGate)
+
+	if annotationIsTrue(dia.config.currentInputs, AnnotationRolloutGatePause) {
+		_, err := dia.config.clientForResource.Patch(name, types.MergePatchType,
+			[]byte(`{"spec":{"paused":true}}`))
+		if err != nil {
+			return errors.Wrapf(err, "Could not pause Deployment '%s' at rollout gate", name)
+		}
+		message += "; rollout paused (set `spec.paused` to false to resume)"
+	}
+
+	if dia.config.host != nil {
+		_ = dia.config.host.Log(dia.config.ctx, diag.Info, dia.config.urn, message)
+	}
+	return nil
 }
 
 func (dia *deploymentInitAwaiter) Read() error {
@@ -191,14 +262,14 @@ func (dia *deploymentInitAwaiter) Read() error {
 	// in a way that is useful to the user.
 	//
 
-	rsList, err := replicaSetClient.List(metav1.ListOptions{})
+	rsList, err := replicaSetClient.List(selectorListOptions(deployment))
 	if err != nil {
 		glog.V(3).Infof("Error retrieving ReplicaSet list for Deployment '%s': %v",
 			deployment.GetName(), err)
 		rsList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
 	}
 
-	podList, err := podClient.List(metav1.ListOptions{})
+	podList, err := podClient.List(selectorListOptions(deployment))
 	if err != nil {
 		glog.V(3).Infof("Error retrieving Pod list for Deployment '%s': %v",
 			deployment.GetName(), err)
@@ -235,6 +306,9 @@ func (dia *deploymentInitAwaiter) read(
 	if dia.succeeded() {
 		return nil
 	}
+	if dia.paused {
+		return dia.pausedError(deployment)
+	}
 
 	return &initializationError{
 		subErrors: dia.errorMessages(),
@@ -251,6 +325,9 @@ func (dia *deploymentInitAwaiter) await(
 		if dia.succeeded() {
 			return nil
 		}
+		if dia.paused {
+			return dia.pausedError(dia.config.currentInputs)
+		}
 
 		// Else, wait for updates.
 		select {
@@ -265,6 +342,10 @@ func (dia *deploymentInitAwaiter) await(
 				subErrors:  dia.errorMessages(),
 			}
 		case <-period:
+			if message, ok := rolloutProgressMessage(dia.deployment); ok {
+				dia.info(message)
+			}
+
 			scheduleErrors, containerErrors := dia.aggregatePodErrors()
 			for _, message := range scheduleErrors {
 				dia.warn(message)
@@ -274,10 +355,19 @@ func (dia *deploymentInitAwaiter) await(
 				dia.warn(message)
 			}
 		case event := <-deploymentWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
 			dia.processDeploymentEvent(event)
 		case event := <-replicaSetWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
 			dia.processReplicaSetEvent(event)
 		case event := <-podWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
 			dia.processPodEvent(event)
 		}
 	}
@@ -293,6 +383,11 @@ func (dia *deploymentInitAwaiter) await(
 //      check that the Deployment was created, and the corresponding ReplicaSet needs to be marked
 //      available.
 func (dia *deploymentInitAwaiter) succeeded() bool {
+	// A canary rollout succeeds as soon as its rollout gate is reached.
+	if dia.rolloutGateReached {
+		return true
+	}
+
 	if dia.currentGeneration == "1" {
 		if dia.deploymentAvailable && dia.updatedReplicaSetReady {
 			return true
@@ -306,6 +401,25 @@ func (dia *deploymentInitAwaiter) succeeded() bool {
 	return false
 }
 
+// pausedError returns the result of awaiting a paused Deployment: nil, unless the user asked us to
+// fail with the `pulumi.com/failIfPaused` annotation.
+func (dia *deploymentInitAwaiter) pausedError(deployment *unstructured.Unstructured) error {
+	name := dia.config.currentInputs.GetName()
+	if annotationIsTrue(dia.config.currentInputs, AnnotationFailIfPaused) {
+		return &initializationError{
+			subErrors: []string{"Deployment is paused; its rollout will not progress until " +
+				"`spec.paused` is set to false"},
+			object: deployment,
+		}
+	}
+
+	if dia.config.host != nil {
+		_ = dia.config.host.Log(dia.config.ctx, diag.Info, dia.config.urn, fmt.Sprintf(
+			"Deployment '%s' is paused; not waiting for its rollout to complete", name))
+	}
+	return nil
+}
+
 func (dia *deploymentInitAwaiter) processDeploymentEvent(event watch.Event) {
 	inputDeploymentName := dia.config.currentInputs.GetName()
 
@@ -324,10 +438,19 @@ func (dia *deploymentInitAwaiter) processDeploymentEvent(event watch.Event) {
 		return
 	}
 
+	dia.paused = false
+	dia.deployment = nil
+
 	// Mark the rollout as incomplete if it's deleted.
 	if event.Type == watch.Deleted {
 		return
 	}
+	dia.deployment = deployment
+
+	// NOTE: A Deployment created paused has no ReplicaSet (and hence no revision) yet, so this
+	// must be checked first.
+	paused, _ := openapi.Pluck(deployment.Object, "spec", "paused")
+	dia.paused = paused == true
 
 	// Get current generation of the Deployment.
 	dia.currentGeneration = deployment.GetAnnotations()[revision]
@@ -455,12 +578,19 @@ func (dia *deploymentInitAwaiter) checkReplicaSetStatus() {
 	glog.V(3).Infof("ReplicaSet '%s' requests '%v' replicas, but has '%v' ready",
 		rs.GetName(), specReplicas, readyReplicas)
 
-	if dia.changeTriggeredRollout() {
-		dia.updatedReplicaSetReady = lastGeneration != dia.currentGeneration && udpatedReplicaSetCreated &&
-			readyReplicasExists && readyReplicas >= int64(specReplicas)
-	} else {
-		dia.updatedReplicaSetReady = udpatedReplicaSetCreated &&
-			readyReplicasExists && readyReplicas >= int64(specReplicas)
+	isNewRevision := !dia.changeTriggeredRollout() || lastGeneration != dia.currentGeneration
+	dia.updatedReplicaSetReady = isNewRevision && udpatedReplicaSetCreated &&
+		readyReplicasExists && readyReplicas >= int64(specReplicas)
+
+	if dia.rolloutGate != nil {
+		gate, err := intstr.GetValueFromIntOrPercent(dia.rolloutGate, int(specReplicas), true)
+		if err != nil {
+			dia.deploymentErrors[AnnotationRolloutGate] = fmt.Sprintf(
+				"Invalid value %q for annotation '%s': %v", dia.rolloutGate, AnnotationRolloutGate, err)
+			return
+		}
+		dia.rolloutGateReached = isNewRevision && udpatedReplicaSetCreated &&
+			readyReplicasExists && readyReplicas >= int64(gate)
 	}
 }
 
@@ -508,6 +638,12 @@ func (dia *deploymentInitAwaiter) processPodEvent(event watch.Event) {
 	dia.pods[podName] = pod
 }
 
+func (dia *deploymentInitAwaiter) info(message string) {
+	if dia.config.host != nil {
+		_ = dia.config.host.Log(dia.config.ctx, diag.Info, dia.config.urn, message)
+	}
+}
+
 func (dia *deploymentInitAwaiter) warn(message string) {
 	if dia.config.host != nil {
 		_ = dia.config.host.Log(dia.config.ctx, diag.Warning, dia.config.urn, message)
@@ -605,6 +741,38 @@ func (dia *deploymentInitAwaiter) makeClients() (
 	return replicaSetClient, podClient, nil
 }
 
+// rolloutProgressMessage describes how far the rollout of `deployment` has progressed, i.e., how
+// many of its desired replicas are both updated and available. It returns false if the Deployment
+// controller has not yet reported any progress.
+func rolloutProgressMessage(deployment *unstructured.Unstructured) (string, bool) {
+	if deployment == nil {
+		return "", false
+	}
+	if _, hasStatus := openapi.Pluck(deployment.Object, "status", "observedGeneration"); !hasStatus {
+		return "", false
+	}
+
+	desired := int64(1)
+	if rawReplicas, exists := openapi.Pluck(deployment.Object, "spec", "replicas"); exists {
+		desired, _ = int64Value(rawReplicas)
+	}
+	if desired <= 0 {
+		return "", false
+	}
+	rawUpdated, _ := openapi.Pluck(deployment.Object, "status", "updatedReplicas")
+	updated, _ := int64Value(rawUpdated)
+	rawAvailable, _ := openapi.Pluck(deployment.Object, "status", "availableReplicas")
+	available, _ := int64Value(rawAvailable)
+
+	// Available replicas may include replicas of the previous revision, so only as many of them as
+	// have been updated count towards progress.
+	if available > updated {
+		available = updated
+	}
+	return fmt.Sprintf("Deployment '%s': %d/%d updated replicas available (%d%%)",
+		deployment.GetName(), available, desired, available*100/desired), true
+}
+
 // canonicalizeDeploymentAPIVersion unifies the various pre-release apiVerion values for a
 // Deployment into "apps/v1".
 func canonicalizeDeploymentAPIVersion(ver string) string {
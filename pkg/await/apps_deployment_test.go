@@ -588,6 +588,148 @@ func Test_Apps_Deployment_MultipleUpdates(t *testing.T) {
 	}
 }
 
+func Test_Apps_Deployment_RolloutGate(t *testing.T) {
+	tests := []struct {
+		description   string
+		gate          string
+		expectedError error
+	}{
+		{
+			description: "Should succeed once the rollout gate is reached",
+			gate:        "25%",
+		},
+		{
+			description: "Should not succeed before the rollout gate is reached",
+			gate:        "4",
+			expectedError: &timeoutError{objectName: deploymentInputName, subErrors: []string{
+				"Updated ReplicaSet was never created"}},
+		},
+	}
+
+	for _, test := range tests {
+		// Request 4 replicas, of which the ReplicaSet reports 3 ready.
+		inputs := deploymentInput(inputNamespace, deploymentInputName)
+		inputs.Object["spec"].(map[string]interface{})["replicas"] = float64(4)
+		inputs.SetAnnotations(map[string]string{AnnotationRolloutGate: test.gate})
+
+		awaiter := makeDeploymentInitAwaiter(
+			updateAwaitConfig{createAwaitConfig: mockAwaitConfig(inputs)})
+		deployments := make(chan watch.Event)
+		replicaSets := make(chan watch.Event)
+		pods := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go func() {
+			deployments <- watchAddedEvent(
+				deploymentProgressing(inputNamespace, deploymentInputName, revision1))
+			replicaSets <- watchAddedEvent(
+				availableReplicaSet(inputNamespace, replicaSetGeneratedName, deploymentInputName, revision1))
+			if test.expectedError != nil {
+				timeout <- time.Now()
+			}
+		}()
+
+		err := awaiter.await(&chanWatcher{results: deployments}, &chanWatcher{results: replicaSets},
+			&chanWatcher{results: pods}, timeout, period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Apps_Deployment_Paused(t *testing.T) {
+	tests := []struct {
+		description   string
+		annotations   map[string]string
+		expectedError error
+	}{
+		{
+			description: "Should succeed immediately if the Deployment is paused",
+		},
+		{
+			description: "Should fail immediately if the Deployment is paused and failIfPaused is set",
+			annotations: map[string]string{AnnotationFailIfPaused: "true"},
+			expectedError: &initializationError{
+				subErrors: []string{"Deployment is paused; its rollout will not progress until " +
+					"`spec.paused` is set to false"}},
+		},
+	}
+
+	for _, test := range tests {
+		inputs := deploymentInput(inputNamespace, deploymentInputName)
+		inputs.Object["spec"].(map[string]interface{})["paused"] = true
+		inputs.SetAnnotations(test.annotations)
+		if initErr, isInitErr := test.expectedError.(*initializationError); isInitErr {
+			initErr.object = inputs
+		}
+
+		awaiter := makeDeploymentInitAwaiter(
+			updateAwaitConfig{createAwaitConfig: mockAwaitConfig(inputs)})
+		deployments := make(chan watch.Event)
+		replicaSets := make(chan watch.Event)
+		pods := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go func() {
+			deployments <- watchAddedEvent(inputs)
+		}()
+
+		err := awaiter.await(&chanWatcher{results: deployments}, &chanWatcher{results: replicaSets},
+			&chanWatcher{results: pods}, timeout, period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Apps_Deployment_RolloutProgress(t *testing.T) {
+	tests := []struct {
+		description string
+		deployment  string
+		expected    string
+	}{
+		{
+			description: "Should not report progress before the controller observes the Deployment",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			              "spec": {"replicas": 10}}`,
+		},
+		{
+			description: "Should report updated replicas that are available",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			              "spec": {"replicas": 10},
+			              "status": {"observedGeneration": 2, "updatedReplicas": 3,
+			                         "availableReplicas": 9}}`,
+			expected: "Deployment 'foo': 3/10 updated replicas available (30%)",
+		},
+		{
+			description: "Should report available replicas that are updated",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			              "spec": {"replicas": 4},
+			              "status": {"observedGeneration": 2, "updatedReplicas": 4,
+			                         "availableReplicas": 1}}`,
+			expected: "Deployment 'foo': 1/4 updated replicas available (25%)",
+		},
+		{
+			description: "Should default to one replica",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			              "status": {"observedGeneration": 1}}`,
+			expected: "Deployment 'foo': 0/1 updated replicas available (0%)",
+		},
+		{
+			description: "Should not report progress of a Deployment scaled to zero",
+			deployment: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			              "spec": {"replicas": 0}, "status": {"observedGeneration": 1}}`,
+		},
+	}
+
+	for _, test := range tests {
+		deployment, err := decodeUnstructured(test.deployment)
+		assert.NoError(t, err, test.description)
+
+		message, ok := rolloutProgressMessage(deployment)
+		assert.Equal(t, test.expected != "", ok, test.description)
+		assert.Equal(t, test.expected, message, test.description)
+	}
+}
+
 func Test_Core_Deployment_Read(t *testing.T) {
 	tests := []struct {
 		description        string
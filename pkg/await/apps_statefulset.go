@@ -0,0 +1,551 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for apps/v1beta1/StatefulSet, apps/v1beta2/StatefulSet, and apps/v1/StatefulSet.
+//
+// The goal of this code is to provide a fine-grained account of the status of a Kubernetes
+// StatefulSet as it is being initialized, in the same spirit as the Deployment awaiter.
+//
+// Unlike a Deployment, a StatefulSet manages its Pods directly. Each change to the Pod template
+// produces a new ControllerRevision, whose name is reported in `.status.updateRevision`, and every
+// Pod is labeled with the revision it was created from (`controller-revision-hash`). Pods are
+// created in order of their ordinal (`web-0`, `web-1`, ...), and rolled in reverse order, one at a
+// time, each waiting for the previous to become ready. This means a single broken Pod blocks the
+// whole rollout, so it is worth reporting exactly which Pod we're waiting on.
+//
+// The success conditions are:
+//
+//   1. `.status.observedGeneration` is at least `.metadata.generation`, i.e., the StatefulSet
+//      controller has seen the current spec.
+//   2. The ControllerRevision named by `.status.updateRevision` exists.
+//   3. `.status.readyReplicas` is equal to the desired number of replicas.
+//   4. If the update strategy is `RollingUpdate`, `.status.updatedReplicas` is equal to the number
+//      of replicas the strategy will update (i.e., all of them, less the `partition`), and, if there
+//      is no partition, `.status.currentRevision` is equal to `.status.updateRevision`. (With the
+//      `OnDelete` strategy, the controller never replaces Pods on its own, so we do not wait for
+//      it to.)
+//
+// The event loop depends on the following channels:
+//
+//   1. The StatefulSet channel, to which the API server pushes every change to the StatefulSet.
+//   2. The ControllerRevision channel, which tells us when the revision we're rolling to exists.
+//   3. The Pod channel, which is used to report per-replica progress and Pod errors.
+//   4. A timeout channel, which fires after some minutes.
+//   5. A cancellation channel, with which the user can signal cancellation (e.g., using SIGINT).
+//   6. A period channel, which is used to signal when we should display an aggregated report of
+//      Pod errors we know about.
+//
+// Progress is reported as the number of ready replicas and the Pod the controller is waiting on,
+// e.g., "2/3 replicas ready, waiting on pod web-2". If a Pod of the update revision is in
+// `CrashLoopBackOff`, the rollout cannot make progress without intervention, so we fail
+// immediately instead of waiting for the timeout.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/
+//   * https://kubernetes.io/docs/tutorials/stateful-application/basic-stateful-set/
+
+// ------------------------------------------------------------------------------------------------
+
+const (
+	controllerRevisionHashLabel = "controller-revision-hash"
+	crashLoopBackOff            = "CrashLoopBackOff"
+)
+
+type statefulsetInitAwaiter struct {
+	config             updateAwaitConfig
+	statefulset        *unstructured.Unstructured
+	generation         int64
+	observedGeneration int64
+	replicas           int64
+	readyReplicas      int64
+	updatedReplicas    int64
+	currentRevision    string
+	updateRevision     string
+	lastProgress       string
+
+	statefulsetErrors map[string]string
+
+	revisions map[string]*unstructured.Unstructured
+	pods      map[string]*unstructured.Unstructured
+}
+
+func makeStatefulSetInitAwaiter(c updateAwaitConfig) *statefulsetInitAwaiter {
+	return &statefulsetInitAwaiter{
+		config:      c,
+		statefulset: c.currentInputs,
+		replicas:    1,
+
+		statefulsetErrors: map[string]string{},
+
+		revisions: map[string]*unstructured.Unstructured{},
+		pods:      map[string]*unstructured.Unstructured{},
+	}
+}
+
+func (sia *statefulsetInitAwaiter) Await() error {
+	revisionClient, podClient, err := sia.makeClients()
+	if err != nil {
+		return err
+	}
+
+	// Create StatefulSet watcher.
+	statefulsetWatcher, err := watchWithReconnect(sia.config.clientForResource,
+		nameListOptions(sia.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for StatefulSet object '%s'",
+			sia.config.currentInputs.GetName())
+	}
+	defer statefulsetWatcher.Stop()
+
+	// Create ControllerRevision watcher.
+	revisionWatcher, err := watchWithReconnect(revisionClient,
+		selectorListOptions(sia.config.currentInputs))
+	if err != nil {
+		return errors.Wrapf(err,
+			"Could not create watcher for ControllerRevision objects associated with StatefulSet '%s'",
+			sia.config.currentInputs.GetName())
+	}
+	defer revisionWatcher.Stop()
+
+	// Create Pod watcher.
+	podWatcher, err := watchWithReconnect(podClient, selectorListOptions(sia.config.currentInputs))
+	if err != nil {
+		return errors.Wrapf(err,
+			"Could not create watcher for Pods objects associated with StatefulSet '%s'",
+			sia.config.currentInputs.GetName())
+	}
+	defer podWatcher.Stop()
+
+	period := time.NewTicker(10 * time.Second)
+	defer period.Stop()
+
+	return sia.await(statefulsetWatcher, revisionWatcher, podWatcher,
+		client.ThrottledAfter(sia.config.timeout(10*time.Minute)), period.C)
+}
+
+func (sia *statefulsetInitAwaiter) Read() error {
+	// Get clients needed to retrieve live versions of relevant StatefulSets, ControllerRevisions,
+	// and Pods.
+	revisionClient, podClient, err := sia.makeClients()
+	if err != nil {
+		return err
+	}
+
+	statefulset, err := sia.config.clientForResource.Get(sia.config.currentInputs.GetName(),
+		metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the StatefulSet as having been deleted.
+		return err
+	}
+
+	revisionList, err := revisionClient.List(selectorListOptions(statefulset))
+	if err != nil {
+		glog.V(3).Infof("Error retrieving ControllerRevision list for StatefulSet '%s': %v",
+			statefulset.GetName(), err)
+		revisionList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
+	}
+
+	podList, err := podClient.List(selectorListOptions(statefulset))
+	if err != nil {
+		glog.V(3).Infof("Error retrieving Pod list for StatefulSet '%s': %v",
+			statefulset.GetName(), err)
+		podList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
+	}
+
+	return sia.read(statefulset, revisionList.(*unstructured.UnstructuredList),
+		podList.(*unstructured.UnstructuredList))
+}
+
+func (sia *statefulsetInitAwaiter) read(
+	statefulset *unstructured.Unstructured, revisions, pods *unstructured.UnstructuredList,
+) error {
+	sia.processStatefulSetEvent(watchAddedEvent(statefulset))
+
+	err := revisions.EachListItem(func(revision runtime.Object) error {
+		sia.processRevisionEvent(watchAddedEvent(revision.(*unstructured.Unstructured)))
+		return nil
+	})
+	if err != nil {
+		glog.V(3).Infof("Error iterating over ControllerRevision list for StatefulSet '%s': %v",
+			statefulset.GetName(), err)
+	}
+
+	err = pods.EachListItem(func(pod runtime.Object) error {
+		sia.processPodEvent(watchAddedEvent(pod.(*unstructured.Unstructured)))
+		return nil
+	})
+	if err != nil {
+		glog.V(3).Infof("Error iterating over Pod list for StatefulSet '%s': %v",
+			statefulset.GetName(), err)
+	}
+
+	if sia.succeeded() {
+		return nil
+	}
+
+	return &initializationError{
+		subErrors: sia.errorMessages(),
+		object:    statefulset,
+	}
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (sia *statefulsetInitAwaiter) await(
+	statefulsetWatcher, revisionWatcher, podWatcher watch.Interface, timeout, period <-chan time.Time,
+) error {
+	inputName := sia.config.currentInputs.GetName()
+	for {
+		if sia.succeeded() {
+			return nil
+		}
+
+		// Fail fast if a Pod we're waiting on is crash looping; the StatefulSet controller will not
+		// make progress past it.
+		if crashLooping := sia.crashLoopingPods(); len(crashLooping) > 0 {
+			return &initializationError{
+				subErrors: append(crashLooping, sia.progress()),
+				object:    sia.statefulset,
+			}
+		}
+
+		sia.reportProgress()
+
+		// Else, wait for updates.
+		select {
+		case <-sia.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  sia.errorMessages(),
+			}
+		case <-timeout:
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  sia.errorMessages(),
+			}
+		case <-period:
+			for _, message := range sia.aggregatePodErrors() {
+				sia.warn(message)
+			}
+		case event := <-statefulsetWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			sia.processStatefulSetEvent(event)
+		case event := <-revisionWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			sia.processRevisionEvent(event)
+		case event := <-podWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			sia.processPodEvent(event)
+		}
+	}
+}
+
+func (sia *statefulsetInitAwaiter) succeeded() bool {
+	if sia.generation == 0 || sia.observedGeneration < sia.generation || sia.updateRevision == "" {
+		return false
+	}
+	if _, revisionCreated := sia.revisions[sia.updateRevision]; !revisionCreated {
+		return false
+	}
+	if sia.readyReplicas < sia.replicas {
+		return false
+	}
+
+	if !sia.isRollingUpdate() {
+		return true
+	}
+	partition := sia.partition()
+	if sia.updatedReplicas < sia.replicas-partition {
+		return false
+	}
+	return partition > 0 || sia.currentRevision == sia.updateRevision
+}
+
+func (sia *statefulsetInitAwaiter) processStatefulSetEvent(event watch.Event) {
+	statefulset, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("StatefulSet watch received unknown object type '%s'",
+			reflect.TypeOf(statefulset))
+		return
+	}
+
+	// Do nothing if this is not the StatefulSet we're waiting for.
+	if statefulset.GetName() != sia.config.currentInputs.GetName() {
+		return
+	}
+
+	// Start over, prove that rollout is complete.
+	sia.statefulsetErrors = map[string]string{}
+	sia.observedGeneration = 0
+
+	// Mark the rollout as incomplete if it's deleted.
+	if event.Type == watch.Deleted {
+		return
+	}
+
+	sia.statefulset = statefulset
+	sia.generation = statefulset.GetGeneration()
+	sia.replicas = 1
+	if replicas, exists := openapi.Pluck(statefulset.Object, "spec", "replicas"); exists {
+		sia.replicas, _ = int64Value(replicas)
+	}
+
+	rawStatus, hasStatus := openapi.Pluck(statefulset.Object, "status")
+	status, isMap := rawStatus.(map[string]interface{})
+	if !hasStatus || !isMap {
+		// StatefulSet controller has not yet seen this StatefulSet. Do nothing.
+		return
+	}
+	sia.observedGeneration, _ = int64Value(status["observedGeneration"])
+	sia.readyReplicas, _ = int64Value(status["readyReplicas"])
+	sia.updatedReplicas, _ = int64Value(status["updatedReplicas"])
+	sia.currentRevision, _ = status["currentRevision"].(string)
+	sia.updateRevision, _ = status["updateRevision"].(string)
+
+	// StatefulSets don't (yet) report conditions in most clusters, but record any failures that are
+	// reported.
+	conditions, _ := status["conditions"].([]interface{})
+	for _, rawCondition := range conditions {
+		condition, isMap := rawCondition.(map[string]interface{})
+		if !isMap || condition["status"] == trueStatus {
+			continue
+		}
+		errorFromCondition(sia.statefulsetErrors, condition)
+	}
+}
+
+func (sia *statefulsetInitAwaiter) processRevisionEvent(event watch.Event) {
+	revision, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("ControllerRevision watch received unknown object type '%s'",
+			reflect.TypeOf(revision))
+		return
+	}
+
+	// Check whether this ControllerRevision was created by our StatefulSet.
+	if !isOwnedBy(revision, sia.config.currentInputs) {
+		return
+	}
+
+	if event.Type == watch.Deleted {
+		delete(sia.revisions, revision.GetName())
+		return
+	}
+	sia.revisions[revision.GetName()] = revision
+}
+
+func (sia *statefulsetInitAwaiter) processPodEvent(event watch.Event) {
+	pod, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Pod watch received unknown object type '%s'",
+			reflect.TypeOf(pod))
+		return
+	}
+
+	// Check whether this Pod was created by our StatefulSet.
+	if !isOwnedBy(pod, sia.config.currentInputs) {
+		return
+	}
+
+	// If Pod was deleted, remove it from our aggregated checkers.
+	if event.Type == watch.Deleted {
+		delete(sia.pods, pod.GetName())
+		return
+	}
+	sia.pods[pod.GetName()] = pod
+}
+
+func (sia *statefulsetInitAwaiter) isRollingUpdate() bool {
+	strategy, _ := openapi.Pluck(sia.statefulset.Object, "spec", "updateStrategy", "type")
+	// NOTE: `RollingUpdate` is the default strategy in apps/v1beta2 and apps/v1. The apps/v1beta1
+	// default is `OnDelete`, but the API server fills it in, so we'll see it on the live object.
+	return strategy == nil || strategy == "RollingUpdate"
+}
+
+func (sia *statefulsetInitAwaiter) partition() int64 {
+	rawPartition, _ := openapi.Pluck(
+		sia.statefulset.Object, "spec", "updateStrategy", "rollingUpdate", "partition")
+	partition, _ := int64Value(rawPartition)
+	return partition
+}
+
+// isUpdated returns true if `pod` was created from the revision the StatefulSet is rolling to.
+func (sia *statefulsetInitAwaiter) isUpdated(pod *unstructured.Unstructured) bool {
+	return sia.updateRevision == "" || pod.GetLabels()[controllerRevisionHashLabel] == sia.updateRevision
+}
+
+// waitingOn returns the name of the Pod the StatefulSet controller is currently waiting on: during
+// creation (or scale-up), the lowest ordinal that is not yet ready; during a rolling update, the
+// highest ordinal that has not yet been replaced with the new revision.
+func (sia *statefulsetInitAwaiter) waitingOn() string {
+	name := sia.config.currentInputs.GetName()
+	for ordinal := int64(0); ordinal < sia.replicas; ordinal++ {
+		podName := fmt.Sprintf("%s-%d", name, ordinal)
+		pod, exists := sia.pods[podName]
+		if !exists {
+			return podName
+		}
+		checker := makePodChecker()
+		checker.check(pod)
+		if !checker.podReady {
+			return podName
+		}
+	}
+
+	if sia.isRollingUpdate() {
+		for ordinal := sia.replicas - 1; ordinal >= sia.partition(); ordinal-- {
+			podName := fmt.Sprintf("%s-%d", name, ordinal)
+			if pod, exists := sia.pods[podName]; !exists || !sia.isUpdated(pod) {
+				return podName
+			}
+		}
+	}
+
+	return ""
+}
+
+// progress returns a short description of the progress of the rollout, e.g., "2/3 replicas ready,
+// waiting on pod web-2".
+func (sia *statefulsetInitAwaiter) progress() string {
+	message := fmt.Sprintf("%d/%d replicas ready", sia.readyReplicas, sia.replicas)
+	if podName := sia.waitingOn(); podName != "" {
+		message = fmt.Sprintf("%s, waiting on pod %s", message, podName)
+	}
+	return message
+}
+
+// reportProgress logs the progress of the rollout, if it has changed since it was last reported.
+func (sia *statefulsetInitAwaiter) reportProgress() {
+	if sia.generation == 0 {
+		// We haven't seen the StatefulSet yet.
+		return
+	}
+	progress := sia.progress()
+	if progress == sia.lastProgress {
+		return
+	}
+	sia.lastProgress = progress
+	if sia.config.host != nil {
+		_ = sia.config.host.Log(sia.config.ctx, diag.Info, sia.config.urn, progress)
+	}
+}
+
+// crashLoopingPods returns an error message for each container in `CrashLoopBackOff` in a Pod of
+// the update revision.
+func (sia *statefulsetInitAwaiter) crashLoopingPods() []string {
+	messages := []string{}
+	for _, podName := range sia.sortedPodNames() {
+		pod := sia.pods[podName]
+		if !sia.isUpdated(pod) {
+			continue
+		}
+
+		checker := makePodChecker()
+		checker.check(pod)
+		for _, message := range checker.containerErrors[crashLoopBackOff] {
+			messages = append(messages, fmt.Sprintf("Pod '%s' is stuck in a crash loop: [%s] %s",
+				podName, crashLoopBackOff, message))
+		}
+	}
+	return messages
+}
+
+func (sia *statefulsetInitAwaiter) sortedPodNames() []string {
+	names := []string{}
+	for name := range sia.pods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (sia *statefulsetInitAwaiter) warn(message string) {
+	if sia.config.host != nil {
+		_ = sia.config.host.Log(sia.config.ctx, diag.Warning, sia.config.urn, message)
+	}
+}
+
+func (sia *statefulsetInitAwaiter) aggregatePodErrors() []string {
+	messages := []string{}
+	for _, podName := range sia.sortedPodNames() {
+		pod := sia.pods[podName]
+		if !sia.isUpdated(pod) {
+			continue
+		}
+
+		checker := makePodChecker()
+		checker.check(pod)
+		for _, message := range checker.errorMessages() {
+			messages = append(messages, fmt.Sprintf("Pod '%s': %s", podName, message))
+		}
+	}
+	return messages
+}
+
+func (sia *statefulsetInitAwaiter) errorMessages() []string {
+	messages := []string{}
+	for _, message := range sia.statefulsetErrors {
+		messages = append(messages, message)
+	}
+	if sia.generation != 0 {
+		messages = append(messages, sia.progress())
+	}
+	return append(messages, sia.aggregatePodErrors()...)
+}
+
+func (sia *statefulsetInitAwaiter) makeClients() (
+	revisionClient, podClient dynamic.ResourceInterface, err error,
+) {
+	revisionClient, err = client.FromGVK(sia.config.pool, sia.config.disco,
+		schema.GroupVersionKind{
+			Group:   "apps",
+			Version: "v1",
+			Kind:    "ControllerRevision",
+		}, sia.config.currentInputs.GetNamespace())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err,
+			"Could not make client to watch ControllerRevisions associated with StatefulSet '%s'",
+			sia.config.currentInputs.GetName())
+	}
+
+	podClient, err = client.FromGVK(sia.config.pool, sia.config.disco,
+		schema.GroupVersionKind{
+			Group:   "",
+			Version: "v1",
+			Kind:    "Pod",
+		}, sia.config.currentInputs.GetNamespace())
+	if err != nil {
+		return nil, nil, errors.Wrapf(err,
+			"Could not make client to watch Pods associated with StatefulSet '%s'",
+			sia.config.currentInputs.GetName())
+	}
+
+	return revisionClient, podClient, nil
+}
@@ -0,0 +1,262 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	statefulsetInputName = "web"
+	revisionA            = "web-6b8d8c9f5d"
+	revisionB            = "web-7f9c8d6b4c"
+)
+
+func Test_Apps_StatefulSet(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when all replicas are ready",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 0, 0, revisionA, revisionA))
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 3, 3, revisionA, revisionA))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail if replicas are not all ready",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 2, 2, revisionA, revisionA))
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				pods <- watchAddedEvent(statefulsetPod("web-0", revisionA, readyContainer))
+				pods <- watchAddedEvent(statefulsetPod("web-1", revisionA, readyContainer))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: statefulsetInputName,
+				subErrors:  []string{"2/3 replicas ready, waiting on pod web-2"}},
+		},
+		{
+			description: "Should wait for the controller to observe the current generation",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(2, 1, 3, 3, 3, revisionA, revisionA))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: statefulsetInputName,
+				subErrors:  []string{"3/3 replicas ready, waiting on pod web-0"}},
+		},
+		{
+			description: "Should wait for the rolling update to finish",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				revisions <- watchAddedEvent(controllerRevision(revisionB))
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(2, 2, 3, 3, 1, revisionA, revisionB))
+				pods <- watchAddedEvent(statefulsetPod("web-0", revisionA, readyContainer))
+				pods <- watchAddedEvent(statefulsetPod("web-1", revisionA, readyContainer))
+				pods <- watchAddedEvent(statefulsetPod("web-2", revisionB, readyContainer))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: statefulsetInputName,
+				subErrors:  []string{"3/3 replicas ready, waiting on pod web-1"}},
+		},
+		{
+			description: "Should succeed when the rolling update finishes",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				revisions <- watchAddedEvent(controllerRevision(revisionB))
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(2, 2, 3, 3, 1, revisionA, revisionB))
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(2, 2, 3, 3, 3, revisionB, revisionB))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail fast if a Pod is crash looping",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 0, 0, revisionA, revisionA))
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				// Crash loop. Fail without waiting for the timeout.
+				pods <- watchAddedEvent(statefulsetPod("web-0", revisionA, crashLoopingContainer))
+			},
+			expectedError: &initializationError{
+				subErrors: []string{
+					"Pod 'web-0' is stuck in a crash loop: [CrashLoopBackOff] Back-off 10s restarting failed container=nginx",
+					"0/3 replicas ready, waiting on pod web-0",
+				},
+				object: statefulsetWithStatus(1, 1, 3, 0, 0, revisionA, revisionA),
+			},
+		},
+		{
+			description: "Should ignore crash looping Pods from other StatefulSets",
+			do: func(statefulsets, revisions, pods chan watch.Event, timeout chan time.Time) {
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 0, 0, revisionA, revisionA))
+				revisions <- watchAddedEvent(controllerRevision(revisionA))
+				other := statefulsetPod("db-0", revisionA, crashLoopingContainer)
+				other.SetOwnerReferences(nil)
+				pods <- watchAddedEvent(other)
+				statefulsets <- watchAddedEvent(statefulsetWithStatus(1, 1, 3, 3, 3, revisionA, revisionA))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeStatefulSetInitAwaiter(
+			updateAwaitConfig{
+				createAwaitConfig: mockAwaitConfig(statefulsetInput()),
+			})
+		statefulsets := make(chan watch.Event)
+		revisions := make(chan watch.Event)
+		pods := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go test.do(statefulsets, revisions, pods, timeout)
+
+		err := awaiter.await(&chanWatcher{results: statefulsets}, &chanWatcher{results: revisions},
+			&chanWatcher{results: pods}, timeout, period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Apps_StatefulSet_Read(t *testing.T) {
+	awaiter := makeStatefulSetInitAwaiter(
+		updateAwaitConfig{createAwaitConfig: mockAwaitConfig(statefulsetInput())})
+	statefulset := statefulsetWithStatus(1, 1, 3, 3, 3, revisionA, revisionA)
+	err := awaiter.read(statefulset,
+		&unstructured.UnstructuredList{Items: []unstructured.Unstructured{*controllerRevision(revisionA)}},
+		&unstructured.UnstructuredList{})
+	assert.Nil(t, err)
+
+	awaiter = makeStatefulSetInitAwaiter(
+		updateAwaitConfig{createAwaitConfig: mockAwaitConfig(statefulsetInput())})
+	statefulset = statefulsetWithStatus(1, 1, 3, 1, 1, revisionA, revisionA)
+	err = awaiter.read(statefulset,
+		&unstructured.UnstructuredList{Items: []unstructured.Unstructured{*controllerRevision(revisionA)}},
+		&unstructured.UnstructuredList{})
+	assert.Equal(t, &initializationError{
+		subErrors: []string{"1/3 replicas ready, waiting on pod web-0"},
+		object:    statefulset,
+	}, err)
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+const (
+	readyContainer = `{
+		"name": "nginx", "ready": true, "restartCount": 0,
+		"state": {"running": {"startedAt": "2018-09-28T21:37:18Z"}}
+	}`
+	crashLoopingContainer = `{
+		"name": "nginx", "ready": false, "restartCount": 3,
+		"state": {"waiting": {
+			"reason": "CrashLoopBackOff",
+			"message": "Back-off 10s restarting failed container=nginx"
+		}}
+	}`
+)
+
+func statefulsetInput() *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "apps/v1",
+		"kind": "StatefulSet",
+		"metadata": {"name": "%s", "namespace": "%s"},
+		"spec": {"replicas": 3, "serviceName": "nginx"}
+	}`, statefulsetInputName, inputNamespace))
+}
+
+func statefulsetWithStatus(
+	generation, observedGeneration, replicas, readyReplicas, updatedReplicas int,
+	currentRevision, updateRevision string,
+) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "apps/v1",
+		"kind": "StatefulSet",
+		"metadata": {"name": "%s", "namespace": "%s", "generation": %d},
+		"spec": {
+			"replicas": %d,
+			"serviceName": "nginx",
+			"updateStrategy": {"type": "RollingUpdate", "rollingUpdate": {"partition": 0}}
+		},
+		"status": {
+			"observedGeneration": %d,
+			"replicas": %d,
+			"readyReplicas": %d,
+			"currentReplicas": %d,
+			"updatedReplicas": %d,
+			"currentRevision": "%s",
+			"updateRevision": "%s"
+		}
+	}`, statefulsetInputName, inputNamespace, generation, replicas, observedGeneration, replicas,
+		readyReplicas, replicas, updatedReplicas, currentRevision, updateRevision))
+}
+
+func controllerRevision(name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "apps/v1",
+		"kind": "ControllerRevision",
+		"metadata": {
+			"name": "%s",
+			"namespace": "%s",
+			"ownerReferences": [{
+				"apiVersion": "apps/v1", "kind": "StatefulSet", "name": "%s",
+				"uid": "e5a6b6a4-c36f-11e8-a6c1-025000000001", "controller": true
+			}]
+		},
+		"revision": 1
+	}`, name, inputNamespace, statefulsetInputName))
+}
+
+func statefulsetPod(name, revision, containerStatus string) *unstructured.Unstructured {
+	ready := "False"
+	if containerStatus == readyContainer {
+		ready = trueStatus
+	}
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "%s",
+			"namespace": "%s",
+			"labels": {"app": "nginx", "controller-revision-hash": "%s"},
+			"ownerReferences": [{
+				"apiVersion": "apps/v1", "kind": "StatefulSet", "name": "%s",
+				"uid": "e5a6b6a4-c36f-11e8-a6c1-025000000001", "controller": true
+			}]
+		},
+		"status": {
+			"phase": "Running",
+			"conditions": [
+				{"type": "Initialized", "status": "True"},
+				{"type": "Ready", "status": "%s"},
+				{"type": "PodScheduled", "status": "True"}
+			],
+			"containerStatuses": [%s]
+		}
+	}`, name, inputNamespace, revision, statefulsetInputName, ready, containerStatus))
+}
@@ -0,0 +1,299 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi-kubernetes/pkg/watcher"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for autoscaling/v1/HorizontalPodAutoscaler,
+// autoscaling/v2beta1/HorizontalPodAutoscaler, and autoscaling/v2beta2/HorizontalPodAutoscaler.
+//
+// An HPA is accepted by the API server even if the workload it scales does not exist, or the
+// metrics it scales on can't be fetched; it then silently never scales. An HPA that scales on
+// custom or external metrics also depends on a metrics adapter (e.g., the Prometheus adapter)
+// serving the `custom.metrics.k8s.io` or `external.metrics.k8s.io` API group, and on that adapter
+// actually resolving the named metric for the scale target.
+//
+// So we check that:
+//
+//   1. The metrics API group each metric depends on is served by the cluster. If it is not, we fail
+//      immediately.
+//   2. The `AbleToScale` condition is `True`, i.e., the HPA controller could fetch the scale of the
+//      target workload. If it is `False` (e.g., `FailedGetScale`), we report the missing target.
+//   3. The `ScalingActive` condition is `True`, i.e., the HPA controller has been able to fetch
+//      every metric. If it is `False` with a reason like `FailedGetExternalMetric`, we report which
+//      metric is unavailable and why. (`ScalingDisabled` means the target was scaled to zero on
+//      purpose, and is not an error.)
+//
+// The HPA controller only evaluates each HPA every 15 seconds or so, so we give it `hpaTimeout` to
+// report both conditions. autoscaling/v1 has no `.status.conditions`; there, the controller reports
+// the same conditions as JSON, in the `autoscaling.alpha.kubernetes.io/conditions` annotation.
+//
+// x-refs:
+//   * https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/#support-for-custom-metrics
+
+// ------------------------------------------------------------------------------------------------
+
+const (
+	resourceMetricsAPI = "metrics.k8s.io"
+	customMetricsAPI   = "custom.metrics.k8s.io"
+	externalMetricsAPI = "external.metrics.k8s.io"
+
+	hpaConditionsAnnotation = "autoscaling.alpha.kubernetes.io/conditions"
+
+	hpaTimeout = 2 * time.Minute
+)
+
+var metricsAPIVersions = []string{"v1beta1", "v1beta2"}
+
+type hpaMetric struct {
+	metricType string
+	name       string
+}
+
+// api returns the metrics API group that serves this metric.
+func (m hpaMetric) api() string {
+	switch m.metricType {
+	case "Pods", "Object":
+		return customMetricsAPI
+	case "External":
+		return externalMetricsAPI
+	default:
+		return resourceMetricsAPI
+	}
+}
+
+// hpaMetrics returns the metrics an HPA scales on, from either the `v2beta1` or `v2beta2` schema.
+func hpaMetrics(hpa *unstructured.Unstructured) []hpaMetric {
+	rawMetrics, _ := openapi.Pluck(hpa.Object, "spec", "metrics")
+	list, _ := rawMetrics.([]interface{})
+
+	var metrics []hpaMetric
+	for _, raw := range list {
+		metric, isMap := raw.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		metricType, _ := metric["type"].(string)
+		source, _ := metric[strings.ToLower(metricType)].(map[string]interface{})
+		if source == nil {
+			continue
+		}
+
+		var name interface{}
+		if metricType == "Resource" {
+			name = source["name"]
+		} else if name = source["metricName"]; name == nil {
+			// v2beta2 nests the metric name in a `MetricIdentifier`.
+			name, _ = openapi.Pluck(source, "metric", "name")
+		}
+		metrics = append(metrics, hpaMetric{metricType: metricType, name: fmt.Sprintf("%v", name)})
+	}
+	return metrics
+}
+
+// unservedMetricsAPIs returns an error message for each metric whose metrics API group is not
+// served by the cluster. `Resource` metrics are skipped: the HPA controller can read them without
+// `metrics.k8s.io` (e.g., from Heapster on older clusters), so if they're unavailable, the HPA's
+// own conditions are the only reliable report of it.
+func unservedMetricsAPIs(disco discovery.ServerResourcesInterface, metrics []hpaMetric) []string {
+	served := map[string]bool{}
+	isServed := func(group string) bool {
+		if result, checked := served[group]; checked {
+			return result
+		}
+		served[group] = false
+		for _, version := range metricsAPIVersions {
+			if _, err := disco.ServerResourcesForGroupVersion(group + "/" + version); err == nil {
+				served[group] = true
+				break
+			}
+		}
+		return served[group]
+	}
+
+	var messages []string
+	for _, metric := range metrics {
+		if metric.metricType == "Resource" {
+			continue
+		}
+		if !isServed(metric.api()) {
+			messages = append(messages, fmt.Sprintf(
+				"%s metric '%s' not available via %s: the API group is not served by the cluster "+
+					"(is a metrics adapter installed?)", metric.metricType, metric.name, metric.api()))
+		}
+	}
+	return messages
+}
+
+// hpaConditions returns the HPA's status conditions. autoscaling/v1 has no `.status.conditions`, so
+// the HPA controller records them in an annotation instead.
+func hpaConditions(hpa *unstructured.Unstructured) []interface{} {
+	rawConditions, _ := openapi.Pluck(hpa.Object, "status", "conditions")
+	if conditions, isList := rawConditions.([]interface{}); isList {
+		return conditions
+	}
+
+	var conditions []interface{}
+	if annotation, exists := hpa.GetAnnotations()[hpaConditionsAnnotation]; exists {
+		if err := json.Unmarshal([]byte(annotation), &conditions); err != nil {
+			glog.V(3).Infof("Could not parse conditions of HPA '%s': %v", hpa.GetName(), err)
+		}
+	}
+	return conditions
+}
+
+// hpaConditionErrors returns a message for each of the `AbleToScale` and `ScalingActive` conditions
+// that the HPA controller has not yet reported (`pending`), and for each that it has reported to be
+// `False` (`failures`).
+func hpaConditionErrors(
+	hpa *unstructured.Unstructured, metrics []hpaMetric,
+) (pending, failures []string) {
+	conditions := hpaConditions(hpa)
+
+	condition, found := findCondition(conditions, "AbleToScale")
+	if !found {
+		pending = append(pending, "HorizontalPodAutoscaler has not reported whether it is able to scale")
+	} else if condition["status"] == "False" {
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		kind, _ := openapi.Pluck(hpa.Object, "spec", "scaleTargetRef", "kind")
+		name, _ := openapi.Pluck(hpa.Object, "spec", "scaleTargetRef", "name")
+		if reason == "FailedGetScale" {
+			failures = append(failures, fmt.Sprintf(
+				"Scale target %v '%v' does not exist or can't be scaled: [%s] %s", kind, name, reason,
+				message))
+		} else {
+			failures = append(failures, fmt.Sprintf(
+				"HorizontalPodAutoscaler is not able to scale %v '%v': [%s] %s", kind, name, reason,
+				message))
+		}
+	}
+
+	condition, found = findCondition(conditions, "ScalingActive")
+	if !found {
+		pending = append(pending, "HorizontalPodAutoscaler has not reported whether scaling is active")
+	} else if condition["status"] == "False" {
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		if metricErrors := hpaMetricErrors(hpa, metrics); len(metricErrors) > 0 {
+			failures = append(failures, metricErrors...)
+		} else if reason != "ScalingDisabled" {
+			failures = append(failures, fmt.Sprintf(
+				"HorizontalPodAutoscaler scaling is not active: [%s] %s", reason, message))
+		}
+	}
+
+	return pending, failures
+}
+
+// hpaMetricErrors returns a message for each failure of the HPA controller to fetch a metric, as
+// reported in the HPA's `ScalingActive` condition.
+func hpaMetricErrors(hpa *unstructured.Unstructured, metrics []hpaMetric) []string {
+	condition, found := findCondition(hpaConditions(hpa), "ScalingActive")
+	if !found || condition["status"] != "False" {
+		return nil
+	}
+	reason, _ := condition["reason"].(string)
+	message, _ := condition["message"].(string)
+	if !strings.HasPrefix(reason, "FailedGet") {
+		return nil
+	}
+
+	// Attribute the failure to the metric it mentions, if we can.
+	for _, metric := range metrics {
+		if metric.name != "" && strings.Contains(message, metric.name) {
+			return []string{fmt.Sprintf("%s metric '%s' not available via %s: [%s] %s",
+				metric.metricType, metric.name, metric.api(), reason, message)}
+		}
+	}
+	return []string{fmt.Sprintf("[%s] %s", reason, message)}
+}
+
+func untilAutoscalingHPAReady(c createAwaitConfig) error {
+	metrics := hpaMetrics(c.currentInputs)
+	if messages := unservedMetricsAPIs(c.disco, metrics); len(messages) > 0 {
+		return &initializationError{subErrors: messages, object: c.currentInputs}
+	}
+
+	var lastErrors []string
+	ready := func(hpa *unstructured.Unstructured, err error) error {
+		if err != nil {
+			return err
+		}
+		pending, failures := hpaConditionErrors(hpa, metrics)
+		lastErrors = append(failures, pending...)
+		if len(lastErrors) > 0 {
+			glog.V(3).Infof("HPA '%s' is not ready: %v", hpa.GetName(), lastErrors)
+			return watcher.RetryableError(fmt.Errorf("%s", strings.Join(lastErrors, "; ")))
+		}
+		return nil
+	}
+
+	name := c.currentInputs.GetName()
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(ready, c.timeout(hpaTimeout))
+	if err == nil || len(lastErrors) == 0 {
+		return err
+	}
+	if c.ctx.Err() != nil {
+		return &cancellationError{objectName: name, subErrors: lastErrors}
+	}
+	return &initializationError{subErrors: lastErrors, object: c.currentInputs}
+}
+
+func untilAutoscalingHPAReadyUpdated(u updateAwaitConfig) error {
+	return untilAutoscalingHPAReady(u.createAwaitConfig)
+}
+
+func readAutoscalingHPA(c createAwaitConfig) error {
+	hpa, err := c.clientForResource.Get(c.currentInputs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the resource as having been deleted.
+		return err
+	}
+
+	// NOTE: Conditions the HPA controller has not reported yet are not an error on refresh; only
+	// those it reported to be `False` are.
+	metrics := hpaMetrics(hpa)
+	messages := unservedMetricsAPIs(c.disco, metrics)
+	if len(messages) == 0 {
+		_, messages = hpaConditionErrors(hpa, metrics)
+	}
+	if len(messages) > 0 {
+		return &initializationError{subErrors: messages, object: hpa}
+	}
+	return nil
+}
+
+var hpaAwaiter = awaitSpec{
+	awaitCreation: untilAutoscalingHPAReady,
+	awaitUpdate:   untilAutoscalingHPAReadyUpdated,
+	awaitRead:     readAutoscalingHPA,
+}
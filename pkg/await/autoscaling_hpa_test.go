@@ -0,0 +1,206 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+func Test_Autoscaling_HPAMetrics(t *testing.T) {
+	tests := []struct {
+		description string
+		hpa         *unstructured.Unstructured
+		metrics     []hpaMetric
+		apis        []string
+	}{
+		{
+			description: "Should parse v2beta1 metrics",
+			hpa: mustDecodeUnstructured(`{
+				"apiVersion": "autoscaling/v2beta1",
+				"kind": "HorizontalPodAutoscaler",
+				"spec": {"metrics": [
+					{"type": "Resource", "resource": {"name": "cpu", "targetAverageUtilization": 50}},
+					{"type": "Pods", "pods": {"metricName": "packets-per-second", "targetAverageValue": "1k"}},
+					{"type": "External", "external": {"metricName": "queue_messages_ready", "targetValue": 30}}
+				]}
+			}`),
+			metrics: []hpaMetric{
+				{metricType: "Resource", name: "cpu"},
+				{metricType: "Pods", name: "packets-per-second"},
+				{metricType: "External", name: "queue_messages_ready"},
+			},
+			apis: []string{resourceMetricsAPI, customMetricsAPI, externalMetricsAPI},
+		},
+		{
+			description: "Should parse v2beta2 metrics",
+			hpa: mustDecodeUnstructured(`{
+				"apiVersion": "autoscaling/v2beta2",
+				"kind": "HorizontalPodAutoscaler",
+				"spec": {"metrics": [
+					{"type": "Object", "object": {
+						"metric": {"name": "requests-per-second"},
+						"describedObject": {"apiVersion": "extensions/v1beta1", "kind": "Ingress", "name": "main-route"},
+						"target": {"type": "Value", "value": "10k"}
+					}}
+				]}
+			}`),
+			metrics: []hpaMetric{{metricType: "Object", name: "requests-per-second"}},
+			apis:    []string{customMetricsAPI},
+		},
+		{
+			description: "Should return no metrics if none are specified",
+			hpa: mustDecodeUnstructured(`{
+				"apiVersion": "autoscaling/v2beta1",
+				"kind": "HorizontalPodAutoscaler",
+				"spec": {"maxReplicas": 3}
+			}`),
+		},
+	}
+
+	for _, test := range tests {
+		metrics := hpaMetrics(test.hpa)
+		assert.Equal(t, test.metrics, metrics, test.description)
+
+		var apis []string
+		for _, metric := range metrics {
+			apis = append(apis, metric.api())
+		}
+		assert.Equal(t, test.apis, apis, test.description)
+	}
+}
+
+func Test_Autoscaling_HPAMetricErrors(t *testing.T) {
+	metrics := []hpaMetric{{metricType: "External", name: "queue_messages_ready"}}
+
+	tests := []struct {
+		description string
+		conditions  string
+		errors      []string
+	}{
+		{
+			description: "Should succeed if scaling is active",
+			conditions:  `[{"type": "ScalingActive", "status": "True", "reason": "ValidMetricFound"}]`,
+		},
+		{
+			description: "Should ignore inactive scaling not caused by a metric",
+			conditions:  `[{"type": "ScalingActive", "status": "False", "reason": "ScalingDisabled"}]`,
+		},
+		{
+			description: "Should attribute the failure to the unavailable metric",
+			conditions: `[{"type": "ScalingActive", "status": "False", "reason": "FailedGetExternalMetric",
+				"message": "unable to get external metric default/queue_messages_ready/nil: no metrics returned"}]`,
+			errors: []string{"External metric 'queue_messages_ready' not available via " +
+				"external.metrics.k8s.io: [FailedGetExternalMetric] unable to get external metric " +
+				"default/queue_messages_ready/nil: no metrics returned"},
+		},
+	}
+
+	for _, test := range tests {
+		hpa := mustDecodeUnstructured(`{
+			"apiVersion": "autoscaling/v2beta1",
+			"kind": "HorizontalPodAutoscaler",
+			"status": {"conditions": ` + test.conditions + `}
+		}`)
+		assert.Equal(t, test.errors, hpaMetricErrors(hpa, metrics), test.description)
+	}
+}
+
+func Test_Autoscaling_HPAUnservedMetricsAPIs(t *testing.T) {
+	metrics := []hpaMetric{
+		{metricType: "Resource", name: "cpu"},
+		{metricType: "Pods", name: "packets-per-second"},
+		{metricType: "External", name: "queue_messages_ready"},
+	}
+
+	// Resource metrics aren't checked against `metrics.k8s.io`, which the cluster needn't serve.
+	disco := &metricsDiscovery{served: map[string]bool{"external.metrics.k8s.io/v1beta1": true}}
+	assert.Equal(t, []string{
+		"Pods metric 'packets-per-second' not available via custom.metrics.k8s.io: the API group " +
+			"is not served by the cluster (is a metrics adapter installed?)",
+	}, unservedMetricsAPIs(disco, metrics))
+}
+
+func Test_Autoscaling_HPAConditionErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		hpa         string
+		pending     []string
+		failures    []string
+	}{
+		{
+			description: "Should succeed if the HPA is able to scale and scaling is active",
+			hpa: `{
+				"apiVersion": "autoscaling/v2beta1",
+				"kind": "HorizontalPodAutoscaler",
+				"status": {"conditions": [
+					{"type": "AbleToScale", "status": "True", "reason": "ReadyForNewScale"},
+					{"type": "ScalingActive", "status": "True", "reason": "ValidMetricFound"}
+				]}
+			}`,
+		},
+		{
+			description: "Should wait for the HPA controller to report conditions",
+			hpa: `{
+				"apiVersion": "autoscaling/v2beta1",
+				"kind": "HorizontalPodAutoscaler",
+				"status": {}
+			}`,
+			pending: []string{
+				"HorizontalPodAutoscaler has not reported whether it is able to scale",
+				"HorizontalPodAutoscaler has not reported whether scaling is active",
+			},
+		},
+		{
+			description: "Should report a missing scale target",
+			hpa: `{
+				"apiVersion": "autoscaling/v2beta1",
+				"kind": "HorizontalPodAutoscaler",
+				"spec": {"scaleTargetRef": {"apiVersion": "apps/v1", "kind": "Deployment", "name": "web"}},
+				"status": {"conditions": [
+					{"type": "AbleToScale", "status": "False", "reason": "FailedGetScale",
+					 "message": "deployments/scale.apps \"web\" not found"},
+					{"type": "ScalingActive", "status": "False", "reason": "ScalingDisabled"}
+				]}
+			}`,
+			failures: []string{"Scale target Deployment 'web' does not exist or can't be scaled: " +
+				"[FailedGetScale] deployments/scale.apps \"web\" not found"},
+		},
+		{
+			description: "Should read autoscaling/v1 conditions from the annotation",
+			hpa: `{
+				"apiVersion": "autoscaling/v1",
+				"kind": "HorizontalPodAutoscaler",
+				"metadata": {"annotations": {"autoscaling.alpha.kubernetes.io/conditions":
+					"[{\"type\":\"AbleToScale\",\"status\":\"True\"},{\"type\":\"ScalingActive\",\"status\":\"False\",\"reason\":\"FailedGetResourceMetric\",\"message\":\"missing request for cpu\"}]"
+				}}
+			}`,
+			failures: []string{"[FailedGetResourceMetric] missing request for cpu"},
+		},
+	}
+
+	for _, test := range tests {
+		hpa := mustDecodeUnstructured(test.hpa)
+		pending, failures := hpaConditionErrors(hpa, hpaMetrics(hpa))
+		assert.Equal(t, test.pending, pending, test.description)
+		assert.Equal(t, test.failures, failures, test.description)
+	}
+}
+
+// metricsDiscovery serves only the group versions in `served`.
+type metricsDiscovery struct {
+	discovery.ServerResourcesInterface
+	served map[string]bool
+}
+
+func (d *metricsDiscovery) ServerResourcesForGroupVersion(
+	groupVersion string,
+) (*metav1.APIResourceList, error) {
+	if !d.served[groupVersion] {
+		return nil, fmt.Errorf("the server could not find the requested resource")
+	}
+	return &metav1.APIResourceList{GroupVersion: groupVersion}, nil
+}
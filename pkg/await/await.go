@@ -26,7 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 )
@@ -48,7 +48,8 @@ import (
 // occurred; or (3) an error has occurred while the resource was being initialized.
 func Creation(
 	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
-	disco discovery.ServerResourcesInterface, urn resource.URN, obj *unstructured.Unstructured,
+	disco discovery.ServerResourcesInterface, opts Options, urn resource.URN,
+	obj *unstructured.Unstructured,
 ) (*unstructured.Unstructured, error) {
 	clientForResource, err := client.FromResource(pool, disco, obj)
 	if err != nil {
@@ -56,34 +57,40 @@ func Creation(
 	}
 
 	// Issue create request.
-	_, err = clientForResource.Create(obj)
+	err = retryAdmission(ctx, host, urn, obj, func() (err error) {
+		if opts.ServerSideApply {
+			return serverSideApply(disco, obj, opts)
+		}
+		_, err = clientForResource.Create(obj)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Wait until create resolves as success or error. Note that the conditional is set up to log
-	// only if we don't have an entry for the resource type; in the event that we do, but the await
-	// logic is blank, simply do nothing instead of logging.
+	// Wait until create resolves as success or error. Note that if we don't have an entry for the
+	// resource type we fall back to the generic (kstatus-style) readiness logic; in the event that we
+	// do, but the await logic is blank, or the user asked us to skip it (for this object, or with the
+	// provider's `suppressAwait` config), simply do nothing.
 	id := fmt.Sprintf("%s/%s", obj.GetAPIVersion(), obj.GetKind())
-	if awaiter, exists := awaiters[id]; exists {
-		if awaiter.awaitCreation != nil {
-			conf := createAwaitConfig{
-				host:              host,
-				ctx:               ctx,
-				pool:              pool,
-				disco:             disco,
-				clientForResource: clientForResource,
-				urn:               urn,
-				currentInputs:     obj,
-			}
-			waitErr := awaiter.awaitCreation(conf)
-			if waitErr != nil {
-				return nil, waitErr
-			}
+	awaiter := awaiterForID(id)
+	if skipAwait(obj, opts) {
+		glog.V(1).Infof("Skipping await logic for '%s'", obj.GetName())
+	} else if awaiter.awaitCreation != nil {
+		conf := createAwaitConfig{
+			host:              host,
+			ctx:               ctx,
+			pool:              pool,
+			disco:             disco,
+			clientForResource: clientForResource,
+			urn:               urn,
+			options:           opts,
+			currentInputs:     obj,
+		}
+		waitErr := awaiter.awaitCreation(conf)
+		if waitErr != nil {
+			return nil, exportError(conf, waitErr)
 		}
-	} else {
-		glog.V(1).Infof(
-			"No initialization logic found for object of type '%s'; defaulting to assuming initialization successful", id)
 	}
 
 	return clientForResource.Get(obj.GetName(), metav1.GetOptions{})
@@ -92,7 +99,8 @@ func Creation(
 // Read checks a resource, returning the object if it was created and initialized successfully.
 func Read(
 	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
-	disco discovery.ServerResourcesInterface, urn resource.URN, obj *unstructured.Unstructured,
+	disco discovery.ServerResourcesInterface, opts Options, urn resource.URN,
+	obj *unstructured.Unstructured,
 ) (*unstructured.Unstructured, error) {
 	// Retrieve live version of last submitted version of object.
 	clientForResource, err := client.FromResource(pool, disco, obj)
@@ -101,27 +109,28 @@ func Read(
 	}
 
 	id := fmt.Sprintf("%s/%s", obj.GetAPIVersion(), obj.GetKind())
-	if awaiter, exists := awaiters[id]; exists {
-		if awaiter.awaitRead != nil {
-			conf := createAwaitConfig{
-				host:              host,
-				ctx:               ctx,
-				pool:              pool,
-				disco:             disco,
-				clientForResource: clientForResource,
-				urn:               urn,
-				currentInputs:     obj,
-			}
-			waitErr := awaiter.awaitRead(conf)
-			if waitErr != nil {
-				return nil, waitErr
-			}
+	if skipAwait(obj, opts) {
+		glog.V(1).Infof("Skipping read logic for '%s'", obj.GetName())
+	} else if awaiter := awaiterForID(id); awaiter.awaitRead != nil {
+		conf := createAwaitConfig{
+			host:              host,
+			ctx:               ctx,
+			pool:              pool,
+			disco:             disco,
+			clientForResource: clientForResource,
+			urn:               urn,
+			options:           opts,
+			currentInputs:     obj,
+		}
+		waitErr := awaiter.awaitRead(conf)
+		if waitErr != nil {
+			return nil, exportError(conf, waitErr)
 		}
+	} else {
+		glog.V(1).Infof(
+			"No read logic found for object of type '%s'; falling back to retrieving object", id)
 	}
 
-	glog.V(1).Infof(
-		"No read logic found for object of type '%s'; falling back to retrieving object", id)
-
 	// Get the "live" version of the last submitted object. This is necessary because the server
 	// may have populated some fields automatically, updated status fields, and so on.
 	return clientForResource.Get(obj.GetName(), metav1.GetOptions{})
@@ -143,7 +152,7 @@ func Read(
 // https://kubernetes.io/docs/concepts/overview/object-management-kubectl/declarative-config/#how-apply-calculates-differences-and-merges-changes
 func Update(
 	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
-	disco discovery.CachedDiscoveryInterface, urn resource.URN,
+	disco discovery.CachedDiscoveryInterface, opts Options, urn resource.URN,
 	lastSubmitted, currentSubmitted *unstructured.Unstructured,
 ) (*unstructured.Unstructured, error) {
 	//
@@ -154,7 +163,7 @@ func Update(
 	// server must decide how to apply the changes inside it, to the version of the resource that it
 	// has stored in etcd. In Kubernetes this decision is turns out to be quite complex. `kubectl`
 	// currently uses the three-way "strategic merge" and falls back to the three-way JSON merge. We
-	// currently support the second, but eventually we'll have to support the first, too.
+	// do the same.
 	//
 	// (NOTE: This comment is scoped to the question of how to patch an existing resource, rather than
 	// how to recognize when a resource needs to be re-created from scratch.)
@@ -180,10 +189,10 @@ func Update(
 	// So the roadmap is:
 	//
 	// - [x] Implement `Update` using the three-way JSON merge strategy.
-	// - [ ] Cause `Update` to default to the three-way JSON merge patch strategy. (This will require
-	//       plumbing, because it expects nominal types representing the API schema, but the
-	//       discovery client is completely dynamic.)
-	// - [ ] Support server-side apply, when it comes out.
+	// - [x] Cause `Update` to default to the three-way strategic merge patch strategy, using the
+	//       patch metadata in the OpenAPI spec exposed by the API server, and falling back to JSON
+	//       merge for custom resources, which don't support strategic merge.
+	// - [x] Support server-side apply (opt-in, with the provider's `enableServerSideApply` config).
 	//
 
 	// Retrieve live version of last submitted version of object.
@@ -199,46 +208,46 @@ func Update(
 		return nil, err
 	}
 
-	// Create merge patch (prefer strategic merge patch, fall back to JSON merge patch).
-	patch, patchType, err := openapi.PatchForResourceUpdate(
-		disco, lastSubmitted, currentSubmitted, liveOldObj)
-	if err != nil {
-		return nil, err
+	// If requested, migrate stored custom resources off of CRD versions this update removes, since
+	// the API server would otherwise reject it.
+	if isCustomResourceDefinition(currentSubmitted) &&
+		annotationIsTrue(currentSubmitted, AnnotationMigrateStoredVersions) {
+		migration := &crdMigration{
+			ctx: ctx, host: host, urn: urn, pool: pool, disco: disco, crds: clientForResource,
+		}
+		if err = migration.migrateStoredVersions(liveOldObj, currentSubmitted); err != nil {
+			return nil, err
+		}
+		// The migration may have updated the CRD, so patch against its latest version.
+		liveOldObj, err = clientForResource.Get(lastSubmitted.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Issue patch request. NOTE: We can use the same client because if the `kind` changes, this
-	// will cause a replace (i.e., destroy and create).
-	_, err = clientForResource.Patch(currentSubmitted.GetName(), patchType, patch)
+	err = retryAdmission(ctx, host, urn, currentSubmitted, func() (err error) {
+		if opts.ServerSideApply {
+			// With server-side apply, the API server computes the patch, removing the fields we no
+			// longer set (unless another field manager also set them).
+			return serverSideApply(disco, currentSubmitted, opts)
+		}
+		// Issue patch request. NOTE: We can use the same client because if the `kind` changes, this
+		// will cause a replace (i.e., destroy and create).
+		_, err = patchForUpdate(disco, lastSubmitted, currentSubmitted, liveOldObj,
+			func(patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+				return clientForResource.Patch(currentSubmitted.GetName(), patchType, patch)
+			})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Wait until patch resolves as success or error. Note that the conditional is set up to log only
-	// if we don't have an entry for the resource type; in the event that we do, but the await logic
-	// is blank, simply do nothing instead of logging.
-	id := fmt.Sprintf("%s/%s", currentSubmitted.GetAPIVersion(), currentSubmitted.GetKind())
-	if awaiter, exists := awaiters[id]; exists {
-		if awaiter.awaitUpdate != nil {
-			conf := updateAwaitConfig{
-				createAwaitConfig: createAwaitConfig{
-					host:              host,
-					ctx:               ctx,
-					pool:              pool,
-					disco:             disco,
-					clientForResource: clientForResource,
-					urn:               urn,
-					currentInputs:     currentSubmitted,
-				},
-				lastInputs:  lastSubmitted,
-				lastOutputs: liveOldObj,
-			}
-			waitErr := awaiter.awaitUpdate(conf)
-			if waitErr != nil {
-				return nil, waitErr
-			}
-		}
-	} else {
-		glog.V(1).Infof("No initialization logic found for object of type '%s'; defaulting to assuming initialization successful", id)
+	// Wait until patch resolves as success or error.
+	err = awaitUpdated(ctx, host, pool, disco, opts, urn, clientForResource, lastSubmitted,
+		currentSubmitted, liveOldObj)
+	if err != nil {
+		return nil, err
 	}
 
 	gvk := currentSubmitted.GroupVersionKind()
@@ -249,12 +258,150 @@ func Update(
 	return clientForResource.Get(currentSubmitted.GetName(), metav1.GetOptions{})
 }
 
+// DryRunUpdate returns the object that `Update` would produce, as computed by the API server with a
+// server-side dry run, i.e., including the defaults and changes made by mutating admission webhooks,
+// without persisting it.
+func DryRunUpdate(
+	disco discovery.CachedDiscoveryInterface, opts Options,
+	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	if opts.ServerSideApply {
+		return client.DryRunApply(disco, currentSubmitted, opts.FieldManagerOrDefault())
+	}
+	return patchForUpdate(disco, lastSubmitted, currentSubmitted, liveOldObj,
+		func(patchType types.PatchType, patch []byte) (*unstructured.Unstructured, error) {
+			return client.DryRunPatch(disco, currentSubmitted, patchType, patch)
+		})
+}
+
+// patchForUpdate creates the three-way merge patch that updates `liveOldObj` from `lastSubmitted`
+// to `currentSubmitted` (preferring strategic merge patch, falling back to JSON merge patch), and
+// issues it with `issue`.
+func patchForUpdate(
+	disco discovery.CachedDiscoveryInterface,
+	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
+	issue func(types.PatchType, []byte) (*unstructured.Unstructured, error),
+) (*unstructured.Unstructured, error) {
+	patch, patchType, err := openapi.PatchForResourceUpdate(
+		disco, lastSubmitted, currentSubmitted, liveOldObj)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := issue(patchType, patch)
+
+	// Custom resources that publish a schema don't support strategic merge patches; fall back to a
+	// JSON merge patch.
+	if patchType == types.StrategicMergePatchType && strategicMergePatchUnsupported(err) {
+		glog.V(1).Infof("Strategic merge not supported for '%s'; retrying with JSON merge",
+			currentSubmitted.GetName())
+		patch, patchType, err = openapi.JSONMergePatchForResourceUpdate(
+			lastSubmitted, currentSubmitted, liveOldObj)
+		if err != nil {
+			return nil, err
+		}
+		patched, err = issue(patchType, patch)
+	}
+	return patched, err
+}
+
+// strategicMergePatchUnsupported returns true if `err` means the API server does not support
+// strategic merge patches for the resource, as is the case for custom resources.
+func strategicMergePatchUnsupported(err error) bool {
+	return errors.ReasonForError(err) == metav1.StatusReasonUnsupportedMediaType
+}
+
+// FieldManager is the field manager on whose behalf the provider makes server-side apply requests,
+// unless the provider's `fieldManager` config overrides it.
+const FieldManager = "pulumi-kubernetes"
+
+// serverSideApply creates or updates `obj` with server-side apply.
+func serverSideApply(
+	disco discovery.ServerResourcesInterface, obj *unstructured.Unstructured, opts Options,
+) error {
+	discoveryClient, isDiscoveryClient := disco.(discovery.DiscoveryInterface)
+	if !isDiscoveryClient {
+		return fmt.Errorf("server-side apply of '%s' requires a discovery client", obj.GetName())
+	}
+	_, err := client.Apply(discoveryClient, obj, opts.FieldManagerOrDefault())
+	return err
+}
+
+// ResumeUpdate resumes awaiting an update that was already applied, but whose await was interrupted
+// (e.g., because `pulumi up` was cancelled in the middle of a long rollout). Unlike `Update`, it does
+// not re-issue the patch; it simply waits for the in-flight rollout of `submitted` to complete.
+func ResumeUpdate(
+	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
+	disco discovery.CachedDiscoveryInterface, opts Options, urn resource.URN,
+	submitted *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	clientForResource, err := client.FromResource(pool, disco, submitted)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := clientForResource.Get(submitted.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The patch has already been applied, so from the awaiter's point of view the last and
+	// current inputs are the same, and the rollout in progress is the one to verify.
+	err = awaitUpdated(ctx, host, pool, disco, opts, urn, clientForResource, submitted,
+		submitted, live)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(3).Infof("Resumed await for '%s.%s' completed", submitted.GetNamespace(), submitted.GetName())
+
+	return clientForResource.Get(submitted.GetName(), metav1.GetOptions{})
+}
+
+// awaitUpdated waits until an update resolves as success or error. Note that if we don't have an
+// entry for the resource type we fall back to the generic (kstatus-style) readiness logic; in the
+// event that we do, but the await logic is blank, or the user asked us to skip it (for this object,
+// or with the provider's `suppressAwait` config), simply do nothing.
+func awaitUpdated(
+	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
+	disco discovery.CachedDiscoveryInterface, opts Options, urn resource.URN,
+	clientForResource dynamic.ResourceInterface,
+	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
+) error {
+	if skipAwait(currentSubmitted, opts) {
+		glog.V(1).Infof("Skipping await logic for '%s'", currentSubmitted.GetName())
+		return nil
+	}
+
+	id := fmt.Sprintf("%s/%s", currentSubmitted.GetAPIVersion(), currentSubmitted.GetKind())
+	if awaiter := awaiterForID(id); awaiter.awaitUpdate != nil {
+		conf := updateAwaitConfig{
+			createAwaitConfig: createAwaitConfig{
+				host:              host,
+				ctx:               ctx,
+				pool:              pool,
+				disco:             disco,
+				clientForResource: clientForResource,
+				urn:               urn,
+				options:           opts,
+				currentInputs:     currentSubmitted,
+			},
+			lastInputs:  lastSubmitted,
+			lastOutputs: liveOldObj,
+		}
+		return exportError(conf.createAwaitConfig, awaiter.awaitUpdate(conf))
+	}
+	return nil
+}
+
 // Deletion (as the usage, `await.Deletion`, implies) will block until one of the following is true:
 // (1) the Kubernetes resource is reported to be deleted; (2) the initialization timeout has
-// occurred; or (3) an error has occurred while the resource was being deleted.
+// occurred; or (3) an error has occurred while the resource was being deleted. `obj` identifies the
+// object to delete; its annotations (e.g., `pulumi.com/timeoutSeconds`) are taken from the last
+// submitted version of the object.
 func Deletion(
 	ctx context.Context, host *provider.HostClient, pool dynamic.ClientPool,
-	disco discovery.DiscoveryInterface, gvk schema.GroupVersionKind, namespace, name string,
+	disco discovery.DiscoveryInterface, opts Options, urn resource.URN,
+	obj *unstructured.Unstructured,
 ) error {
 	// Make delete options based on the version of the client.
 	version, err := client.FetchVersion(disco)
@@ -262,44 +409,63 @@ func Deletion(
 		version = client.DefaultVersion()
 	}
 
+	policy, err := DeletionPropagationPolicy(obj, opts)
+	if err != nil {
+		return err
+	}
+
 	deleteOpts := metav1.DeleteOptions{}
 	if version.Compare(1, 6) < 0 {
 		// 1.5.x option.
-		boolFalse := false
+		orphan := policy == metav1.DeletePropagationOrphan
 		// nolint
-		deleteOpts.OrphanDependents = &boolFalse
+		deleteOpts.OrphanDependents = &orphan
 	} else {
-		// 1.6.x option. (NOTE: Background delete propagation is broken in k8s v1.6, and maybe later.)
-		fg := metav1.DeletePropagationForeground
-		deleteOpts.PropagationPolicy = &fg
+		// 1.6.x option. (NOTE: Background delete propagation is broken in k8s v1.6, and maybe later,
+		// which is why we default to foreground deletion.)
+		deleteOpts.PropagationPolicy = &policy
 	}
 
 	// Obtain client for the resource being deleted.
-	clientForResource, err := client.FromGVK(pool, disco, gvk, namespace)
+	clientForResource, err := client.FromResource(pool, disco, obj)
 	if err != nil {
 		return err
 	}
 
 	// Issue deletion request.
+	namespace, name := obj.GetNamespace(), obj.GetName()
 	err = clientForResource.Delete(name, &deleteOpts)
+	awaitCacheFor(disco).forget(obj)
 	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("Could not find resource '%s/%s' for deletion: %s", namespace, name, err)
 	} else if err != nil {
 		return err
 	}
 
-	// Wait until delete resolves as success or error. Note that the conditional is set up to log only
-	// if we don't have an entry for the resource type; in the event that we do, but the await logic
-	// is blank, simply do nothing instead of logging.
-	var waitErr error
-	id := fmt.Sprintf("%s/%s", gvk.GroupVersion().String(), gvk.Kind)
-	if awaiter, exists := awaiters[id]; exists {
-		if awaiter.awaitDeletion != nil {
-			waitErr = awaiter.awaitDeletion(ctx, clientForResource, name)
-		}
-	} else {
-		glog.V(1).Infof("No deletion logic found for object of type '%s'; defaulting to assuming deletion successful", id)
+	// Wait until delete resolves as success or error. Unless the kind registers its own deletion
+	// logic, we wait for the object (and, since we delete in the foreground, its dependents) to be
+	// gone, so that a stuck finalizer is reported as an error rather than a successful delete.
+	if skipAwait(obj, opts) {
+		glog.V(1).Infof("Skipping await logic for '%s'", name)
+		return nil
+	}
+	id := fmt.Sprintf("%s/%s", obj.GetAPIVersion(), obj.GetKind())
+	awaitDeletion := untilDeleted
+	if awaiter, exists := awaiters[id]; exists && awaiter.awaitDeletion != nil {
+		awaitDeletion = awaiter.awaitDeletion
 	}
 
-	return waitErr
+	conf := deleteAwaitConfig{
+		createAwaitConfig: createAwaitConfig{
+			host:              host,
+			ctx:               ctx,
+			pool:              pool,
+			disco:             disco,
+			clientForResource: clientForResource,
+			urn:               urn,
+			options:           opts,
+			currentInputs:     obj,
+		},
+	}
+	return exportError(conf.createAwaitConfig, awaitDeletion(conf))
 }
@@ -45,6 +45,28 @@ type createAwaitConfig struct {
 	clientForResource dynamic.ResourceInterface
 	urn               resource.URN
 	currentInputs     *unstructured.Unstructured
+
+	// options holds the await configuration of the provider.
+	options Options
+}
+
+// timeout returns the time to wait for the object to become ready: `defaultTimeout`, unless the
+// user overrode it with the `pulumi.com/timeoutSeconds` annotation, or for the object's kind with
+// the provider's `timeouts` config.
+func (cac *createAwaitConfig) timeout(defaultTimeout time.Duration) time.Duration {
+	timeout, err := TimeoutSeconds(cac.currentInputs)
+	if err != nil {
+		// NOTE: `Check` rejects invalid values, so this should never happen.
+		glog.V(3).Infof("Ignoring invalid timeout for '%s': %v", cac.currentInputs.GetName(), err)
+		return defaultTimeout
+	}
+	if timeout != 0 {
+		return timeout
+	}
+	if timeout, exists := cac.options.Timeouts.forObject(cac.currentInputs); exists {
+		return timeout
+	}
+	return defaultTimeout
 }
 
 func (cac *createAwaitConfig) eventClient() (dynamic.ResourceInterface, error) {
@@ -67,10 +89,17 @@ type updateAwaitConfig struct {
 	lastOutputs *unstructured.Unstructured
 }
 
+// deleteAwaitConfig specifies on which conditions we are to consider a resource deleted, i.e., the
+// object (including any finalizers it carries) is gone from the API server. `currentInputs` holds
+// only the identity of the object being deleted; its spec is not known.
+type deleteAwaitConfig struct {
+	createAwaitConfig
+}
+
 type createAwaiter func(createAwaitConfig) error
 type updateAwaiter func(updateAwaitConfig) error
 type readAwaiter func(createAwaitConfig) error
-type deletionAwaiter func(context.Context, dynamic.ResourceInterface, string) error
+type deletionAwaiter func(deleteAwaitConfig) error
 
 // --------------------------------------------------------------------------
 
@@ -82,36 +111,53 @@ type deletionAwaiter func(context.Context, dynamic.ResourceInterface, string) er
 // --------------------------------------------------------------------------
 
 const (
-	appsV1Deployment                            = "apps/v1/Deployment"
-	appsV1Beta1Deployment                       = "apps/v1beta1/Deployment"
-	appsV1Beta2Deployment                       = "apps/v1beta2/Deployment"
-	autoscalingV1HorizontalPodAutoscaler        = "autoscaling/v1/HorizontalPodAutoscaler"
-	coreV1ConfigMap                             = "v1/ConfigMap"
-	coreV1LimitRange                            = "v1/LimitRange"
-	coreV1Namespace                             = "v1/Namespace"
-	coreV1PersistentVolume                      = "v1/PersistentVolume"
-	coreV1PersistentVolumeClaim                 = "v1/PersistentVolumeClaim"
-	coreV1Pod                                   = "v1/Pod"
-	coreV1ReplicationController                 = "v1/ReplicationController"
-	coreV1ResourceQuota                         = "v1/ResourceQuota"
-	coreV1Secret                                = "v1/Secret"
-	coreV1Service                               = "v1/Service"
-	coreV1ServiceAccount                        = "v1/ServiceAccount"
-	extensionsV1Beta1Deployment                 = "extensions/v1beta1/Deployment"
-	extensionsV1Beta1Ingress                    = "extensions/v1beta1/Ingress"
-	rbacAuthorizationV1ClusterRole              = "rbac.authorization.k8s.io/v1/ClusterRole"
-	rbacAuthorizationV1ClusterRoleBinding       = "rbac.authorization.k8s.io/v1/ClusterRoleBinding"
-	rbacAuthorizationV1Role                     = "rbac.authorization.k8s.io/v1/Role"
-	rbacAuthorizationV1RoleBinding              = "rbac.authorization.k8s.io/v1/RoleBinding"
-	rbacAuthorizationV1Alpha1ClusterRole        = "rbac.authorization.k8s.io/v1alpha1/ClusterRole"
-	rbacAuthorizationV1Alpha1ClusterRoleBinding = "rbac.authorization.k8s.io/v1alpha1/ClusterRoleBinding"
-	rbacAuthorizationV1Alpha1Role               = "rbac.authorization.k8s.io/v1alpha1/Role"
-	rbacAuthorizationV1Alpha1RoleBinding        = "rbac.authorization.k8s.io/v1alpha1/RoleBinding"
-	rbacAuthorizationV1Beta1ClusterRole         = "rbac.authorization.k8s.io/v1beta1/ClusterRole"
-	rbacAuthorizationV1Beta1ClusterRoleBinding  = "rbac.authorization.k8s.io/v1beta1/ClusterRoleBinding"
-	rbacAuthorizationV1Beta1Role                = "rbac.authorization.k8s.io/v1beta1/Role"
-	rbacAuthorizationV1Beta1RoleBinding         = "rbac.authorization.k8s.io/v1beta1/RoleBinding"
-	storageV1StorageClass                       = "storage.k8s.io/v1/StorageClass"
+	apiextensionsV1CustomResourceDefinition      = "apiextensions.k8s.io/v1/CustomResourceDefinition"
+	apiextensionsV1Beta1CustomResourceDefinition = "apiextensions.k8s.io/v1beta1/CustomResourceDefinition"
+	apiregistrationV1APIService                  = "apiregistration.k8s.io/v1/APIService"
+	apiregistrationV1Beta1APIService             = "apiregistration.k8s.io/v1beta1/APIService"
+	appsV1DaemonSet                              = "apps/v1/DaemonSet"
+	appsV1Beta2DaemonSet                         = "apps/v1beta2/DaemonSet"
+	appsV1Deployment                             = "apps/v1/Deployment"
+	appsV1Beta1Deployment                        = "apps/v1beta1/Deployment"
+	appsV1Beta2Deployment                        = "apps/v1beta2/Deployment"
+	appsV1StatefulSet                            = "apps/v1/StatefulSet"
+	appsV1Beta1StatefulSet                       = "apps/v1beta1/StatefulSet"
+	appsV1Beta2StatefulSet                       = "apps/v1beta2/StatefulSet"
+	autoscalingV1HorizontalPodAutoscaler         = "autoscaling/v1/HorizontalPodAutoscaler"
+	autoscalingV2Beta1HorizontalPodAutoscaler    = "autoscaling/v2beta1/HorizontalPodAutoscaler"
+	autoscalingV2Beta2HorizontalPodAutoscaler    = "autoscaling/v2beta2/HorizontalPodAutoscaler"
+	batchV1Job                                   = "batch/v1/Job"
+	coreV1ConfigMap                              = "v1/ConfigMap"
+	coreV1LimitRange                             = "v1/LimitRange"
+	coreV1Namespace                              = "v1/Namespace"
+	coreV1PersistentVolume                       = "v1/PersistentVolume"
+	coreV1PersistentVolumeClaim                  = "v1/PersistentVolumeClaim"
+	coreV1Pod                                    = "v1/Pod"
+	coreV1ReplicationController                  = "v1/ReplicationController"
+	coreV1ResourceQuota                          = "v1/ResourceQuota"
+	coreV1Secret                                 = "v1/Secret"
+	coreV1Service                                = "v1/Service"
+	coreV1ServiceAccount                         = "v1/ServiceAccount"
+	extensionsV1Beta1DaemonSet                   = "extensions/v1beta1/DaemonSet"
+	extensionsV1Beta1Deployment                  = "extensions/v1beta1/Deployment"
+	extensionsV1Beta1Ingress                     = "extensions/v1beta1/Ingress"
+	networkingV1Ingress                          = "networking.k8s.io/v1/Ingress"
+	networkingV1Beta1Ingress                     = "networking.k8s.io/v1beta1/Ingress"
+	policyV1PodDisruptionBudget                  = "policy/v1/PodDisruptionBudget"
+	policyV1Beta1PodDisruptionBudget             = "policy/v1beta1/PodDisruptionBudget"
+	rbacAuthorizationV1ClusterRole               = "rbac.authorization.k8s.io/v1/ClusterRole"
+	rbacAuthorizationV1ClusterRoleBinding        = "rbac.authorization.k8s.io/v1/ClusterRoleBinding"
+	rbacAuthorizationV1Role                      = "rbac.authorization.k8s.io/v1/Role"
+	rbacAuthorizationV1RoleBinding               = "rbac.authorization.k8s.io/v1/RoleBinding"
+	rbacAuthorizationV1Alpha1ClusterRole         = "rbac.authorization.k8s.io/v1alpha1/ClusterRole"
+	rbacAuthorizationV1Alpha1ClusterRoleBinding  = "rbac.authorization.k8s.io/v1alpha1/ClusterRoleBinding"
+	rbacAuthorizationV1Alpha1Role                = "rbac.authorization.k8s.io/v1alpha1/Role"
+	rbacAuthorizationV1Alpha1RoleBinding         = "rbac.authorization.k8s.io/v1alpha1/RoleBinding"
+	rbacAuthorizationV1Beta1ClusterRole          = "rbac.authorization.k8s.io/v1beta1/ClusterRole"
+	rbacAuthorizationV1Beta1ClusterRoleBinding   = "rbac.authorization.k8s.io/v1beta1/ClusterRoleBinding"
+	rbacAuthorizationV1Beta1Role                 = "rbac.authorization.k8s.io/v1beta1/Role"
+	rbacAuthorizationV1Beta1RoleBinding          = "rbac.authorization.k8s.io/v1beta1/RoleBinding"
+	storageV1StorageClass                        = "storage.k8s.io/v1/StorageClass"
 )
 
 type awaitSpec struct {
@@ -134,16 +180,69 @@ var deploymentAwaiter = awaitSpec{
 	awaitDeletion: untilAppsDeploymentDeleted,
 }
 
+var daemonsetAwaiter = awaitSpec{
+	awaitCreation: func(c createAwaitConfig) error {
+		return makeDaemonSetInitAwaiter(updateAwaitConfig{createAwaitConfig: c}).Await()
+	},
+	awaitUpdate: func(u updateAwaitConfig) error {
+		return makeDaemonSetInitAwaiter(u).Await()
+	},
+	awaitRead: func(c createAwaitConfig) error {
+		return makeDaemonSetInitAwaiter(updateAwaitConfig{createAwaitConfig: c}).Read()
+	},
+}
+
+var ingressAwaiter = awaitSpec{
+	awaitCreation: awaitIngressInit,
+	awaitUpdate:   awaitIngressUpdate,
+	awaitRead:     awaitIngressRead,
+}
+
+var pdbAwaiter = awaitSpec{
+	awaitCreation: untilPolicyPDBReady,
+	awaitUpdate:   untilPolicyPDBReadyUpdated,
+}
+
+var statefulsetAwaiter = awaitSpec{
+	awaitCreation: func(c createAwaitConfig) error {
+		return makeStatefulSetInitAwaiter(updateAwaitConfig{createAwaitConfig: c}).Await()
+	},
+	awaitUpdate: func(u updateAwaitConfig) error {
+		return makeStatefulSetInitAwaiter(u).Await()
+	},
+	awaitRead: func(c createAwaitConfig) error {
+		return makeStatefulSetInitAwaiter(updateAwaitConfig{createAwaitConfig: c}).Read()
+	},
+}
+
 // NOTE: Some GVKs below are blank so that we can distinguish between resource types that we know
 // about, but don't require await logic, vs. resource types that we don't know about.
 
 var awaiters = map[string]awaitSpec{
-	appsV1Deployment:                     deploymentAwaiter,
-	appsV1Beta1Deployment:                deploymentAwaiter,
-	appsV1Beta2Deployment:                deploymentAwaiter,
-	autoscalingV1HorizontalPodAutoscaler: { /* NONE */ },
-	coreV1ConfigMap:                      { /* NONE */ },
-	coreV1LimitRange:                     { /* NONE */ },
+	apiextensionsV1CustomResourceDefinition:      crdAwaiter,
+	apiextensionsV1Beta1CustomResourceDefinition: crdAwaiter,
+	apiregistrationV1APIService:                  apiServiceAwaiter,
+	apiregistrationV1Beta1APIService:             apiServiceAwaiter,
+	appsV1DaemonSet:                              daemonsetAwaiter,
+	appsV1Beta2DaemonSet:                         daemonsetAwaiter,
+	appsV1Deployment:                             deploymentAwaiter,
+	appsV1Beta1Deployment:                        deploymentAwaiter,
+	appsV1Beta2Deployment:                        deploymentAwaiter,
+	appsV1StatefulSet:                            statefulsetAwaiter,
+	appsV1Beta1StatefulSet:                       statefulsetAwaiter,
+	appsV1Beta2StatefulSet:                       statefulsetAwaiter,
+	autoscalingV1HorizontalPodAutoscaler:         hpaAwaiter,
+	autoscalingV2Beta1HorizontalPodAutoscaler:    hpaAwaiter,
+	autoscalingV2Beta2HorizontalPodAutoscaler:    hpaAwaiter,
+	batchV1Job: {
+		awaitCreation: func(c createAwaitConfig) error { return makeJobInitAwaiter(c).Await() },
+		awaitUpdate: func(u updateAwaitConfig) error {
+			return makeJobInitAwaiter(u.createAwaitConfig).Await()
+		},
+		awaitRead: func(c createAwaitConfig) error { return makeJobInitAwaiter(c).Read() },
+	},
+	coreV1ConfigMap:  { /* NONE */ },
+	coreV1LimitRange: { /* NONE */ },
 	coreV1Namespace: {
 		awaitDeletion: untilCoreV1NamespaceDeleted,
 	},
@@ -152,11 +251,13 @@ var awaiters = map[string]awaitSpec{
 	},
 	coreV1PersistentVolumeClaim: {
 		awaitCreation: untilCoreV1PersistentVolumeClaimBound,
+		awaitUpdate:   untilCoreV1PersistentVolumeClaimBoundUpdated,
 	},
 	coreV1Pod: {
 		// NOTE: Because we replace the Pod in most situations, we do not require special logic for
 		// the update path.
 		awaitCreation: func(c createAwaitConfig) error { return makePodInitAwaiter(c).Await() },
+		awaitRead:     func(c createAwaitConfig) error { return makePodInitAwaiter(c).Read() },
 		awaitDeletion: untilCoreV1PodDeleted,
 	},
 	coreV1ReplicationController: {
@@ -171,14 +272,18 @@ var awaiters = map[string]awaitSpec{
 	coreV1Secret: { /* NONE */ },
 	coreV1Service: {
 		awaitCreation: awaitServiceInit,
+		awaitUpdate:   awaitServiceUpdate,
 	},
 	coreV1ServiceAccount: {
 		awaitCreation: untilCoreV1ServiceAccountInitialized,
 	},
-	extensionsV1Beta1Deployment: deploymentAwaiter,
-	extensionsV1Beta1Ingress: {
-		awaitCreation: untilExtensionsV1Beta1IngressInitialized,
-	},
+	extensionsV1Beta1DaemonSet:                  daemonsetAwaiter,
+	extensionsV1Beta1Deployment:                 deploymentAwaiter,
+	extensionsV1Beta1Ingress:                    ingressAwaiter,
+	networkingV1Ingress:                         ingressAwaiter,
+	networkingV1Beta1Ingress:                    ingressAwaiter,
+	policyV1PodDisruptionBudget:                 pdbAwaiter,
+	policyV1Beta1PodDisruptionBudget:            pdbAwaiter,
 	rbacAuthorizationV1ClusterRole:              { /* NONE */ },
 	rbacAuthorizationV1ClusterRoleBinding:       { /* NONE */ },
 	rbacAuthorizationV1Role:                     { /* NONE */ },
@@ -194,6 +299,22 @@ var awaiters = map[string]awaitSpec{
 	storageV1StorageClass:                       { /* NONE */ },
 }
 
+// awaiterForID returns the await spec registered for a `group/version/kind` ID. If no spec is
+// registered, we use the readiness handler registered for the ID in `readinessHandlers`, if any,
+// and otherwise fall back to the generic readiness logic, which is based on the conventions common
+// to most kinds (e.g., `.status.conditions` and `.status.observedGeneration`).
+func awaiterForID(id string) awaitSpec {
+	if awaiter, exists := awaiters[id]; exists {
+		return awaiter
+	}
+	if handler, exists := readinessHandlers[id]; exists {
+		return customResourceAwaiter(handler)
+	}
+	glog.V(1).Infof(
+		"No await logic found for object of type '%s'; falling back to generic readiness logic", id)
+	return genericAwaiter
+}
+
 // --------------------------------------------------------------------------
 
 // Awaiters.
@@ -215,9 +336,8 @@ func deploymentSpecReplicas(deployment *unstructured.Unstructured) (interface{},
 	return openapi.Pluck(deployment.Object, "spec", "replicas")
 }
 
-func untilAppsDeploymentDeleted(
-	ctx context.Context, clientForResource dynamic.ResourceInterface, name string,
-) error {
+func untilAppsDeploymentDeleted(c deleteAwaitConfig) error {
+	name := c.currentInputs.GetName()
 	//
 	// TODO(hausdorff): Should we scale pods to 0 and then delete instead? Kubernetes should allow us
 	// to check the status after deletion, but there is some possibility if there is a long-ish
@@ -245,8 +365,8 @@ func untilAppsDeploymentDeleted(
 	}
 
 	// Wait until all replicas are gone. 10 minutes should be enough for ~10k replicas.
-	err := watcher.ForObject(ctx, clientForResource, name).
-		RetryUntil(deploymentMissing, 10*time.Minute)
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(deploymentMissing, c.timeout(10*time.Minute))
 	if err != nil {
 		return err
 	}
@@ -258,36 +378,6 @@ func untilAppsDeploymentDeleted(
 
 // --------------------------------------------------------------------------
 
-// core/v1/Namespace
-
-// --------------------------------------------------------------------------
-
-func untilCoreV1NamespaceDeleted(
-	ctx context.Context, clientForResource dynamic.ResourceInterface, name string,
-) error {
-	namespaceMissingOrKilled := func(ns *unstructured.Unstructured, err error) error {
-		if is404(err) {
-			return nil
-		} else if err != nil {
-			glog.V(3).Infof("Received error deleting namespace '%s': %#v", ns.GetName(), err)
-			return err
-		}
-
-		statusPhase, _ := openapi.Pluck(ns.Object, "status", "phase")
-		glog.V(3).Infof("Namespace '%s' status received: %#v", name, statusPhase)
-		if statusPhase == "" {
-			return nil
-		}
-
-		return watcher.RetryableError(fmt.Errorf("Namespace '%s' still exists (%v)", name, statusPhase))
-	}
-
-	return watcher.ForObject(ctx, clientForResource, name).
-		RetryUntil(namespaceMissingOrKilled, 5*time.Minute)
-}
-
-// --------------------------------------------------------------------------
-
 // core/v1/PersistentVolume
 
 // --------------------------------------------------------------------------
@@ -300,24 +390,7 @@ func untilCoreV1PersistentVolumeInitialized(c createAwaitConfig) error {
 	}
 
 	return watcher.ForObject(c.ctx, c.clientForResource, c.currentInputs.GetName()).
-		WatchUntil(pvAvailableOrBound, 5*time.Minute)
-}
-
-// --------------------------------------------------------------------------
-
-// core/v1/PersistentVolumeClaim
-
-// --------------------------------------------------------------------------
-
-func untilCoreV1PersistentVolumeClaimBound(c createAwaitConfig) error {
-	pvcBound := func(pvc *unstructured.Unstructured) bool {
-		statusPhase, _ := openapi.Pluck(pvc.Object, "status", "phase")
-		glog.V(3).Infof("Persistent volume claim %s status received: %#v", pvc.GetName(), statusPhase)
-		return statusPhase == "Bound"
-	}
-
-	return watcher.ForObject(c.ctx, c.clientForResource, c.currentInputs.GetName()).
-		WatchUntil(pvcBound, 5*time.Minute)
+		WatchUntil(pvAvailableOrBound, c.timeout(5*time.Minute))
 }
 
 // --------------------------------------------------------------------------
@@ -326,9 +399,8 @@ func untilCoreV1PersistentVolumeClaimBound(c createAwaitConfig) error {
 
 // --------------------------------------------------------------------------
 
-func untilCoreV1PodDeleted(
-	ctx context.Context, clientForResource dynamic.ResourceInterface, name string,
-) error {
+func untilCoreV1PodDeleted(c deleteAwaitConfig) error {
+	name := c.currentInputs.GetName()
 	podMissingOrKilled := func(pod *unstructured.Unstructured, err error) error {
 		if is404(err) {
 			return nil
@@ -342,8 +414,8 @@ func untilCoreV1PodDeleted(
 		return watcher.RetryableError(e)
 	}
 
-	return watcher.ForObject(ctx, clientForResource, name).
-		RetryUntil(podMissingOrKilled, 5*time.Minute)
+	return watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(podMissingOrKilled, c.timeout(5*time.Minute))
 }
 
 // --------------------------------------------------------------------------
@@ -375,7 +447,7 @@ func untilCoreV1ReplicationControllerInitialized(c createAwaitConfig) error {
 				name,
 				replicationControllerSpecReplicas,
 				availableReplicas),
-			10*time.Minute)
+			c.timeout(10*time.Minute))
 	if err != nil {
 		return err
 	}
@@ -392,9 +464,8 @@ func untilCoreV1ReplicationControllerUpdated(c updateAwaitConfig) error {
 	return untilCoreV1ReplicationControllerInitialized(c.createAwaitConfig)
 }
 
-func untilCoreV1ReplicationControllerDeleted(
-	ctx context.Context, clientForResource dynamic.ResourceInterface, name string,
-) error {
+func untilCoreV1ReplicationControllerDeleted(c deleteAwaitConfig) error {
+	name := c.currentInputs.GetName()
 	//
 	// TODO(hausdorff): Should we scale pods to 0 and then delete instead? Kubernetes should allow us
 	// to check the status after deletion, but there is some possibility if there is a long-ish
@@ -422,8 +493,8 @@ func untilCoreV1ReplicationControllerDeleted(
 	}
 
 	// Wait until all replicas are gone. 10 minutes should be enough for ~10k replicas.
-	err := watcher.ForObject(ctx, clientForResource, name).
-		RetryUntil(rcMissing, 10*time.Minute)
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(rcMissing, c.timeout(10*time.Minute))
 	if err != nil {
 		return err
 	}
@@ -456,7 +527,7 @@ func untilCoreV1ResourceQuotaInitialized(c createAwaitConfig) error {
 	}
 
 	return watcher.ForObject(c.ctx, c.clientForResource, c.currentInputs.GetName()).
-		WatchUntil(rqInitialized, 1*time.Minute)
+		WatchUntil(rqInitialized, c.timeout(1*time.Minute))
 }
 
 func untilCoreV1ResourceQuotaUpdated(c updateAwaitConfig) error {
@@ -477,8 +548,21 @@ func untilCoreV1ResourceQuotaUpdated(c updateAwaitConfig) error {
 func untilCoreV1ServiceAccountInitialized(c createAwaitConfig) error {
 	//
 	// A ServiceAccount is considered initialized when the controller adds the default secret to the
-	// secrets array (i.e., in addition to the secrets specified by the user).
+	// secrets array (i.e., in addition to the secrets specified by the user). Programs frequently
+	// read the token secret right after creating the ServiceAccount, so this prevents them from
+	// racing the token controller.
 	//
+	// Starting with Kubernetes 1.24, Pods get short-lived tokens through projected volumes (i.e.,
+	// `BoundServiceAccountTokenVolume`), and the token controller no longer generates a secret for
+	// every ServiceAccount, so waiting for one would only end in a timeout. Users who don't need the
+	// secret on older clusters can opt out with the `pulumi.com/skipAwait` annotation.
+	//
+
+	if !generatesTokenSecrets(c) {
+		glog.V(3).Infof("Cluster does not generate token secrets for ServiceAccounts; "+
+			"not waiting for ServiceAccount '%s'", c.currentInputs.GetName())
+		return nil
+	}
 
 	specSecrets, _ := openapi.Pluck(c.currentInputs.Object, "secrets")
 	var numSpecSecrets int
@@ -501,53 +585,23 @@ func untilCoreV1ServiceAccountInitialized(c createAwaitConfig) error {
 	}
 
 	return watcher.ForObject(c.ctx, c.clientForResource, c.currentInputs.GetName()).
-		WatchUntil(defaultSecretAllocated, 5*time.Minute)
+		WatchUntil(defaultSecretAllocated, c.timeout(5*time.Minute))
 }
 
-// --------------------------------------------------------------------------
-
-// extensions/v1beta1/Ingress
-
-// --------------------------------------------------------------------------
-
-func untilExtensionsV1Beta1IngressInitialized(c createAwaitConfig) error {
-	clientForEvents, err := c.eventClient()
-	if err != nil {
-		return err
+// generatesTokenSecrets returns true if the token controller of the cluster generates a token
+// secret for every ServiceAccount, i.e., the cluster is older than Kubernetes 1.24. If the version
+// can't be determined, we assume it does, as we always have.
+func generatesTokenSecrets(c createAwaitConfig) bool {
+	versionClient, isVersionClient := c.disco.(discovery.ServerVersionInterface)
+	if !isVersionClient {
+		return true
 	}
-
-	name := c.currentInputs.GetName()
-
-	externalIPAllocated := func(svc *unstructured.Unstructured) bool {
-		lbIngress, _ := openapi.Pluck(svc.Object, "status", "loadBalancer", "ingress")
-		status, _ := openapi.Pluck(svc.Object, "status")
-
-		glog.V(3).Infof("Received Ingress status: %#v", status)
-		if ing, isSlice := lbIngress.([]interface{}); isSlice && len(ing) > 0 {
-			return true
-		}
-
-		glog.V(3).Infof("Waiting for Ingress '%q' to assign IP/hostname for a load balancer", name)
-
-		return false
-	}
-
-	// Await.
-	glog.V(3).Info("Waiting for load balancer to assign IP/hostname")
-
-	err = watcher.ForObject(c.ctx, c.clientForResource, c.currentInputs.GetName()).
-		WatchUntil(externalIPAllocated, 10*time.Minute)
-
+	version, err := client.FetchVersion(versionClient)
 	if err != nil {
-		lastWarnings, wErr := getLastWarningsForObject(clientForEvents, c.currentInputs.GetNamespace(),
-			name, "Ingress", 3)
-		if wErr != nil {
-			return wErr
-		}
-		return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+		glog.V(3).Infof("Could not determine cluster version: %v", err)
+		return true
 	}
-
-	return nil
+	return version.Compare(1, 24) < 0
 }
 
 // --------------------------------------------------------------------------
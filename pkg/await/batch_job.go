@@ -0,0 +1,381 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for batch/v1/Job.
+//
+// Unlike most resources, a Job is not "ready" when its Pods are running: it is done when its Pods
+// have run to completion. The Job controller reports this in `.status.conditions`:
+//
+//   1. A condition with `type` equal to `Complete` and `status` equal to `True` means the Job
+//      succeeded, i.e., the requested number of Pods completed successfully.
+//   2. A condition with `type` equal to `Failed` and `status` equal to `True` means the Job will
+//      never succeed, e.g., because it exceeded its `backoffLimit` or `activeDeadlineSeconds`. Its
+//      `reason` and `message` explain why, and we report them as the cause of the failure. We also
+//      fail as soon as more Pods have failed (`.status.failed`) than `spec.backoffLimit` allows,
+//      since the Job controller will then inevitably fail the Job with `BackoffLimitExceeded`.
+//
+// While the Job runs, we also watch its Pods, so that we can report the errors that usually cause
+// a Job to fail (e.g., an image that can't be pulled, or a container that exits non-zero) as they
+// happen, rather than only after the Job controller gives up. When the Job fails, the controller
+// deletes its remaining Pods, so we keep the last observed state of deleted Pods, and attach their
+// errors to the failure.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/workloads/controllers/jobs-run-to-completion/
+
+// ------------------------------------------------------------------------------------------------
+
+const (
+	jobCompleteCondition = "Complete"
+	jobFailedCondition   = "Failed"
+
+	jobBackoffLimitExceededReason = "BackoffLimitExceeded"
+
+	// defaultJobBackoffLimit is the value of `spec.backoffLimit` if it is not set.
+	defaultJobBackoffLimit = 6
+)
+
+type jobInitAwaiter struct {
+	config   createAwaitConfig
+	job      *unstructured.Unstructured
+	complete bool
+	failed   bool
+
+	jobErrors map[string]string
+
+	pods map[string]*unstructured.Unstructured
+
+	// deletedPods holds the last observed state of the Pods of the Job that were deleted, e.g., by the
+	// Job controller when the Job failed.
+	deletedPods map[string]*unstructured.Unstructured
+}
+
+func makeJobInitAwaiter(c createAwaitConfig) *jobInitAwaiter {
+	return &jobInitAwaiter{
+		config: c,
+		job:    c.currentInputs,
+
+		jobErrors: map[string]string{},
+
+		pods:        map[string]*unstructured.Unstructured{},
+		deletedPods: map[string]*unstructured.Unstructured{},
+	}
+}
+
+func (jia *jobInitAwaiter) Await() error {
+	podClient, err := jia.makePodClient()
+	if err != nil {
+		return err
+	}
+
+	// Create Job watcher.
+	jobWatcher, err := watchWithReconnect(jia.config.clientForResource,
+		nameListOptions(jia.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for Job object '%s'",
+			jia.config.currentInputs.GetName())
+	}
+	defer jobWatcher.Stop()
+
+	// Create Pod watcher.
+	podWatcher, err := watchWithReconnect(podClient, jobPodListOptions(jia.config.currentInputs))
+	if err != nil {
+		return errors.Wrapf(err,
+			"Could not create watcher for Pods objects associated with Job '%s'",
+			jia.config.currentInputs.GetName())
+	}
+	defer podWatcher.Stop()
+
+	period := time.NewTicker(10 * time.Second)
+	defer period.Stop()
+
+	err = jia.await(jobWatcher, podWatcher,
+		client.ThrottledAfter(jia.config.timeout(10*time.Minute)), period.C)
+	if awaitFailed(err) {
+		reportPodLogs(jia.config, podValues(jia.pods))
+	}
+	return err
+}
+
+func (jia *jobInitAwaiter) Read() error {
+	podClient, err := jia.makePodClient()
+	if err != nil {
+		return err
+	}
+
+	job, err := jia.config.clientForResource.Get(jia.config.currentInputs.GetName(),
+		metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the Job as having been deleted.
+		return err
+	}
+
+	podList, err := podClient.List(jobPodListOptions(job))
+	if err != nil {
+		glog.V(3).Infof("Error retrieving Pod list for Job '%s': %v", job.GetName(), err)
+		podList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
+	}
+
+	return jia.read(job, podList.(*unstructured.UnstructuredList))
+}
+
+func (jia *jobInitAwaiter) read(job *unstructured.Unstructured, pods *unstructured.UnstructuredList) error {
+	jia.processJobEvent(watchAddedEvent(job))
+
+	err := pods.EachListItem(func(pod runtime.Object) error {
+		jia.processPodEvent(watchAddedEvent(pod.(*unstructured.Unstructured)))
+		return nil
+	})
+	if err != nil {
+		glog.V(3).Infof("Error iterating over Pod list for Job '%s': %v", job.GetName(), err)
+	}
+
+	// NOTE: A Job that is still running is not an error on refresh; only one that has failed is.
+	if !jia.failed {
+		return nil
+	}
+
+	return &initializationError{
+		subErrors: jia.errorMessages(),
+		object:    job,
+	}
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (jia *jobInitAwaiter) await(
+	jobWatcher, podWatcher watch.Interface, timeout, period <-chan time.Time,
+) error {
+	inputName := jia.config.currentInputs.GetName()
+	for {
+		if jia.complete {
+			return nil
+		}
+		if jia.failed {
+			return &initializationError{
+				subErrors: jia.errorMessages(),
+				object:    jia.job,
+			}
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-jia.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  jia.errorMessages(),
+			}
+		case <-timeout:
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  jia.errorMessages(),
+			}
+		case <-period:
+			for _, message := range jia.podErrors() {
+				jia.warn(message)
+			}
+		case event := <-jobWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			jia.processJobEvent(event)
+		case event := <-podWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			jia.processPodEvent(event)
+		}
+	}
+}
+
+func (jia *jobInitAwaiter) processJobEvent(event watch.Event) {
+	job, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Job watch received unknown object type '%s'",
+			reflect.TypeOf(job))
+		return
+	}
+
+	// Do nothing if this is not the Job we're waiting for.
+	if job.GetName() != jia.config.currentInputs.GetName() {
+		return
+	}
+
+	// Start over, prove that the Job is complete.
+	jia.jobErrors = map[string]string{}
+	jia.complete = false
+	jia.failed = false
+
+	if event.Type == watch.Deleted {
+		return
+	}
+	jia.job = job
+
+	rawConditions, _ := openapi.Pluck(job.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+	if condition, exists := findCondition(conditions, jobCompleteCondition); exists {
+		jia.complete = condition["status"] == trueStatus
+	}
+	if condition, exists := findCondition(conditions, jobFailedCondition); exists &&
+		condition["status"] == trueStatus {
+		jia.failed = true
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		jia.jobErrors[reason] = fmt.Sprintf("Job failed: [%s] %s", reason, message)
+	} else if failed, backoffLimit, exceeded := backoffLimitExceeded(job); exceeded && !jia.complete {
+		jia.failed = true
+		jia.jobErrors[jobBackoffLimitExceededReason] = fmt.Sprintf(
+			"Job failed: [%s] %d Pods failed, exceeding the backoff limit of %d",
+			jobBackoffLimitExceededReason, failed, backoffLimit)
+	}
+}
+
+// backoffLimitExceeded returns true if more Pods of `job` have failed than its `spec.backoffLimit`
+// allows, along with the number of failed Pods and the limit. Jobs with a per-index backoff limit
+// are left to the Job controller, which accounts for each index separately.
+func backoffLimitExceeded(
+	job *unstructured.Unstructured,
+) (failed, backoffLimit int64, exceeded bool) {
+	if _, perIndex := openapi.Pluck(job.Object, "spec", "backoffLimitPerIndex"); perIndex {
+		return 0, 0, false
+	}
+
+	backoffLimit = defaultJobBackoffLimit
+	if rawLimit, exists := openapi.Pluck(job.Object, "spec", "backoffLimit"); exists {
+		backoffLimit, _ = int64Value(rawLimit)
+	}
+	rawFailed, _ := openapi.Pluck(job.Object, "status", "failed")
+	failed, _ = int64Value(rawFailed)
+	return failed, backoffLimit, failed > backoffLimit
+}
+
+func (jia *jobInitAwaiter) processPodEvent(event watch.Event) {
+	pod, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Pod watch received unknown object type '%s'",
+			reflect.TypeOf(pod))
+		return
+	}
+
+	// Check whether this Pod was created by our Job.
+	if !isOwnedBy(pod, jia.config.currentInputs) {
+		return
+	}
+
+	// If Pod was deleted, remove it from our aggregated checkers, but remember its last state, in
+	// case the Job fails.
+	if event.Type == watch.Deleted {
+		delete(jia.pods, pod.GetName())
+		jia.deletedPods[pod.GetName()] = pod
+		return
+	}
+	delete(jia.deletedPods, pod.GetName())
+	jia.pods[pod.GetName()] = pod
+}
+
+// podErrors aggregates the errors of the Job's Pods (e.g., `ErrImagePull`, `CrashLoopBackOff`, or
+// a container that terminated with an `Error`), counting the Pods that failed for each reason.
+func (jia *jobInitAwaiter) podErrors() []string {
+	return aggregateJobPodErrors(podValues(jia.pods))
+}
+
+func aggregateJobPodErrors(pods []*unstructured.Unstructured) []string {
+	scheduleErrorCounts := map[string]int{}
+	containerErrorCounts := map[string]int{}
+	for _, pod := range pods {
+		checker := makePodChecker()
+		checker.check(pod)
+
+		for reason, message := range checker.podScheduledErrors {
+			message = fmt.Sprintf("[%s] %s", reason, message)
+			scheduleErrorCounts[message] = scheduleErrorCounts[message] + 1
+		}
+		for reason, messages := range checker.containerErrors {
+			// A Job's containers are expected to terminate; only report unsuccessful ones.
+			if reason == "Completed" {
+				continue
+			}
+			for _, message := range messages {
+				message = fmt.Sprintf("[%s] %s", reason, message)
+				containerErrorCounts[message] = containerErrorCounts[message] + 1
+			}
+		}
+	}
+
+	messages := []string{}
+	for message, count := range scheduleErrorCounts {
+		messages = append(messages,
+			fmt.Sprintf("%d Pods failed to schedule because: %s", count, message))
+	}
+	for message, count := range containerErrorCounts {
+		messages = append(messages, fmt.Sprintf("%d Pods failed to run because: %s", count, message))
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+func (jia *jobInitAwaiter) warn(message string) {
+	if jia.config.host != nil {
+		_ = jia.config.host.Log(jia.config.ctx, diag.Warning, jia.config.urn, message)
+	}
+}
+
+func (jia *jobInitAwaiter) errorMessages() []string {
+	messages := []string{}
+	for _, message := range jia.jobErrors {
+		messages = append(messages, message)
+	}
+	if jia.failed {
+		// The Pods that caused the failure may already have been deleted by the Job controller.
+		pods := append(podValues(jia.pods), podValues(jia.deletedPods)...)
+		return append(messages, aggregateJobPodErrors(pods)...)
+	}
+	return append(messages, jia.podErrors()...)
+}
+
+// jobPodListOptions returns list options that restrict a watch to the Pods of `job`. Unless the user
+// specified a selector themselves, the Job controller generates one when the Job is created, so it
+// isn't in the inputs, but the controller also labels each Pod with the name of its Job.
+func jobPodListOptions(job *unstructured.Unstructured) metav1.ListOptions {
+	if opts := selectorListOptions(job); opts.LabelSelector != "" {
+		return opts
+	}
+	return metav1.ListOptions{LabelSelector: labels.Set{"job-name": job.GetName()}.String()}
+}
+
+func (jia *jobInitAwaiter) makePodClient() (dynamic.ResourceInterface, error) {
+	podClient, err := client.FromGVK(jia.config.pool, jia.config.disco,
+		schema.GroupVersionKind{
+			Group:   "",
+			Version: "v1",
+			Kind:    "Pod",
+		}, jia.config.currentInputs.GetNamespace())
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"Could not make client to watch Pods associated with Job '%s'",
+			jia.config.currentInputs.GetName())
+	}
+	return podClient, nil
+}
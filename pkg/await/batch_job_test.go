@@ -0,0 +1,303 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const jobInputName = "migrate"
+
+func Test_Batch_Job(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(jobs, pods chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when the Job completes",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobRunning(inputNamespace, jobInputName))
+				pods <- watchAddedEvent(jobPod("migrate-8hz7q", completedContainer))
+				jobs <- watchAddedEvent(jobComplete(inputNamespace, jobInputName))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail when the Job fails",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobRunning(inputNamespace, jobInputName))
+				pods <- watchAddedEvent(jobPod("migrate-8hz7q", erroredContainer))
+				pods <- watchAddedEvent(jobPod("migrate-w2k4m", erroredContainer))
+				jobs <- watchAddedEvent(jobBackoffLimitExceeded(inputNamespace, jobInputName))
+			},
+			expectedError: &initializationError{
+				subErrors: []string{
+					"Job failed: [BackoffLimitExceeded] Job has reached the specified backoff limit",
+					"2 Pods failed to run because: [Error] Container completed with exit code 1",
+				},
+				object: jobBackoffLimitExceeded(inputNamespace, jobInputName),
+			},
+		},
+		{
+			description: "Should fail as soon as more Pods failed than the backoff limit allows",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobOnePodFailed(inputNamespace, jobInputName))
+				pods <- watchAddedEvent(jobPod("migrate-8hz7q", erroredContainer))
+				pods <- watchAddedEvent(jobPod("migrate-w2k4m", erroredContainer))
+				jobs <- watchAddedEvent(jobTwoPodsFailed(inputNamespace, jobInputName))
+			},
+			expectedError: &initializationError{
+				subErrors: []string{
+					"Job failed: [BackoffLimitExceeded] 2 Pods failed, exceeding the backoff limit of 1",
+					"2 Pods failed to run because: [Error] Container completed with exit code 1",
+				},
+				object: jobTwoPodsFailed(inputNamespace, jobInputName),
+			},
+		},
+		{
+			description: "Should report errors of Pods deleted when the Job fails",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobRunning(inputNamespace, jobInputName))
+				pods <- watchAddedEvent(jobPod("migrate-8hz7q", erroredContainer))
+				pods <- watch.Event{Type: watch.Deleted, Object: jobPod("migrate-8hz7q", erroredContainer)}
+				jobs <- watchAddedEvent(jobBackoffLimitExceeded(inputNamespace, jobInputName))
+			},
+			expectedError: &initializationError{
+				subErrors: []string{
+					"Job failed: [BackoffLimitExceeded] Job has reached the specified backoff limit",
+					"1 Pods failed to run because: [Error] Container completed with exit code 1",
+				},
+				object: jobBackoffLimitExceeded(inputNamespace, jobInputName),
+			},
+		},
+		{
+			description: "Should report image pull errors on timeout",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobRunning(inputNamespace, jobInputName))
+				pods <- watchAddedEvent(jobPod("migrate-8hz7q", imagePullContainer))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: jobInputName,
+				subErrors: []string{
+					"1 Pods failed to run because: [ErrImagePull] manifest for migrate:v2 not found",
+				}},
+		},
+		{
+			description: "Should ignore Pods of other Jobs",
+			do: func(jobs, pods chan watch.Event, timeout chan time.Time) {
+				jobs <- watchAddedEvent(jobRunning(inputNamespace, jobInputName))
+				other := jobPod("backup-2n9xw", imagePullContainer)
+				other.SetOwnerReferences(nil)
+				pods <- watchAddedEvent(other)
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{objectName: jobInputName, subErrors: []string{}},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeJobInitAwaiter(mockAwaitConfig(jobInput(inputNamespace, jobInputName)))
+		jobs := make(chan watch.Event)
+		pods := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go test.do(jobs, pods, timeout)
+
+		err := awaiter.await(&chanWatcher{results: jobs}, &chanWatcher{results: pods}, timeout, period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Batch_Job_Read(t *testing.T) {
+	awaiter := makeJobInitAwaiter(mockAwaitConfig(jobInput(inputNamespace, jobInputName)))
+	err := awaiter.read(jobRunning(inputNamespace, jobInputName), &unstructured.UnstructuredList{})
+	assert.Nil(t, err, "A running Job should not be an error")
+
+	awaiter = makeJobInitAwaiter(mockAwaitConfig(jobInput(inputNamespace, jobInputName)))
+	job := jobBackoffLimitExceeded(inputNamespace, jobInputName)
+	err = awaiter.read(job, &unstructured.UnstructuredList{})
+	assert.Equal(t, &initializationError{
+		subErrors: []string{"Job failed: [BackoffLimitExceeded] Job has reached the specified backoff limit"},
+		object:    job,
+	}, err)
+}
+
+func Test_Batch_Job_PodListOptions(t *testing.T) {
+	job := jobInput(inputNamespace, jobInputName)
+	assert.Equal(t, "job-name="+job.GetName(), jobPodListOptions(job).LabelSelector,
+		"Job without selector should be scoped to the Pods labeled with its name")
+
+	unstructured.SetNestedField(job.Object, map[string]interface{}{
+		"matchLabels": map[string]interface{}{"controller-uid": "1234"},
+	}, "spec", "selector")
+	assert.Equal(t, "controller-uid=1234", jobPodListOptions(job).LabelSelector,
+		"Job with selector should be scoped to it")
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+const (
+	completedContainer = `{
+		"name": "migrate", "ready": false, "restartCount": 0,
+		"state": {"terminated": {"exitCode": 0, "reason": "Completed"}}
+	}`
+	erroredContainer = `{
+		"name": "migrate", "ready": false, "restartCount": 0,
+		"state": {"terminated": {"exitCode": 1, "reason": "Error"}}
+	}`
+	imagePullContainer = `{
+		"name": "migrate", "ready": false, "restartCount": 0,
+		"state": {"waiting": {"reason": "ErrImagePull", "message": "manifest for migrate:v2 not found"}}
+	}`
+)
+
+func jobInput(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    }
+}`, name, namespace))
+}
+
+func jobRunning(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    },
+    "status": {
+        "conditions": []
+    }
+}`, name, namespace))
+}
+
+func jobComplete(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    },
+    "status": {
+        "conditions": [
+            {
+                "type": "Complete",
+                "status": "True"
+            }
+        ]
+    }
+}`, name, namespace))
+}
+
+func jobBackoffLimitExceeded(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    },
+    "status": {
+        "conditions": [
+            {
+                "type": "Failed",
+                "status": "True",
+                "reason": "BackoffLimitExceeded",
+                "message": "Job has reached the specified backoff limit"
+            }
+        ]
+    }
+}`, name, namespace))
+}
+
+func jobOnePodFailed(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    },
+    "status": {
+        "active": 1,
+        "failed": 1
+    }
+}`, name, namespace))
+}
+
+func jobTwoPodsFailed(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "batch/v1",
+    "kind": "Job",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backoffLimit": 1
+    },
+    "status": {
+        "active": 1,
+        "failed": 2
+    }
+}`, name, namespace))
+}
+
+func jobPod(name, containerStatus string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {
+			"name": "%s",
+			"namespace": "%s",
+			"labels": {"job-name": "%s"},
+			"ownerReferences": [{
+				"apiVersion": "batch/v1", "kind": "Job", "name": "%s",
+				"uid": "3f1c2e0a-c3b1-11e8-a6c1-025000000001", "controller": true
+			}]
+		},
+		"status": {
+			"phase": "Failed",
+			"conditions": [{"type": "PodScheduled", "status": "True"}],
+			"containerStatuses": [%s]
+		}
+	}`, name, inputNamespace, jobInputName, jobInputName, containerStatus))
+}
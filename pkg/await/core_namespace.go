@@ -0,0 +1,110 @@
+package await
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi-kubernetes/pkg/watcher"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for core/v1/Namespace.
+//
+// Deleting a Namespace only marks it as `Terminating`. The namespace controller then deletes every
+// object in it, and the Namespace itself is removed only once all of them are gone, and the
+// `kubernetes` finalizer in `.spec.finalizers` has been removed. Until then, a Namespace of the
+// same name can't be created, so we wait for the Namespace to be gone entirely.
+//
+// If objects in the Namespace can't be deleted (most commonly, because they have finalizers whose
+// controller is no longer running, or because an aggregated API is unavailable), the namespace
+// controller reports this in `.status.conditions` (e.g., `NamespaceFinalizersRemaining`), and we
+// report those conditions as the reasons the Namespace is stuck.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/overview/working-with-objects/namespaces/
+//   * https://kubernetes.io/docs/tasks/administer-cluster/namespaces/#deleting-a-namespace
+
+// ------------------------------------------------------------------------------------------------
+
+const namespaceDeletionTimeout = 10 * time.Minute
+
+// namespaceDeletionConditions are the conditions the namespace controller sets to `True` when it
+// can't finish deleting a Namespace.
+var namespaceDeletionConditions = []string{
+	"NamespaceDeletionDiscoveryFailure",
+	"NamespaceDeletionGroupVersionParsingFailure",
+	"NamespaceDeletionContentFailure",
+	"NamespaceContentRemaining",
+	"NamespaceFinalizersRemaining",
+}
+
+// namespaceTerminationErrors returns a message for each reason a terminating Namespace has not
+// been removed yet.
+func namespaceTerminationErrors(ns *unstructured.Unstructured) []string {
+	rawConditions, _ := openapi.Pluck(ns.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+
+	messages := []string{}
+	for _, conditionType := range namespaceDeletionConditions {
+		condition, found := findCondition(conditions, conditionType)
+		if !found || condition["status"] != trueStatus {
+			continue
+		}
+		reason, _ := condition["reason"].(string)
+		message, _ := condition["message"].(string)
+		messages = append(messages, fmt.Sprintf("[%s] %s", reason, message))
+	}
+	if len(messages) > 0 {
+		return messages
+	}
+
+	// Older clusters don't report conditions; the finalizers are the best we can do.
+	rawFinalizers, _ := openapi.Pluck(ns.Object, "spec", "finalizers")
+	finalizers, _ := rawFinalizers.([]interface{})
+	names := []string{}
+	for _, finalizer := range finalizers {
+		names = append(names, fmt.Sprintf("%v", finalizer))
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		return []string{fmt.Sprintf("Namespace is waiting on finalizers: %s", strings.Join(names, ", "))}
+	}
+
+	phase, _ := openapi.Pluck(ns.Object, "status", "phase")
+	return []string{fmt.Sprintf("Namespace still exists (phase: %v)", phase)}
+}
+
+func untilCoreV1NamespaceDeleted(c deleteAwaitConfig) error {
+	name := c.currentInputs.GetName()
+	var lastErrors []string
+	namespaceMissing := func(ns *unstructured.Unstructured, err error) error {
+		if is404(err) {
+			return nil
+		} else if err != nil {
+			glog.V(3).Infof("Received error deleting namespace '%s': %#v", name, err)
+			return err
+		}
+
+		lastErrors = namespaceTerminationErrors(ns)
+		glog.V(3).Infof("Namespace '%s' is still terminating: %v", name, lastErrors)
+		return watcher.RetryableError(fmt.Errorf("Namespace '%s' still exists: %s", name,
+			strings.Join(lastErrors, "; ")))
+	}
+
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(namespaceMissing, c.timeout(namespaceDeletionTimeout))
+	if err == nil || len(lastErrors) == 0 {
+		return err
+	}
+	if c.ctx.Err() != nil {
+		return &cancellationError{objectName: name, subErrors: lastErrors}
+	}
+	return &timeoutError{objectName: name, subErrors: lastErrors}
+}
@@ -0,0 +1,63 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_Core_Namespace_TerminationErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		namespace   *unstructured.Unstructured
+		errors      []string
+	}{
+		{
+			description: "Should report resources whose finalizers block termination",
+			namespace: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Namespace",
+				"metadata": {"name": "foo"},
+				"spec": {"finalizers": ["kubernetes"]},
+				"status": {"phase": "Terminating", "conditions": [
+					{"type": "NamespaceDeletionDiscoveryFailure", "status": "False", "reason": "ResourcesDiscovered"},
+					{"type": "NamespaceContentRemaining", "status": "True", "reason": "SomeResourcesRemain",
+					 "message": "Some resources are remaining: widgets.example.com has 1 resource instances"},
+					{"type": "NamespaceFinalizersRemaining", "status": "True", "reason": "SomeFinalizersRemain",
+					 "message": "Some content in the namespace has finalizers remaining: example.com/cleanup in 1 resource instances"}
+				]}
+			}`),
+			errors: []string{
+				"[SomeResourcesRemain] Some resources are remaining: widgets.example.com has 1 resource instances",
+				"[SomeFinalizersRemain] Some content in the namespace has finalizers remaining: " +
+					"example.com/cleanup in 1 resource instances",
+			},
+		},
+		{
+			description: "Should fall back to finalizers if no conditions are reported",
+			namespace: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Namespace",
+				"metadata": {"name": "foo"},
+				"spec": {"finalizers": ["kubernetes"]},
+				"status": {"phase": "Terminating"}
+			}`),
+			errors: []string{"Namespace is waiting on finalizers: kubernetes"},
+		},
+		{
+			description: "Should report the phase if nothing else is known",
+			namespace: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Namespace",
+				"metadata": {"name": "foo"},
+				"status": {"phase": "Terminating"}
+			}`),
+			errors: []string{"Namespace still exists (phase: Terminating)"},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.errors, namespaceTerminationErrors(test.namespace), test.description)
+	}
+}
@@ -8,10 +8,12 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
 	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
 	"github.com/pulumi/pulumi/pkg/diag"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 )
 
@@ -71,7 +73,10 @@ import (
 // time the success conditions described above a reached, we will terminate the awaiter.
 //
 // The opportunity to display intermediate results will typically appear after a container in the
-// Pod fails, (e.g., volume fails to mount, image fails to pull, exited with code 1, etc.).
+// Pod fails, (e.g., volume fails to mount, image fails to pull, exited with code 1, etc.). Since
+// we're awaiting a single Pod, we report these per container, with the details the user needs to
+// act on them: the image that could not be pulled, or how many times a crash-looping container has
+// been restarted.
 //
 //
 // x-refs:
@@ -97,6 +102,11 @@ type podChecker struct {
 	podReadyErrors     map[string]string
 	podSuccess         bool
 	containerErrors    map[string][]string
+	platformErrors     []string
+
+	// containerDiagnostics describe the failing containers individually, e.g., "Container 'nginx'
+	// could not pull image 'nginx:1.15': [ErrImagePull] ...".
+	containerDiagnostics []string
 }
 
 func makePodChecker() *podChecker {
@@ -139,6 +149,16 @@ func (pc *podChecker) check(pod *unstructured.Unstructured) {
 		glog.V(3).Infof("Pod '%s' has unknown status phase '%s'",
 			pod.GetName(), phase)
 	}
+
+	// Explain failures caused by an OS/architecture mismatch between the image and the node.
+	messages := []string{}
+	for _, message := range pc.podScheduledErrors {
+		messages = append(messages, message)
+	}
+	for _, errors := range pc.containerErrors {
+		messages = append(messages, errors...)
+	}
+	pc.platformErrors = platformDiagnostics(pod, messages)
 }
 
 func (pc *podChecker) checkPod(pod *unstructured.Unstructured, status map[string]interface{}) {
@@ -200,21 +220,23 @@ func (pc *podChecker) checkPod(pod *unstructured.Unstructured, status map[string
 		rawWaiting, isWaiting := openapi.Pluck(containerStatus, "state", "waiting")
 		waiting, isMap := rawWaiting.(map[string]interface{})
 		if isWaiting && rawWaiting != nil && isMap {
-			pc.checkWaitingContainer(name, waiting)
+			pc.checkWaitingContainer(name, containerStatus, waiting)
 		}
 
 		// Process container that's terminated.
 		rawTerminated, isTerminated := openapi.Pluck(containerStatus, "state", "terminated")
 		terminated, isMap := rawTerminated.(map[string]interface{})
 		if isTerminated && rawTerminated != nil && isMap {
-			pc.checkTerminatedContainer(name, terminated)
+			pc.checkTerminatedContainer(name, containerStatus, terminated)
 		}
 	}
 
 	// Exhausted our knowledge of possible error states for Pods. Return.
 }
 
-func (pc *podChecker) checkWaitingContainer(name string, waiting map[string]interface{}) {
+func (pc *podChecker) checkWaitingContainer(
+	name string, containerStatus, waiting map[string]interface{},
+) {
 	rawReason, hasReason := waiting["reason"]
 	reason, isString := rawReason.(string)
 	if !hasReason || !isString || reason == "" || reason == "ContainerCreating" {
@@ -233,9 +255,30 @@ func (pc *podChecker) checkWaitingContainer(name string, waiting map[string]inte
 	message = strings.TrimPrefix(message, imagePullJunk)
 
 	pc.containerErrors[reason] = append(pc.containerErrors[reason], message)
+
+	var diagnostic string
+	switch reason {
+	case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull":
+		image, _ := containerStatus["image"].(string)
+		diagnostic = fmt.Sprintf("Container '%s' could not pull image '%s'", name, image)
+	case "CrashLoopBackOff":
+		restarts, _ := int64Value(containerStatus["restartCount"])
+		diagnostic = fmt.Sprintf("Container '%s' is crash looping (restarted %d times", name, restarts)
+		lastExitCode, exited := openapi.Pluck(containerStatus, "lastState", "terminated", "exitCode")
+		if exited {
+			diagnostic += fmt.Sprintf(", last exit code %v", lastExitCode)
+		}
+		diagnostic += ")"
+	default:
+		diagnostic = fmt.Sprintf("Container '%s' is waiting", name)
+	}
+	pc.containerDiagnostics = append(pc.containerDiagnostics,
+		fmt.Sprintf("%s: [%s] %s", diagnostic, reason, message))
 }
 
-func (pc *podChecker) checkTerminatedContainer(name string, terminated map[string]interface{}) {
+func (pc *podChecker) checkTerminatedContainer(
+	name string, containerStatus, terminated map[string]interface{},
+) {
 	rawReason, hasReason := terminated["reason"]
 	reason, isString := rawReason.(string)
 	if !hasReason || !isString || reason == "" {
@@ -249,14 +292,35 @@ func (pc *podChecker) checkTerminatedContainer(name string, terminated map[strin
 	}
 
 	pc.containerErrors[reason] = append(pc.containerErrors[reason], message)
+	pc.containerDiagnostics = append(pc.containerDiagnostics,
+		fmt.Sprintf("Container '%s' terminated: [%s] %s", name, reason, message))
 }
 
 func (pc *podChecker) clearErrors() {
 	pc.podScheduledErrors = map[string]string{}
 	pc.containerErrors = map[string][]string{}
+	pc.platformErrors = nil
+	pc.containerDiagnostics = nil
 }
 
 func (pc *podChecker) errorMessages() []string {
+	messages := pc.conditionMessages()
+	for reason, errors := range pc.containerErrors {
+		// Ignore non-useful status messages.
+		if reason == "ContainersNotReady" {
+			continue
+		}
+		for _, message := range errors {
+			messages = append(messages, fmt.Sprintf("[%s] %s", reason, message))
+		}
+	}
+
+	return append(messages, pc.platformErrors...)
+}
+
+// conditionMessages returns the errors reported in the Pod's conditions, e.g., the reason it could
+// not be scheduled.
+func (pc *podChecker) conditionMessages() []string {
 	messages := []string{}
 	for reason, message := range pc.podScheduledErrors {
 		messages = append(messages, fmt.Sprintf("Pod unscheduled: [%s] %s", reason, message))
@@ -270,15 +334,6 @@ func (pc *podChecker) errorMessages() []string {
 		messages = append(messages, fmt.Sprintf("Pod not ready: [%s] %s", reason, message))
 	}
 
-	for reason, errors := range pc.containerErrors {
-		// Ignore non-useful status messages.
-		if reason == "ContainersNotReady" {
-			continue
-		}
-		for _, message := range errors {
-			messages = append(messages, fmt.Sprintf("[%s] %s", reason, message))
-		}
-	}
 	return messages
 }
 
@@ -305,6 +360,7 @@ func errorFromCondition(errors map[string]string, condition map[string]interface
 type podInitAwaiter struct {
 	podChecker
 	config createAwaitConfig
+	pod    *unstructured.Unstructured
 }
 
 func makePodInitAwaiter(c createAwaitConfig) *podInitAwaiter {
@@ -319,14 +375,19 @@ func (pia *podInitAwaiter) Await() error {
 	// We succeed when `.status.phase` is set to "Running".
 	//
 
-	podWatcher, err := pia.config.clientForResource.Watch(metav1.ListOptions{})
+	podWatcher, err := watchWithReconnect(pia.config.clientForResource,
+		nameListOptions(pia.config.currentInputs.GetName()))
 	if err != nil {
 		return errors.Wrapf(err, "Couldn't set up watch for Pod object '%s'",
 			pia.config.currentInputs.GetName())
 	}
 	defer podWatcher.Stop()
 
-	return pia.await(podWatcher, time.After(5*time.Minute))
+	err = pia.await(podWatcher, client.ThrottledAfter(pia.config.timeout(5*time.Minute)))
+	if awaitFailed(err) && pia.pod != nil {
+		reportPodLogs(pia.config, []*unstructured.Unstructured{pia.pod})
+	}
+	return err
 }
 
 func (pia *podInitAwaiter) Read() error {
@@ -382,9 +443,12 @@ func (pia *podInitAwaiter) await(podWatcher watch.Interface, timeout <-chan time
 		case <-timeout:
 			return &timeoutError{
 				objectName: inputPodName,
-				subErrors:  pia.errorMessages(),
+				subErrors:  append(pia.errorMessages(), pia.platformEventMessages()...),
 			}
 		case event := <-podWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
 			pia.processPodEvent(event)
 		}
 	}
@@ -411,9 +475,67 @@ func (pia *podInitAwaiter) processPodEvent(event watch.Event) {
 		return
 	}
 
+	pia.pod = pod
 	pia.check(pod)
 }
 
+// platformEventMessages looks for OS/architecture mismatches reported in the Pod's warning events
+// (which are sometimes the only place they're reported), and describes the platforms of the
+// cluster's nodes if there are any.
+func (pia *podInitAwaiter) platformEventMessages() []string {
+	if pia.config.pool == nil {
+		return nil
+	}
+
+	clientForEvents, err := pia.config.eventClient()
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for Pod '%s': %v",
+			pia.config.currentInputs.GetName(), err)
+		return nil
+	}
+	warnings, err := getLastWarningsForObject(clientForEvents, pia.config.currentInputs.GetNamespace(),
+		pia.config.currentInputs.GetName(), "Pod", 10)
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for Pod '%s': %v",
+			pia.config.currentInputs.GetName(), err)
+		return nil
+	}
+
+	messages := []string{}
+	for _, warning := range warnings {
+		messages = append(messages, warning.Message)
+	}
+	diagnostics := []string{}
+	for _, diagnostic := range platformDiagnostics(pia.config.currentInputs, messages) {
+		if !containsString(pia.platformErrors, diagnostic) {
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	if len(diagnostics) == 0 && len(pia.platformErrors) == 0 {
+		return nil
+	}
+
+	// Describe the nodes, so the user can see which platforms are actually available.
+	nodeClient, err := client.FromGVK(pia.config.pool, pia.config.disco, schema.GroupVersionKind{
+		Group: "", Version: "v1", Kind: "Node",
+	}, "")
+	if err == nil {
+		if nodes, err := nodeClient.List(metav1.ListOptions{}); err == nil {
+			summary := nodePlatformSummary(nodes.(*unstructured.UnstructuredList).Items)
+			diagnostics = append(diagnostics, fmt.Sprintf("Cluster nodes: %s", summary))
+		}
+	}
+	return diagnostics
+}
+
+// errorMessages reports the errors of each of the Pod's containers individually. (Controllers that
+// manage many Pods instead aggregate the errors of `podChecker`, which are identical across Pods.)
+func (pia *podInitAwaiter) errorMessages() []string {
+	messages := pia.conditionMessages()
+	messages = append(messages, pia.containerDiagnostics...)
+	return append(messages, pia.platformErrors...)
+}
+
 func (pia *podInitAwaiter) succeeded() bool {
 	return pia.podReady || pia.podSuccess
 }
@@ -110,8 +110,8 @@ func Test_Core_Pod(t *testing.T) {
 				objectName: "foo-4setj4y6",
 				subErrors: []string{
 					"Pod not ready: [ContainersNotReady] containers with unready status: [nginx]",
-					"[ErrImagePull] repository dsjkdsjkljks not found: does not exist or no " +
-						"pull access",
+					"Container 'nginx' could not pull image 'dsjkdsjkljks': [ErrImagePull] repository " +
+						"dsjkdsjkljks not found: does not exist or no pull access",
 				},
 			},
 		},
@@ -127,7 +127,7 @@ func Test_Core_Pod(t *testing.T) {
 				objectName: "foo-4setj4y6",
 				subErrors: []string{
 					"Pod not ready: [ContainersNotReady] containers with unready status: [completer]",
-					"[RunContainerError] failed to start container " +
+					"Container 'completer' is waiting: [RunContainerError] failed to start container " +
 						"\"ce5652ee18060c0f58144968587f5c333cf97dad907c6743e1e95a63541aab72\": Error " +
 						"response from daemon: oci runtime error: container_linux.go:262: starting " +
 						"container process caused \"exec: \\\"echo foo\\\": executable file not " +
@@ -147,7 +147,7 @@ func Test_Core_Pod(t *testing.T) {
 				objectName: "foo-4setj4y6",
 				subErrors: []string{
 					"Pod not ready: [ContainersNotReady] containers with unready status: [completer]",
-					"[Completed] Container completed with exit code 0",
+					"Container 'completer' terminated: [Completed] Container completed with exit code 0",
 				},
 			},
 		},
@@ -210,7 +210,8 @@ func Test_Core_Pod_Read(t *testing.T) {
 			pod:         podErrImagePull,
 			expectedSubErrors: []string{
 				"Pod not ready: [ContainersNotReady] containers with unready status: [nginx]",
-				"[ErrImagePull] repository dsjkdsjkljks not found: does not exist or no pull access",
+				"Container 'nginx' could not pull image 'dsjkdsjkljks': [ErrImagePull] repository " +
+					"dsjkdsjkljks not found: does not exist or no pull access",
 			},
 		},
 		{
@@ -218,7 +219,17 @@ func Test_Core_Pod_Read(t *testing.T) {
 			pod:         podImagePullBackoff,
 			expectedSubErrors: []string{
 				"Pod not ready: [ContainersNotReady] containers with unready status: [nginx]",
-				"[ImagePullBackOff] Back-off pulling image \"dsjkdsjkljks\"",
+				"Container 'nginx' could not pull image 'dsjkdsjkljks': [ImagePullBackOff] Back-off " +
+					"pulling image \"dsjkdsjkljks\"",
+			},
+		},
+		{
+			description: "Read should fail if container is crash looping",
+			pod:         podCrashLoopBackOff,
+			expectedSubErrors: []string{
+				"Pod not ready: [ContainersNotReady] containers with unready status: [nginx]",
+				"Container 'nginx' is crash looping (restarted 4 times, last exit code 1): " +
+					"[CrashLoopBackOff] Back-off 1m20s restarting failed container=nginx",
 			},
 		},
 		{
@@ -230,7 +241,7 @@ func Test_Core_Pod_Read(t *testing.T) {
 			pod:         podTerminatedError,
 			expectedSubErrors: []string{
 				"Pod not ready: [ContainersNotReady] containers with unready status: [completer]",
-				"[RunContainerError] failed to start container " +
+				"Container 'completer' is waiting: [RunContainerError] failed to start container " +
 					"\"ce5652ee18060c0f58144968587f5c333cf97dad907c6743e1e95a63541aab72\": Error " +
 					"response from daemon: oci runtime error: container_linux.go:262: starting " +
 					"container process caused \"exec: \\\"echo foo\\\": executable file not " +
@@ -242,7 +253,7 @@ func Test_Core_Pod_Read(t *testing.T) {
 			pod:         podTerminatedSuccess,
 			expectedSubErrors: []string{
 				"Pod not ready: [ContainersNotReady] containers with unready status: [completer]",
-				"[Completed] Container completed with exit code 0",
+				"Container 'completer' terminated: [Completed] Container completed with exit code 0",
 			},
 		},
 		{
@@ -572,6 +583,56 @@ func podImagePullBackoff(namespace, name string) *unstructured.Unstructured {
 	return obj
 }
 
+func podCrashLoopBackOff(namespace, name string) *unstructured.Unstructured {
+	obj, err := decodeUnstructured(fmt.Sprintf(`{
+    "kind": "Pod",
+    "apiVersion": "v1",
+    "metadata": {
+        "namespace": "%s",
+        "name": "%s"
+    },
+    "spec": {
+        "containers": [
+            {"name": "nginx", "image": "nginx:1.15"}
+        ]
+    },
+    "status": {
+        "phase": "Running",
+        "conditions": [
+            {"type": "Initialized", "status": "True"},
+            {
+                "type": "Ready",
+                "status": "False",
+                "reason": "ContainersNotReady",
+                "message": "containers with unready status: [nginx]"
+            },
+            {"type": "PodScheduled", "status": "True"}
+        ],
+        "containerStatuses": [
+            {
+                "name": "nginx",
+                "state": {
+                    "waiting": {
+                        "reason": "CrashLoopBackOff",
+                        "message": "Back-off 1m20s restarting failed container=nginx"
+                    }
+                },
+                "lastState": {
+                    "terminated": {"exitCode": 1, "reason": "Error"}
+                },
+                "ready": false,
+                "restartCount": 4,
+                "image": "nginx:1.15"
+            }
+        ]
+    }
+}`, namespace, name))
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
 func podRunning(namespace, name string) *unstructured.Unstructured {
 	// nolint
 	obj, err := decodeUnstructured(fmt.Sprintf(`{
@@ -0,0 +1,274 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for core/v1/PersistentVolumeClaim.
+//
+// A PersistentVolumeClaim is usable once it is bound to a PersistentVolume, i.e., when
+// `.status.phase` is `Bound`. The volume is either pre-provisioned (and matched to the claim by the
+// PV controller), or dynamically provisioned by the claim's StorageClass.
+//
+// The exception is a claim whose StorageClass has `volumeBindingMode: WaitForFirstConsumer`. Such
+// claims intentionally stay `Pending` until a Pod that uses them is scheduled, so that the volume
+// can be provisioned in the Pod's topology domain. Waiting for these would deadlock a program that
+// creates the Pod after the claim, so we succeed immediately, with an informational note.
+//
+// A claim that can't be bound almost always says why in its warning events (e.g.,
+// `ProvisioningFailed` because a quota is exceeded, or `FailedBinding` because no PV matches), so
+// we periodically surface any new ones as warnings, and include them when we time out.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/storage/persistent-volumes/
+//   * https://kubernetes.io/docs/concepts/storage/storage-classes/#volume-binding-mode
+
+// ------------------------------------------------------------------------------------------------
+
+const (
+	waitForFirstConsumer = "WaitForFirstConsumer"
+
+	defaultStorageClassAnnotation     = "storageclass.kubernetes.io/is-default-class"
+	betaDefaultStorageClassAnnotation = "storageclass.beta.kubernetes.io/is-default-class"
+)
+
+type pvcInitAwaiter struct {
+	config    createAwaitConfig
+	phase     string
+	lost      bool
+	pvcEvents []string
+	warned    map[string]bool
+}
+
+func makePVCInitAwaiter(c createAwaitConfig) *pvcInitAwaiter {
+	return &pvcInitAwaiter{
+		config: c,
+		warned: map[string]bool{},
+	}
+}
+
+func untilCoreV1PersistentVolumeClaimBound(c createAwaitConfig) error {
+	return makePVCInitAwaiter(c).Await()
+}
+
+func untilCoreV1PersistentVolumeClaimBoundUpdated(u updateAwaitConfig) error {
+	return makePVCInitAwaiter(u.createAwaitConfig).Await()
+}
+
+func (pia *pvcInitAwaiter) Await() error {
+	// Claims of a `WaitForFirstConsumer` StorageClass are not bound until they're used, so there's
+	// nothing to wait for.
+	className, mode := pia.storageClassBindingMode()
+	if mode == waitForFirstConsumer {
+		if pia.config.host != nil {
+			_ = pia.config.host.Log(pia.config.ctx, diag.Info, pia.config.urn, fmt.Sprintf(
+				"PersistentVolumeClaim '%s' will be bound when its first consumer Pod is scheduled "+
+					"(StorageClass '%s' has volumeBindingMode %s)",
+				pia.config.currentInputs.GetName(), className, waitForFirstConsumer))
+		}
+		return nil
+	}
+
+	pvcWatcher, err := watchWithReconnect(pia.config.clientForResource,
+		nameListOptions(pia.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for PersistentVolumeClaim object '%s'",
+			pia.config.currentInputs.GetName())
+	}
+	defer pvcWatcher.Stop()
+
+	period := time.NewTicker(10 * time.Second)
+	defer period.Stop()
+
+	return pia.await(pvcWatcher, client.ThrottledAfter(pia.config.timeout(5*time.Minute)),
+		period.C)
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (pia *pvcInitAwaiter) await(
+	pvcWatcher watch.Interface, timeout, period <-chan time.Time,
+) error {
+	inputName := pia.config.currentInputs.GetName()
+	for {
+		if pia.phase == "Bound" {
+			return nil
+		}
+		if pia.lost {
+			return &initializationError{
+				subErrors: pia.errorMessages(),
+				object:    pia.config.currentInputs,
+			}
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-pia.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  pia.errorMessages(),
+			}
+		case <-timeout:
+			pia.pvcEvents = pia.eventWarnings()
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  pia.errorMessages(),
+			}
+		case <-period:
+			pia.pvcEvents = pia.eventWarnings()
+			for _, message := range pia.pvcEvents {
+				if !pia.warned[message] {
+					pia.warned[message] = true
+					pia.warn(message)
+				}
+			}
+		case event := <-pvcWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			pia.processPVCEvent(event)
+		}
+	}
+}
+
+func (pia *pvcInitAwaiter) processPVCEvent(event watch.Event) {
+	pvc, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("PersistentVolumeClaim watch received unknown object type '%s'",
+			reflect.TypeOf(pvc))
+		return
+	}
+
+	// Do nothing if this is not the claim we're waiting for.
+	if pvc.GetName() != pia.config.currentInputs.GetName() {
+		return
+	}
+
+	pia.phase = ""
+	if event.Type == watch.Deleted {
+		return
+	}
+
+	phase, _ := openapi.Pluck(pvc.Object, "status", "phase")
+	glog.V(3).Infof("Persistent volume claim %s status received: %#v", pvc.GetName(), phase)
+	pia.phase, _ = phase.(string)
+	pia.lost = pia.phase == "Lost"
+}
+
+// storageClassBindingMode returns the name and `volumeBindingMode` of the claim's StorageClass. If
+// the claim doesn't name a StorageClass, this is the cluster's default StorageClass, if any.
+// Failure to look up the StorageClass is not fatal: we simply wait for the claim to be bound.
+func (pia *pvcInitAwaiter) storageClassBindingMode() (className, mode string) {
+	if pia.config.pool == nil {
+		return "", ""
+	}
+
+	classClient, err := client.FromGVK(pia.config.pool, pia.config.disco, schema.GroupVersionKind{
+		Group:   "storage.k8s.io",
+		Version: "v1",
+		Kind:    "StorageClass",
+	}, "")
+	if err != nil {
+		glog.V(3).Infof("Could not make client to retrieve StorageClasses: %v", err)
+		return "", ""
+	}
+	classes, err := classClient.List(metav1.ListOptions{})
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve StorageClasses: %v", err)
+		return "", ""
+	}
+
+	return volumeBindingMode(pia.config.currentInputs, classes.(*unstructured.UnstructuredList))
+}
+
+// volumeBindingMode returns the name and `volumeBindingMode` of the StorageClass that will provision
+// `pvc`, among `classes`.
+func volumeBindingMode(
+	pvc *unstructured.Unstructured, classes *unstructured.UnstructuredList,
+) (className, mode string) {
+	rawClassName, hasClassName := openapi.Pluck(pvc.Object, "spec", "storageClassName")
+	className, _ = rawClassName.(string)
+	if hasClassName && className == "" {
+		// An explicitly empty class means "no dynamic provisioning", not "the default class".
+		return "", ""
+	}
+	if !hasClassName {
+		// The beta annotation takes precedence over the field, as in the API server.
+		className = pvc.GetAnnotations()["volume.beta.kubernetes.io/storage-class"]
+	}
+
+	for _, class := range classes.Items {
+		annotations := class.GetAnnotations()
+		isDefault := annotations[defaultStorageClassAnnotation] == "true" ||
+			annotations[betaDefaultStorageClassAnnotation] == "true"
+		if class.GetName() == className || (className == "" && isDefault) {
+			mode, _ := class.Object["volumeBindingMode"].(string)
+			return class.GetName(), mode
+		}
+	}
+	return className, ""
+}
+
+// eventWarnings returns the claim's recent warning events, e.g., `ProvisioningFailed`.
+func (pia *pvcInitAwaiter) eventWarnings() []string {
+	if pia.config.pool == nil {
+		return nil
+	}
+
+	name := pia.config.currentInputs.GetName()
+	clientForEvents, err := pia.config.eventClient()
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for PersistentVolumeClaim '%s': %v",
+			name, err)
+		return nil
+	}
+	warnings, err := getLastWarningsForObject(clientForEvents,
+		pia.config.currentInputs.GetNamespace(), name, "PersistentVolumeClaim", 5)
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for PersistentVolumeClaim '%s': %v",
+			name, err)
+		return nil
+	}
+
+	messages := []string{}
+	for _, warning := range warnings {
+		messages = append(messages, fmt.Sprintf("[%s] %s", warning.Reason, warning.Message))
+	}
+	return messages
+}
+
+func (pia *pvcInitAwaiter) warn(message string) {
+	if pia.config.host != nil {
+		_ = pia.config.host.Log(pia.config.ctx, diag.Warning, pia.config.urn, message)
+	}
+}
+
+func (pia *pvcInitAwaiter) errorMessages() []string {
+	messages := []string{}
+	if pia.lost {
+		messages = append(messages,
+			"PersistentVolumeClaim lost its PersistentVolume; the volume may have been deleted")
+	} else {
+		phase := pia.phase
+		if phase == "" {
+			phase = "Pending"
+		}
+		messages = append(messages,
+			fmt.Sprintf("PersistentVolumeClaim was not bound (phase: %s)", phase))
+	}
+	return append(messages, pia.pvcEvents...)
+}
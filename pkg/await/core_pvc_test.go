@@ -0,0 +1,157 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const pvcInputName = "data"
+
+func Test_Core_PersistentVolumeClaim(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(pvcs chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when claim is bound",
+			do: func(pvcs chan watch.Event, timeout chan time.Time) {
+				pvcs <- watchAddedEvent(pvcWithPhase(pvcInputName, "Pending"))
+				pvcs <- watchAddedEvent(pvcWithPhase(pvcInputName, "Bound"))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail if claim is never bound",
+			do: func(pvcs chan watch.Event, timeout chan time.Time) {
+				pvcs <- watchAddedEvent(pvcWithPhase(pvcInputName, "Pending"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: pvcInputName,
+				subErrors:  []string{"PersistentVolumeClaim was not bound (phase: Pending)"}},
+		},
+		{
+			description: "Should fail if claim is lost",
+			do: func(pvcs chan watch.Event, timeout chan time.Time) {
+				pvcs <- watchAddedEvent(pvcWithPhase(pvcInputName, "Lost"))
+			},
+			expectedError: &initializationError{
+				subErrors: []string{
+					"PersistentVolumeClaim lost its PersistentVolume; the volume may have been deleted"},
+				object: pvcWithPhase(pvcInputName, ""),
+			},
+		},
+		{
+			description: "Should ignore unrelated claims",
+			do: func(pvcs chan watch.Event, timeout chan time.Time) {
+				pvcs <- watchAddedEvent(pvcWithPhase("logs", "Bound"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: pvcInputName,
+				subErrors:  []string{"PersistentVolumeClaim was not bound (phase: Pending)"}},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makePVCInitAwaiter(mockAwaitConfig(pvcWithPhase(pvcInputName, "")))
+		pvcs := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		period := make(chan time.Time)
+		go test.do(pvcs, timeout)
+
+		err := awaiter.await(&chanWatcher{results: pvcs}, timeout, period)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Core_PersistentVolumeClaim_BindingMode(t *testing.T) {
+	classes := &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		*storageClass("standard", "Immediate", true),
+		*storageClass("local", "WaitForFirstConsumer", false),
+	}}
+
+	tests := []struct {
+		description string
+		spec        string
+		className   string
+		mode        string
+	}{
+		{
+			description: "Should use the named StorageClass",
+			spec:        `{"storageClassName": "local"}`,
+			className:   "local",
+			mode:        waitForFirstConsumer,
+		},
+		{
+			description: "Should use the default StorageClass if none is named",
+			spec:        `{}`,
+			className:   "standard",
+			mode:        "Immediate",
+		},
+		{
+			description: "Should not use the default StorageClass if class is explicitly empty",
+			spec:        `{"storageClassName": ""}`,
+		},
+		{
+			description: "Should report no binding mode for an unknown StorageClass",
+			spec:        `{"storageClassName": "fast"}`,
+			className:   "fast",
+		},
+	}
+
+	for _, test := range tests {
+		pvc := mustDecodeUnstructured(fmt.Sprintf(`{
+			"apiVersion": "v1",
+			"kind": "PersistentVolumeClaim",
+			"metadata": {"name": "%s"},
+			"spec": %s
+		}`, pvcInputName, test.spec))
+		className, mode := volumeBindingMode(pvc, classes)
+		assert.Equal(t, test.className, className, test.description)
+		assert.Equal(t, test.mode, mode, test.description)
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func pvcWithPhase(name, phase string) *unstructured.Unstructured {
+	status := "{}"
+	if phase != "" {
+		status = fmt.Sprintf(`{"phase": "%s"}`, phase)
+	}
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"kind": "PersistentVolumeClaim",
+		"metadata": {"name": "%s", "namespace": "%s"},
+		"spec": {"accessModes": ["ReadWriteOnce"], "resources": {"requests": {"storage": "1Gi"}}},
+		"status": %s
+	}`, name, inputNamespace, status))
+}
+
+func storageClass(name, mode string, isDefault bool) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "storage.k8s.io/v1",
+		"kind": "StorageClass",
+		"metadata": {"name": "%s", "annotations": {"%s": "%t"}},
+		"provisioner": "kubernetes.io/gce-pd",
+		"volumeBindingMode": "%s"
+	}`, name, defaultStorageClassAnnotation, isDefault, mode))
+}
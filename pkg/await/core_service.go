@@ -3,6 +3,7 @@ package await
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -16,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 )
 
 // ------------------------------------------------------------------------------------------------
@@ -34,17 +36,30 @@ import (
 //   - If the type is `ClusterIP`, the Service is directly addressable only from inside the
 //     cluster, so no public IP address will be allocated. An Endpoint object will still be created
 //     to specify to which Pods traffic on different ports should be directed.
+//   - If the Service is headless (i.e., `clusterIP` is `None`), it is typically the governing
+//     Service of a StatefulSet, and is routinely created before any of the Pods it targets exist.
+//     We do not wait for its Endpoints to target any Pods.
+//   - If the type is `ExternalName`, the Service is just a DNS alias (a `CNAME` record) for a host
+//     outside the cluster. It has no selector and never gets Endpoints, so it is ready as soon as
+//     it exists.
+//   - If the Service has no `selector`, its Endpoints are managed by hand (or by some other
+//     controller), and frequently don't exist yet when the Service is created, so we do not wait
+//     for them either.
 //
 // The design of this awaiter is fundamentally an event loop on five channels:
 //
 //   1. The Service channel, to which the Kubernetes API server will proactively push every change
 //      (additions, modifications, deletions) to any Service it knows about.
 //   2. The Endpoint channel, which is the same idea as the Service channel, except it gets updates
-//      to Endpoint objects.
+//      to the EndpointSlice objects of the Service (labeled `kubernetes.io/service-name`), or, on
+//      clusters that don't serve `discovery.k8s.io/v1`, to its Endpoints object. EndpointSlices are
+//      preferred because the Endpoints object is truncated for very large Services, and is not
+//      kept up to date on clusters where EndpointSlice mirroring is disabled.
 //   3. A timeout channel, which fires after some minutes.
 //   4. A cancellation channel, with which the user can signal cancellation (e.g., using SIGINT).
-//   5. A "settled" channel, which is meant to fire a few seconds after any update to an Endpoint
-//      object, so that we're sure they have time to "settle".
+//   5. A "settled" channel, which fires once the endpoints have gone a few seconds (10 by default;
+//      see `pulumi.com/endpointSettleSeconds`) without an update, so that we're sure they have had
+//      time to "settle". Every update resets the timer, so a burst of updates settles once.
 //
 // The `serviceInitAwaiter` will synchronously process events from the union of all these channels.
 // Any time the success conditions described above a reached, we will terminate the awaiter.
@@ -58,11 +73,41 @@ import (
 
 // ------------------------------------------------------------------------------------------------
 
+const (
+	endpointSliceServiceNameLabel = "kubernetes.io/service-name"
+	defaultEndpointSettlePeriod   = 10 * time.Second
+
+	// primedServiceConfirmPeriod is how long we wait for the resumed watch to deliver an event
+	// before we read the Service to confirm the state it was primed with.
+	primedServiceConfirmPeriod = 5 * time.Second
+)
+
+var endpointSliceGVK = schema.GroupVersionKind{
+	Group:   "discovery.k8s.io",
+	Version: "v1",
+	Kind:    "EndpointSlice",
+}
+
 type serviceInitAwaiter struct {
 	config           createAwaitConfig
 	serviceReady     bool
 	endpointsReady   bool
 	endpointsSettled bool
+
+	// endpointSlices records, for each EndpointSlice of the Service, whether it targets any Pods.
+	endpointSlices map[string]bool
+
+	// settle fires once the endpoints have stopped changing.
+	settle *settleTimer
+
+	// cache holds the state of the Service and its Endpoints observed by earlier awaiters.
+	cache *watchCache
+
+	// primed is set while the state of the Service comes only from the watch cache, i.e., until the
+	// resumed watch delivers an event, or a read confirms the state. Until then, we can't declare
+	// success. confirmPrimed fires when it's time to read the Service; it is nil in tests.
+	primed        bool
+	confirmPrimed <-chan time.Time
 }
 
 func makeServiceInitAwaiter(c createAwaitConfig) *serviceInitAwaiter {
@@ -71,13 +116,42 @@ func makeServiceInitAwaiter(c createAwaitConfig) *serviceInitAwaiter {
 		serviceReady:     false,
 		endpointsReady:   false,
 		endpointsSettled: false,
+		endpointSlices:   map[string]bool{},
+		settle:           newSettleTimer(endpointSettlePeriod(c)),
+		cache:            awaitCacheFor(c.disco),
 	}
 }
 
+// endpointSettlePeriod returns how long the endpoints of the Service must go without an update
+// before they are considered settled: 10 seconds, unless overridden with the
+// `pulumi.com/endpointSettleSeconds` annotation, or with the provider's `endpointSettleSeconds`
+// config.
+func endpointSettlePeriod(c createAwaitConfig) time.Duration {
+	period, exists, err := EndpointSettleSeconds(c.currentInputs)
+	if err != nil {
+		// NOTE: `Check` rejects invalid values, so this should never happen.
+		glog.V(3).Infof("Ignoring invalid endpoint settle period for '%s': %v",
+			c.currentInputs.GetName(), err)
+	} else if exists {
+		return period
+	}
+	if c.options.EndpointSettlePeriod > 0 {
+		return c.options.EndpointSettlePeriod
+	}
+	return defaultEndpointSettlePeriod
+}
+
 func awaitServiceInit(c createAwaitConfig) error {
 	return makeServiceInitAwaiter(c).Await()
 }
 
+// awaitServiceUpdate re-checks the success conditions of a Service after an update. These don't
+// depend on the previous version of the Service: e.g., a Service whose type changed from
+// `ClusterIP` to `LoadBalancer` must now be allocated an IP address.
+func awaitServiceUpdate(u updateAwaitConfig) error {
+	return makeServiceInitAwaiter(u.createAwaitConfig).Await()
+}
+
 func (sia *serviceInitAwaiter) Await() error {
 	//
 	// We succeed only when all of the following are true:
@@ -85,31 +159,52 @@ func (sia *serviceInitAwaiter) Await() error {
 	//   1. Service object exists.
 	//   2. Endpoint objects created. Each time we get an update, wait ~5-10 seconds
 	//      after update to wait for any stragglers.
-	//   3. The endpoints objects target some number of living objects.
+	//   3. The endpoints objects target some number of living objects (unless the Service is not
+	//      expected to target any Pods, e.g., because it is headless).
 	//   4. External IP address is allocated (if we're type `LoadBalancer`).
 	//
 
+	defer sia.settle.stop()
+
+	// If an earlier awaiter in this deployment already observed the Service and its Endpoints, start
+	// from that state and resume watching from there, rather than starting from scratch.
+	inputs := sia.config.currentInputs
+	cachedService, _ := sia.cache.lookup(inputs.GetAPIVersion(), inputs.GetKind(),
+		inputs.GetNamespace(), inputs.GetName())
+	if cachedService != nil {
+		sia.processServiceEvent(watchAddedEvent(cachedService))
+		sia.primed = true
+		sia.confirmPrimed = time.After(primedServiceConfirmPeriod)
+	}
+
 	// Create service watcher.
-	serviceWatcher, err := sia.config.clientForResource.Watch(metav1.ListOptions{})
+	serviceWatcher, err := sia.cache.watch(sia.config.clientForResource, inputs.GetName(), cachedService)
 	if err != nil {
 		return errors.Wrapf(err, "Could set up watch for Service object '%s'",
 			sia.config.currentInputs.GetName())
 	}
 	defer serviceWatcher.Stop()
 
-	// Create endpoint watcher.
-	endpointClient, err := client.FromGVK(sia.config.pool, sia.config.disco, schema.GroupVersionKind{
-		Group:   "",
-		Version: "v1",
-		Kind:    "Endpoints",
-	}, sia.config.currentInputs.GetNamespace())
-	if err != nil {
-		return errors.Wrapf(err,
-			"Could not make client to watch Endpoint object associated with Service '%s'",
-			sia.config.currentInputs.GetName())
-	}
+	// Create endpoint watcher, preferring EndpointSlices if the cluster serves them.
+	var endpointWatcher watch.Interface
+	if sliceClient, sliceErr := sia.endpointSliceClient(); sliceErr == nil {
+		endpointWatcher, err = watchWithReconnect(sliceClient, endpointSliceListOptions(inputs.GetName()))
+	} else {
+		cachedEndpoint, _ := sia.cache.lookup("v1", "Endpoints", inputs.GetNamespace(),
+			inputs.GetName())
+		if cachedEndpoint != nil {
+			sia.processEndpointEvent(watchAddedEvent(cachedEndpoint))
+		}
 
-	endpointWatcher, err := endpointClient.Watch(metav1.ListOptions{})
+		var endpointClient dynamic.ResourceInterface
+		endpointClient, err = sia.endpointsClient()
+		if err != nil {
+			return errors.Wrapf(err,
+				"Could not make client to watch Endpoint object associated with Service '%s'",
+				sia.config.currentInputs.GetName())
+		}
+		endpointWatcher, err = sia.cache.watch(endpointClient, inputs.GetName(), cachedEndpoint)
+	}
 	if err != nil {
 		return errors.Wrapf(err,
 			"Could not create watcher for Endpoint objects associated with Service '%s'",
@@ -117,7 +212,8 @@ func (sia *serviceInitAwaiter) Await() error {
 	}
 	defer endpointWatcher.Stop()
 
-	return sia.await(serviceWatcher, endpointWatcher, time.After(10*time.Minute), make(chan struct{}))
+	return sia.await(serviceWatcher, endpointWatcher,
+		client.ThrottledAfter(sia.config.timeout(10*time.Minute)), sia.settle.C())
 }
 
 func (sia *serviceInitAwaiter) Read() error {
@@ -136,19 +232,19 @@ func (sia *serviceInitAwaiter) Read() error {
 	// in a way that is useful to the user.
 	//
 
-	// Create endpoint watcher.
-	endpointClient, err := client.FromGVK(sia.config.pool, sia.config.disco, schema.GroupVersionKind{
-		Group:   "",
-		Version: "v1",
-		Kind:    "Endpoints",
-	}, sia.config.currentInputs.GetNamespace())
-	if err != nil {
-		return errors.Wrapf(err,
-			"Could not make client to list Endpoint object associated with Service '%s'",
-			sia.config.currentInputs.GetName())
+	// List endpoints, preferring EndpointSlices if the cluster serves them.
+	var endpointList runtime.Object
+	if sliceClient, sliceErr := sia.endpointSliceClient(); sliceErr == nil {
+		endpointList, err = sliceClient.List(endpointSliceListOptions(service.GetName()))
+	} else {
+		endpointClient, clientErr := sia.endpointsClient()
+		if clientErr != nil {
+			return errors.Wrapf(clientErr,
+				"Could not make client to list Endpoint object associated with Service '%s'",
+				sia.config.currentInputs.GetName())
+		}
+		endpointList, err = endpointClient.List(nameListOptions(service.GetName()))
 	}
-
-	endpointList, err := endpointClient.List(metav1.ListOptions{})
 	if err != nil {
 		glog.V(3).Infof("Error retrieving ReplicaSet list for Service '%s': %v",
 			service.GetName(), err)
@@ -163,10 +259,11 @@ func (sia *serviceInitAwaiter) read(
 ) error {
 	sia.processServiceEvent(watchAddedEvent(service))
 
+	defer sia.settle.stop()
+
 	var err error
-	settled := make(chan struct{})
 	err = endpoints.EachListItem(func(endpoint runtime.Object) error {
-		sia.processEndpointEvent(watchAddedEvent(endpoint.(*unstructured.Unstructured)), settled)
+		sia.processEndpointEvent(watchAddedEvent(endpoint.(*unstructured.Unstructured)))
 		return nil
 	})
 	if err != nil {
@@ -186,15 +283,16 @@ func (sia *serviceInitAwaiter) read(
 	}
 }
 
-// await is a helper companion to `Await` designed to make it easy to test this module.
+// await is a helper companion to `Await` designed to make it easy to test this module. `settled`
+// is the channel of `sia.settle`, or, in tests, a channel the test signals directly, so that the
+// test doesn't depend on timing.
 func (sia *serviceInitAwaiter) await(
-	serviceWatcher, endpointWatcher watch.Interface, timeout <-chan time.Time,
-	settled chan struct{},
+	serviceWatcher, endpointWatcher watch.Interface, timeout, settled <-chan time.Time,
 ) error {
 	inputServiceName := sia.config.currentInputs.GetName()
 	for {
-		// Check whether we've succeeded.
-		if sia.serviceReady && sia.endpointsSettled && sia.endpointsReady {
+		// Check whether we've succeeded. Primed state hasn't been confirmed to be current yet.
+		if !sia.primed && sia.succeeded() {
 			return nil
 		}
 
@@ -202,7 +300,7 @@ func (sia *serviceInitAwaiter) await(
 		select {
 		case <-sia.config.ctx.Done():
 			// On cancel, check one last time if the service is ready.
-			if sia.serviceReady && sia.endpointsReady {
+			if !sia.primed && sia.serviceReady && (sia.endpointsReady || !sia.shouldWaitForPods()) {
 				return nil
 			}
 			return &cancellationError{
@@ -211,7 +309,7 @@ func (sia *serviceInitAwaiter) await(
 			}
 		case <-timeout:
 			// On timeout, check one last time if the service is ready.
-			if sia.serviceReady && sia.endpointsReady {
+			if !sia.primed && sia.serviceReady && (sia.endpointsReady || !sia.shouldWaitForPods()) {
 				return nil
 			}
 			return &timeoutError{
@@ -233,10 +331,29 @@ func (sia *serviceInitAwaiter) await(
 				_ = sia.config.host.Log(sia.config.ctx, sev, sia.config.urn, message)
 			}
 			sia.endpointsSettled = true
+		case <-sia.confirmPrimed:
+			// The resumed watch has been quiet, likely because the Service hasn't changed since it
+			// was cached. Read it to confirm; if that fails, keep waiting for the watch.
+			sia.confirmPrimed = nil
+			service, err := sia.config.clientForResource.Get(inputServiceName, metav1.GetOptions{})
+			if err != nil {
+				glog.V(3).Infof("Could not read Service '%s' to confirm its cached state: %v",
+					inputServiceName, err)
+				continue
+			}
+			sia.processServiceEvent(watchAddedEvent(service))
+			sia.primed = false
 		case event := <-serviceWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
 			sia.processServiceEvent(event)
+			sia.primed = false
 		case event := <-endpointWatcher.ResultChan():
-			sia.processEndpointEvent(event, settled)
+			if err := watchError(event); err != nil {
+				return err
+			}
+			sia.processEndpointEvent(event)
 		}
 	}
 }
@@ -255,6 +372,7 @@ func (sia *serviceInitAwaiter) processServiceEvent(event watch.Event) {
 	if service.GetName() != inputServiceName {
 		return
 	}
+	sia.cache.observe(event)
 
 	// Start with a blank slate.
 	sia.serviceReady = false
@@ -266,20 +384,18 @@ func (sia *serviceInitAwaiter) processServiceEvent(event watch.Event) {
 
 	specType, _ := openapi.Pluck(sia.config.currentInputs.Object, "spec", "type")
 	if fmt.Sprintf("%v", specType) == string(v1.ServiceTypeLoadBalancer) {
-		// If it's type `LoadBalancer`, check whether an IP was allocated.
-		lbIngress, _ := openapi.Pluck(service.Object, "status", "loadBalancer", "ingress")
+		// If it's type `LoadBalancer`, check whether an IP address or hostname was allocated.
 		status, _ := openapi.Pluck(service.Object, "status")
-
 		glog.V(3).Infof("Received status for service '%s': %#v", inputServiceName, status)
-		ing, isSlice := lbIngress.([]interface{})
+		addresses := loadBalancerAddresses(service)
 
 		// Update status of service object so that we can check success.
-		sia.serviceReady = isSlice && len(ing) > 0
+		sia.serviceReady = len(addresses) > 0
 
 		if sia.serviceReady {
 			if sia.config.host != nil {
-				_ = sia.config.host.Log(sia.config.ctx, diag.Info, sia.config.urn,
-					"✅ Service has been allocated an IP")
+				_ = sia.config.host.Log(sia.config.ctx, diag.Info, sia.config.urn, fmt.Sprintf(
+					"✅ Service has been allocated an address: %s", strings.Join(addresses, ", ")))
 			}
 		}
 		glog.V(3).Infof("Waiting for service '%q' to assign IP/hostname for a load balancer",
@@ -290,7 +406,26 @@ func (sia *serviceInitAwaiter) processServiceEvent(event watch.Event) {
 	}
 }
 
-func (sia *serviceInitAwaiter) processEndpointEvent(event watch.Event, settledCh chan<- struct{}) {
+// loadBalancerAddresses returns the addresses allocated to the load balancer of `service`, as
+// reported in `.status.loadBalancer.ingress`. Depending on the cloud provider, an address is either
+// an IP address (e.g., GCP) or a hostname (e.g., an AWS ELB).
+func loadBalancerAddresses(service *unstructured.Unstructured) []string {
+	rawIngress, _ := openapi.Pluck(service.Object, "status", "loadBalancer", "ingress")
+	ingress, _ := rawIngress.([]interface{})
+
+	var addresses []string
+	for _, rawEntry := range ingress {
+		entry, _ := rawEntry.(map[string]interface{})
+		if ip, _ := entry["ip"].(string); ip != "" {
+			addresses = append(addresses, ip)
+		} else if hostname, _ := entry["hostname"].(string); hostname != "" {
+			addresses = append(addresses, hostname)
+		}
+	}
+	return addresses
+}
+
+func (sia *serviceInitAwaiter) processEndpointEvent(event watch.Event) {
 	inputServiceName := sia.config.currentInputs.GetName()
 
 	// Get endpoint object.
@@ -301,6 +436,11 @@ func (sia *serviceInitAwaiter) processEndpointEvent(event watch.Event, settledCh
 		return
 	}
 
+	if endpoint.GetKind() == endpointSliceGVK.Kind {
+		sia.processEndpointSliceEvent(event.Type, endpoint)
+		return
+	}
+
 	// Ignore if it's not one of the endpoint objects created by the service.
 	//
 	// NOTE: Because the client pool is per-namespace, the endpointName can be used as an
@@ -308,33 +448,170 @@ func (sia *serviceInitAwaiter) processEndpointEvent(event watch.Event, settledCh
 	if endpoint.GetName() != inputServiceName {
 		return
 	}
+	sia.cache.observe(event)
 
 	// Start over, prove that service is ready.
 	sia.endpointsReady = false
 
 	// Update status of endpoint objects so we can check success.
 	if event.Type == watch.Added || event.Type == watch.Modified {
-		subsets, hasTargets := openapi.Pluck(endpoint.Object, "subsets")
-		targets, targetsIsSlice := subsets.([]interface{})
-		endpointTargetsPod := hasTargets && targetsIsSlice && len(targets) > 0
-
-		sia.endpointsReady = endpointTargetsPod
+		sia.endpointsReady = endpointsTargetPods(endpoint, sia.publishesNotReadyAddresses())
 	} else if event.Type == watch.Deleted {
 		sia.endpointsReady = false
 	}
 
-	// Every time we get an update to one of our endpoints objects, give it a few seconds
-	// for them to settle.
+	sia.settleEndpoints()
+}
+
+func (sia *serviceInitAwaiter) processEndpointSliceEvent(
+	eventType watch.EventType, slice *unstructured.Unstructured,
+) {
+	// Ignore if it's not one of the EndpointSlices of the service.
+	if slice.GetLabels()[endpointSliceServiceNameLabel] != sia.config.currentInputs.GetName() {
+		return
+	}
+
+	if eventType == watch.Deleted {
+		delete(sia.endpointSlices, slice.GetName())
+	} else {
+		sia.endpointSlices[slice.GetName()] = endpointSliceTargetsPods(slice,
+			sia.publishesNotReadyAddresses())
+	}
+
+	// The Service targets Pods if any of its EndpointSlices does.
+	sia.endpointsReady = false
+	for _, targetsPods := range sia.endpointSlices {
+		sia.endpointsReady = sia.endpointsReady || targetsPods
+	}
+
+	sia.settleEndpoints()
+}
+
+// settleEndpoints marks the endpoints as unsettled after an update to them, and restarts the
+// settle timer.
+func (sia *serviceInitAwaiter) settleEndpoints() {
 	sia.endpointsSettled = false
-	go func() {
-		time.Sleep(10 * time.Second)
-		settledCh <- struct{}{}
-	}()
+	sia.settle.reset()
+}
+
+// settleTimer is a resettable timer that fires once `period` has passed since it was last reset.
+// Unlike starting a timer per update, a burst of updates makes it fire once, after the last of
+// them, and it never delivers a stale signal from an earlier update.
+//
+// NOTE: The timer is not safe for concurrent use: it must be reset from the goroutine that
+// receives from `C()`.
+type settleTimer struct {
+	period time.Duration
+	timer  *time.Timer
+}
+
+func newSettleTimer(period time.Duration) *settleTimer {
+	st := &settleTimer{period: period, timer: time.NewTimer(period)}
+	st.stop()
+	return st
+}
+
+// C returns the channel on which the timer fires.
+func (st *settleTimer) C() <-chan time.Time {
+	return st.timer.C
+}
+
+// reset restarts the timer, discarding any signal of an earlier period that was not yet received.
+func (st *settleTimer) reset() {
+	st.stop()
+	st.timer.Reset(st.period)
+}
+
+// stop stops the timer, discarding any signal that was not yet received.
+func (st *settleTimer) stop() {
+	if !st.timer.Stop() {
+		select {
+		case <-st.timer.C:
+		default:
+		}
+	}
+}
+
+// endpointSliceClient returns a client for EndpointSlices, or an error if the cluster doesn't
+// serve them, in which case the Endpoints object of the Service should be used instead.
+func (sia *serviceInitAwaiter) endpointSliceClient() (dynamic.ResourceInterface, error) {
+	sliceClient, err := client.FromGVK(sia.config.pool, sia.config.disco, endpointSliceGVK,
+		sia.config.currentInputs.GetNamespace())
+	if err != nil {
+		glog.V(3).Infof("EndpointSlices are not available, falling back to Endpoints: %v", err)
+	}
+	return sliceClient, err
+}
+
+func (sia *serviceInitAwaiter) endpointsClient() (dynamic.ResourceInterface, error) {
+	return client.FromGVK(sia.config.pool, sia.config.disco, schema.GroupVersionKind{
+		Group:   "",
+		Version: "v1",
+		Kind:    "Endpoints",
+	}, sia.config.currentInputs.GetNamespace())
+}
+
+// endpointSliceListOptions returns list options that restrict a watch to the EndpointSlices of the
+// Service named `serviceName`.
+func endpointSliceListOptions(serviceName string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", endpointSliceServiceNameLabel, serviceName),
+	}
+}
+
+// publishesNotReadyAddresses returns true if the Service sets `spec.publishNotReadyAddresses`,
+// i.e., it targets its Pods whether or not they are ready (e.g., the governing Service of a
+// StatefulSet, whose Pods must be able to find each other before they become ready).
+func (sia *serviceInitAwaiter) publishesNotReadyAddresses() bool {
+	publish, _ := openapi.Pluck(sia.config.currentInputs.Object, "spec", "publishNotReadyAddresses")
+	return publish == true
+}
+
+// endpointsTargetPods returns true if the Endpoints object `endpoint` has any ready address, or,
+// if `publishNotReady` is true, any address at all.
+func endpointsTargetPods(endpoint *unstructured.Unstructured, publishNotReady bool) bool {
+	fields := []string{"addresses"}
+	if publishNotReady {
+		fields = append(fields, "notReadyAddresses")
+	}
+
+	rawSubsets, _ := openapi.Pluck(endpoint.Object, "subsets")
+	subsets, _ := rawSubsets.([]interface{})
+	for _, rawSubset := range subsets {
+		subset, isMap := rawSubset.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		for _, field := range fields {
+			if addresses, _ := subset[field].([]interface{}); len(addresses) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// endpointSliceTargetsPods returns true if `slice` has any endpoint that is ready, or, if
+// `publishNotReady` is true, any endpoint at all. An endpoint whose readiness is unknown (i.e.,
+// `conditions.ready` is unset) is considered ready, as specified by the EndpointSlice API.
+func endpointSliceTargetsPods(slice *unstructured.Unstructured, publishNotReady bool) bool {
+	rawEndpoints, _ := openapi.Pluck(slice.Object, "endpoints")
+	endpoints, _ := rawEndpoints.([]interface{})
+	for _, rawEndpoint := range endpoints {
+		endpoint, isMap := rawEndpoint.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		if ready, _ := openapi.Pluck(endpoint, "conditions", "ready"); publishNotReady || ready != false {
+			return true
+		}
+	}
+	return false
 }
 
 func (sia *serviceInitAwaiter) errorMessages() []string {
 	messages := []string{}
-	if !sia.endpointsReady {
+	if !sia.endpointsReady && sia.shouldWaitForPods() {
 		messages = append(messages, "Service does not target any Pods")
 	}
 
@@ -346,22 +623,31 @@ func (sia *serviceInitAwaiter) errorMessages() []string {
 	return messages
 }
 
-func (sia *serviceInitAwaiter) collectWarningEvents() error {
-	clientForEvents, err := sia.config.eventClient()
-	if err != nil {
-		glog.V(3).Infof("Could not retrieve warning events for service '%s': %v",
-			sia.config.currentInputs.GetName(), err)
+// shouldWaitForPods returns false if the Service is not expected to target any Pods when it is
+// created, in which case we don't wait for its Endpoints.
+func (sia *serviceInitAwaiter) shouldWaitForPods() bool {
+	if sia.isHeadlessService() {
+		return false
 	}
-	lastWarnings, wErr := getLastWarningsForObject(clientForEvents,
-		sia.config.currentInputs.GetNamespace(),
-		sia.config.currentInputs.GetName(), "Service", 3)
-	if wErr != nil {
-		glog.V(3).Infof("Could not retrieve warning events for service '%s': %v",
-			sia.config.currentInputs.GetName(), wErr)
+
+	selector, _ := openapi.Pluck(sia.config.currentInputs.Object, "spec", "selector")
+	if selectorMap, isMap := selector.(map[string]interface{}); !isMap || len(selectorMap) == 0 {
+		return false
 	}
-	return fmt.Errorf("%s%s", err, stringifyEvents(lastWarnings))
+
+	specType, _ := openapi.Pluck(sia.config.currentInputs.Object, "spec", "type")
+	return fmt.Sprintf("%v", specType) != string(v1.ServiceTypeExternalName)
+}
+
+// isHeadlessService returns true if the Service has no cluster IP, i.e., `clusterIP` is `None`.
+func (sia *serviceInitAwaiter) isHeadlessService() bool {
+	clusterIP, _ := openapi.Pluck(sia.config.currentInputs.Object, "spec", "clusterIP")
+	return clusterIP == v1.ClusterIPNone
 }
 
 func (sia *serviceInitAwaiter) succeeded() bool {
+	if !sia.shouldWaitForPods() {
+		return sia.serviceReady
+	}
 	return sia.serviceReady && sia.endpointsSettled && sia.endpointsReady
 }
@@ -13,23 +13,23 @@ import (
 func Test_Core_Service(t *testing.T) {
 	tests := []struct {
 		description   string
-		do            func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time)
+		do            func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time)
 		expectedError error
 	}{
 		{
 			description: "Should succeed when Service is allocated an IP address and Endpoints target a Pod",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// API server passes initialized service and endpoint objects back.
 				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
 				endpoints <- watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6"))
 
 				// Mark endpoint objects as having settled. Success.
-				settled <- struct{}{}
+				settled <- time.Now()
 			},
 		},
 		{
 			description: "Should succeed if Endpoints have settled when timeout occurs",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// API server passes initialized service back.
 				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
 
@@ -42,7 +42,7 @@ func Test_Core_Service(t *testing.T) {
 		},
 		{
 			description: "Should fail if unrelated Service succeeds",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				services <- watchAddedEvent(initializedService("default", "bar"))
 				endpoints <- watchAddedEvent(initializedEndpoint("default", "bar"))
 
@@ -55,26 +55,26 @@ func Test_Core_Service(t *testing.T) {
 		},
 		{
 			description: "Should succeed when unrelated Service fails",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
 				endpoints <- watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6"))
 
 				// Unrelated Service should fail because it does not have an Endpoint.
 				services <- watchAddedEvent(initializedService("default", "bar"))
 
-				settled <- struct{}{}
+				settled <- time.Now()
 				timeout <- time.Now()
 			},
 		},
 		{
 			description: "Should report success immediately even if the next event is a failure",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// API server passes initialized service and endpoint objects back.
 				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
 				endpoints <- watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6"))
 
 				// Mark endpoint objects as having settled. Success.
-				settled <- struct{}{}
+				settled <- time.Now()
 
 				endpoints <- watchAddedEvent(
 					uninitializedEndpoint("default", "foo-4setj4y6"))
@@ -82,7 +82,7 @@ func Test_Core_Service(t *testing.T) {
 		},
 		{
 			description: "Should fail if neither the Service nor the Endpoints have initialized",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// Trigger timeout.
 				timeout <- time.Now()
 			},
@@ -94,14 +94,14 @@ func Test_Core_Service(t *testing.T) {
 		},
 		{
 			description: "Should fail if Endpoints have not initialized",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// API server passes initialized service back.
 				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
 
 				// Pass uninitialized endpoint objects. Mark them as settled.
 				endpoints <- watchAddedEvent(
 					uninitializedEndpoint("default", "foo-4setj4y6"))
-				settled <- struct{}{}
+				settled <- time.Now()
 
 				// Finally, time out.
 				timeout <- time.Now()
@@ -112,14 +112,14 @@ func Test_Core_Service(t *testing.T) {
 		},
 		{
 			description: "Should fail if Service is not allocated an IP address",
-			do: func(services, endpoints chan watch.Event, settled chan struct{}, timeout chan time.Time) {
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
 				// API server passes uninitialized service back.
 				services <- watchAddedEvent(serviceInput("default", "foo-4setj4y6"))
 
 				// Pass initialized endpoint objects. Mark them as settled.
 				endpoints <- watchAddedEvent(
 					initializedEndpoint("default", "foo-4setj4y6"))
-				settled <- struct{}{}
+				settled <- time.Now()
 
 				// Finally, time out.
 				timeout <- time.Now()
@@ -128,6 +128,42 @@ func Test_Core_Service(t *testing.T) {
 				objectName: "foo-4setj4y6",
 				subErrors:  []string{"Service was not allocated an IP address"}},
 		},
+		{
+			description: "Should succeed when an EndpointSlice of the Service targets a Pod",
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
+				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
+
+				// One slice has no ready endpoints, but the other does. Success.
+				endpoints <- watchAddedEvent(
+					endpointSlice("default", "foo-4setj4y6", "foo-4setj4y6-abcde", "false"))
+				endpoints <- watchAddedEvent(
+					endpointSlice("default", "foo-4setj4y6", "foo-4setj4y6-fghij", "true"))
+				settled <- time.Now()
+			},
+		},
+		{
+			description: "Should fail if no EndpointSlice of the Service targets a ready Pod",
+			do: func(services, endpoints chan watch.Event, settled chan time.Time, timeout chan time.Time) {
+				services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
+
+				// Ready slice of an unrelated Service is ignored, as is a deleted slice.
+				endpoints <- watchAddedEvent(
+					endpointSlice("default", "bar", "bar-abcde", "true"))
+				endpoints <- watchAddedEvent(
+					endpointSlice("default", "foo-4setj4y6", "foo-4setj4y6-abcde", "false"))
+				endpoints <- watchAddedEvent(
+					endpointSlice("default", "foo-4setj4y6", "foo-4setj4y6-fghij", "true"))
+				endpoints <- watch.Event{Type: watch.Deleted,
+					Object: endpointSlice("default", "foo-4setj4y6", "foo-4setj4y6-fghij", "true")}
+				settled <- time.Now()
+
+				// Finally, time out.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: "foo-4setj4y6",
+				subErrors:  []string{"Service does not target any Pods"}},
+		},
 	}
 
 	for _, test := range tests {
@@ -136,7 +172,7 @@ func Test_Core_Service(t *testing.T) {
 
 		services := make(chan watch.Event)
 		endpoints := make(chan watch.Event)
-		settled := make(chan struct{})
+		settled := make(chan time.Time)
 		timeout := make(chan time.Time)
 		go test.do(services, endpoints, settled, timeout)
 
@@ -146,6 +182,89 @@ func Test_Core_Service(t *testing.T) {
 	}
 }
 
+func Test_Core_Service_EndpointsTargetPods(t *testing.T) {
+	tests := []struct {
+		description     string
+		subsets         string
+		publishNotReady bool
+		expected        bool
+	}{
+		{
+			description: "Should not target Pods without subsets",
+			subsets:     `[]`,
+		},
+		{
+			description: "Should target ready Pods",
+			subsets:     `[{"addresses": [{"ip": "10.1.2.3"}]}]`,
+			expected:    true,
+		},
+		{
+			description: "Should not count Pods that are not ready",
+			subsets:     `[{"notReadyAddresses": [{"ip": "10.1.2.3"}]}]`,
+		},
+		{
+			description:     "Should count Pods that are not ready if the Service publishes them",
+			subsets:         `[{"notReadyAddresses": [{"ip": "10.1.2.3"}]}]`,
+			publishNotReady: true,
+			expected:        true,
+		},
+	}
+
+	for _, test := range tests {
+		endpoint := mustDecodeUnstructured(`{
+			"apiVersion": "v1",
+			"kind": "Endpoints",
+			"metadata": {"name": "foo"},
+			"subsets": ` + test.subsets + `
+		}`)
+		assert.Equal(t, test.expected, endpointsTargetPods(endpoint, test.publishNotReady),
+			test.description)
+
+		slice := endpointSlice("default", "foo", "foo-abcde", "false")
+		assert.Equal(t, test.publishNotReady, endpointSliceTargetsPods(slice, test.publishNotReady),
+			test.description)
+	}
+}
+
+func Test_Core_Service_LoadBalancerAddresses(t *testing.T) {
+	tests := []struct {
+		description string
+		ingress     string
+		expected    []string
+	}{
+		{
+			description: "Should report nothing if no address was allocated",
+			ingress:     `[]`,
+		},
+		{
+			description: "Should report allocated IP address",
+			ingress:     `[{"ip": "35.184.65.22"}]`,
+			expected:    []string{"35.184.65.22"},
+		},
+		{
+			description: "Should report allocated hostname",
+			ingress:     `[{"hostname": "a1b2c3.us-west-2.elb.amazonaws.com"}]`,
+			expected:    []string{"a1b2c3.us-west-2.elb.amazonaws.com"},
+		},
+		{
+			description: "Should ignore entries without an address",
+			ingress:     `[{}, {"ip": "35.184.65.22", "hostname": "lb.example.com"}]`,
+			expected:    []string{"35.184.65.22"},
+		},
+	}
+
+	for _, test := range tests {
+		service := mustDecodeUnstructured(`{
+			"apiVersion": "v1",
+			"kind": "Service",
+			"metadata": {"name": "foo"},
+			"spec": {"type": "LoadBalancer"},
+			"status": {"loadBalancer": {"ingress": ` + test.ingress + `}}
+		}`)
+		assert.Equal(t, test.expected, loadBalancerAddresses(service), test.description)
+	}
+}
+
 func Test_Core_Service_Read(t *testing.T) {
 	tests := []struct {
 		description       string
@@ -185,6 +304,141 @@ func Test_Core_Service_Read(t *testing.T) {
 	}
 }
 
+func Test_Core_Service_NoPods(t *testing.T) {
+	tests := []struct {
+		description string
+		service     *unstructured.Unstructured
+	}{
+		{
+			description: "Should succeed for headless Service that targets no Pods",
+			service: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Service",
+				"metadata": {"name": "foo-4setj4y6", "namespace": "default"},
+				"spec": {"clusterIP": "None", "selector": {"app": "foo"}, "type": "ClusterIP"}
+			}`),
+		},
+		{
+			description: "Should succeed for ExternalName Service",
+			service: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Service",
+				"metadata": {"name": "foo-4setj4y6", "namespace": "default"},
+				"spec": {"externalName": "my.database.example.com", "type": "ExternalName"}
+			}`),
+		},
+		{
+			description: "Should succeed for Service without a selector",
+			service: mustDecodeUnstructured(`{
+				"apiVersion": "v1",
+				"kind": "Service",
+				"metadata": {"name": "foo-4setj4y6", "namespace": "default"},
+				"spec": {"ports": [{"port": 5432, "protocol": "TCP"}], "type": "ClusterIP"}
+			}`),
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeServiceInitAwaiter(mockAwaitConfig(test.service))
+
+		services := make(chan watch.Event)
+		endpoints := make(chan watch.Event)
+		settled := make(chan time.Time)
+		timeout := make(chan time.Time)
+		go func() {
+			// No Endpoints event is needed to succeed.
+			services <- watchAddedEvent(test.service)
+		}()
+
+		err := awaiter.await(&chanWatcher{results: services}, &chanWatcher{results: endpoints},
+			timeout, settled)
+		assert.Nil(t, err, test.description)
+
+		err = awaiter.read(test.service,
+			unstructuredList(*uninitializedEndpoint("default", "foo-4setj4y6")))
+		assert.Nil(t, err, test.description)
+	}
+}
+
+func Test_Core_Service_PrimedState(t *testing.T) {
+	// An awaiter primed with the cached state of a ready Service doesn't succeed until the resumed
+	// watch confirms that state.
+	awaiter := makeServiceInitAwaiter(mockAwaitConfig(serviceInput("default", "foo-4setj4y6")))
+	awaiter.processServiceEvent(watchAddedEvent(initializedService("default", "foo-4setj4y6")))
+	awaiter.processEndpointEvent(watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6")))
+	awaiter.endpointsSettled = true
+	awaiter.primed = true
+
+	services := make(chan watch.Event)
+	endpoints := make(chan watch.Event)
+	timeout := make(chan time.Time)
+	go func() {
+		// The Service was replaced since it was cached, and the new one isn't ready yet.
+		services <- watchAddedEvent(serviceInput("default", "foo-4setj4y6"))
+		timeout <- time.Now()
+	}()
+	err := awaiter.await(&chanWatcher{results: services}, &chanWatcher{results: endpoints},
+		timeout, make(chan time.Time))
+	assert.Equal(t, &timeoutError{
+		objectName: "foo-4setj4y6",
+		subErrors:  []string{"Service was not allocated an IP address"},
+	}, err, "Should not succeed on primed state contradicted by the watch")
+
+	// Primed state is also not trusted on timeout.
+	awaiter = makeServiceInitAwaiter(mockAwaitConfig(serviceInput("default", "foo-4setj4y6")))
+	awaiter.processServiceEvent(watchAddedEvent(initializedService("default", "foo-4setj4y6")))
+	awaiter.processEndpointEvent(watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6")))
+	awaiter.primed = true
+	timeout = make(chan time.Time, 1)
+	timeout <- time.Now()
+	err = awaiter.await(&chanWatcher{results: services}, &chanWatcher{results: endpoints},
+		timeout, make(chan time.Time))
+	assert.IsType(t, &timeoutError{}, err, "Should not succeed on unconfirmed primed state")
+
+	// Once the watch delivers the Service, the awaiter succeeds.
+	awaiter = makeServiceInitAwaiter(mockAwaitConfig(serviceInput("default", "foo-4setj4y6")))
+	awaiter.processServiceEvent(watchAddedEvent(initializedService("default", "foo-4setj4y6")))
+	awaiter.processEndpointEvent(watchAddedEvent(initializedEndpoint("default", "foo-4setj4y6")))
+	awaiter.endpointsSettled = true
+	awaiter.primed = true
+	go func() {
+		services <- watchAddedEvent(initializedService("default", "foo-4setj4y6"))
+	}()
+	err = awaiter.await(&chanWatcher{results: services}, &chanWatcher{results: endpoints},
+		make(chan time.Time), make(chan time.Time))
+	assert.Nil(t, err, "Should succeed once the watch confirms the primed state")
+}
+
+func Test_Core_Service_EndpointSettlePeriod(t *testing.T) {
+	service := serviceInput("default", "foo-4setj4y6")
+	c := mockAwaitConfig(service)
+	assert.Equal(t, 10*time.Second, endpointSettlePeriod(c), "Default settle period")
+
+	c.options.EndpointSettlePeriod = 3 * time.Second
+	assert.Equal(t, 3*time.Second, endpointSettlePeriod(c), "Configured settle period")
+
+	service.SetAnnotations(map[string]string{AnnotationEndpointSettleSeconds: "0"})
+	assert.Equal(t, time.Duration(0), endpointSettlePeriod(c), "Annotation overrides config")
+}
+
+func Test_Core_Service_SettleTimer(t *testing.T) {
+	st := newSettleTimer(time.Millisecond)
+	st.reset()
+	<-st.C()
+
+	// A signal that is not received before the timer is reset is discarded.
+	st.reset()
+	time.Sleep(10 * time.Millisecond)
+	st.period = time.Hour
+	st.reset()
+	select {
+	case <-st.C():
+		assert.Fail(t, "Settle timer delivered a stale signal")
+	case <-time.After(10 * time.Millisecond):
+	}
+	st.stop()
+}
+
 // --------------------------------------------------------------------------
 
 // Utility constructs.
@@ -328,6 +582,41 @@ func initializedEndpoint(namespace, name string) *unstructured.Unstructured {
 	return obj
 }
 
+// endpointSlice returns an EndpointSlice of the Service `serviceName` with one endpoint, whose
+// `ready` condition is `ready` ("true", "false", or "null").
+func endpointSlice(namespace, serviceName, name, ready string) *unstructured.Unstructured {
+	obj, err := decodeUnstructured(
+		fmt.Sprintf(`{
+    "apiVersion": "discovery.k8s.io/v1",
+    "kind": "EndpointSlice",
+    "metadata": {
+        "labels": {
+            "kubernetes.io/service-name": "%s"
+        },
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "addressType": "IPv4",
+    "endpoints": [
+        {
+            "addresses": ["10.1.2.3"],
+            "conditions": {"ready": %s}
+        }
+    ],
+    "ports": [
+        {
+            "name": "https",
+            "port": 443,
+            "protocol": "TCP"
+        }
+    ]
+}`, serviceName, name, namespace, ready))
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
 func unstructuredList(us ...unstructured.Unstructured) *unstructured.UnstructuredList {
 	return &unstructured.UnstructuredList{Items: us}
 }
@@ -0,0 +1,117 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+func Test_Core_ServiceAccount(t *testing.T) {
+	tests := []struct {
+		description string
+		version     *version.Info
+		versionErr  error
+		waits       bool
+	}{
+		{
+			description: "Should wait for the token secret on Kubernetes 1.23",
+			version:     &version.Info{Major: "1", Minor: "23", GitVersion: "v1.23.6"},
+			waits:       true,
+		},
+		{
+			description: "Should not wait for a token secret on Kubernetes 1.24",
+			version:     &version.Info{Major: "1", Minor: "24", GitVersion: "v1.24.0"},
+			waits:       false,
+		},
+		{
+			description: "Should not wait for a token secret on Kubernetes 1.25",
+			version:     &version.Info{Major: "1", Minor: "25", GitVersion: "v1.25.2"},
+			waits:       false,
+		},
+		{
+			description: "Should wait for the token secret if the cluster version is unknown",
+			versionErr:  fmt.Errorf("the server could not find the requested resource"),
+			waits:       true,
+		},
+	}
+
+	for _, test := range tests {
+		serviceAccounts := &serviceAccountClient{
+			serviceAccount: serviceAccountWithTokenSecret(inputNamespace, serviceAccountInputName),
+		}
+		config := mockAwaitConfig(serviceAccountInput(inputNamespace, serviceAccountInputName))
+		config.disco = &fakeVersionClient{version: test.version, err: test.versionErr}
+		config.clientForResource = serviceAccounts
+
+		err := untilCoreV1ServiceAccountInitialized(config)
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.waits, serviceAccounts.gets > 0, test.description)
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+const serviceAccountInputName = "deployer"
+
+// fakeVersionClient is a discovery client that reports the version of the cluster as `version`, or
+// fails with `err`.
+type fakeVersionClient struct {
+	discovery.ServerResourcesInterface
+	version *version.Info
+	err     error
+}
+
+func (c *fakeVersionClient) ServerVersion() (*version.Info, error) {
+	return c.version, c.err
+}
+
+// serviceAccountClient is a client that serves `serviceAccount`, and counts how often it was read.
+type serviceAccountClient struct {
+	dynamic.ResourceInterface
+	serviceAccount *unstructured.Unstructured
+	gets           int
+}
+
+func (c *serviceAccountClient) Get(
+	name string, opts metav1.GetOptions,
+) (*unstructured.Unstructured, error) {
+	c.gets++
+	return c.serviceAccount, nil
+}
+
+func serviceAccountInput(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "v1",
+    "kind": "ServiceAccount",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    }
+}`, name, namespace))
+}
+
+func serviceAccountWithTokenSecret(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "v1",
+    "kind": "ServiceAccount",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "secrets": [
+        {
+            "name": "%s-token-9kd2x"
+        }
+    ]
+}`, name, namespace, name))
+}
@@ -0,0 +1,294 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/provider"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// --------------------------------------------------------------------------
+
+// CRD storage version migration.
+//
+// When an update to a CustomResourceDefinition removes a version from `spec.versions`, the API
+// server requires that no objects remain stored at that version, i.e., that the version is no
+// longer listed in `status.storedVersions`. Kubernetes does not migrate stored objects on its own,
+// so if the CRD is annotated with `pulumi.com/migrateStoredVersions: "true"`, we do it before
+// applying the update:
+//
+//   1. If the live CRD's storage version is not yet the new storage version, update the live CRD
+//      so that it is (keeping every existing version served).
+//   2. Rewrite every existing custom resource by reading it and writing it back unchanged, which
+//      causes the API server to re-encode it at the new storage version.
+//   3. Set `status.storedVersions` to contain only the new storage version.
+//
+// After this, the update removing the old versions is accepted.
+
+// --------------------------------------------------------------------------
+
+const maxMigrationConflictRetries = 3
+
+type crdMigration struct {
+	ctx   context.Context
+	host  *provider.HostClient
+	urn   resource.URN
+	pool  dynamic.ClientPool
+	disco discovery.CachedDiscoveryInterface
+	crds  dynamic.ResourceInterface
+}
+
+// isCustomResourceDefinition returns true if `obj` is a CustomResourceDefinition.
+func isCustomResourceDefinition(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition"
+}
+
+// crdVersionNames returns the names of the versions in a CRD's `spec.versions` (or its legacy
+// `spec.version` field).
+func crdVersionNames(crd *unstructured.Unstructured) []string {
+	var names []string
+	for _, version := range crdVersions(crd) {
+		if name, isString := version["name"].(string); isString {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		version, _ := openapi.Pluck(crd.Object, "spec", "version")
+		if version, isString := version.(string); isString {
+			names = append(names, version)
+		}
+	}
+	return names
+}
+
+// crdStorageVersion returns the name of the version marked `storage: true`.
+func crdStorageVersion(crd *unstructured.Unstructured) string {
+	for _, version := range crdVersions(crd) {
+		if version["storage"] == true {
+			name, _ := version["name"].(string)
+			return name
+		}
+	}
+	if names := crdVersionNames(crd); len(names) == 1 {
+		return names[0]
+	}
+	return ""
+}
+
+func crdVersions(crd *unstructured.Unstructured) []map[string]interface{} {
+	raw, _ := openapi.Pluck(crd.Object, "spec", "versions")
+	list, _ := raw.([]interface{})
+	var versions []map[string]interface{}
+	for _, item := range list {
+		if version, isMap := item.(map[string]interface{}); isMap {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// obsoleteStoredVersions returns the versions in the live CRD's `status.storedVersions` that the
+// submitted CRD no longer lists.
+func obsoleteStoredVersions(live, submitted *unstructured.Unstructured) []string {
+	keep := map[string]bool{}
+	for _, name := range crdVersionNames(submitted) {
+		keep[name] = true
+	}
+
+	raw, _ := openapi.Pluck(live.Object, "status", "storedVersions")
+	stored, _ := raw.([]interface{})
+	var obsolete []string
+	for _, version := range stored {
+		if name, isString := version.(string); isString && !keep[name] {
+			obsolete = append(obsolete, name)
+		}
+	}
+	return obsolete
+}
+
+// migrateStoredVersions migrates the stored custom resources of the `live` CRD, so that the
+// `submitted` version of the CRD can be applied. It does nothing if no stored versions are being
+// removed.
+func (m *crdMigration) migrateStoredVersions(live, submitted *unstructured.Unstructured) error {
+	obsolete := obsoleteStoredVersions(live, submitted)
+	if len(obsolete) == 0 {
+		return nil
+	}
+
+	storage := crdStorageVersion(submitted)
+	if storage == "" {
+		return fmt.Errorf("cannot migrate stored versions of CustomResourceDefinition '%s': the new "+
+			"definition does not specify a storage version", submitted.GetName())
+	}
+	m.logf(diag.Info, "Migrating stored objects of CustomResourceDefinition '%s' from %v to %s",
+		submitted.GetName(), obsolete, storage)
+
+	// 1. Make the new storage version the live storage version.
+	if crdStorageVersion(live) != storage {
+		interim, err := withStorageVersion(live, submitted, storage)
+		if err != nil {
+			return err
+		}
+		live, err = m.crds.Update(interim)
+		if err != nil {
+			return errors.Wrapf(err, "failed to set storage version of CustomResourceDefinition '%s' to %s",
+				submitted.GetName(), storage)
+		}
+		// The set of served versions may have changed.
+		m.disco.Invalidate()
+	}
+
+	// 2. Rewrite every custom resource at the new storage version.
+	group, _ := openapi.Pluck(submitted.Object, "spec", "group")
+	kind, _ := openapi.Pluck(submitted.Object, "spec", "names", "kind")
+	gvk := schema.GroupVersionKind{
+		Group: fmt.Sprintf("%v", group), Version: storage, Kind: fmt.Sprintf("%v", kind),
+	}
+	count, err := m.rewriteAll(gvk)
+	if err != nil {
+		return errors.Wrapf(err, "failed to migrate objects of kind '%s' to version %s", gvk.Kind, storage)
+	}
+
+	// 3. Prune the obsolete versions from `status.storedVersions`.
+	live.Object["status"].(map[string]interface{})["storedVersions"] = []interface{}{storage}
+	body, err := json.Marshal(live.Object)
+	if err != nil {
+		return err
+	}
+	err = m.disco.RESTClient().Put().
+		AbsPath("/apis", live.GetAPIVersion(), "customresourcedefinitions", live.GetName(), "status").
+		Body(body).
+		Do().
+		Error()
+	if err != nil {
+		return errors.Wrapf(err, "failed to update stored versions of CustomResourceDefinition '%s'",
+			submitted.GetName())
+	}
+
+	m.logf(diag.Info, "✅ Migrated %d objects of kind '%s' to version %s", count, gvk.Kind, storage)
+	return nil
+}
+
+// rewriteAll reads every object of the given GVK (across all namespaces) and writes it back
+// unchanged, causing the API server to store it at the current storage version.
+func (m *crdMigration) rewriteAll(gvk schema.GroupVersionKind) (int, error) {
+	all, err := client.FromGVK(m.pool, m.disco, gvk, "")
+	if err != nil {
+		return 0, err
+	}
+	list, err := all.List(metav1.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	items := list.(*unstructured.UnstructuredList).Items
+	for i := range items {
+		if err := m.ctx.Err(); err != nil {
+			return i, err
+		}
+		if err := m.rewrite(gvk, &items[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(items), nil
+}
+
+func (m *crdMigration) rewrite(gvk schema.GroupVersionKind, obj *unstructured.Unstructured) error {
+	namespaced, err := client.FromGVK(m.pool, m.disco, gvk, obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		_, err = namespaced.Update(obj)
+		if err == nil || k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if !k8serrors.IsConflict(err) || attempt >= maxMigrationConflictRetries {
+			return err
+		}
+
+		// Someone else wrote the object concurrently (which also migrates it), but retry anyway
+		// to be sure.
+		glog.V(3).Infof("Conflict migrating '%s'; retrying", client.FqObjName(obj))
+		obj, err = namespaced.Get(obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (m *crdMigration) logf(sev diag.Severity, format string, args ...interface{}) {
+	glog.V(3).Infof(format, args...)
+	if m.host != nil {
+		_ = m.host.Log(m.ctx, sev, m.urn, fmt.Sprintf(format, args...))
+	}
+}
+
+// withStorageVersion returns a copy of the `live` CRD in which `storage` is the storage version.
+// If the live CRD does not yet have that version, its definition is taken from `submitted`.
+func withStorageVersion(live, submitted *unstructured.Unstructured, storage string) (
+	*unstructured.Unstructured, error,
+) {
+	interim := live.DeepCopy()
+
+	var versions []interface{}
+	found := false
+	for _, version := range crdVersions(interim) {
+		version["storage"] = version["name"] == storage
+		found = found || version["name"] == storage
+		versions = append(versions, version)
+	}
+	if !found {
+		for _, version := range crdVersions(submitted) {
+			if version["name"] == storage {
+				added := map[string]interface{}{}
+				for k, v := range version {
+					added[k] = v
+				}
+				added["served"] = true
+				added["storage"] = true
+				versions = append(versions, added)
+			}
+		}
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("CustomResourceDefinition '%s' has no version %s", live.GetName(), storage)
+	}
+
+	spec := interim.Object["spec"].(map[string]interface{})
+	spec["versions"] = versions
+	// The legacy `spec.version` field must match the first listed version, if set.
+	if _, hasVersion := spec["version"]; hasVersion {
+		spec["version"] = versions[0].(map[string]interface{})["name"]
+	}
+	return interim, nil
+}
@@ -0,0 +1,51 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func crdWithVersions(storage string, stored []interface{}, names ...string) *unstructured.Unstructured {
+	versions := []interface{}{}
+	for _, name := range names {
+		versions = append(versions, map[string]interface{}{
+			"name": name, "served": true, "storage": name == storage,
+		})
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "foos.example.com"},
+		"spec": map[string]interface{}{
+			"group":    "example.com",
+			"names":    map[string]interface{}{"kind": "Foo", "plural": "foos"},
+			"versions": versions,
+		},
+		"status": map[string]interface{}{"storedVersions": stored},
+	}}
+}
+
+func Test_CRD_ObsoleteStoredVersions(t *testing.T) {
+	live := crdWithVersions("v1alpha1", []interface{}{"v1alpha1"}, "v1alpha1", "v1")
+
+	assert.Empty(t, obsoleteStoredVersions(live, crdWithVersions("v1", nil, "v1alpha1", "v1")),
+		"Expected no migration when no versions are removed")
+	assert.Equal(t, []string{"v1alpha1"}, obsoleteStoredVersions(live, crdWithVersions("v1", nil, "v1")),
+		"Expected removed stored version to be obsolete")
+}
+
+func Test_CRD_WithStorageVersion(t *testing.T) {
+	live := crdWithVersions("v1alpha1", []interface{}{"v1alpha1"}, "v1alpha1")
+	submitted := crdWithVersions("v1", nil, "v1")
+
+	interim, err := withStorageVersion(live, submitted, "v1")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", crdStorageVersion(interim))
+	assert.Equal(t, []string{"v1alpha1", "v1"}, crdVersionNames(interim),
+		"Expected existing versions to remain served during migration")
+
+	// The live object must not be modified.
+	assert.Equal(t, "v1alpha1", crdStorageVersion(live))
+}
@@ -0,0 +1,163 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for the custom resources of well-known operators.
+//
+// The generic readiness logic (see `generic.go`) works for any custom resource that follows the
+// Kubernetes API conventions, but it can't know which condition a particular operator uses to
+// report that an object is ready, or which of its reasons mean that the operator has given up. For
+// example, a Knative `Service` whose `Ready` condition is `False` has failed for good, while a
+// cert-manager `Certificate` whose `Ready` condition is `False` is usually just being issued.
+//
+// So, custom resource kinds whose semantics we know register a `readinessHandler` in the
+// `readinessHandlers` table, keyed by `group/version/kind`. An object of such a kind is awaited
+// exactly as the generic logic does (i.e., on create, update, and read), except that its readiness
+// is computed by the registered handler. To support another operator, write a handler (most can
+// be expressed with `conditionReadiness`) and add an entry for each version of its kind.
+//
+// The built-in handlers are:
+//
+//   * cert-manager `Certificate`: ready when the `Ready` condition is `True`. A failed issuance
+//     (the `Issuing` condition is `False` with reason `Failed`) is reported as a failure, since
+//     cert-manager backs off for far longer than we wait before retrying it.
+//   * Istio `Gateway`: ready unless Istio's analyzers report an `ERROR`-level validation message,
+//     and, if status reporting is enabled, once the `Reconciled` condition is `True`.
+//   * Knative `Service`: ready when the `Ready` condition is `True`, and failed if it is `False`.
+//     (Knative reports `Unknown` while a revision is rolling out.)
+//
+// x-refs:
+//   * https://cert-manager.io/docs/concepts/certificate/
+//   * https://istio.io/latest/docs/reference/config/analysis/
+//   * https://github.com/knative/specs/blob/main/specs/serving/knative-api-specification-1.0.md
+
+// ------------------------------------------------------------------------------------------------
+
+// readinessHandler computes the readiness of an object of a kind whose semantics we know.
+type readinessHandler func(obj *unstructured.Unstructured) readiness
+
+var readinessHandlers = map[string]readinessHandler{
+	"cert-manager.io/v1/Certificate":          certManagerCertificateReadiness,
+	"cert-manager.io/v1alpha2/Certificate":    certManagerCertificateReadiness,
+	"cert-manager.io/v1alpha3/Certificate":    certManagerCertificateReadiness,
+	"cert-manager.io/v1beta1/Certificate":     certManagerCertificateReadiness,
+	"certmanager.k8s.io/v1alpha1/Certificate": certManagerCertificateReadiness,
+	"networking.istio.io/v1/Gateway":          istioGatewayReadiness,
+	"networking.istio.io/v1alpha3/Gateway":    istioGatewayReadiness,
+	"networking.istio.io/v1beta1/Gateway":     istioGatewayReadiness,
+	"serving.knative.dev/v1/Service":          knativeServiceReadiness,
+	"serving.knative.dev/v1alpha1/Service":    knativeServiceReadiness,
+	"serving.knative.dev/v1beta1/Service":     knativeServiceReadiness,
+}
+
+// customResourceAwaiter returns an await spec that awaits objects using `handler`.
+func customResourceAwaiter(handler readinessHandler) awaitSpec {
+	return awaitSpec{
+		awaitCreation: func(c createAwaitConfig) error {
+			return untilResourceReady(c, handler)
+		},
+		awaitUpdate: func(u updateAwaitConfig) error {
+			return untilResourceReady(u.createAwaitConfig, handler)
+		},
+		awaitRead: func(c createAwaitConfig) error {
+			return readResource(c, handler)
+		},
+	}
+}
+
+// conditionReadiness computes readiness from the condition of type `conditionType`. The object is
+// `InProgress` while it is being deleted, while its status is stale, and until the condition is
+// reported. If `falseIsFailure` is true, the condition being `False` means the object is `Failed`;
+// otherwise it is still `InProgress`.
+func conditionReadiness(
+	obj *unstructured.Unstructured, conditionType string, falseIsFailure bool,
+) readiness {
+	if obj.GetDeletionTimestamp() != nil {
+		return readiness{statusInProgress, "Resource is scheduled for deletion"}
+	}
+
+	status, _ := obj.Object["status"].(map[string]interface{})
+	if r, stale := staleReadiness(obj, status); stale {
+		return r
+	}
+
+	conditions, _ := status["conditions"].([]interface{})
+	condition, exists := findCondition(conditions, conditionType)
+	if !exists {
+		return readiness{statusInProgress,
+			fmt.Sprintf("Waiting for the controller to report the '%s' condition", conditionType)}
+	}
+	switch condition["status"] {
+	case trueStatus:
+		return readiness{statusCurrent, "Resource is ready"}
+	case "False":
+		if falseIsFailure {
+			return readiness{statusFailed, conditionMessage(condition, "Resource is not ready")}
+		}
+	}
+	return readiness{statusInProgress, conditionMessage(condition, "Resource is not ready")}
+}
+
+func certManagerCertificateReadiness(obj *unstructured.Unstructured) readiness {
+	rawConditions, _ := openapi.Pluck(obj.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+	if issuing, exists := findCondition(conditions, "Issuing"); exists &&
+		issuing["status"] == "False" && issuing["reason"] == "Failed" {
+		return readiness{statusFailed, conditionMessage(issuing, "Certificate issuance failed")}
+	}
+	return conditionReadiness(obj, "Ready", false)
+}
+
+func istioGatewayReadiness(obj *unstructured.Unstructured) readiness {
+	rawMessages, _ := openapi.Pluck(obj.Object, "status", "validationMessages")
+	messages, _ := rawMessages.([]interface{})
+	var validationErrors []string
+	for _, rawMessage := range messages {
+		message, _ := rawMessage.(map[string]interface{})
+		if message["level"] != "ERROR" {
+			continue
+		}
+		code, _ := openapi.Pluck(message, "type", "code")
+		name, _ := openapi.Pluck(message, "type", "name")
+		validationErrors = append(validationErrors, fmt.Sprintf("[%v] %v", code, name))
+	}
+	if len(validationErrors) > 0 {
+		return readiness{statusFailed, fmt.Sprintf("Gateway failed validation: %s",
+			strings.Join(validationErrors, "; "))}
+	}
+
+	// NOTE: Istio only reports the `Reconciled` condition if status reporting is enabled in the
+	// mesh, so we can't require it.
+	rawConditions, _ := openapi.Pluck(obj.Object, "status", "conditions")
+	conditions, _ := rawConditions.([]interface{})
+	if _, exists := findCondition(conditions, "Reconciled"); exists {
+		return conditionReadiness(obj, "Reconciled", false)
+	}
+	return computeReadiness(obj)
+}
+
+func knativeServiceReadiness(obj *unstructured.Unstructured) readiness {
+	return conditionReadiness(obj, "Ready", true)
+}
@@ -0,0 +1,110 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CustomResource_Readiness(t *testing.T) {
+	tests := []struct {
+		description string
+		object      string
+		expected    readinessStatus
+	}{
+		{
+			description: "Certificate without status should be in progress",
+			object: `{"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			          "metadata": {"name": "foo"}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Certificate being issued should be in progress",
+			object: `{"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			          "metadata": {"name": "foo"},
+			          "status": {"conditions": [
+			              {"type": "Ready", "status": "False", "reason": "DoesNotExist"},
+			              {"type": "Issuing", "status": "True"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Certificate whose issuance failed should fail",
+			object: `{"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			          "metadata": {"name": "foo"},
+			          "status": {"conditions": [
+			              {"type": "Ready", "status": "False", "reason": "DoesNotExist"},
+			              {"type": "Issuing", "status": "False", "reason": "Failed",
+			               "message": "The certificate request has failed to complete"}]}}`,
+			expected: statusFailed,
+		},
+		{
+			description: "Issued Certificate should be current",
+			object: `{"apiVersion": "cert-manager.io/v1", "kind": "Certificate",
+			          "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`,
+			expected: statusCurrent,
+		},
+		{
+			description: "Gateway without status should be current",
+			object: `{"apiVersion": "networking.istio.io/v1beta1", "kind": "Gateway",
+			          "metadata": {"name": "foo"}}`,
+			expected: statusCurrent,
+		},
+		{
+			description: "Gateway with validation errors should fail",
+			object: `{"apiVersion": "networking.istio.io/v1beta1", "kind": "Gateway",
+			          "metadata": {"name": "foo"},
+			          "status": {"validationMessages": [
+			              {"level": "ERROR", "type": {"code": "IST0101", "name": "ReferencedResourceNotFound"}}]}}`,
+			expected: statusFailed,
+		},
+		{
+			description: "Gateway that is not yet reconciled should be in progress",
+			object: `{"apiVersion": "networking.istio.io/v1beta1", "kind": "Gateway",
+			          "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Reconciled", "status": "False"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Knative Service rolling out should be in progress",
+			object: `{"apiVersion": "serving.knative.dev/v1", "kind": "Service",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 2,
+			                     "conditions": [{"type": "Ready", "status": "Unknown"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Knative Service with stale status should be in progress",
+			object: `{"apiVersion": "serving.knative.dev/v1", "kind": "Service",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 1,
+			                     "conditions": [{"type": "Ready", "status": "True"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Knative Service that is not ready should fail",
+			object: `{"apiVersion": "serving.knative.dev/v1", "kind": "Service",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 2, "conditions": [
+			              {"type": "Ready", "status": "False", "reason": "RevisionMissing"}]}}`,
+			expected: statusFailed,
+		},
+		{
+			description: "Ready Knative Service should be current",
+			object: `{"apiVersion": "serving.knative.dev/v1", "kind": "Service",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 2,
+			                     "conditions": [{"type": "Ready", "status": "True"}]}}`,
+			expected: statusCurrent,
+		},
+	}
+
+	for _, test := range tests {
+		obj, err := decodeUnstructured(test.object)
+		assert.NoError(t, err, test.description)
+
+		handler, exists := readinessHandlers[obj.GetAPIVersion()+"/"+obj.GetKind()]
+		assert.True(t, exists, test.description)
+		assert.Equal(t, test.expected, handler(obj).status, test.description)
+	}
+}
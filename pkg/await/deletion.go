@@ -0,0 +1,253 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Generic await logic for deleting objects of any kind.
+//
+// A successful `DELETE` only means the API server has accepted the request. If the object carries
+// finalizers, it is merely marked for deletion (`.metadata.deletionTimestamp` is set), and it is
+// removed only once every finalizer has been removed by its controller. Since we delete with the
+// `Foreground` propagation policy by default, the garbage collector adds the `foregroundDeletion`
+// finalizer itself, and removes it only once every dependent (i.e., every object whose
+// `ownerReferences` point at the object being deleted) has been deleted. (Users can opt into the
+// `Background` or `Orphan` policies instead, with the `pulumi.com/deletionPropagationPolicy`
+// annotation or the provider's `deletionPropagationPolicy` config, in which case the object is
+// removed without waiting for its dependents.)
+//
+// So, to report that a delete succeeded, we watch the object until the API server reports it gone.
+// While we wait, we periodically look up the dependents of kinds whose dependents we know (e.g.,
+// the ReplicaSets of a Deployment, or the Pods of a ReplicaSet), so that if the delete gets stuck,
+// we can report what it's stuck on: the dependents that remain, or the finalizers whose
+// controllers have not removed them.
+//
+// Kinds that need more than this register a kind-specific `awaitDeletion` in the `awaiters` table,
+// which takes precedence over this logic.
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/
+//   * https://kubernetes.io/docs/tasks/access-kubernetes-api/custom-resources/custom-resource-definitions/#finalizers
+
+// ------------------------------------------------------------------------------------------------
+
+const deletionTimeout = 10 * time.Minute
+
+// dependentKinds maps the kind of an object to the kinds of the dependents its controller creates.
+var dependentKinds = map[string][]schema.GroupVersionKind{
+	"CronJob":               {{Group: "batch", Version: "v1", Kind: "Job"}},
+	"DaemonSet":             {{Group: "", Version: "v1", Kind: "Pod"}},
+	"Deployment":            {{Group: "apps", Version: "v1", Kind: "ReplicaSet"}},
+	"Job":                   {{Group: "", Version: "v1", Kind: "Pod"}},
+	"ReplicaSet":            {{Group: "", Version: "v1", Kind: "Pod"}},
+	"ReplicationController": {{Group: "", Version: "v1", Kind: "Pod"}},
+	"StatefulSet":           {{Group: "", Version: "v1", Kind: "Pod"}},
+}
+
+type deleteAwaiter struct {
+	config  deleteAwaitConfig
+	object  *unstructured.Unstructured
+	deleted bool
+
+	// dependents maps the kind of each dependent that remains to the names of those dependents.
+	dependents map[string][]string
+}
+
+func makeDeleteAwaiter(c deleteAwaitConfig) *deleteAwaiter {
+	return &deleteAwaiter{
+		config:     c,
+		dependents: map[string][]string{},
+	}
+}
+
+func untilDeleted(c deleteAwaitConfig) error {
+	return makeDeleteAwaiter(c).Await()
+}
+
+func (da *deleteAwaiter) Await() error {
+	name := da.config.currentInputs.GetName()
+
+	// NOTE: Create the watcher before we check that the object exists, so that we can't miss the
+	// event that reports it gone.
+	objectWatcher, err := watchWithReconnect(da.config.clientForResource, nameListOptions(name))
+	if err != nil {
+		return errors.Wrapf(err, "Could not set up watch for object '%s'", name)
+	}
+	defer objectWatcher.Stop()
+
+	obj, err := da.config.clientForResource.Get(name, metav1.GetOptions{})
+	if is404(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	da.processObjectEvent(watchAddedEvent(obj))
+
+	period := time.NewTicker(10 * time.Second)
+	defer period.Stop()
+
+	return da.await(objectWatcher, client.ThrottledAfter(da.config.timeout(deletionTimeout)),
+		period.C)
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (da *deleteAwaiter) await(
+	objectWatcher watch.Interface, timeout, period <-chan time.Time,
+) error {
+	inputName := da.config.currentInputs.GetName()
+	for {
+		if da.deleted {
+			return nil
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-da.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  da.errorMessages(),
+			}
+		case <-timeout:
+			da.refreshDependents()
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  da.errorMessages(),
+			}
+		case <-period:
+			da.refreshDependents()
+		case event := <-objectWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			da.processObjectEvent(event)
+		}
+	}
+}
+
+func (da *deleteAwaiter) processObjectEvent(event watch.Event) {
+	obj, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Object watch received unknown object type '%s'", reflect.TypeOf(obj))
+		return
+	}
+
+	// Do nothing if this is not the object we're waiting for.
+	if obj.GetName() != da.config.currentInputs.GetName() {
+		return
+	}
+
+	if event.Type == watch.Deleted {
+		da.deleted = true
+		return
+	}
+	da.object = obj
+}
+
+// refreshDependents looks up the dependents of the object that remain, if we know which kinds its
+// controller creates. Failure to do so is not an error; we simply can't report them.
+func (da *deleteAwaiter) refreshDependents() {
+	if da.object == nil || da.config.pool == nil {
+		return
+	}
+
+	dependents := map[string][]string{}
+	for _, gvk := range dependentKinds[da.object.GetKind()] {
+		dependentClient, err := client.FromGVK(da.config.pool, da.config.disco, gvk,
+			da.object.GetNamespace())
+		if err != nil {
+			glog.V(3).Infof("Could not make client for dependents of '%s': %v", da.object.GetName(), err)
+			continue
+		}
+		list, err := dependentClient.List(metav1.ListOptions{})
+		if err != nil {
+			glog.V(3).Infof("Could not list dependents of '%s': %v", da.object.GetName(), err)
+			continue
+		}
+		for _, dependent := range list.(*unstructured.UnstructuredList).Items {
+			if ownedBy(&dependent, da.object.GetUID()) {
+				dependents[gvk.Kind] = append(dependents[gvk.Kind], dependent.GetName())
+			}
+		}
+	}
+	da.dependents = dependents
+}
+
+func (da *deleteAwaiter) errorMessages() []string {
+	if da.object == nil {
+		return []string{"Resource still exists"}
+	}
+
+	var messages []string
+	var finalizers []string
+	for _, finalizer := range da.object.GetFinalizers() {
+		if finalizer == metav1.FinalizerDeleteDependents {
+			messages = append(messages,
+				"Waiting for dependents to be deleted (foreground cascading deletion)")
+			continue
+		}
+		finalizers = append(finalizers, finalizer)
+	}
+
+	kinds := make([]string, 0, len(da.dependents))
+	for kind := range da.dependents {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		names := da.dependents[kind]
+		sort.Strings(names)
+		messages = append(messages, fmt.Sprintf("%d dependent %s object(s) remain: %s",
+			len(names), kind, strings.Join(names, ", ")))
+	}
+
+	if len(finalizers) > 0 {
+		messages = append(messages, fmt.Sprintf("Waiting on finalizers: %s",
+			strings.Join(finalizers, ", ")))
+	}
+	if len(messages) == 0 {
+		messages = append(messages, "Resource still exists")
+	}
+	return messages
+}
+
+// ownedBy returns true if `obj` has an owner reference to the object with UID `uid`.
+func ownedBy(obj *unstructured.Unstructured, uid types.UID) bool {
+	if uid == "" {
+		return false
+	}
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
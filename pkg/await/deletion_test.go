@@ -0,0 +1,115 @@
+package await
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func Test_Deletion(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(objects chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed once the object is reported deleted",
+			do: func(objects chan watch.Event, timeout chan time.Time) {
+				objects <- watch.Event{
+					Type: watch.Modified, Object: deletingObject("foo", "example.com/cleanup"),
+				}
+				objects <- watch.Event{Type: watch.Deleted, Object: deletingObject("foo")}
+			},
+		},
+		{
+			description: "Should ignore deletion of other objects",
+			do: func(objects chan watch.Event, timeout chan time.Time) {
+				objects <- watch.Event{Type: watch.Deleted, Object: deletingObject("bar")}
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: "foo",
+				subErrors:  []string{"Waiting on finalizers: example.com/cleanup"},
+			},
+		},
+		{
+			description: "Should report dependents blocking a foreground deletion",
+			do: func(objects chan watch.Event, timeout chan time.Time) {
+				objects <- watch.Event{
+					Type: watch.Modified, Object: deletingObject("foo", "foregroundDeletion"),
+				}
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: "foo",
+				subErrors: []string{
+					"Waiting for dependents to be deleted (foreground cascading deletion)",
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeDeleteAwaiter(deleteAwaitConfig{
+			createAwaitConfig: mockAwaitConfig(deletingObject("foo")),
+		})
+		awaiter.processObjectEvent(watchAddedEvent(deletingObject("foo", "example.com/cleanup")))
+
+		objects := make(chan watch.Event)
+		timeout := make(chan time.Time)
+		go test.do(objects, timeout)
+
+		err := awaiter.await(&chanWatcher{results: objects}, timeout, nil)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Deletion_ErrorMessages(t *testing.T) {
+	awaiter := makeDeleteAwaiter(deleteAwaitConfig{
+		createAwaitConfig: mockAwaitConfig(deletingObject("foo")),
+	})
+	assert.Equal(t, []string{"Resource still exists"}, awaiter.errorMessages())
+
+	awaiter.processObjectEvent(watchAddedEvent(deletingObject("foo", "foregroundDeletion", "a", "b")))
+	awaiter.dependents = map[string][]string{"Pod": {"foo-2", "foo-1"}}
+	assert.Equal(t, []string{
+		"Waiting for dependents to be deleted (foreground cascading deletion)",
+		"2 dependent Pod object(s) remain: foo-1, foo-2",
+		"Waiting on finalizers: a, b",
+	}, awaiter.errorMessages())
+}
+
+func Test_Deletion_OwnedBy(t *testing.T) {
+	obj := deletingObject("foo-1")
+	obj.Object["metadata"].(map[string]interface{})["ownerReferences"] = []interface{}{
+		map[string]interface{}{
+			"apiVersion": "apps/v1", "kind": "ReplicaSet", "name": "foo", "uid": "1234",
+		},
+	}
+	assert.True(t, ownedBy(obj, "1234"))
+	assert.False(t, ownedBy(obj, "5678"))
+	assert.False(t, ownedBy(obj, ""))
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func deletingObject(name string, finalizers ...string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "default",
+			"deletionTimestamp": "2018-06-11T17:38:19Z",
+		},
+	}}
+	obj.SetFinalizers(finalizers)
+	return obj
+}
@@ -3,19 +3,13 @@ package await
 import (
 	"fmt"
 
+	"github.com/pulumi/pulumi-kubernetes/pkg/failure"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-// AggregatedError represents an error with 0 or more sub-errors.
-type AggregatedError interface {
-	SubErrors() []string
-}
-
-// InitializationError represents an object that was successfully created, but which failed to be
-// initialized.
-type InitializationError interface {
-	Object() *unstructured.Unstructured
-}
+// The errors below are internal to the await logic. At the boundary of this package (e.g.,
+// `Creation` and `Update`), they are converted to the exported errors of package `failure` by
+// `exportError`, which adds the identity of the object and its recent warning events.
 
 // cancellationError represents an operation that failed because the user cancelled it.
 type cancellationError struct {
@@ -24,7 +18,6 @@ type cancellationError struct {
 }
 
 var _ error = (*cancellationError)(nil)
-var _ AggregatedError = (*cancellationError)(nil)
 
 func (ce *cancellationError) Error() string {
 	return fmt.Sprintf("Resource operation was cancelled for '%s'", ce.objectName)
@@ -42,7 +35,6 @@ type timeoutError struct {
 }
 
 var _ error = (*timeoutError)(nil)
-var _ AggregatedError = (*timeoutError)(nil)
 
 func (te *timeoutError) Error() string {
 	return fmt.Sprintf("Timeout occurred for '%s'", te.objectName)
@@ -60,8 +52,6 @@ type initializationError struct {
 }
 
 var _ error = (*initializationError)(nil)
-var _ AggregatedError = (*initializationError)(nil)
-var _ InitializationError = (*initializationError)(nil)
 
 func (ie *initializationError) Error() string {
 	return fmt.Sprintf("Resource '%s' was created but failed to initialize", ie.object.GetName())
@@ -75,3 +65,31 @@ func (ie *initializationError) SubErrors() []string {
 func (ie *initializationError) Object() *unstructured.Unstructured {
 	return ie.object
 }
+
+// exportError converts `err`, if it is one of the internal await errors, into the corresponding
+// error of package `failure`. Other errors are returned unchanged.
+func exportError(c createAwaitConfig, err error) error {
+	obj := c.currentInputs
+	details := failure.Details{
+		URN:       c.urn,
+		GVK:       obj.GroupVersionKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	switch e := err.(type) {
+	case *initializationError:
+		details.SubErrors = e.subErrors
+		details.Events = warningEventMessages(c)
+		return &failure.InitializationError{Details: details, Object: e.object}
+	case *timeoutError:
+		details.SubErrors = e.subErrors
+		details.Events = warningEventMessages(c)
+		return &failure.TimeoutError{Details: details}
+	case *cancellationError:
+		details.SubErrors = e.subErrors
+		return &failure.CancellationError{Details: details}
+	default:
+		return err
+	}
+}
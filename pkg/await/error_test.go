@@ -0,0 +1,62 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/failure"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_ExportError(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("apps/v1")
+	obj.SetKind("Deployment")
+	obj.SetNamespace("default")
+	obj.SetName("nginx")
+	c := mockAwaitConfig(obj)
+	c.urn = "urn:pulumi:dev::app::kubernetes:apps/v1:Deployment::nginx"
+
+	tests := []struct {
+		description string
+		err         error
+		cause       failure.Cause
+	}{
+		{
+			description: "Should export initialization errors",
+			err:         &initializationError{subErrors: []string{"Pod is not ready"}, object: obj},
+			cause:       failure.CauseInitialization,
+		},
+		{
+			description: "Should export timeout errors",
+			err:         &timeoutError{objectName: "nginx", subErrors: []string{"Pod is not ready"}},
+			cause:       failure.CauseTimeout,
+		},
+		{
+			description: "Should export cancellation errors",
+			err:         &cancellationError{objectName: "nginx", subErrors: []string{"Pod is not ready"}},
+			cause:       failure.CauseCancellation,
+		},
+	}
+
+	for _, test := range tests {
+		exported, ok := failure.As(exportError(c, test.err))
+		if assert.True(t, ok, test.description) {
+			assert.Equal(t, test.cause, exported.Cause(), test.description)
+			assert.Equal(t, test.err.Error(), exported.Error(), test.description)
+			assert.Equal(t, &failure.Details{
+				URN:       c.urn,
+				GVK:       schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace: "default",
+				Name:      "nginx",
+				SubErrors: []string{"Pod is not ready"},
+			}, exported.Failure(), test.description)
+		}
+	}
+
+	other := fmt.Errorf("some other error")
+	assert.Equal(t, other, exportError(c, other))
+	assert.Nil(t, exportError(c, nil))
+}
@@ -0,0 +1,216 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// --------------------------------------------------------------------------
+
+// Warning event correlation.
+//
+// When an await fails, the most useful explanation is usually a warning Event, and it is usually
+// not recorded against the object we were awaiting, but against one of the objects its controllers
+// created. A Deployment that never becomes ready, for example, rarely has warnings of its own; its
+// Pods, on the other hand, will report `FailedScheduling`, `FailedMount`, `Unhealthy`, and so on.
+//
+// So, when an await fails, we collect the recent warning Events of the object and of its related
+// objects (its dependents, found by following `ownerReferences` down from the object, and, for a
+// Service, the Pods it selects), and attach them to the error. Events recorded against dependents
+// are prefixed with the object they were recorded against, and identical warnings recorded against
+// several dependents (e.g., every replica of a Deployment failing to schedule) are reported once.
+//
+// Collecting events is best-effort: if it fails, we simply report fewer of them.
+
+// --------------------------------------------------------------------------
+
+const (
+	// maxExportedEvents is the number of recent warning events of the awaited object, and
+	// separately of its related objects, attached to exported errors.
+	maxExportedEvents = 5
+
+	// maxRelatedObjects is the number of related objects whose events we look up.
+	maxRelatedObjects = 10
+)
+
+var podGVK = schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Pod"}
+
+// eventSubject is an object whose warning events are reported, along with those events.
+type eventSubject struct {
+	kind   string
+	name   string
+	events []v1.Event
+}
+
+// warningEventMessages returns the messages of the most recent warning events for the object being
+// awaited and its related objects, or nil if they can't be retrieved.
+func warningEventMessages(c createAwaitConfig) []string {
+	if c.pool == nil {
+		return nil
+	}
+	obj := c.currentInputs
+	clientForEvents, err := c.eventClient()
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve warning events for '%s': %v", obj.GetName(), err)
+		return nil
+	}
+
+	subjects := append([]eventSubject{{kind: obj.GetKind(), name: obj.GetName()}},
+		relatedObjects(c)...)
+	for i := range subjects {
+		subject := &subjects[i]
+		subject.events, err = getLastWarningsForObject(
+			clientForEvents, obj.GetNamespace(), subject.name, subject.kind, maxExportedEvents)
+		if err != nil {
+			glog.V(3).Infof("Could not retrieve warning events for '%s': %v", subject.name, err)
+		}
+	}
+	return correlateWarningEvents(subjects)
+}
+
+// correlateWarningEvents renders the warning events of `subjects`, the first of which is the
+// awaited object, and the rest its related objects.
+func correlateWarningEvents(subjects []eventSubject) []string {
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, event := range subjects[0].events {
+		messages = append(messages, fmt.Sprintf("%s: %s", event.Reason, event.Message))
+	}
+
+	// Identical warnings of related objects are reported once, against the first object they were
+	// recorded against, along with the number of other objects that reported them.
+	type warning struct{ kind, reason, message string }
+	var order []warning
+	first := map[warning]string{}
+	others := map[warning]int{}
+	for _, subject := range subjects[1:] {
+		seen := map[warning]bool{}
+		for _, event := range subject.events {
+			w := warning{kind: subject.kind, reason: event.Reason, message: event.Message}
+			if seen[w] {
+				continue
+			}
+			seen[w] = true
+
+			if _, exists := first[w]; exists {
+				others[w]++
+				continue
+			}
+			first[w] = subject.name
+			order = append(order, w)
+		}
+	}
+
+	for i, w := range order {
+		if i == maxExportedEvents {
+			break
+		}
+		more := ""
+		if others[w] > 0 {
+			more = fmt.Sprintf(" (and %d more)", others[w])
+		}
+		messages = append(messages,
+			fmt.Sprintf("%s '%s'%s: %s: %s", w.kind, first[w], more, w.reason, w.message))
+	}
+	return messages
+}
+
+// relatedObjects returns the objects whose warnings might explain why the awaited object did not
+// become ready: its dependents (e.g., the ReplicaSets and Pods of a Deployment), or, for a
+// Service, the Pods it selects.
+func relatedObjects(c createAwaitConfig) []eventSubject {
+	if c.clientForResource == nil {
+		return nil
+	}
+	obj, err := c.clientForResource.Get(c.currentInputs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		glog.V(3).Infof("Could not retrieve '%s' to find its related objects: %v",
+			c.currentInputs.GetName(), err)
+		return nil
+	}
+
+	if obj.GetKind() == "Service" {
+		selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector")
+		if len(selector) == 0 {
+			return nil
+		}
+		opts := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(selector).String()}
+		var subjects []eventSubject
+		for _, pod := range listRelated(c, obj, podGVK, opts) {
+			subjects = append(subjects, eventSubject{kind: podGVK.Kind, name: pod.GetName()})
+		}
+		return capSubjects(subjects)
+	}
+
+	var subjects []eventSubject
+	owners := []*unstructured.Unstructured{obj}
+	for len(owners) > 0 && len(subjects) < maxRelatedObjects {
+		owner := owners[0]
+		owners = owners[1:]
+		for _, gvk := range dependentKinds[owner.GetKind()] {
+			for _, dependent := range listRelated(c, owner, gvk, selectorListOptions(owner)) {
+				if !ownedBy(dependent, owner.GetUID()) {
+					continue
+				}
+				subjects = append(subjects, eventSubject{kind: gvk.Kind, name: dependent.GetName()})
+				owners = append(owners, dependent)
+			}
+		}
+	}
+	return capSubjects(subjects)
+}
+
+// listRelated lists the objects of kind `gvk` in the namespace of `obj` that match `opts`.
+func listRelated(
+	c createAwaitConfig, obj *unstructured.Unstructured, gvk schema.GroupVersionKind,
+	opts metav1.ListOptions,
+) []*unstructured.Unstructured {
+	clientForRelated, err := client.FromGVK(c.pool, c.disco, gvk, obj.GetNamespace())
+	if err != nil {
+		glog.V(3).Infof("Could not make client for objects related to '%s': %v", obj.GetName(), err)
+		return nil
+	}
+	list, err := clientForRelated.List(opts)
+	if err != nil {
+		glog.V(3).Infof("Could not list objects related to '%s': %v", obj.GetName(), err)
+		return nil
+	}
+
+	var related []*unstructured.Unstructured
+	items := list.(*unstructured.UnstructuredList).Items
+	for i := range items {
+		related = append(related, &items[i])
+	}
+	return related
+}
+
+func capSubjects(subjects []eventSubject) []eventSubject {
+	if len(subjects) > maxRelatedObjects {
+		return subjects[:maxRelatedObjects]
+	}
+	return subjects
+}
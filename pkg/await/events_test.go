@@ -0,0 +1,72 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func Test_CorrelateWarningEvents(t *testing.T) {
+	tests := []struct {
+		description string
+		subjects    []eventSubject
+		expected    []string
+	}{
+		{
+			description: "Should report nothing if there are no warnings",
+			subjects: []eventSubject{
+				{kind: "Deployment", name: "web"},
+				{kind: "ReplicaSet", name: "web-5d4f"},
+			},
+		},
+		{
+			description: "Should report warnings of the object without a prefix",
+			subjects: []eventSubject{
+				{kind: "Service", name: "web", events: []v1.Event{
+					warningEvent("SyncLoadBalancerFailed", "Error creating load balancer")}},
+			},
+			expected: []string{"SyncLoadBalancerFailed: Error creating load balancer"},
+		},
+		{
+			description: "Should prefix warnings of related objects with the object",
+			subjects: []eventSubject{
+				{kind: "Deployment", name: "web", events: []v1.Event{
+					warningEvent("FailedCreate", "quota exceeded")}},
+				{kind: "ReplicaSet", name: "web-5d4f"},
+				{kind: "Pod", name: "web-5d4f-abcde", events: []v1.Event{
+					warningEvent("FailedMount", "secret \"tls\" not found"),
+					warningEvent("Unhealthy", "Readiness probe failed")}},
+			},
+			expected: []string{
+				"FailedCreate: quota exceeded",
+				"Pod 'web-5d4f-abcde': FailedMount: secret \"tls\" not found",
+				"Pod 'web-5d4f-abcde': Unhealthy: Readiness probe failed",
+			},
+		},
+		{
+			description: "Should report identical warnings of related objects once",
+			subjects: []eventSubject{
+				{kind: "Deployment", name: "web"},
+				{kind: "Pod", name: "web-5d4f-abcde", events: []v1.Event{
+					warningEvent("FailedScheduling", "0/3 nodes are available"),
+					warningEvent("FailedScheduling", "0/3 nodes are available")}},
+				{kind: "Pod", name: "web-5d4f-fghij", events: []v1.Event{
+					warningEvent("FailedScheduling", "0/3 nodes are available")}},
+				{kind: "Pod", name: "web-5d4f-klmno", events: []v1.Event{
+					warningEvent("FailedScheduling", "0/3 nodes are available")}},
+			},
+			expected: []string{
+				"Pod 'web-5d4f-abcde' (and 2 more): FailedScheduling: 0/3 nodes are available",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, correlateWarningEvents(test.subjects), test.description)
+	}
+}
+
+func warningEvent(reason, message string) v1.Event {
+	return v1.Event{Type: v1.EventTypeWarning, Reason: reason, Message: message}
+}
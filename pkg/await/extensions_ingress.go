@@ -0,0 +1,354 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for extensions/v1beta1/Ingress, networking.k8s.io/v1beta1/Ingress, and
+// networking.k8s.io/v1/Ingress.
+//
+// An Ingress is a set of rules that route external traffic to backend Services. It is only useful
+// once an ingress controller has programmed a load balancer for it, and the Services it routes to
+// exist. In the same spirit as the Service awaiter, we succeed only when both are true:
+//
+//   1. `.status.loadBalancer.ingress` is non-empty, i.e., the ingress controller has assigned an
+//      IP address or hostname.
+//   2. Every Service referenced by the Ingress (its default backend, and the backend of every path
+//      of every rule) exists in the Ingress's namespace.
+//
+// The event loop depends on the following channels:
+//
+//   1. The Ingress channel, to which the API server pushes every change to the Ingress.
+//   2. The Service channel, which tells us when referenced Services are created (or deleted).
+//   3. A timeout channel, which fires after some minutes.
+//   4. A cancellation channel, with which the user can signal cancellation (e.g., using SIGINT).
+//
+// On timeout, we report which backends (and which hosts/paths route to them) are unresolved, since
+// a typo in a Service name is the most common reason an Ingress never becomes healthy.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/services-networking/ingress/
+
+// ------------------------------------------------------------------------------------------------
+
+// ingressBackend is a reference from an Ingress path to a backend Service.
+type ingressBackend struct {
+	host        string
+	path        string
+	serviceName string
+}
+
+func (ib ingressBackend) String() string {
+	if ib.host == "" && ib.path == "" {
+		return "default backend"
+	}
+	host := ib.host
+	if host == "" {
+		host = "*"
+	}
+	return fmt.Sprintf("path '%s%s'", host, ib.path)
+}
+
+type ingressInitAwaiter struct {
+	config        createAwaitConfig
+	ingressReady  bool
+	backends      []ingressBackend
+	knownServices map[string]bool
+}
+
+func makeIngressInitAwaiter(c createAwaitConfig) *ingressInitAwaiter {
+	return &ingressInitAwaiter{
+		config:        c,
+		backends:      ingressBackends(c.currentInputs),
+		knownServices: map[string]bool{},
+	}
+}
+
+func awaitIngressInit(c createAwaitConfig) error {
+	return makeIngressInitAwaiter(c).Await()
+}
+
+func awaitIngressUpdate(u updateAwaitConfig) error {
+	return makeIngressInitAwaiter(u.createAwaitConfig).Await()
+}
+
+func awaitIngressRead(c createAwaitConfig) error {
+	return makeIngressInitAwaiter(c).Read()
+}
+
+func (iia *ingressInitAwaiter) Await() error {
+	serviceClient, err := iia.makeServiceClient()
+	if err != nil {
+		return err
+	}
+
+	// Create Ingress watcher.
+	ingressWatcher, err := watchWithReconnect(iia.config.clientForResource,
+		nameListOptions(iia.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for Ingress object '%s'",
+			iia.config.currentInputs.GetName())
+	}
+	defer ingressWatcher.Stop()
+
+	// Create Service watcher.
+	serviceWatcher, err := watchWithReconnect(serviceClient, metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err,
+			"Could not create watcher for Service objects associated with Ingress '%s'",
+			iia.config.currentInputs.GetName())
+	}
+	defer serviceWatcher.Stop()
+
+	return iia.await(ingressWatcher, serviceWatcher,
+		client.ThrottledAfter(iia.config.timeout(10*time.Minute)))
+}
+
+func (iia *ingressInitAwaiter) Read() error {
+	serviceClient, err := iia.makeServiceClient()
+	if err != nil {
+		return err
+	}
+
+	ingress, err := iia.config.clientForResource.Get(iia.config.currentInputs.GetName(),
+		metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the Ingress as having been deleted.
+		return err
+	}
+
+	serviceList, err := serviceClient.List(metav1.ListOptions{})
+	if err != nil {
+		glog.V(3).Infof("Error retrieving Service list for Ingress '%s': %v",
+			ingress.GetName(), err)
+		serviceList = &unstructured.UnstructuredList{Items: []unstructured.Unstructured{}}
+	}
+
+	return iia.read(ingress, serviceList.(*unstructured.UnstructuredList))
+}
+
+func (iia *ingressInitAwaiter) read(
+	ingress *unstructured.Unstructured, services *unstructured.UnstructuredList,
+) error {
+	iia.processIngressEvent(watchAddedEvent(ingress))
+
+	err := services.EachListItem(func(service runtime.Object) error {
+		iia.processServiceEvent(watchAddedEvent(service.(*unstructured.Unstructured)))
+		return nil
+	})
+	if err != nil {
+		glog.V(3).Infof("Error iterating over Service list for Ingress '%s': %v",
+			ingress.GetName(), err)
+	}
+
+	if iia.succeeded() {
+		return nil
+	}
+
+	return &initializationError{
+		subErrors: iia.errorMessages(),
+		object:    ingress,
+	}
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (iia *ingressInitAwaiter) await(
+	ingressWatcher, serviceWatcher watch.Interface, timeout <-chan time.Time,
+) error {
+	inputName := iia.config.currentInputs.GetName()
+	for {
+		if iia.succeeded() {
+			return nil
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-iia.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  iia.errorMessages(),
+			}
+		case <-timeout:
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  iia.errorMessages(),
+			}
+		case event := <-ingressWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			iia.processIngressEvent(event)
+		case event := <-serviceWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			iia.processServiceEvent(event)
+		}
+	}
+}
+
+func (iia *ingressInitAwaiter) succeeded() bool {
+	return iia.ingressReady && len(iia.unresolvedBackends()) == 0
+}
+
+func (iia *ingressInitAwaiter) processIngressEvent(event watch.Event) {
+	inputName := iia.config.currentInputs.GetName()
+
+	ingress, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Ingress watch received unknown object type '%s'",
+			reflect.TypeOf(ingress))
+		return
+	}
+
+	// Do nothing if this is not the Ingress we're waiting for.
+	if ingress.GetName() != inputName {
+		return
+	}
+
+	// Start with a blank slate.
+	iia.ingressReady = false
+
+	// Mark the Ingress as not ready if it's deleted.
+	if event.Type == watch.Deleted {
+		return
+	}
+
+	lbIngress, _ := openapi.Pluck(ingress.Object, "status", "loadBalancer", "ingress")
+	glog.V(3).Infof("Received load balancer status for Ingress '%s': %#v", inputName, lbIngress)
+	ing, isSlice := lbIngress.([]interface{})
+	iia.ingressReady = isSlice && len(ing) > 0
+
+	if iia.ingressReady && iia.config.host != nil {
+		_ = iia.config.host.Log(iia.config.ctx, diag.Info, iia.config.urn,
+			"✅ Ingress has been allocated an IP address or hostname")
+	}
+}
+
+func (iia *ingressInitAwaiter) processServiceEvent(event watch.Event) {
+	service, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("Service watch received unknown object type '%s'",
+			reflect.TypeOf(service))
+		return
+	}
+
+	// NOTE: Because the client is per-namespace, the Service name can be used as an ID.
+	iia.knownServices[service.GetName()] = event.Type != watch.Deleted
+}
+
+// unresolvedBackends returns the backends of the Ingress whose Services do not exist.
+func (iia *ingressInitAwaiter) unresolvedBackends() []ingressBackend {
+	unresolved := []ingressBackend{}
+	for _, backend := range iia.backends {
+		if !iia.knownServices[backend.serviceName] {
+			unresolved = append(unresolved, backend)
+		}
+	}
+	return unresolved
+}
+
+func (iia *ingressInitAwaiter) errorMessages() []string {
+	messages := []string{}
+	if !iia.ingressReady {
+		messages = append(messages, "Ingress was not allocated an IP address or hostname")
+	}
+
+	// Group the unresolved backends by Service, so each missing Service is reported once.
+	unresolved := map[string][]string{}
+	for _, backend := range iia.unresolvedBackends() {
+		unresolved[backend.serviceName] = append(unresolved[backend.serviceName], backend.String())
+	}
+	services := []string{}
+	for service := range unresolved {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	for _, service := range services {
+		messages = append(messages, fmt.Sprintf("Backend Service '%s' does not exist (referenced by %s)",
+			service, strings.Join(unresolved[service], ", ")))
+	}
+
+	return messages
+}
+
+func (iia *ingressInitAwaiter) makeServiceClient() (dynamic.ResourceInterface, error) {
+	serviceClient, err := client.FromGVK(iia.config.pool, iia.config.disco,
+		schema.GroupVersionKind{
+			Group:   "",
+			Version: "v1",
+			Kind:    "Service",
+		}, iia.config.currentInputs.GetNamespace())
+	if err != nil {
+		return nil, errors.Wrapf(err,
+			"Could not make client to watch Services associated with Ingress '%s'",
+			iia.config.currentInputs.GetName())
+	}
+	return serviceClient, nil
+}
+
+// ingressBackends returns every backend referenced by an Ingress. It understands both the
+// `serviceName` backends of extensions/v1beta1 and networking.k8s.io/v1beta1, and the
+// `service.name` backends (and `defaultBackend`) of networking.k8s.io/v1.
+func ingressBackends(ingress *unstructured.Unstructured) []ingressBackend {
+	backends := []ingressBackend{}
+	for _, field := range []string{"backend", "defaultBackend"} {
+		backend, _ := openapi.Pluck(ingress.Object, "spec", field)
+		if name := backendServiceName(backend); name != "" {
+			backends = append(backends, ingressBackend{serviceName: name})
+		}
+	}
+
+	rawRules, _ := openapi.Pluck(ingress.Object, "spec", "rules")
+	rules, _ := rawRules.([]interface{})
+	for _, rawRule := range rules {
+		rule, _ := rawRule.(map[string]interface{})
+		host, _ := rule["host"].(string)
+		rawPaths, _ := openapi.Pluck(rule, "http", "paths")
+		paths, _ := rawPaths.([]interface{})
+		for _, rawPath := range paths {
+			path, _ := rawPath.(map[string]interface{})
+			if name := backendServiceName(path["backend"]); name != "" {
+				pathValue, _ := path["path"].(string)
+				if pathValue == "" {
+					pathValue = "/"
+				}
+				backends = append(backends, ingressBackend{host: host, path: pathValue, serviceName: name})
+			}
+		}
+	}
+	return backends
+}
+
+func backendServiceName(rawBackend interface{}) string {
+	backend, isMap := rawBackend.(map[string]interface{})
+	if !isMap {
+		return ""
+	}
+	if name, isString := backend["serviceName"].(string); isString {
+		return name
+	}
+	name, _ := openapi.Pluck(backend, "service", "name")
+	nameString, _ := name.(string)
+	return nameString
+}
@@ -0,0 +1,258 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const ingressInputName = "frontend"
+
+func Test_Extensions_Ingress(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(ingresses, services chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when load balancer is allocated and backends exist",
+			do: func(ingresses, services chan watch.Event, timeout chan time.Time) {
+				services <- watchAddedEvent(ingressBackendService("default-http"))
+				services <- watchAddedEvent(ingressBackendService("web"))
+				ingresses <- watchAddedEvent(ingressInput(inputNamespace, ingressInputName))
+				ingresses <- watchAddedEvent(
+					ingressLoadBalancerAllocated(inputNamespace, ingressInputName))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should succeed when backend Service is created after the load balancer",
+			do: func(ingresses, services chan watch.Event, timeout chan time.Time) {
+				services <- watchAddedEvent(ingressBackendService("default-http"))
+				ingresses <- watchAddedEvent(
+					ingressLoadBalancerAllocated(inputNamespace, ingressInputName))
+				services <- watchAddedEvent(ingressBackendService("web"))
+
+				// Timeout. Success.
+				timeout <- time.Now()
+			},
+		},
+		{
+			description: "Should fail if load balancer is not allocated",
+			do: func(ingresses, services chan watch.Event, timeout chan time.Time) {
+				services <- watchAddedEvent(ingressBackendService("default-http"))
+				services <- watchAddedEvent(ingressBackendService("web"))
+				ingresses <- watchAddedEvent(ingressInput(inputNamespace, ingressInputName))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: ingressInputName,
+				subErrors:  []string{"Ingress was not allocated an IP address or hostname"}},
+		},
+		{
+			description: "Should report unresolved backends",
+			do: func(ingresses, services chan watch.Event, timeout chan time.Time) {
+				ingresses <- watchAddedEvent(
+					ingressLoadBalancerAllocated(inputNamespace, ingressInputName))
+				services <- watchAddedEvent(ingressBackendService("web"))
+				services <- watchEvent(watch.Deleted, ingressBackendService("web"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: ingressInputName,
+				subErrors: []string{
+					"Backend Service 'default-http' does not exist (referenced by default backend)",
+					"Backend Service 'web' does not exist (referenced by path 'example.com/', " +
+						"path 'example.com/api')",
+				}},
+		},
+		{
+			description: "Should ignore unrelated Ingress",
+			do: func(ingresses, services chan watch.Event, timeout chan time.Time) {
+				services <- watchAddedEvent(ingressBackendService("default-http"))
+				services <- watchAddedEvent(ingressBackendService("web"))
+				ingresses <- watchAddedEvent(ingressLoadBalancerAllocated(inputNamespace, "bar"))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: ingressInputName,
+				subErrors:  []string{"Ingress was not allocated an IP address or hostname"}},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makeIngressInitAwaiter(
+			mockAwaitConfig(ingressInput(inputNamespace, ingressInputName)))
+		ingresses := make(chan watch.Event)
+		services := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		go test.do(ingresses, services, timeout)
+
+		err := awaiter.await(&chanWatcher{results: ingresses}, &chanWatcher{results: services}, timeout)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+func Test_Extensions_IngressBackends(t *testing.T) {
+	v1Ingress := mustDecodeUnstructured(`{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind": "Ingress",
+		"metadata": {"name": "frontend"},
+		"spec": {
+			"defaultBackend": {"service": {"name": "default-http", "port": {"number": 80}}},
+			"rules": [{"http": {"paths": [
+				{"path": "/api", "pathType": "Prefix",
+				 "backend": {"service": {"name": "api", "port": {"name": "http"}}}},
+				{"path": "/static", "pathType": "Prefix",
+				 "backend": {"resource": {"apiGroup": "k8s.example.com", "kind": "StorageBucket", "name": "static"}}}
+			]}}]
+		}
+	}`)
+	assert.Equal(t, []ingressBackend{
+		{serviceName: "default-http"},
+		{path: "/api", serviceName: "api"},
+	}, ingressBackends(v1Ingress))
+
+	assert.Equal(t, "default backend", ingressBackend{serviceName: "default-http"}.String())
+	assert.Equal(t, "path '*/api'", ingressBackend{path: "/api", serviceName: "api"}.String())
+}
+
+func Test_Extensions_Ingress_Read(t *testing.T) {
+	input := ingressInput(inputNamespace, ingressInputName)
+	awaiter := makeIngressInitAwaiter(mockAwaitConfig(input))
+	ingress := ingressLoadBalancerAllocated(inputNamespace, ingressInputName)
+	err := awaiter.read(ingress, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		*ingressBackendService("default-http"), *ingressBackendService("web"),
+	}})
+	assert.Nil(t, err)
+
+	awaiter = makeIngressInitAwaiter(mockAwaitConfig(input))
+	err = awaiter.read(ingress, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+		*ingressBackendService("web"),
+	}})
+	assert.Equal(t, &initializationError{
+		subErrors: []string{
+			"Backend Service 'default-http' does not exist (referenced by default backend)"},
+		object: ingress,
+	}, err)
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func watchEvent(eventType watch.EventType, obj *unstructured.Unstructured) watch.Event {
+	return watch.Event{Type: eventType, Object: obj}
+}
+
+func ingressInput(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "extensions/v1beta1",
+    "kind": "Ingress",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backend": {
+            "serviceName": "default-http",
+            "servicePort": 80
+        },
+        "rules": [
+            {
+                "host": "example.com",
+                "http": {
+                    "paths": [
+                        {
+                            "backend": {
+                                "serviceName": "web",
+                                "servicePort": 80
+                            }
+                        },
+                        {
+                            "path": "/api",
+                            "backend": {
+                                "serviceName": "web",
+                                "servicePort": 8080
+                            }
+                        }
+                    ]
+                }
+            }
+        ]
+    },
+    "status": {}
+}`, name, namespace))
+}
+
+func ingressLoadBalancerAllocated(namespace, name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "extensions/v1beta1",
+    "kind": "Ingress",
+    "metadata": {
+        "name": "%s",
+        "namespace": "%s"
+    },
+    "spec": {
+        "backend": {
+            "serviceName": "default-http",
+            "servicePort": 80
+        },
+        "rules": [
+            {
+                "host": "example.com",
+                "http": {
+                    "paths": [
+                        {
+                            "backend": {
+                                "serviceName": "web",
+                                "servicePort": 80
+                            }
+                        },
+                        {
+                            "path": "/api",
+                            "backend": {
+                                "serviceName": "web",
+                                "servicePort": 8080
+                            }
+                        }
+                    ]
+                }
+            }
+        ]
+    },
+    "status": {
+        "loadBalancer": {
+            "ingress": [
+                {
+                    "ip": "35.186.226.42"
+                }
+            ]
+        }
+    }
+}`, name, namespace))
+}
+
+func ingressBackendService(name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+		"apiVersion": "v1",
+		"kind": "Service",
+		"metadata": {"name": "%s", "namespace": "%s"},
+		"spec": {"ports": [{"port": 80}]}
+	}`, name, inputNamespace))
+}
@@ -0,0 +1,354 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi-kubernetes/pkg/watcher"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Generic await logic for kinds we have no specific knowledge of (including custom resources).
+//
+// Historically, any object whose GVK did not appear in the `awaiters` table was considered
+// initialized as soon as the API server accepted it. This is wrong for nearly every controller-
+// backed kind, so instead we compute readiness following the conventions described by the
+// Kubernetes `kstatus` library[1]. The result of this computation is one of three states:
+//
+//   - `Current`: the controller has observed the latest spec and reports the object as healthy.
+//   - `InProgress`: the controller has not yet caught up, or reports that it is still working.
+//   - `Failed`: the controller reports that it cannot make progress.
+//
+// The computation uses only fields that are (by convention) common to all kinds:
+//
+//   1. `.metadata.deletionTimestamp`: an object being deleted is never `Current`.
+//   2. `.status.observedGeneration`: if present, it must equal `.metadata.generation`, otherwise
+//      the status describes a stale spec.
+//   3. `.status.conditions`: the standard `Reconciling` and `Stalled` condition types, as well as
+//      the widely-used `Ready` condition.
+//   4. `.status.replicas`, `.status.readyReplicas`, `.status.updatedReplicas`, and
+//      `.status.availableReplicas`: for scalable kinds (e.g., ReplicaSets, or custom resources that
+//      implement the `scale` subresource), each must reach `.spec.replicas`.
+//   5. `.status.phase`: a heuristic for older kinds that predate conditions.
+//
+// An object with no status at all is assumed to be `Current`, since there is nothing to wait on,
+// unless its kind has a status subresource. In that case some controller is expected to report a
+// status (this is the norm for operators), so we wait until it has done so.
+//
+// Kinds that need more than this (e.g., Deployments, whose rollouts we report in detail) register a
+// kind-specific awaiter in the `awaiters` table, which takes precedence over this logic. Those
+// awaiters can call `computeReadiness` themselves to cover the conventional fields, and layer their
+// own checks on top.
+//
+// x-refs:
+//   [1]: https://github.com/kubernetes-sigs/kustomize/tree/master/kstatus
+
+// ------------------------------------------------------------------------------------------------
+
+type readinessStatus string
+
+const (
+	statusCurrent    readinessStatus = "Current"
+	statusInProgress readinessStatus = "InProgress"
+	statusFailed     readinessStatus = "Failed"
+)
+
+// readiness is the result of computing the status of an arbitrary Kubernetes object.
+type readiness struct {
+	status  readinessStatus
+	message string
+}
+
+var failedPhases = map[string]bool{
+	"Failed": true,
+	"Error":  true,
+	"Lost":   true,
+}
+
+var inProgressPhases = map[string]bool{
+	"Pending":      true,
+	"Provisioning": true,
+	"Creating":     true,
+	"Initializing": true,
+	"Progressing":  true,
+	"Terminating":  true,
+	"Unknown":      true,
+}
+
+// computeReadiness derives a kstatus-style readiness status from the standard fields of `obj`.
+func computeReadiness(obj *unstructured.Unstructured) readiness {
+	if obj.GetDeletionTimestamp() != nil {
+		return readiness{statusInProgress, "Resource is scheduled for deletion"}
+	}
+
+	rawStatus, hasStatus := openapi.Pluck(obj.Object, "status")
+	status, isMap := rawStatus.(map[string]interface{})
+	if !hasStatus || !isMap || len(status) == 0 {
+		return readiness{statusCurrent, "Resource does not report a status"}
+	}
+
+	if r, stale := staleReadiness(obj, status); stale {
+		return r
+	}
+
+	conditions, _ := status["conditions"].([]interface{})
+	if stalled, exists := findCondition(conditions, "Stalled"); exists && stalled["status"] == trueStatus {
+		return readiness{statusFailed, conditionMessage(stalled, "Resource is stalled")}
+	}
+	if reconciling, exists := findCondition(conditions, "Reconciling"); exists &&
+		reconciling["status"] == trueStatus {
+		return readiness{statusInProgress, conditionMessage(reconciling, "Resource is reconciling")}
+	}
+	if r, scalable := replicaReadiness(obj, status); scalable && r.status != statusCurrent {
+		return r
+	}
+	if ready, exists := findCondition(conditions, "Ready"); exists {
+		if ready["status"] == trueStatus {
+			return readiness{statusCurrent, "Resource is ready"}
+		}
+		return readiness{statusInProgress, conditionMessage(ready, "Resource is not ready")}
+	}
+
+	if phase, isString := status["phase"].(string); isString && phase != "" {
+		if failedPhases[phase] {
+			return readiness{statusFailed, fmt.Sprintf("Resource is in phase '%s'", phase)}
+		}
+		if inProgressPhases[phase] {
+			return readiness{statusInProgress, fmt.Sprintf("Resource is in phase '%s'", phase)}
+		}
+	}
+
+	return readiness{statusCurrent, "Resource is current"}
+}
+
+// staleReadiness returns true if `status` describes an older generation of `obj` than its current
+// spec, per `.status.observedGeneration`.
+func staleReadiness(obj *unstructured.Unstructured, status map[string]interface{}) (readiness, bool) {
+	observed, hasObserved := int64Value(status["observedGeneration"])
+	if generation := obj.GetGeneration(); hasObserved && generation != 0 && observed < generation {
+		return readiness{statusInProgress, fmt.Sprintf(
+			"Controller has not yet observed the latest generation (%d of %d)", observed, generation)}, true
+	}
+	return readiness{}, false
+}
+
+// replicaReadiness compares the replica counts in `status` against `.spec.replicas`. It returns
+// false if `obj` does not look like a scalable kind, i.e., it has no `.spec.replicas`, or its
+// status does not report `.status.replicas`.
+func replicaReadiness(
+	obj *unstructured.Unstructured, status map[string]interface{},
+) (readiness, bool) {
+	rawDesired, _ := openapi.Pluck(obj.Object, "spec", "replicas")
+	desired, hasDesired := int64Value(rawDesired)
+	if _, hasReplicas := status["replicas"]; !hasDesired || !hasReplicas {
+		return readiness{}, false
+	}
+
+	// NOTE: Controllers omit counts that are zero, so `readyReplicas` is required, but the other
+	// counts are only checked if the kind reports them at all.
+	ready, _ := int64Value(status["readyReplicas"])
+	if ready < desired {
+		return readiness{statusInProgress, fmt.Sprintf("Ready: %d/%d", ready, desired)}, true
+	}
+	if updated, reported := int64Value(status["updatedReplicas"]); reported && updated < desired {
+		return readiness{statusInProgress, fmt.Sprintf("Updated: %d/%d", updated, desired)}, true
+	}
+	available, reported := int64Value(status["availableReplicas"])
+	if reported && available < desired {
+		return readiness{statusInProgress, fmt.Sprintf("Available: %d/%d", available, desired)}, true
+	}
+	return readiness{statusCurrent, fmt.Sprintf("Ready: %d/%d", ready, desired)}, true
+}
+
+// computeGenericReadiness is `computeReadiness`, except that if `statusExpected` is true (i.e., the
+// kind has a status subresource), an object with no status is not yet `Current`.
+func computeGenericReadiness(obj *unstructured.Unstructured, statusExpected bool) readiness {
+	if status, _ := obj.Object["status"].(map[string]interface{}); statusExpected && len(status) == 0 &&
+		obj.GetDeletionTimestamp() == nil {
+		return readiness{statusInProgress, "Waiting for the controller to report the resource's status"}
+	}
+	return computeReadiness(obj)
+}
+
+// hasStatusSubresource returns true if the API server serves a `/status` subresource for `gvk`.
+// Failure to discover the kind's resources is not an error; we assume there is no subresource.
+func hasStatusSubresource(
+	disco discovery.ServerResourcesInterface, gvk schema.GroupVersionKind,
+) bool {
+	if disco == nil {
+		return false
+	}
+	resources, err := disco.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		glog.V(3).Infof("Could not discover resources of %s: %v", gvk.GroupVersion(), err)
+		return false
+	}
+	return statusSubresourceServed(resources, gvk.Kind)
+}
+
+// statusSubresourceServed returns true if `resources` includes a `/status` subresource for `kind`.
+func statusSubresourceServed(resources *metav1.APIResourceList, kind string) bool {
+	for _, resource := range resources.APIResources {
+		if resource.Kind != kind || strings.Contains(resource.Name, "/") {
+			continue
+		}
+		for _, subresource := range resources.APIResources {
+			if subresource.Name == resource.Name+"/status" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findCondition returns the condition of type `conditionType` from a `.status.conditions` list.
+func findCondition(conditions []interface{}, conditionType string) (map[string]interface{}, bool) {
+	for _, rawCondition := range conditions {
+		condition, isMap := rawCondition.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		if condition["type"] == conditionType {
+			return condition, true
+		}
+	}
+	return nil, false
+}
+
+// conditionMessage renders a condition as "[reason] message", falling back to `fallback` if the
+// condition carries neither.
+func conditionMessage(condition map[string]interface{}, fallback string) string {
+	reason, _ := condition["reason"].(string)
+	message, _ := condition["message"].(string)
+	switch {
+	case reason != "" && message != "":
+		return fmt.Sprintf("[%s] %s", reason, message)
+	case message != "":
+		return message
+	case reason != "":
+		return fmt.Sprintf("[%s] %s", reason, fallback)
+	default:
+		return fallback
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Generic awaiters.
+
+// --------------------------------------------------------------------------
+
+func untilGenericResourceReady(c createAwaitConfig) error {
+	statusExpected := hasStatusSubresource(c.disco, c.currentInputs.GroupVersionKind())
+	return untilResourceReady(c, func(obj *unstructured.Unstructured) readiness {
+		return computeGenericReadiness(obj, statusExpected)
+	})
+}
+
+func untilGenericResourceUpdated(u updateAwaitConfig) error {
+	return untilGenericResourceReady(u.createAwaitConfig)
+}
+
+func readGenericResource(c createAwaitConfig) error {
+	statusExpected := hasStatusSubresource(c.disco, c.currentInputs.GroupVersionKind())
+	return readResource(c, func(obj *unstructured.Unstructured) readiness {
+		return computeGenericReadiness(obj, statusExpected)
+	})
+}
+
+// untilResourceReady waits until `compute` reports the object as `Current`, failing immediately if
+// it reports the object as `Failed`.
+func untilResourceReady(
+	c createAwaitConfig, compute func(*unstructured.Unstructured) readiness,
+) error {
+	name := c.currentInputs.GetName()
+
+	var last readiness
+	resourceReady := func(obj *unstructured.Unstructured, err error) error {
+		if err != nil {
+			return err
+		}
+
+		last = compute(obj)
+		glog.V(3).Infof("Resource '%s' is '%s': %s", name, last.status, last.message)
+		switch last.status {
+		case statusCurrent:
+			return nil
+		case statusFailed:
+			return &initializationError{
+				subErrors: []string{last.message},
+				object:    obj,
+			}
+		default:
+			return watcher.RetryableError(fmt.Errorf("%s", last.message))
+		}
+	}
+
+	err := watcher.ForObject(c.ctx, c.clientForResource, name).
+		RetryUntil(resourceReady, c.timeout(10*time.Minute))
+	if err == nil {
+		return nil
+	}
+	if _, isInitErr := err.(*initializationError); isInitErr {
+		return err
+	}
+
+	var subErrors []string
+	if last.message != "" {
+		subErrors = []string{last.message}
+	}
+	if c.ctx.Err() != nil {
+		return &cancellationError{objectName: name, subErrors: subErrors}
+	}
+	if last.status == statusInProgress {
+		return &timeoutError{objectName: name, subErrors: subErrors}
+	}
+	return err
+}
+
+// readResource checks that `compute` reports the live object as `Current`.
+func readResource(c createAwaitConfig, compute func(*unstructured.Unstructured) readiness) error {
+	obj, err := c.clientForResource.Get(c.currentInputs.GetName(), metav1.GetOptions{})
+	if err != nil {
+		// IMPORTANT: Do not wrap this error! If this is a 404, the provider need to know so that it
+		// can mark the resource as having been deleted.
+		return err
+	}
+
+	if r := compute(obj); r.status != statusCurrent {
+		return &initializationError{
+			subErrors: []string{r.message},
+			object:    obj,
+		}
+	}
+	return nil
+}
+
+// genericAwaiter is used for any GVK that does not appear in the `awaiters` table.
+var genericAwaiter = awaitSpec{
+	awaitCreation: untilGenericResourceReady,
+	awaitUpdate:   untilGenericResourceUpdated,
+	awaitRead:     readGenericResource,
+}
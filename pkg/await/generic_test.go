@@ -0,0 +1,153 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_Generic_Readiness(t *testing.T) {
+	tests := []struct {
+		description string
+		object      string
+		expected    readinessStatus
+	}{
+		{
+			description: "Object without status should be current",
+			object:      `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"}}`,
+			expected:    statusCurrent,
+		},
+		{
+			description: "Object with stale observedGeneration should be in progress",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 1}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Object with up-to-date observedGeneration and no conditions should be current",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget",
+			          "metadata": {"name": "foo", "generation": 2},
+			          "status": {"observedGeneration": 2}}`,
+			expected: statusCurrent,
+		},
+		{
+			description: "Object with Stalled condition should fail",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Stalled", "status": "True", "reason": "Broken"}]}}`,
+			expected: statusFailed,
+		},
+		{
+			description: "Object with Reconciling condition should be in progress",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Reconciling", "status": "True"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Object with Ready=False condition should be in progress",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Ready", "status": "False"}]}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Scalable object without enough ready replicas should be in progress",
+			object: `{"apiVersion": "apps/v1", "kind": "ReplicaSet", "metadata": {"name": "foo"},
+			          "spec": {"replicas": 3}, "status": {"replicas": 3, "readyReplicas": 2}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Scalable object without enough available replicas should be in progress",
+			object: `{"apiVersion": "apps/v1", "kind": "ReplicaSet", "metadata": {"name": "foo"},
+			          "spec": {"replicas": 3},
+			          "status": {"replicas": 3, "readyReplicas": 3, "availableReplicas": 1}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Scalable object with all replicas ready should be current",
+			object: `{"apiVersion": "apps/v1", "kind": "ReplicaSet", "metadata": {"name": "foo"},
+			          "spec": {"replicas": 3},
+			          "status": {"replicas": 3, "readyReplicas": 3, "availableReplicas": 3}}`,
+			expected: statusCurrent,
+		},
+		{
+			description: "Object with Ready=True condition should be current",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"conditions": [{"type": "Ready", "status": "True"}]}}`,
+			expected: statusCurrent,
+		},
+		{
+			description: "Object in a pending phase should be in progress",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"phase": "Pending"}}`,
+			expected: statusInProgress,
+		},
+		{
+			description: "Object in a failed phase should fail",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"phase": "Failed"}}`,
+			expected: statusFailed,
+		},
+		{
+			description: "Object in an unrecognized phase should be current",
+			object: `{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"},
+			          "status": {"phase": "Active"}}`,
+			expected: statusCurrent,
+		},
+	}
+
+	for _, test := range tests {
+		obj, err := decodeUnstructured(test.object)
+		if err != nil {
+			panic(err)
+		}
+		assert.Equal(t, test.expected, computeReadiness(obj).status, test.description)
+	}
+}
+
+func Test_Generic_Readiness_Deleted(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata": map[string]interface{}{
+			"name":              "foo",
+			"deletionTimestamp": "2018-07-01T00:00:00Z",
+		},
+	}}
+	assert.Equal(t, statusInProgress, computeReadiness(obj).status)
+}
+
+func Test_Generic_Readiness_StatusSubresource(t *testing.T) {
+	noStatus, err := decodeUnstructured(
+		`{"apiVersion": "example.com/v1", "kind": "Widget", "metadata": {"name": "foo"}}`)
+	if err != nil {
+		panic(err)
+	}
+	ready, err := decodeUnstructured(`{"apiVersion": "example.com/v1", "kind": "Widget",
+		"metadata": {"name": "foo"},
+		"status": {"conditions": [{"type": "Ready", "status": "True"}]}}`)
+	if err != nil {
+		panic(err)
+	}
+
+	assert.Equal(t, statusCurrent, computeGenericReadiness(noStatus, false).status,
+		"Object without status subresource should be current")
+	assert.Equal(t, statusInProgress, computeGenericReadiness(noStatus, true).status,
+		"Object with status subresource should wait for status")
+	assert.Equal(t, statusCurrent, computeGenericReadiness(ready, true).status,
+		"Object with status subresource should be current once ready")
+
+	resources := &metav1.APIResourceList{
+		GroupVersion: "example.com/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "widgets", Kind: "Widget"},
+			{Name: "widgets/status", Kind: "Widget"},
+			{Name: "gadgets", Kind: "Gadget"},
+			{Name: "gadgets/scale", Kind: "Scale"},
+		},
+	}
+	assert.True(t, statusSubresourceServed(resources, "Widget"))
+	assert.False(t, statusSubresourceServed(resources, "Gadget"))
+	assert.False(t, statusSubresourceServed(resources, "Gizmo"))
+}
@@ -0,0 +1,146 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// OS/architecture diagnostics.
+//
+// A surprisingly common cause of Pods that never become ready is a mismatch between the platform
+// an image was built for and the platform of the nodes in the cluster (e.g., a Linux image
+// scheduled on a Windows node pool, or an arm64 image on amd64 nodes). Kubernetes reports these
+// failures in several different, fairly obscure ways:
+//
+//   * The image pull fails because the image's manifest list has no entry for the node's platform.
+//   * The container runtime refuses to run an image built for a different OS.
+//   * The container starts, but its entrypoint fails with `exec format error`.
+//   * The Pod is never scheduled, because its `nodeSelector` requires an OS or architecture that
+//     no node in the cluster has.
+//
+// The routines here recognize these patterns in Pod status and event messages, and explain them
+// explicitly.
+
+// --------------------------------------------------------------------------
+
+var (
+	osLabels   = []string{"kubernetes.io/os", "beta.kubernetes.io/os"}
+	archLabels = []string{"kubernetes.io/arch", "beta.kubernetes.io/arch"}
+
+	noMatchingManifestPattern = regexp.MustCompile(`no matching manifest for (\S+) in the manifest list`)
+	imageOSPattern            = regexp.MustCompile(`image operating system "([^"]+)" cannot be used on this platform`)
+	execFormatPattern         = regexp.MustCompile(`exec format error`)
+	selectorMismatchPattern   = regexp.MustCompile(`didn't match (node selector|Pod's node affinity)`)
+)
+
+// platformDiagnostics inspects the messages reported for a Pod (from its status, or from events),
+// and returns an explanation for each failure caused by an OS/architecture mismatch.
+func platformDiagnostics(pod *unstructured.Unstructured, messages []string) []string {
+	diagnostics := []string{}
+	seen := map[string]bool{}
+	add := func(diagnostic string) {
+		if !seen[diagnostic] {
+			seen[diagnostic] = true
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	for _, message := range messages {
+		if match := noMatchingManifestPattern.FindStringSubmatch(message); match != nil {
+			add(fmt.Sprintf("Image has no variant for platform %s, which is the platform of the node "+
+				"the Pod was scheduled on. Use a multi-platform image, or constrain the Pod to matching "+
+				"nodes with the `kubernetes.io/os` and `kubernetes.io/arch` node selectors", match[1]))
+		}
+		if match := imageOSPattern.FindStringSubmatch(message); match != nil {
+			add(fmt.Sprintf("Image is built for OS %q, but the Pod was scheduled on a node running a "+
+				"different OS. Set the node selector `kubernetes.io/os: %s`", match[1], match[1]))
+		}
+		if execFormatPattern.MatchString(message) {
+			add("Container failed with `exec format error`, which usually means the image was built for " +
+				"a different CPU architecture than the node. Use an image built for the node's " +
+				"architecture, or set the `kubernetes.io/arch` node selector")
+		}
+		if selectorMismatchPattern.MatchString(message) {
+			if required := requiredPlatform(pod); required != "" {
+				add(fmt.Sprintf("Pod requires nodes with %s, but no schedulable node matches. Check that "+
+					"the cluster has nodes for this OS/architecture", required))
+			}
+		}
+	}
+	return diagnostics
+}
+
+// requiredPlatform returns a human-readable description of the OS/architecture constraints in a
+// Pod's (or Pod template's) `nodeSelector`, or "" if there are none.
+func requiredPlatform(pod *unstructured.Unstructured) string {
+	rawSelector, _ := openapi.Pluck(pod.Object, "spec", "nodeSelector")
+	if rawSelector == nil {
+		rawSelector, _ = openapi.Pluck(pod.Object, "spec", "template", "spec", "nodeSelector")
+	}
+	selector, isMap := rawSelector.(map[string]interface{})
+	if !isMap {
+		return ""
+	}
+
+	var constraints []string
+	for _, label := range append(append([]string{}, osLabels...), archLabels...) {
+		if value, exists := selector[label]; exists {
+			constraints = append(constraints, fmt.Sprintf("`%s=%v`", label, value))
+		}
+	}
+	return strings.Join(constraints, " and ")
+}
+
+// nodePlatformSummary summarizes the platforms of the given nodes, e.g., "linux/amd64 (3 nodes)".
+func nodePlatformSummary(nodes []unstructured.Unstructured) string {
+	counts := map[string]int{}
+	for _, node := range nodes {
+		labels := node.GetLabels()
+		os, arch := "unknown", "unknown"
+		for _, label := range osLabels {
+			if value, exists := labels[label]; exists {
+				os = value
+				break
+			}
+		}
+		for _, label := range archLabels {
+			if value, exists := labels[label]; exists {
+				arch = value
+				break
+			}
+		}
+		counts[os+"/"+arch]++
+	}
+
+	var platforms []string
+	for platform, count := range counts {
+		noun := "nodes"
+		if count == 1 {
+			noun = "node"
+		}
+		platforms = append(platforms, fmt.Sprintf("%s (%d %s)", platform, count, noun))
+	}
+	sort.Strings(platforms)
+	return strings.Join(platforms, ", ")
+}
@@ -0,0 +1,80 @@
+package await
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func Test_PlatformDiagnostics(t *testing.T) {
+	podWithSelector := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"nodeSelector": map[string]interface{}{"kubernetes.io/os": "windows"},
+		},
+	}}
+	podWithoutSelector := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	tests := []struct {
+		description string
+		pod         *unstructured.Unstructured
+		message     string
+		diagnostics int
+	}{
+		{
+			description: "Should explain missing manifest for platform",
+			pod:         podWithoutSelector,
+			message: `Failed to pull image "foo:latest": rpc error: code = Unknown desc = no matching ` +
+				`manifest for linux/arm64 in the manifest list entries`,
+			diagnostics: 1,
+		},
+		{
+			description: "Should explain image OS mismatch",
+			pod:         podWithoutSelector,
+			message:     `image operating system "linux" cannot be used on this platform`,
+			diagnostics: 1,
+		},
+		{
+			description: "Should explain exec format error",
+			pod:         podWithoutSelector,
+			message:     `standard_init_linux.go:211: exec user process caused "exec format error"`,
+			diagnostics: 1,
+		},
+		{
+			description: "Should explain unschedulable Pod with platform node selector",
+			pod:         podWithSelector,
+			message:     "0/3 nodes are available: 3 node(s) didn't match node selector.",
+			diagnostics: 1,
+		},
+		{
+			description: "Should not blame platform for node selector without platform labels",
+			pod:         podWithoutSelector,
+			message:     "0/3 nodes are available: 3 node(s) didn't match node selector.",
+			diagnostics: 0,
+		},
+		{
+			description: "Should ignore unrelated messages",
+			pod:         podWithoutSelector,
+			message:     "Back-off pulling image \"foo:latest\"",
+			diagnostics: 0,
+		},
+	}
+
+	for _, test := range tests {
+		diagnostics := platformDiagnostics(test.pod, []string{test.message, test.message})
+		assert.Len(t, diagnostics, test.diagnostics, test.description)
+	}
+}
+
+func Test_NodePlatformSummary(t *testing.T) {
+	node := func(os, arch string) unstructured.Unstructured {
+		n := unstructured.Unstructured{Object: map[string]interface{}{}}
+		n.SetLabels(map[string]string{"kubernetes.io/os": os, "kubernetes.io/arch": arch})
+		return n
+	}
+
+	summary := nodePlatformSummary([]unstructured.Unstructured{
+		node("linux", "amd64"), node("linux", "amd64"), node("windows", "amd64"),
+	})
+	assert.Equal(t, "linux/amd64 (2 nodes), windows/amd64 (1 node)", summary)
+}
@@ -0,0 +1,161 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+)
+
+// --------------------------------------------------------------------------
+
+// Pod log diagnostics.
+//
+// When an await of a Pod (or of a workload that runs Pods, like a Deployment or a Job) fails, the
+// reason is very often only visible in the logs of the failing container, e.g., a stack trace
+// printed just before it crashed. Rather than making the user go find it with `kubectl logs`, we
+// fetch the last few lines of logs of each unhealthy container, and report them alongside the
+// error. For a container that is crash looping, the logs of the current instance are usually
+// empty, so we fetch the logs of the previous (crashed) instance instead.
+//
+// Fetching logs is best-effort: if it fails (e.g., because the node is unreachable), we simply
+// don't report them.
+
+// --------------------------------------------------------------------------
+
+const (
+	podLogTailLines = 20
+	maxPodsToLog    = 3
+)
+
+// unhealthyContainer identifies a container whose logs might explain why it's unhealthy.
+type unhealthyContainer struct {
+	name     string
+	previous bool
+}
+
+// unhealthyContainers returns the containers of `pod` that have crashed, exited with an error, or
+// are running but not ready. Containers that have never started (e.g., because their image can't
+// be pulled) have no logs, and are omitted.
+func unhealthyContainers(pod *unstructured.Unstructured) []unhealthyContainer {
+	var containers []unhealthyContainer
+	for _, field := range []string{"initContainerStatuses", "containerStatuses"} {
+		rawStatuses, _ := openapi.Pluck(pod.Object, "status", field)
+		statuses, _ := rawStatuses.([]interface{})
+		for _, rawStatus := range statuses {
+			status, _ := rawStatus.(map[string]interface{})
+			name, _ := status["name"].(string)
+			restartCount, _ := int64Value(status["restartCount"])
+
+			_, isWaiting := openapi.Pluck(status, "state", "waiting")
+			rawExitCode, isTerminated := openapi.Pluck(status, "state", "terminated", "exitCode")
+			exitCode, _ := int64Value(rawExitCode)
+			_, isRunning := openapi.Pluck(status, "state", "running")
+
+			switch {
+			case isWaiting && restartCount > 0:
+				containers = append(containers, unhealthyContainer{name: name, previous: true})
+			case isTerminated && exitCode != 0:
+				containers = append(containers, unhealthyContainer{name: name})
+			case isRunning && status["ready"] != true && field == "containerStatuses":
+				containers = append(containers, unhealthyContainer{name: name})
+			}
+		}
+	}
+	return containers
+}
+
+// reportPodLogs reports the last lines of logs of the unhealthy containers of (at most
+// `maxPodsToLog` of) `pods`.
+func reportPodLogs(c createAwaitConfig, pods []*unstructured.Unstructured) {
+	discoveryClient, isDiscoveryClient := c.disco.(discovery.DiscoveryInterface)
+	if c.host == nil || !isDiscoveryClient {
+		return
+	}
+
+	sort.Slice(pods, func(i, j int) bool { return pods[i].GetName() < pods[j].GetName() })
+	logged := 0
+	for _, pod := range pods {
+		containers := unhealthyContainers(pod)
+		if len(containers) == 0 {
+			continue
+		}
+		if logged == maxPodsToLog {
+			break
+		}
+		logged++
+
+		for _, container := range containers {
+			logs, err := discoveryClient.RESTClient().Get().
+				AbsPath("/api/v1/namespaces", pod.GetNamespace(), "pods", pod.GetName(), "log").
+				Param("container", container.name).
+				Param("previous", strconv.FormatBool(container.previous)).
+				Param("tailLines", strconv.Itoa(podLogTailLines)).
+				Do().
+				Raw()
+			if err != nil {
+				glog.V(3).Infof("Could not retrieve logs of container '%s' of Pod '%s': %v",
+					container.name, pod.GetName(), err)
+				continue
+			}
+			if message := formatPodLogs(pod.GetName(), container, string(logs)); message != "" {
+				_ = c.host.Log(c.ctx, diag.Warning, c.urn, message)
+			}
+		}
+	}
+}
+
+// podValues returns the Pods in `pods`, a map of the Pods an awaiter has observed.
+func podValues(pods map[string]*unstructured.Unstructured) []*unstructured.Unstructured {
+	values := make([]*unstructured.Unstructured, 0, len(pods))
+	for _, pod := range pods {
+		values = append(values, pod)
+	}
+	return values
+}
+
+// formatPodLogs renders the logs of a container for display, or returns "" if there are none.
+func formatPodLogs(podName string, container unhealthyContainer, logs string) string {
+	logs = strings.TrimRight(logs, "\n")
+	if strings.TrimSpace(logs) == "" {
+		return ""
+	}
+
+	instance := ""
+	if container.previous {
+		instance = " (previous instance)"
+	}
+	return fmt.Sprintf("Recent logs of container '%s' in Pod '%s'%s:\n    %s",
+		container.name, podName, instance, strings.Replace(logs, "\n", "\n    ", -1))
+}
+
+// awaitFailed returns true if `err` means the object did not become ready (as opposed to, e.g.,
+// the await being canceled), i.e., it's worth reporting diagnostics.
+func awaitFailed(err error) bool {
+	switch err.(type) {
+	case *initializationError, *timeoutError:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,71 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UnhealthyContainers(t *testing.T) {
+	tests := []struct {
+		description string
+		statuses    string
+		expected    []unhealthyContainer
+	}{
+		{
+			description: "Ready containers should not be reported",
+			statuses: `[{"name": "app", "ready": true, "restartCount": 0,
+			             "state": {"running": {"startedAt": "2018-06-11T17:38:19Z"}}}]`,
+			expected: nil,
+		},
+		{
+			description: "Crash looping containers should report their previous instance",
+			statuses: `[{"name": "app", "ready": false, "restartCount": 4,
+			             "state": {"waiting": {"reason": "CrashLoopBackOff"}}}]`,
+			expected: []unhealthyContainer{{name: "app", previous: true}},
+		},
+		{
+			description: "Containers that exited with an error should be reported",
+			statuses: `[{"name": "app", "ready": false, "restartCount": 0,
+			             "state": {"terminated": {"exitCode": 2, "reason": "Error"}}}]`,
+			expected: []unhealthyContainer{{name: "app"}},
+		},
+		{
+			description: "Running containers that are not ready should be reported",
+			statuses: `[{"name": "app", "ready": false, "restartCount": 0,
+			             "state": {"running": {"startedAt": "2018-06-11T17:38:19Z"}}}]`,
+			expected: []unhealthyContainer{{name: "app"}},
+		},
+		{
+			description: "Containers that never started should not be reported",
+			statuses: `[{"name": "app", "ready": false, "restartCount": 0,
+			             "state": {"waiting": {"reason": "ImagePullBackOff"}}}]`,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		pod, err := decodeUnstructured(fmt.Sprintf(`{
+			"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "foo"},
+			"status": {"containerStatuses": %s}}`, test.statuses))
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.expected, unhealthyContainers(pod), test.description)
+	}
+}
+
+func Test_FormatPodLogs(t *testing.T) {
+	assert.Equal(t, "", formatPodLogs("foo", unhealthyContainer{name: "app"}, "\n"))
+	assert.Equal(t,
+		"Recent logs of container 'app' in Pod 'foo' (previous instance):\n"+
+			"    panic: oops\n    exit status 2",
+		formatPodLogs("foo", unhealthyContainer{name: "app", previous: true},
+			"panic: oops\nexit status 2\n"))
+}
+
+func Test_AwaitFailed(t *testing.T) {
+	assert.True(t, awaitFailed(&timeoutError{objectName: "foo"}))
+	assert.True(t, awaitFailed(&initializationError{}))
+	assert.False(t, awaitFailed(&cancellationError{objectName: "foo"}))
+	assert.False(t, awaitFailed(nil))
+}
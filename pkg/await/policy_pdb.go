@@ -0,0 +1,163 @@
+package await
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ------------------------------------------------------------------------------------------------
+
+// Await logic for policy/v1beta1/PodDisruptionBudget and policy/v1/PodDisruptionBudget.
+//
+// A PodDisruptionBudget limits how many of the Pods matched by its selector can be taken down at
+// once by voluntary disruptions (e.g., `kubectl drain`). The disruption controller reports the
+// Pods it matched in `.status`:
+//
+//   * `expectedPods` is the number of Pods matched by the selector.
+//   * `currentHealthy` and `desiredHealthy` are the number of those Pods that are (and must be)
+//     healthy for a disruption to be allowed.
+//
+// The PodDisruptionBudget is considered initialized once `.status.observedGeneration` is at least
+// `.metadata.generation`, i.e., the disruption controller has seen the current spec and counted the
+// Pods it matches.
+//
+// If `.status.expectedPods` is 0 at that point, the selector matches no Pods. This is legal, but
+// such a PodDisruptionBudget protects nothing, which is usually a mistake that is otherwise only
+// discovered during a node drain. Since the Pods it is meant to protect may well be created after
+// it (e.g., later in the same program), we don't fail in this case, but we do warn about it.
+//
+//
+// x-refs:
+//   * https://kubernetes.io/docs/concepts/workloads/pods/disruptions/
+//   * https://kubernetes.io/docs/tasks/run-application/configure-pdb/
+
+// ------------------------------------------------------------------------------------------------
+
+type pdbInitAwaiter struct {
+	config             createAwaitConfig
+	generation         int64
+	observedGeneration int64
+	observed           bool
+	expectedPods       int64
+	currentHealthy     int64
+	desiredHealthy     int64
+}
+
+func makePDBInitAwaiter(c createAwaitConfig) *pdbInitAwaiter {
+	return &pdbInitAwaiter{
+		config: c,
+	}
+}
+
+func untilPolicyPDBReady(c createAwaitConfig) error {
+	return makePDBInitAwaiter(c).Await()
+}
+
+func untilPolicyPDBReadyUpdated(u updateAwaitConfig) error {
+	return makePDBInitAwaiter(u.createAwaitConfig).Await()
+}
+
+func (pia *pdbInitAwaiter) Await() error {
+	pdbWatcher, err := watchWithReconnect(pia.config.clientForResource,
+		nameListOptions(pia.config.currentInputs.GetName()))
+	if err != nil {
+		return errors.Wrapf(err, "Could set up watch for PodDisruptionBudget object '%s'",
+			pia.config.currentInputs.GetName())
+	}
+	defer pdbWatcher.Stop()
+
+	return pia.await(pdbWatcher, client.ThrottledAfter(pia.config.timeout(1*time.Minute)))
+}
+
+// await is a helper companion to `Await` designed to make it easy to test this module.
+func (pia *pdbInitAwaiter) await(pdbWatcher watch.Interface, timeout <-chan time.Time) error {
+	inputName := pia.config.currentInputs.GetName()
+	for {
+		if pia.observed {
+			if pia.expectedPods == 0 {
+				pia.warn(fmt.Sprintf(
+					"PodDisruptionBudget '%s' does not match any Pods, so it does not protect anything "+
+						"from voluntary disruptions (e.g., node drains); check its selector", inputName))
+			}
+			return nil
+		}
+
+		// Else, wait for updates.
+		select {
+		case <-pia.config.ctx.Done():
+			return &cancellationError{
+				objectName: inputName,
+				subErrors:  pia.errorMessages(),
+			}
+		case <-timeout:
+			return &timeoutError{
+				objectName: inputName,
+				subErrors:  pia.errorMessages(),
+			}
+		case event := <-pdbWatcher.ResultChan():
+			if err := watchError(event); err != nil {
+				return err
+			}
+			pia.processPDBEvent(event)
+		}
+	}
+}
+
+func (pia *pdbInitAwaiter) processPDBEvent(event watch.Event) {
+	pdb, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		glog.V(3).Infof("PodDisruptionBudget watch received unknown object type '%s'",
+			reflect.TypeOf(pdb))
+		return
+	}
+
+	// Do nothing if this is not the PodDisruptionBudget we're waiting for.
+	if pdb.GetName() != pia.config.currentInputs.GetName() {
+		return
+	}
+
+	pia.observed = false
+	if event.Type == watch.Deleted {
+		return
+	}
+
+	pia.generation = pdb.GetGeneration()
+	rawStatus, hasStatus := openapi.Pluck(pdb.Object, "status")
+	status, isMap := rawStatus.(map[string]interface{})
+	if !hasStatus || !isMap {
+		// The disruption controller has not yet seen this PodDisruptionBudget. Do nothing.
+		return
+	}
+
+	var hasObservedGeneration bool
+	pia.observedGeneration, hasObservedGeneration = int64Value(status["observedGeneration"])
+	pia.expectedPods, _ = int64Value(status["expectedPods"])
+	pia.currentHealthy, _ = int64Value(status["currentHealthy"])
+	pia.desiredHealthy, _ = int64Value(status["desiredHealthy"])
+	pia.observed = hasObservedGeneration && pia.observedGeneration >= pia.generation
+	glog.V(3).Infof("PodDisruptionBudget '%s' status received: %#v", pdb.GetName(), status)
+}
+
+func (pia *pdbInitAwaiter) warn(message string) {
+	if pia.config.host != nil {
+		_ = pia.config.host.Log(pia.config.ctx, diag.Warning, pia.config.urn, message)
+	}
+}
+
+func (pia *pdbInitAwaiter) errorMessages() []string {
+	if pia.observedGeneration < pia.generation || pia.generation == 0 {
+		return []string{
+			"PodDisruptionBudget has not yet been observed by the disruption controller"}
+	}
+	return []string{fmt.Sprintf("%d Pods expected, %d/%d healthy",
+		pia.expectedPods, pia.currentHealthy, pia.desiredHealthy)}
+}
@@ -0,0 +1,119 @@
+package await
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const pdbInputName = "web"
+
+func Test_Policy_PodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		description   string
+		do            func(pdbs chan watch.Event, timeout chan time.Time)
+		expectedError error
+	}{
+		{
+			description: "Should succeed when the budget matches Pods",
+			do: func(pdbs chan watch.Event, timeout chan time.Time) {
+				pdbs <- watchAddedEvent(pdbUnobserved(pdbInputName))
+				pdbs <- watchAddedEvent(pdbObserved(pdbInputName, 3))
+			},
+		},
+		{
+			description: "Should succeed when the budget matches no Pods",
+			do: func(pdbs chan watch.Event, timeout chan time.Time) {
+				pdbs <- watchAddedEvent(pdbObserved(pdbInputName, 0))
+			},
+		},
+		{
+			description: "Should fail if the disruption controller never observes the budget",
+			do: func(pdbs chan watch.Event, timeout chan time.Time) {
+				pdbs <- watchAddedEvent(pdbUnobserved(pdbInputName))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: pdbInputName,
+				subErrors: []string{
+					"PodDisruptionBudget has not yet been observed by the disruption controller"},
+			},
+		},
+		{
+			description: "Should ignore unrelated budgets",
+			do: func(pdbs chan watch.Event, timeout chan time.Time) {
+				pdbs <- watchAddedEvent(pdbObserved("db", 3))
+
+				// Timeout. Failure.
+				timeout <- time.Now()
+			},
+			expectedError: &timeoutError{
+				objectName: pdbInputName,
+				subErrors: []string{
+					"PodDisruptionBudget has not yet been observed by the disruption controller"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		awaiter := makePDBInitAwaiter(mockAwaitConfig(pdbUnobserved(pdbInputName)))
+		pdbs := make(chan watch.Event)
+
+		timeout := make(chan time.Time)
+		go test.do(pdbs, timeout)
+
+		err := awaiter.await(&chanWatcher{results: pdbs}, timeout)
+		assert.Equal(t, test.expectedError, err, test.description)
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func pdbUnobserved(name string) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "policy/v1beta1",
+    "kind": "PodDisruptionBudget",
+    "metadata": {
+        "name": "%s",
+        "namespace": "default",
+        "generation": 1
+    },
+    "spec": {
+        "minAvailable": 2,
+        "selector": {"matchLabels": {"app": "web"}}
+    }
+}`, name))
+}
+
+func pdbObserved(name string, expectedPods int) *unstructured.Unstructured {
+	return mustDecodeUnstructured(fmt.Sprintf(`{
+    "apiVersion": "policy/v1beta1",
+    "kind": "PodDisruptionBudget",
+    "metadata": {
+        "name": "%s",
+        "namespace": "default",
+        "generation": 1
+    },
+    "spec": {
+        "minAvailable": 2,
+        "selector": {"matchLabels": {"app": "web"}}
+    },
+    "status": {
+        "observedGeneration": 1,
+        "expectedPods": %d,
+        "currentHealthy": %d,
+        "desiredHealthy": 2,
+        "disruptionsAllowed": 1
+    }
+}`, name, expectedPods, expectedPods))
+}
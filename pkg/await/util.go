@@ -15,11 +15,11 @@
 package await
 
 import (
-	"fmt"
 	"log"
 	"sort"
 
 	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,16 +65,6 @@ func watchAddedEvent(obj runtime.Object) watch.Event {
 	}
 }
 
-func stringifyEvents(events []v1.Event) string {
-	var output string
-	for _, e := range events {
-		output += fmt.Sprintf("\n   * %s (%s): %s: %s",
-			e.InvolvedObject.Name, e.InvolvedObject.Kind,
-			e.Reason, e.Message)
-	}
-	return output
-}
-
 func getLastWarningsForObject(
 	clientForEvents dynamic.ResourceInterface, namespace, name, kind string, limit int,
 ) ([]v1.Event, error) {
@@ -175,6 +165,75 @@ func resourceListEquals(x, y v1.ResourceList) bool {
 	return true
 }
 
+// int64Value converts a numeric value plucked from an object into an `int64`. Objects returned by
+// the API server decode numbers as `int64`, while objects built from Pulumi inputs decode them as
+// `float64`, so we need to handle both.
+func int64Value(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case float64:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// --------------------------------------------------------------------------
+
+// Watch helpers.
+//
+// A watch with no selectors receives an event for every change to every object of the kind in the
+// namespace. In a large namespace (or when deploying hundreds of objects at once, each with its own
+// awaiter), that is a lot of load on the API server and on us, for events we immediately discard.
+// So, watches are scoped on the server side to the objects an awaiter actually cares about.
+
+// --------------------------------------------------------------------------
+
+// nameListOptions returns list options that restrict a watch to the object named `name`.
+func nameListOptions(name string) metav1.ListOptions {
+	return metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+	}
+}
+
+// selectorListOptions returns list options that restrict a watch to the objects matched by the
+// label selector at `.spec.selector` of `obj` (e.g., the Pods of a Deployment). If `obj` has no
+// valid selector, the watch is not restricted, so callers must still filter the events they get.
+func selectorListOptions(obj *unstructured.Unstructured) metav1.ListOptions {
+	rawSelector, _ := openapi.Pluck(obj.Object, "spec", "selector")
+	selectorMap, isMap := rawSelector.(map[string]interface{})
+	if !isMap || len(selectorMap) == 0 {
+		return metav1.ListOptions{}
+	}
+
+	var labelSelector metav1.LabelSelector
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(selectorMap, &labelSelector)
+	if err != nil {
+		glog.V(3).Infof("Could not parse selector of '%s': %v", obj.GetName(), err)
+		return metav1.ListOptions{}
+	}
+	selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil || selector.Empty() {
+		glog.V(3).Infof("Could not use selector of '%s' to scope watch: %v", obj.GetName(), err)
+		return metav1.ListOptions{}
+	}
+	return metav1.ListOptions{LabelSelector: selector.String()}
+}
+
 // --------------------------------------------------------------------------
 
 // Response helpers.
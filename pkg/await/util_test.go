@@ -3,10 +3,56 @@ package await
 import (
 	"context"
 	"fmt"
+	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+func Test_NameListOptions(t *testing.T) {
+	assert.Equal(t, "metadata.name=foo", nameListOptions("foo").FieldSelector)
+}
+
+func Test_SelectorListOptions(t *testing.T) {
+	tests := []struct {
+		description string
+		object      string
+		selector    string
+	}{
+		{
+			description: "Object without selector should not be scoped",
+			object:      `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"}}`,
+			selector:    "",
+		},
+		{
+			description: "Object with matchLabels should be scoped to them",
+			object: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			          "spec": {"selector": {"matchLabels": {"app": "foo", "tier": "web"}}}}`,
+			selector: "app=foo,tier=web",
+		},
+		{
+			description: "Object with matchExpressions should be scoped to them",
+			object: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			          "spec": {"selector": {"matchExpressions": [
+			              {"key": "app", "operator": "In", "values": ["foo", "bar"]}]}}}`,
+			selector: "app in (bar,foo)",
+		},
+		{
+			description: "Object with invalid selector should not be scoped",
+			object: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "foo"},
+			          "spec": {"selector": {"matchExpressions": [
+			              {"key": "app", "operator": "Sideways"}]}}}`,
+			selector: "",
+		},
+	}
+
+	for _, test := range tests {
+		obj, err := decodeUnstructured(test.object)
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.selector, selectorListOptions(obj).LabelSelector, test.description)
+	}
+}
+
 func mockAwaitConfig(obj *unstructured.Unstructured) createAwaitConfig {
 	return createAwaitConfig{
 		ctx:               context.Background(),
@@ -28,3 +74,11 @@ func decodeUnstructured(text string) (*unstructured.Unstructured, error) {
 	}
 	return unst, nil
 }
+
+func mustDecodeUnstructured(text string) *unstructured.Unstructured {
+	obj, err := decodeUnstructured(text)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
@@ -0,0 +1,145 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// --------------------------------------------------------------------------
+
+// Watch resumption across awaiters.
+//
+// A single deployment can await the same object several times (e.g., a Service that is replaced,
+// and then awaited again when it is created). Rather than having each awaiter re-list and re-watch
+// from scratch, awaiters record the last state they observed for each object in a `watchCache`.
+// Subsequent awaiters prime themselves with that state, and resume the watch from its
+// `resourceVersion`, so that they receive only the events that happened since.
+//
+// Each cluster the provider connects to has a cache of its own (see `awaitCacheFor`), so that objects
+// of the same name on different clusters (e.g., of two providers in one program) don't collide.
+// The caches live for the lifetime of the provider process, i.e., a single deployment. Entries
+// older than `watchCacheTTL` are ignored, since the API server may already have compacted the
+// history we'd need to resume from. Entries are dropped when their object is deleted, and primed
+// state is only trusted once the resumed watch (or a fresh read) has confirmed it.
+
+// --------------------------------------------------------------------------
+
+const watchCacheTTL = 2 * time.Minute
+
+type watchCacheEntry struct {
+	object   *unstructured.Unstructured
+	observed time.Time
+}
+
+type watchCache struct {
+	lock    sync.Mutex
+	entries map[string]watchCacheEntry
+}
+
+var awaitCaches = struct {
+	lock   sync.Mutex
+	caches map[interface{}]*watchCache
+}{caches: map[interface{}]*watchCache{}}
+
+// awaitCacheFor returns the cache of the cluster that the discovery client `disco` connects to.
+// Every provider creates its own discovery client (see `client.NewClients`), so the caches of the
+// clusters of different providers are distinct.
+func awaitCacheFor(disco interface{}) *watchCache {
+	awaitCaches.lock.Lock()
+	defer awaitCaches.lock.Unlock()
+
+	cache, exists := awaitCaches.caches[disco]
+	if !exists {
+		cache = &watchCache{entries: map[string]watchCacheEntry{}}
+		awaitCaches.caches[disco] = cache
+	}
+	return cache
+}
+
+func watchCacheKey(apiVersion, kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", apiVersion, kind, namespace, name)
+}
+
+// observe records the state of an object as seen in a watch event.
+func (wc *watchCache) observe(event watch.Event) {
+	obj, isUnstructured := event.Object.(*unstructured.Unstructured)
+	if !isUnstructured {
+		return
+	}
+
+	wc.lock.Lock()
+	defer wc.lock.Unlock()
+
+	key := watchCacheKey(obj.GetAPIVersion(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	if event.Type == watch.Deleted {
+		delete(wc.entries, key)
+		return
+	}
+	wc.entries[key] = watchCacheEntry{object: obj.DeepCopy(), observed: time.Now()}
+}
+
+// forget drops the recorded state of an object that is being deleted, so that an awaiter of an
+// object of the same name that replaces it isn't primed with the state of the old one. The
+// Endpoints of a Service are dropped along with it.
+func (wc *watchCache) forget(obj *unstructured.Unstructured) {
+	wc.lock.Lock()
+	defer wc.lock.Unlock()
+
+	namespace, name := obj.GetNamespace(), obj.GetName()
+	delete(wc.entries, watchCacheKey(obj.GetAPIVersion(), obj.GetKind(), namespace, name))
+	if obj.GetKind() == "Service" {
+		delete(wc.entries, watchCacheKey("v1", "Endpoints", namespace, name))
+	}
+}
+
+// lookup returns the last observed state of an object, if it was observed recently enough to
+// resume a watch from.
+func (wc *watchCache) lookup(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, bool) {
+	wc.lock.Lock()
+	defer wc.lock.Unlock()
+
+	entry, exists := wc.entries[watchCacheKey(apiVersion, kind, namespace, name)]
+	if !exists || time.Since(entry.observed) > watchCacheTTL {
+		return nil, false
+	}
+	return entry.object.DeepCopy(), true
+}
+
+// watch starts a watch of the object named `name` using `clientForResource`, resuming from the
+// `resourceVersion` of `cached` if it is non-nil. If the watch cannot be resumed, a fresh watch is
+// started instead.
+func (wc *watchCache) watch(
+	clientForResource dynamic.ResourceInterface, name string, cached *unstructured.Unstructured,
+) (watch.Interface, error) {
+	if cached != nil && cached.GetResourceVersion() != "" {
+		opts := nameListOptions(name)
+		opts.ResourceVersion = cached.GetResourceVersion()
+		watcher, err := watchWithReconnect(clientForResource, opts)
+		if err == nil {
+			return watcher, nil
+		}
+		glog.V(3).Infof("Could not resume watch for '%s' from resourceVersion %s: %v",
+			cached.GetName(), cached.GetResourceVersion(), err)
+	}
+	return watchWithReconnect(clientForResource, nameListOptions(name))
+}
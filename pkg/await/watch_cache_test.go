@@ -0,0 +1,61 @@
+package await
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func Test_WatchCache(t *testing.T) {
+	cache := &watchCache{entries: map[string]watchCacheEntry{}}
+
+	_, found := cache.lookup("v1", "Service", "default", "foo")
+	assert.False(t, found, "Expected empty cache to have no entries")
+
+	service := initializedService("default", "foo")
+	service.SetResourceVersion("42")
+	cache.observe(watchAddedEvent(service))
+
+	cached, found := cache.lookup("v1", "Service", "default", "foo")
+	assert.True(t, found, "Expected observed Service to be cached")
+	assert.Equal(t, "42", cached.GetResourceVersion())
+
+	_, found = cache.lookup("v1", "Endpoints", "default", "foo")
+	assert.False(t, found, "Expected objects of other kinds not to collide")
+
+	// Stale entries are ignored, since the watch may no longer be resumable.
+	key := watchCacheKey("v1", "Service", "default", "foo")
+	entry := cache.entries[key]
+	entry.observed = time.Now().Add(-2 * watchCacheTTL)
+	cache.entries[key] = entry
+	_, found = cache.lookup("v1", "Service", "default", "foo")
+	assert.False(t, found, "Expected stale entry to be ignored")
+
+	// Deletion evicts the entry.
+	cache.observe(watchAddedEvent(service))
+	cache.observe(watch.Event{Type: watch.Deleted, Object: service})
+	_, found = cache.lookup("v1", "Service", "default", "foo")
+	assert.False(t, found, "Expected deleted Service to be evicted")
+
+	// Deleting a Service forgets it and its Endpoints, so that a replacement isn't primed with them.
+	cache.observe(watchAddedEvent(service))
+	cache.observe(watchAddedEvent(initializedEndpoint("default", "foo")))
+	cache.forget(service)
+	_, found = cache.lookup("v1", "Service", "default", "foo")
+	assert.False(t, found, "Expected forgotten Service to be evicted")
+	_, found = cache.lookup("v1", "Endpoints", "default", "foo")
+	assert.False(t, found, "Expected Endpoints of forgotten Service to be evicted")
+}
+
+func Test_WatchCache_PerCluster(t *testing.T) {
+	// Stand-ins for the discovery clients of two providers.
+	cluster1, cluster2 := new(int), new(int)
+
+	awaitCacheFor(cluster1).observe(watchAddedEvent(initializedService("default", "foo")))
+	_, found := awaitCacheFor(cluster1).lookup("v1", "Service", "default", "foo")
+	assert.True(t, found, "Expected the cache of a cluster to be reused")
+	_, found = awaitCacheFor(cluster2).lookup("v1", "Service", "default", "foo")
+	assert.False(t, found, "Expected clusters not to share cached objects")
+}
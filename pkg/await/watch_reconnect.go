@@ -0,0 +1,233 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package await
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+// --------------------------------------------------------------------------
+
+// Watch reconnection.
+//
+// The API server ends watches routinely: when it restarts, when the watch has been idle for too
+// long, or when the history it would need to serve the watch has been compacted away. When that
+// happens, the watch's result channel is closed (sometimes after an `Error` event). An awaiter
+// reading from it directly would then either spin on the closed channel, or see no further events
+// and eventually time out, even though the object may well have become ready.
+//
+// So, awaiters watch through a `reconnectingWatcher`, which re-establishes the watch whenever it
+// ends, resuming from the `resourceVersion` of the last event it received so that no events are
+// lost. If the history needed to resume has been compacted (`410 Gone`), it starts a fresh watch
+// instead, which begins with a synthetic `Added` event for each existing object, so awaiters see
+// the current state of the world. Reconnection is retried with exponential backoff, and only if it
+// fails `maxWatchReconnects` times in a row does the watcher give up and report an `Error` event,
+// which awaiters surface with `watchError`. Each connection attempt retries a `401 Unauthorized`
+// once, so that watches outlive the short-lived credentials of exec plugins and auth providers (see
+// `client.RetryUnauthorized`).
+
+// --------------------------------------------------------------------------
+
+var (
+	maxWatchReconnects        = 5
+	initialWatchReconnectWait = 1 * time.Second
+	maxWatchReconnectWait     = 30 * time.Second
+)
+
+type reconnectingWatcher struct {
+	connect func(resourceVersion string) (watch.Interface, error)
+	results chan watch.Event
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+var _ watch.Interface = (*reconnectingWatcher)(nil)
+
+// watchWithReconnect starts a watch using `clientForResource`, which is re-established if the API
+// server ends it.
+func watchWithReconnect(
+	clientForResource dynamic.ResourceInterface, opts metav1.ListOptions,
+) (watch.Interface, error) {
+	return newReconnectingWatcher(func(resourceVersion string) (watch.Interface, error) {
+		resumeOpts := opts
+		resumeOpts.ResourceVersion = resourceVersion
+		var watcher watch.Interface
+		err := client.RetryUnauthorized(func() (err error) {
+			watcher, err = clientForResource.Watch(resumeOpts)
+			return err
+		})
+		return watcher, err
+	}, opts.ResourceVersion)
+}
+
+func newReconnectingWatcher(
+	connect func(resourceVersion string) (watch.Interface, error), resourceVersion string,
+) (*reconnectingWatcher, error) {
+	current, err := connect(resourceVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	rw := &reconnectingWatcher{
+		connect: connect,
+		results: make(chan watch.Event),
+		stopCh:  make(chan struct{}),
+	}
+	go rw.run(current, resourceVersion)
+	return rw, nil
+}
+
+func (rw *reconnectingWatcher) Stop() {
+	rw.stopOnce.Do(func() { close(rw.stopCh) })
+}
+
+func (rw *reconnectingWatcher) ResultChan() <-chan watch.Event {
+	return rw.results
+}
+
+func (rw *reconnectingWatcher) stopped() bool {
+	select {
+	case <-rw.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (rw *reconnectingWatcher) run(current watch.Interface, resourceVersion string) {
+	for {
+		resourceVersion = rw.forward(current, resourceVersion)
+		current.Stop()
+		if rw.stopped() {
+			return
+		}
+
+		var err error
+		current, resourceVersion, err = rw.reconnect(resourceVersion)
+		if rw.stopped() {
+			return
+		}
+		if err != nil {
+			rw.send(watch.Event{Type: watch.Error, Object: &metav1.Status{
+				Status: metav1.StatusFailure,
+				Message: fmt.Sprintf("Watch could not be re-established after %d attempts: %v",
+					maxWatchReconnects, err),
+			}})
+			return
+		}
+	}
+}
+
+// forward relays the events of `current` until it ends or the watcher is stopped, and returns the
+// `resourceVersion` to resume from.
+func (rw *reconnectingWatcher) forward(current watch.Interface, resourceVersion string) string {
+	for {
+		select {
+		case <-rw.stopCh:
+			return resourceVersion
+		case event, ok := <-current.ResultChan():
+			if !ok {
+				glog.V(3).Infof("Watch closed by the API server; reconnecting")
+				return resourceVersion
+			}
+			if event.Type == watch.Error {
+				// The watch is about to end. Typically this is a `410 Gone`, in which case we can't
+				// resume, so start over with a fresh watch. Expired credentials don't invalidate the
+				// `resourceVersion`, though.
+				err := apierrors.FromObject(event.Object)
+				glog.V(3).Infof("Watch ended with error; reconnecting: %v", err)
+				if !apierrors.IsUnauthorized(err) {
+					resourceVersion = ""
+				}
+				continue
+			}
+			if obj, isUnstructured := event.Object.(*unstructured.Unstructured); isUnstructured {
+				resourceVersion = obj.GetResourceVersion()
+			}
+			if !rw.send(event) {
+				return resourceVersion
+			}
+		}
+	}
+}
+
+// reconnect re-establishes the watch from `resourceVersion`, retrying with exponential backoff.
+func (rw *reconnectingWatcher) reconnect(
+	resourceVersion string,
+) (watch.Interface, string, error) {
+	wait := initialWatchReconnectWait
+	var err error
+	for attempt := 1; attempt <= maxWatchReconnects; attempt++ {
+		select {
+		case <-rw.stopCh:
+			return nil, resourceVersion, nil
+		case <-time.After(wait):
+		}
+
+		var next watch.Interface
+		next, err = rw.connect(resourceVersion)
+		if err == nil {
+			return next, resourceVersion, nil
+		}
+		glog.V(3).Infof("Could not re-establish watch (attempt %d of %d): %v", attempt,
+			maxWatchReconnects, err)
+		if isGone(err) {
+			resourceVersion = ""
+		}
+
+		wait *= 2
+		if wait > maxWatchReconnectWait {
+			wait = maxWatchReconnectWait
+		}
+	}
+	return nil, resourceVersion, err
+}
+
+// send delivers `event` to the consumer, returning false if the watcher was stopped instead.
+func (rw *reconnectingWatcher) send(event watch.Event) bool {
+	select {
+	case rw.results <- event:
+		return true
+	case <-rw.stopCh:
+		return false
+	}
+}
+
+// watchError returns the error reported by an `Error` event of a `reconnectingWatcher`, i.e., the
+// watch was lost and could not be re-established, or nil for any other event.
+func watchError(event watch.Event) error {
+	if event.Type != watch.Error {
+		return nil
+	}
+	return fmt.Errorf("Lost connection to the Kubernetes API server: %v",
+		apierrors.FromObject(event.Object))
+}
+
+func isGone(err error) bool {
+	statusErr, ok := err.(*apierrors.StatusError)
+	return ok && statusErr.ErrStatus.Code == http.StatusGone
+}
@@ -0,0 +1,140 @@
+package await
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// fakeWatchServer hands out a new `chanWatcher` for each connection, recording the
+// `resourceVersion` each connection was made from.
+type fakeWatchServer struct {
+	lock             sync.Mutex
+	watchers         chan chan watch.Event
+	resourceVersions []string
+	failAfter        int
+}
+
+func (fws *fakeWatchServer) connect(resourceVersion string) (watch.Interface, error) {
+	fws.lock.Lock()
+	defer fws.lock.Unlock()
+
+	if fws.failAfter > 0 && len(fws.resourceVersions) >= fws.failAfter {
+		return nil, fmt.Errorf("connection refused")
+	}
+	fws.resourceVersions = append(fws.resourceVersions, resourceVersion)
+	results := make(chan watch.Event)
+	fws.watchers <- results
+	return &chanWatcher{results: results}, nil
+}
+
+func (fws *fakeWatchServer) connections() []string {
+	fws.lock.Lock()
+	defer fws.lock.Unlock()
+	return append([]string{}, fws.resourceVersions...)
+}
+
+func withFastReconnects(f func()) {
+	maxAttempts, initialWait := maxWatchReconnects, initialWatchReconnectWait
+	maxWatchReconnects, initialWatchReconnectWait = 2, time.Millisecond
+	defer func() {
+		maxWatchReconnects, initialWatchReconnectWait = maxAttempts, initialWait
+	}()
+	f()
+}
+
+func Test_ReconnectingWatcher_ResumesAfterClose(t *testing.T) {
+	withFastReconnects(func() {
+		server := &fakeWatchServer{watchers: make(chan chan watch.Event, 2)}
+		watcher, err := newReconnectingWatcher(server.connect, "")
+		assert.NoError(t, err)
+		defer watcher.Stop()
+
+		first := <-server.watchers
+		first <- watchAddedEvent(objectAtVersion("foo", "10"))
+		event := <-watcher.ResultChan()
+		assert.Equal(t, "10", event.Object.(*unstructured.Unstructured).GetResourceVersion())
+		close(first)
+
+		second := <-server.watchers
+		second <- watch.Event{Type: watch.Modified, Object: objectAtVersion("foo", "11")}
+		event = <-watcher.ResultChan()
+		assert.Equal(t, watch.Modified, event.Type)
+		assert.Nil(t, watchError(event))
+		assert.Equal(t, []string{"", "10"}, server.connections())
+	})
+}
+
+func Test_ReconnectingWatcher_StartsOverAfterError(t *testing.T) {
+	withFastReconnects(func() {
+		server := &fakeWatchServer{watchers: make(chan chan watch.Event, 2)}
+		watcher, err := newReconnectingWatcher(server.connect, "")
+		assert.NoError(t, err)
+		defer watcher.Stop()
+
+		first := <-server.watchers
+		first <- watchAddedEvent(objectAtVersion("foo", "10"))
+		<-watcher.ResultChan()
+		first <- watch.Event{Type: watch.Error, Object: &metav1.Status{
+			Status: metav1.StatusFailure, Code: 410, Reason: metav1.StatusReasonGone,
+		}}
+		close(first)
+
+		second := <-server.watchers
+		second <- watchAddedEvent(objectAtVersion("foo", "12"))
+		<-watcher.ResultChan()
+		assert.Equal(t, []string{"", ""}, server.connections())
+	})
+}
+
+func Test_ReconnectingWatcher_ResumesAfterUnauthorized(t *testing.T) {
+	withFastReconnects(func() {
+		server := &fakeWatchServer{watchers: make(chan chan watch.Event, 2)}
+		watcher, err := newReconnectingWatcher(server.connect, "")
+		assert.NoError(t, err)
+		defer watcher.Stop()
+
+		first := <-server.watchers
+		first <- watchAddedEvent(objectAtVersion("foo", "10"))
+		<-watcher.ResultChan()
+		first <- watch.Event{Type: watch.Error, Object: &metav1.Status{
+			Status: metav1.StatusFailure, Code: 401, Reason: metav1.StatusReasonUnauthorized,
+		}}
+		close(first)
+
+		second := <-server.watchers
+		second <- watch.Event{Type: watch.Modified, Object: objectAtVersion("foo", "11")}
+		<-watcher.ResultChan()
+		assert.Equal(t, []string{"", "10"}, server.connections())
+	})
+}
+
+func Test_ReconnectingWatcher_GivesUp(t *testing.T) {
+	withFastReconnects(func() {
+		server := &fakeWatchServer{watchers: make(chan chan watch.Event, 1), failAfter: 1}
+		watcher, err := newReconnectingWatcher(server.connect, "")
+		assert.NoError(t, err)
+		defer watcher.Stop()
+
+		close(<-server.watchers)
+		event := <-watcher.ResultChan()
+		assert.Equal(t, watch.Error, event.Type)
+		assert.Error(t, watchError(event))
+	})
+}
+
+func objectAtVersion(name, resourceVersion string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+	}}
+	obj.SetName(name)
+	obj.SetResourceVersion(resourceVersion)
+	return obj
+}
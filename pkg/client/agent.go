@@ -0,0 +1,119 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"k8s.io/client-go/rest"
+)
+
+// --------------------------------------------------------------------------
+
+// In-cluster agent mode.
+//
+// Some clusters have API servers that are not reachable from the network the provider runs in
+// (e.g., private GKE/EKS endpoints, and CI runners outside the VPC). For these, a small agent
+// (`pulumi-kubernetes-agent`) is deployed once inside the cluster, and exposed to the provider over
+// whatever tunnel is available (a bastion, an SSH or VPN tunnel, an authenticated ingress, etc.).
+//
+// The agent is a thin, authenticated reverse proxy: it forwards every request to the API server
+// using its own ServiceAccount credentials, and streams the responses (including watches) back. All
+// diff, await, and checkpoint logic stays in the provider, which talks to the agent exactly as it
+// would to the API server.
+
+// --------------------------------------------------------------------------
+
+// AgentTokenHeader carries the shared secret that authenticates the provider to the agent. It is
+// distinct from `Authorization`, which the agent replaces with its own credentials.
+const AgentTokenHeader = "X-Pulumi-Agent-Token"
+
+// AgentConfig specifies how to reach an in-cluster agent.
+type AgentConfig struct {
+	// URL is the address at which the agent is reachable, e.g., "https://localhost:8443".
+	URL string `json:"url"`
+	// Token is the shared secret the agent was deployed with.
+	Token string `json:"token"`
+	// CACert is the PEM-encoded certificate authority for the agent's serving certificate. If
+	// empty, the system roots are used.
+	CACert string `json:"caCert"`
+	// Insecure disables verification of the agent's serving certificate.
+	Insecure bool `json:"insecure"`
+}
+
+// ParseAgentConfig parses a JSON-encoded agent config (e.g., from provider config).
+func ParseAgentConfig(text string) (AgentConfig, error) {
+	agent := AgentConfig{}
+	if err := json.Unmarshal([]byte(text), &agent); err != nil {
+		return AgentConfig{}, fmt.Errorf("failed to parse agent config: %v", err)
+	}
+	u, err := url.Parse(agent.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return AgentConfig{}, fmt.Errorf("agent config must specify an http(s) `url`, got %q", agent.URL)
+	}
+	if agent.Token == "" {
+		return AgentConfig{}, fmt.Errorf("agent config must specify a `token`")
+	}
+	return agent, nil
+}
+
+// WithAgent returns a copy of `conf` that sends every request to the in-cluster agent instead of
+// directly to the API server. The credentials in `conf` are dropped, since the agent authenticates
+// to the API server with its own; rate limiting, throttling, and retry settings are preserved.
+func WithAgent(conf *rest.Config, agent AgentConfig) *rest.Config {
+	agentConf := &rest.Config{
+		Host:          agent.URL,
+		APIPath:       conf.APIPath,
+		ContentConfig: conf.ContentConfig,
+		UserAgent:     conf.UserAgent,
+		QPS:           conf.QPS,
+		Burst:         conf.Burst,
+		RateLimiter:   conf.RateLimiter,
+		Timeout:       conf.Timeout,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: agent.Insecure,
+			CAData:   []byte(agent.CACert),
+		},
+	}
+
+	wrap := conf.WrapTransport
+	agentConf.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &agentRoundTripper{rt: rt, token: agent.Token}
+	}
+	return agentConf
+}
+
+type agentRoundTripper struct {
+	rt    http.RoundTripper
+	token string
+}
+
+func (a *agentRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Per the `http.RoundTripper` contract, don't modify the caller's request.
+	withToken := new(http.Request)
+	*withToken = *req
+	withToken.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		withToken.Header[k] = v
+	}
+	withToken.Header.Set(AgentTokenHeader, a.token)
+	return a.rt.RoundTrip(withToken)
+}
@@ -0,0 +1,72 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestParseAgentConfig(t *testing.T) {
+	agent, err := ParseAgentConfig(`{"url": "https://localhost:8443", "token": "s3cr3t"}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if agent.URL != "https://localhost:8443" || agent.Token != "s3cr3t" {
+		t.Errorf("Unexpected agent config: %+v", agent)
+	}
+
+	for _, text := range []string{
+		`{"token": "s3cr3t"}`,
+		`{"url": "localhost:8443", "token": "s3cr3t"}`,
+		`{"url": "https://localhost:8443"}`,
+	} {
+		if _, err := ParseAgentConfig(text); err == nil {
+			t.Errorf("Expected error parsing %s", text)
+		}
+	}
+}
+
+func TestWithAgent(t *testing.T) {
+	conf := WithAgent(&rest.Config{
+		Host:        "https://10.0.0.1",
+		BearerToken: "cluster-token",
+	}, AgentConfig{URL: "https://localhost:8443", Token: "s3cr3t"})
+
+	if conf.Host != "https://localhost:8443" {
+		t.Errorf("Expected requests to be sent to the agent, got host %q", conf.Host)
+	}
+	if conf.BearerToken != "" {
+		t.Errorf("Expected cluster credentials to be dropped")
+	}
+
+	var sent *http.Request
+	rt := conf.WrapTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		sent = req
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	req, _ := http.NewRequest("GET", "https://localhost:8443/api/v1/namespaces", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sent.Header.Get(AgentTokenHeader) != "s3cr3t" {
+		t.Errorf("Expected agent token to be sent")
+	}
+	if req.Header.Get(AgentTokenHeader) != "" {
+		t.Errorf("Expected caller's request to be left unmodified")
+	}
+}
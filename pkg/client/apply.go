@@ -0,0 +1,191 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// --------------------------------------------------------------------------
+
+// Server-side apply and dry run.
+//
+// With server-side apply, we send the API server the complete object as we want it to be, and the
+// API server merges it into the live object, tracking which fields each "field manager" (e.g.,
+// Pulumi, `kubectl`, or a controller) set in `.metadata.managedFields`. Fields we stop setting are
+// removed, unless another manager also set them; and if we try to set a field another manager set
+// to a different value, the API server rejects the request with a conflict naming that manager,
+// rather than silently clobbering its change.
+//
+// Similarly, with a server-side dry run (`dryRun=All`), the API server processes a write request as
+// usual, including defaulting, validation, and admission webhooks, and returns the resulting object,
+// but does not persist it. This lets previews show what an update would actually do.
+//
+// The dynamic client we build against predates both (it can't set the `fieldManager` or `dryRun`
+// parameters), so we issue these requests with the REST client of the discovery client instead.
+// Other writes are attributed to the field manager named by their user agent.
+
+// --------------------------------------------------------------------------
+
+// ApplyPatchType is the patch type of server-side apply requests.
+const ApplyPatchType types.PatchType = "application/apply-patch+yaml"
+
+// ApplyConflictError is returned by `Apply` when the object sets fields that are owned by other
+// field managers.
+type ApplyConflictError struct {
+	Object string
+
+	// Conflicts describes each contested field, and the manager that owns it, e.g.,
+	// `.spec.replicas: conflict with "kubectl-edit" using apps/v1`.
+	Conflicts []string
+}
+
+func (e *ApplyConflictError) Error() string {
+	return fmt.Sprintf(
+		"server-side apply of '%s' conflicts with changes made by other field managers:\n  * %s\n"+
+			"Stop setting these fields, or revert the other changes, and retry.",
+		e.Object, strings.Join(e.Conflicts, "\n  * "))
+}
+
+// Apply issues a server-side apply of `obj` on behalf of `fieldManager`, and returns the resulting
+// live object. Apply creates the object if it doesn't exist.
+func Apply(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, fieldManager string,
+) (*unstructured.Unstructured, error) {
+	return apply(disco, obj, map[string]string{"fieldManager": fieldManager})
+}
+
+// ForceApply is like `Apply`, but takes ownership of fields set by other field managers rather than
+// failing with an `*ApplyConflictError`.
+func ForceApply(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, fieldManager string,
+) (*unstructured.Unstructured, error) {
+	return apply(disco, obj, map[string]string{"fieldManager": fieldManager, "force": "true"})
+}
+
+// DryRunApply returns the object that would result from `Apply`, without persisting it.
+func DryRunApply(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, fieldManager string,
+) (*unstructured.Unstructured, error) {
+	return apply(disco, obj, map[string]string{"fieldManager": fieldManager, "dryRun": "All"})
+}
+
+// DryRunPatch returns the object that would result from patching the live version of `obj` with
+// `patch`, without persisting it.
+func DryRunPatch(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, patchType types.PatchType,
+	patch []byte,
+) (*unstructured.Unstructured, error) {
+	return patchObject(disco, obj, patchType, patch, map[string]string{"dryRun": "All"})
+}
+
+// WithFieldManager configures `conf` so that the API server attributes the changes made by requests
+// other than server-side applies (e.g., creates and client-side patches) to `fieldManager` as well.
+// The API server derives the field manager of such requests from their user agent.
+func WithFieldManager(conf *rest.Config, fieldManager string) *rest.Config {
+	conf.UserAgent = fieldManager
+	return conf
+}
+
+func apply(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, params map[string]string,
+) (*unstructured.Unstructured, error) {
+	body, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: JSON is YAML, so we needn't convert the body to YAML.
+	applied, err := patchObject(disco, obj, ApplyPatchType, body, params)
+	if err != nil {
+		return nil, applyError(obj, err)
+	}
+	return applied, nil
+}
+
+// patchObject issues a patch request for `obj` with the REST client of `disco`, and returns the
+// resulting object.
+func patchObject(
+	disco discovery.DiscoveryInterface, obj *unstructured.Unstructured, patchType types.PatchType,
+	body []byte, params map[string]string,
+) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	resource, err := serverResourceForGVK(disco, gvk)
+	if err != nil {
+		return nil, err
+	}
+
+	request := disco.RESTClient().Patch(patchType).
+		AbsPath(resourcePath(gvk, resource, obj.GetNamespace(), obj.GetName())...).
+		Body(body)
+	for key, value := range params {
+		request = request.Param(key, value)
+	}
+	raw, err := request.Do().Raw()
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &unstructured.Unstructured{}
+	if err = patched.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// resourcePath returns the segments of the API path of the object `name` of kind `gvk`.
+func resourcePath(
+	gvk schema.GroupVersionKind, resource *metav1.APIResource, namespace, name string,
+) []string {
+	path := []string{"/apis", gvk.Group, gvk.Version}
+	if gvk.Group == "" {
+		path = []string{"/api", gvk.Version}
+	}
+	if resource.Namespaced {
+		path = append(path, "namespaces", NamespaceOrDefault(namespace))
+	}
+	return append(path, resource.Name, name)
+}
+
+// applyError turns a conflict returned by a server-side apply of `obj` into an
+// `*ApplyConflictError`, and returns other errors unchanged.
+func applyError(obj *unstructured.Unstructured, err error) error {
+	statusErr, isStatusErr := err.(*errors.StatusError)
+	if !isStatusErr || !errors.IsConflict(err) || statusErr.ErrStatus.Details == nil {
+		return err
+	}
+
+	var conflicts []string
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if cause.Field == "" {
+			conflicts = append(conflicts, cause.Message)
+		} else {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+	if len(conflicts) == 0 {
+		return err
+	}
+	return &ApplyConflictError{Object: FqObjName(obj), Conflicts: conflicts}
+}
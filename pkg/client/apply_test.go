@@ -0,0 +1,91 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestResourcePath(t *testing.T) {
+	tests := []struct {
+		gvk       schema.GroupVersionKind
+		resource  metav1.APIResource
+		namespace string
+		expected  []string
+	}{
+		{
+			gvk:       schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+			resource:  metav1.APIResource{Name: "deployments", Namespaced: true},
+			namespace: "prod",
+			expected:  []string{"/apis", "apps", "v1", "namespaces", "prod", "deployments", "web"},
+		},
+		{
+			gvk:      schema.GroupVersionKind{Version: "v1", Kind: "Service"},
+			resource: metav1.APIResource{Name: "services", Namespaced: true},
+			expected: []string{"/api", "v1", "namespaces", "default", "services", "web"},
+		},
+		{
+			gvk: schema.GroupVersionKind{
+				Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"},
+			resource: metav1.APIResource{Name: "clusterroles"},
+			expected: []string{"/apis", "rbac.authorization.k8s.io", "v1", "clusterroles", "web"},
+		},
+	}
+
+	for _, test := range tests {
+		path := resourcePath(test.gvk, &test.resource, test.namespace, "web")
+		if !reflect.DeepEqual(test.expected, path) {
+			t.Errorf("Expected path %v for %s, got %v", test.expected, test.gvk, path)
+		}
+	}
+}
+
+func TestApplyError(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetNamespace("prod")
+	obj.SetName("web")
+
+	conflict := &errors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusConflict,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{
+			Type:    "FieldManagerConflict",
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   ".spec.replicas",
+		}}},
+	}}
+	err, isConflict := applyError(obj, conflict).(*ApplyConflictError)
+	if !isConflict {
+		t.Fatalf("Expected a conflict error, got %v", err)
+	}
+	expected := []string{`.spec.replicas: conflict with "kubectl-edit" using apps/v1`}
+	if err.Object != "prod/web" || !reflect.DeepEqual(expected, err.Conflicts) {
+		t.Errorf("Unexpected conflict error: %#v", err)
+	}
+
+	other := fmt.Errorf("connection refused")
+	if applyError(obj, other) != other {
+		t.Errorf("Expected other errors to be returned unchanged")
+	}
+}
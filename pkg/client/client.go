@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apiVers "k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 )
 
@@ -40,6 +41,29 @@ import (
 
 // --------------------------------------------------------------------------
 
+// NewClients creates the discovery client and the dynamic client pool for the cluster configured by
+// `conf`. Each call creates new clients, with their own discovery cache, so that each configured
+// provider (e.g., one per cluster or kubeconfig context of a multi-cluster program) has its own
+// view of the API server it targets, and none of them can observe another's.
+func NewClients(
+	conf *rest.Config,
+) (discovery.CachedDiscoveryInterface, dynamic.ClientPool, error) {
+	disco, err := discovery.NewDiscoveryClientForConfig(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Cache the discovery information (OpenAPI schema, etc.) so we don't have to retrieve it for
+	// every request.
+	discoCache := NewMemcachedDiscoveryClient(disco)
+	mapper := discovery.NewDeferredDiscoveryRESTMapper(discoCache, dynamic.VersionInterfaces)
+	pathresolver := dynamic.LegacyAPIPathResolverFunc
+
+	// Create client pool, reusing one client per API group (e.g., one each for core, extensions,
+	// apps, etc.)
+	return discoCache, dynamic.NewClientPool(conf, mapper, pathresolver), nil
+}
+
 type memcachedDiscoveryClient struct {
 	cl              discovery.DiscoveryInterface
 	lock            sync.RWMutex
@@ -47,6 +71,11 @@ type memcachedDiscoveryClient struct {
 	serverresources map[string]*metav1.APIResourceList
 	schemas         map[string]*swagger.ApiDeclaration
 	schema          *openapi_v2.Document
+
+	// fresh is false from the time the cache is invalidated until it is repopulated, so that a
+	// `DeferredDiscoveryRESTMapper` built on it rebuilds its mappings rather than failing to find
+	// kinds registered in the meantime.
+	fresh bool
 }
 
 var _ discovery.CachedDiscoveryInterface = &memcachedDiscoveryClient{}
@@ -60,7 +89,9 @@ func NewMemcachedDiscoveryClient(cl discovery.DiscoveryInterface) discovery.Cach
 }
 
 func (c *memcachedDiscoveryClient) Fresh() bool {
-	return true
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.fresh
 }
 
 func (c *memcachedDiscoveryClient) Invalidate() {
@@ -70,6 +101,8 @@ func (c *memcachedDiscoveryClient) Invalidate() {
 	c.servergroups = nil
 	c.serverresources = make(map[string]*metav1.APIResourceList)
 	c.schemas = make(map[string]*swagger.ApiDeclaration)
+	c.schema = nil
+	c.fresh = false
 }
 
 func (c *memcachedDiscoveryClient) RESTClient() rest.Interface {
@@ -85,6 +118,7 @@ func (c *memcachedDiscoveryClient) ServerGroups() (*metav1.APIGroupList, error)
 		return c.servergroups, nil
 	}
 	c.servergroups, err = c.cl.ServerGroups()
+	c.fresh = err == nil
 	return c.servergroups, err
 }
 
@@ -0,0 +1,52 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// --------------------------------------------------------------------------
+
+// Credential refresh.
+//
+// Many clusters hand out short-lived credentials: EKS tokens last 15 minutes, and GKE and OIDC
+// tokens about an hour. The kubeconfig of such a cluster obtains them from an exec credential
+// plugin (`users[].user.exec`, e.g., `aws-iam-authenticator`), or from an auth provider
+// (`users[].user.auth-provider`, e.g., `gcp` or `oidc`, all of which are linked into the provider).
+// `clientcmd` carries either through to the `rest.Config`, and every client created from it asks
+// the plugin for the current credentials on each request, so nothing we layer on top of the
+// config (throttling, retries, etc.) may drop them.
+//
+// The plugin only learns that its credentials have expired when the API server rejects a request
+// with `401 Unauthorized`: it renews them then, but the rejected request itself fails. Since an
+// await can run for far longer than a token lives, and each re-established watch or poll is a new
+// request, callers that make requests over the course of an await retry a `401` once with
+// `RetryUnauthorized`, by which point the renewed credentials are in place. Static credentials are
+// rejected again, and the error is returned as usual.
+
+// --------------------------------------------------------------------------
+
+// RetryUnauthorized runs `op`, and runs it again if the API server rejected its credentials, so
+// that an exec credential plugin or auth provider can transparently renew expired credentials.
+func RetryUnauthorized(op func() error) error {
+	err := op()
+	if !errors.IsUnauthorized(err) {
+		return err
+	}
+	glog.V(3).Infof("Retrying request with renewed credentials after error: %v", err)
+	return op()
+}
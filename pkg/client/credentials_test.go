@@ -0,0 +1,57 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestRetryUnauthorized(t *testing.T) {
+	// Expired credentials are renewed by the rejected request, so the retry succeeds.
+	attempts := 0
+	err := RetryUnauthorized(func() error {
+		attempts++
+		if attempts == 1 {
+			return errors.NewUnauthorized("token expired")
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("Expected a successful retry, got %v after %d attempts", err, attempts)
+	}
+
+	// Other errors are not retried.
+	attempts = 0
+	err = RetryUnauthorized(func() error {
+		attempts++
+		return fmt.Errorf("connection refused")
+	})
+	if err == nil || attempts != 1 {
+		t.Errorf("Expected other errors not to be retried, got %v after %d attempts", err, attempts)
+	}
+
+	// Credentials that can't be renewed are rejected again.
+	attempts = 0
+	err = RetryUnauthorized(func() error {
+		attempts++
+		return errors.NewUnauthorized("invalid token")
+	})
+	if !errors.IsUnauthorized(err) || attempts != 2 {
+		t.Errorf("Expected a single retry, got %v after %d attempts", err, attempts)
+	}
+}
@@ -0,0 +1,411 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/emicklei/go-restful-swagger12"
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apiVers "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// --------------------------------------------------------------------------
+
+// Offline mode.
+//
+// Previews often run where the cluster isn't reachable (e.g., in CI). In offline mode, the
+// discovery client answers from the OpenAPI schema of a given Kubernetes minor version, which is
+// bundled with the provider (in the `schemas` directory next to its executable, as
+// `swagger-v1.<minor>.json`), rather than from the API server: the schema itself is used to
+// validate objects and compute patches, and the kinds the version serves (and whether they are
+// namespaced) are derived from the paths it documents. Nothing else can be done without the
+// cluster, so the dynamic client pool fails every request with `ErrOffline`.
+
+// --------------------------------------------------------------------------
+
+// ErrOffline is returned for requests that need the cluster while the provider is offline.
+var ErrOffline = fmt.Errorf("the cluster can't be reached in offline mode")
+
+var offlineVersionRe = regexp.MustCompile(`^v?1\.([0-9]+)$`)
+
+// ParseOfflineVersion parses a Kubernetes minor version, e.g., "1.16", that selects the bundled
+// schema of offline mode.
+func ParseOfflineVersion(version string) (ServerVersion, error) {
+	match := offlineVersionRe.FindStringSubmatch(version)
+	if match == nil {
+		return ServerVersion{}, fmt.Errorf(
+			"expected a Kubernetes version of the form \"1.<minor>\", got %q", version)
+	}
+	minor, _ := strconv.Atoi(match[1])
+	return ServerVersion{Major: 1, Minor: minor}, nil
+}
+
+// NewOfflineClients creates the discovery client and the dynamic client pool of offline mode, for
+// the bundled schema of Kubernetes `version`.
+func NewOfflineClients(
+	version ServerVersion,
+) (discovery.CachedDiscoveryInterface, dynamic.ClientPool, error) {
+	path, err := bundledSchemaPath(version)
+	if err != nil {
+		return nil, nil, err
+	}
+	swaggerJSON, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("no schema is bundled for Kubernetes %s (expected %s)", version, path)
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := openapi_v2.ParseDocument(swaggerJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse bundled schema %s: %v", path, err)
+	}
+	resources, err := offlineResources(swaggerJSON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse bundled schema %s: %v", path, err)
+	}
+
+	disco := &offlineDiscoveryClient{version: version, schema: doc, resources: resources}
+	return NewMemcachedDiscoveryClient(disco), offlineClientPool{}, nil
+}
+
+// LatestBundledVersion returns the newest Kubernetes version whose schema is bundled.
+func LatestBundledVersion() (ServerVersion, error) {
+	dir, err := bundledSchemaDir()
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	return latestSchemaVersion(dir)
+}
+
+var bundledSchemaRe = regexp.MustCompile(`^swagger-v(1\.[0-9]+)\.json$`)
+
+// latestSchemaVersion returns the newest Kubernetes version whose schema is in `dir`.
+func latestSchemaVersion(dir string) (ServerVersion, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return ServerVersion{}, err
+	}
+	var latest *ServerVersion
+	for _, file := range files {
+		match := bundledSchemaRe.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+		version, err := ParseOfflineVersion(match[1])
+		if err != nil {
+			continue
+		}
+		if latest == nil || version.Minor > latest.Minor {
+			latest = &version
+		}
+	}
+	if latest == nil {
+		return ServerVersion{}, fmt.Errorf("no schemas are bundled (expected them in %s)", dir)
+	}
+	return *latest, nil
+}
+
+// bundledSchemaPath returns the path of the bundled schema of Kubernetes `version`.
+func bundledSchemaPath(version ServerVersion) (string, error) {
+	dir, err := bundledSchemaDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, fmt.Sprintf("swagger-v%s.json", version)), nil
+}
+
+func bundledSchemaDir() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(executable), "schemas"), nil
+}
+
+// offlineResources derives the resources served by each group/version from the paths of an
+// OpenAPI document.
+func offlineResources(swaggerJSON []byte) (map[string]*metav1.APIResourceList, error) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(swaggerJSON, &doc); err != nil {
+		return nil, err
+	}
+
+	lists := map[string]*metav1.APIResourceList{}
+	byName := map[string]*metav1.APIResource{}
+	for path, operations := range doc.Paths {
+		gv, name, namespaced, ok := parseResourcePath(path)
+		if !ok {
+			continue
+		}
+		for method, operationJSON := range operations {
+			if method == "parameters" {
+				continue
+			}
+			var operation struct {
+				Action string `json:"x-kubernetes-action"`
+				GVK    *struct {
+					Kind string `json:"kind"`
+				} `json:"x-kubernetes-group-version-kind"`
+			}
+			if err := json.Unmarshal(operationJSON, &operation); err != nil || operation.GVK == nil {
+				continue
+			}
+
+			key := gv.String() + "/" + name
+			resource := byName[key]
+			if resource == nil {
+				list := lists[gv.String()]
+				if list == nil {
+					list = &metav1.APIResourceList{GroupVersion: gv.String()}
+					lists[gv.String()] = list
+				}
+				list.APIResources = append(list.APIResources, metav1.APIResource{
+					Name: name, Kind: operation.GVK.Kind,
+				})
+				resource = &list.APIResources[len(list.APIResources)-1]
+				byName[key] = resource
+			}
+			resource.Namespaced = resource.Namespaced || namespaced
+			if verb := actionVerb(operation.Action); verb != "" && !hasVerb(resource.Verbs, verb) {
+				resource.Verbs = append(resource.Verbs, verb)
+			}
+		}
+	}
+
+	for _, list := range lists {
+		sort.Slice(list.APIResources, func(i, j int) bool {
+			return list.APIResources[i].Name < list.APIResources[j].Name
+		})
+		for i := range list.APIResources {
+			sort.Strings(list.APIResources[i].Verbs)
+		}
+	}
+	return lists, nil
+}
+
+// parseResourcePath returns the group/version and name of the resource an API path (e.g.,
+// `/apis/apps/v1/namespaces/{namespace}/deployments/{name}`) refers to, and whether it is
+// namespaced. Paths of subresources (e.g., `.../status`), and watch paths, are skipped.
+func parseResourcePath(path string) (gv schema.GroupVersion, name string, namespaced, ok bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(segments) >= 3 && segments[0] == "api":
+		gv, segments = schema.GroupVersion{Version: segments[1]}, segments[2:]
+	case len(segments) >= 4 && segments[0] == "apis":
+		gv, segments = schema.GroupVersion{Group: segments[1], Version: segments[2]}, segments[3:]
+	default:
+		return schema.GroupVersion{}, "", false, false
+	}
+	if segments[0] == "watch" {
+		return schema.GroupVersion{}, "", false, false
+	}
+	if len(segments) > 2 && segments[0] == "namespaces" && segments[1] == "{namespace}" {
+		namespaced, segments = true, segments[2:]
+	}
+	if len(segments) > 2 {
+		return schema.GroupVersion{}, "", false, false
+	}
+	return gv, segments[0], namespaced, true
+}
+
+// actionVerb returns the discovery verb of an `x-kubernetes-action`.
+func actionVerb(action string) string {
+	switch action {
+	case "get", "list", "patch", "delete", "deletecollection":
+		return action
+	case "post":
+		return "create"
+	case "put":
+		return "update"
+	case "watch", "watchlist":
+		return "watch"
+	}
+	return ""
+}
+
+func hasVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+var apiVersionRe = regexp.MustCompile(`^v([0-9]+)(?:(alpha|beta)([0-9]+))?$`)
+
+// preferredVersion returns the version of a group the API server prefers: GA versions over beta
+// versions over alpha versions, and later versions over earlier ones.
+func preferredVersion(versions []string) string {
+	priority := func(version string) []int {
+		match := apiVersionRe.FindStringSubmatch(version)
+		if match == nil {
+			return []int{0, 0, 0}
+		}
+		major, _ := strconv.Atoi(match[1])
+		stability := map[string]int{"alpha": 1, "beta": 2, "": 3}[match[2]]
+		minor, _ := strconv.Atoi(match[3])
+		return []int{stability, major, minor}
+	}
+	sorted := append([]string{}, versions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := priority(sorted[i]), priority(sorted[j])
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted[0]
+}
+
+// offlineDiscoveryClient answers discovery requests from a bundled schema (see above).
+type offlineDiscoveryClient struct {
+	version   ServerVersion
+	schema    *openapi_v2.Document
+	resources map[string]*metav1.APIResourceList
+}
+
+var _ discovery.DiscoveryInterface = &offlineDiscoveryClient{}
+
+// RESTClient returns nil: raw requests need the cluster, so callers must not make any offline.
+func (c *offlineDiscoveryClient) RESTClient() rest.Interface { return nil }
+
+func (c *offlineDiscoveryClient) ServerGroups() (*metav1.APIGroupList, error) {
+	versions := map[string][]string{}
+	for groupVersion := range c.resources {
+		gv, _ := schema.ParseGroupVersion(groupVersion)
+		versions[gv.Group] = append(versions[gv.Group], gv.Version)
+	}
+
+	groups := &metav1.APIGroupList{}
+	for group, groupVersions := range versions {
+		apiGroup := metav1.APIGroup{Name: group}
+		for _, version := range groupVersions {
+			apiGroup.Versions = append(apiGroup.Versions, metav1.GroupVersionForDiscovery{
+				GroupVersion: schema.GroupVersion{Group: group, Version: version}.String(),
+				Version:      version,
+			})
+		}
+		preferred := preferredVersion(groupVersions)
+		apiGroup.PreferredVersion = metav1.GroupVersionForDiscovery{
+			GroupVersion: schema.GroupVersion{Group: group, Version: preferred}.String(),
+			Version:      preferred,
+		}
+		groups.Groups = append(groups.Groups, apiGroup)
+	}
+	sort.Slice(groups.Groups, func(i, j int) bool {
+		return groups.Groups[i].Name < groups.Groups[j].Name
+	})
+	return groups, nil
+}
+
+func (c *offlineDiscoveryClient) ServerResourcesForGroupVersion(
+	groupVersion string,
+) (*metav1.APIResourceList, error) {
+	list, exists := c.resources[groupVersion]
+	if !exists {
+		return nil, errors.NewNotFound(schema.GroupResource{}, groupVersion)
+	}
+	return list, nil
+}
+
+func (c *offlineDiscoveryClient) ServerResources() ([]*metav1.APIResourceList, error) {
+	var lists []*metav1.APIResourceList
+	for _, list := range c.resources {
+		lists = append(lists, list)
+	}
+	return lists, nil
+}
+
+func (c *offlineDiscoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	groups, _ := c.ServerGroups()
+	var lists []*metav1.APIResourceList
+	for _, group := range groups.Groups {
+		lists = append(lists, c.resources[group.PreferredVersion.GroupVersion])
+	}
+	return lists, nil
+}
+
+func (c *offlineDiscoveryClient) ServerPreferredNamespacedResources() (
+	[]*metav1.APIResourceList, error,
+) {
+	preferred, _ := c.ServerPreferredResources()
+	var lists []*metav1.APIResourceList
+	for _, list := range preferred {
+		namespaced := &metav1.APIResourceList{GroupVersion: list.GroupVersion}
+		for _, resource := range list.APIResources {
+			if resource.Namespaced {
+				namespaced.APIResources = append(namespaced.APIResources, resource)
+			}
+		}
+		lists = append(lists, namespaced)
+	}
+	return lists, nil
+}
+
+func (c *offlineDiscoveryClient) ServerVersion() (*apiVers.Info, error) {
+	return &apiVers.Info{
+		Major:      strconv.Itoa(c.version.Major),
+		Minor:      strconv.Itoa(c.version.Minor),
+		GitVersion: fmt.Sprintf("v%s.0", c.version),
+	}, nil
+}
+
+func (c *offlineDiscoveryClient) SwaggerSchema(
+	version schema.GroupVersion,
+) (*swagger.ApiDeclaration, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *offlineDiscoveryClient) OpenAPISchema() (*openapi_v2.Document, error) {
+	return c.schema, nil
+}
+
+// offlineClientPool is the dynamic client pool of offline mode, which fails every request.
+type offlineClientPool struct{}
+
+var _ dynamic.ClientPool = offlineClientPool{}
+
+func (offlineClientPool) ClientForGroupVersionResource(
+	schema.GroupVersionResource,
+) (dynamic.Interface, error) {
+	return nil, ErrOffline
+}
+
+func (offlineClientPool) ClientForGroupVersionKind(
+	schema.GroupVersionKind,
+) (dynamic.Interface, error) {
+	return nil, ErrOffline
+}
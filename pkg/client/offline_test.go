@@ -0,0 +1,112 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const offlineSwagger = `{"paths": {
+  "/api/v1/namespaces": {
+    "get": {"x-kubernetes-action": "list",
+      "x-kubernetes-group-version-kind": {"group": "", "kind": "Namespace", "version": "v1"}}
+  },
+  "/api/v1/namespaces/{name}": {
+    "parameters": [{"name": "name", "in": "path"}],
+    "delete": {"x-kubernetes-action": "delete",
+      "x-kubernetes-group-version-kind": {"group": "", "kind": "Namespace", "version": "v1"}}
+  },
+  "/apis/apps/v1/namespaces/{namespace}/deployments": {
+    "post": {"x-kubernetes-action": "post",
+      "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}}
+  },
+  "/apis/apps/v1/namespaces/{namespace}/deployments/{name}/status": {
+    "get": {"x-kubernetes-action": "get",
+      "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}}
+  },
+  "/apis/apps/v1/watch/deployments": {
+    "get": {"x-kubernetes-action": "watchlist",
+      "x-kubernetes-group-version-kind": {"group": "apps", "kind": "Deployment", "version": "v1"}}
+  }
+}}`
+
+func TestOfflineResources(t *testing.T) {
+	resources, err := offlineResources([]byte(offlineSwagger))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]*metav1.APIResourceList{
+		"v1": {GroupVersion: "v1", APIResources: []metav1.APIResource{
+			{Name: "namespaces", Kind: "Namespace", Verbs: []string{"delete", "list"}},
+		}},
+		"apps/v1": {GroupVersion: "apps/v1", APIResources: []metav1.APIResource{
+			{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"create"}},
+		}},
+	}
+	if !reflect.DeepEqual(resources, expected) {
+		t.Errorf("Got '%v' expected '%v'", resources, expected)
+	}
+}
+
+func TestPreferredVersion(t *testing.T) {
+	if v := preferredVersion([]string{"v1beta1", "v1", "v2alpha1"}); v != "v1" {
+		t.Errorf("Expected GA version to be preferred, got '%s'", v)
+	}
+	if v := preferredVersion([]string{"v1beta1", "v1beta2", "v1alpha1"}); v != "v1beta2" {
+		t.Errorf("Expected latest beta version to be preferred, got '%s'", v)
+	}
+}
+
+func TestParseOfflineVersion(t *testing.T) {
+	version, err := ParseOfflineVersion("1.16")
+	if err != nil || version != (ServerVersion{Major: 1, Minor: 16}) {
+		t.Errorf("Expected 1.16, got '%v' (%v)", version, err)
+	}
+	if _, err := ParseOfflineVersion("v1.16.3"); err == nil {
+		t.Errorf("Expected patch versions to be rejected")
+	}
+}
+
+func TestLatestSchemaVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := latestSchemaVersion(dir); err == nil {
+		t.Errorf("expected an error for a directory without schemas")
+	}
+	for _, name := range []string{"swagger-v1.9.json", "swagger-v1.16.json", "swagger-v1.14.json",
+		"README.md"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	version, err := latestSchemaVersion(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != (ServerVersion{Major: 1, Minor: 16}) {
+		t.Errorf("expected 1.16, got %v", version)
+	}
+}
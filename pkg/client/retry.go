@@ -0,0 +1,275 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/client-go/rest"
+)
+
+// --------------------------------------------------------------------------
+
+// Transient-error retry policy and circuit breaker.
+//
+// Every request the provider makes to the API server (discovery, CRUD, watches, etc.) passes
+// through the retry policy configured here, so that transient failures are handled uniformly,
+// rather than ad hoc by each caller:
+//
+//   * Requests that fail before reaching the API server (e.g., connection refused, DNS failure)
+//     are retried for every HTTP method, since the server never saw them.
+//   * Requests that fail with a retryable status code (e.g., `503 Service Unavailable`) are retried
+//     only for idempotent methods, since a non-idempotent request (e.g., a `POST`) may already have
+//     taken effect.
+//
+// The circuit breaker tracks consecutive connection failures. Once the API server has been
+// unreachable for `circuitBreakerThreshold` consecutive attempts, we stop trying and fail fast with
+// a clear message for `circuitBreakerCooldown`, after which requests are allowed through again to
+// probe whether the server has recovered. A single further failure re-opens the circuit.
+
+// --------------------------------------------------------------------------
+
+const (
+	circuitBreakerThreshold = 5
+	circuitBreakerCooldown  = 30 * time.Second
+)
+
+// RetryPolicy specifies how requests to the API server are retried when they fail transiently.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is attempted (including the first).
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialBackoff is the delay before the first retry. It doubles on each retry.
+	InitialBackoff Duration `json:"initialBackoff"`
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff Duration `json:"maxBackoff"`
+	// RetryableStatusCodes is the set of HTTP status codes that are considered transient.
+	RetryableStatusCodes []int `json:"retryableStatusCodes"`
+}
+
+// DefaultRetryPolicy returns the retry policy used when the provider is not configured otherwise.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: Duration(500 * time.Millisecond),
+		MaxBackoff:     Duration(10 * time.Second),
+		RetryableStatusCodes: []int{
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// ParseRetryPolicy parses a JSON-encoded retry policy (e.g., from provider config), using the
+// defaults for any fields that are not specified.
+func ParseRetryPolicy(text string) (RetryPolicy, error) {
+	policy := DefaultRetryPolicy()
+	if err := json.Unmarshal([]byte(text), &policy); err != nil {
+		return RetryPolicy{}, fmt.Errorf("failed to parse retry policy: %v", err)
+	}
+	if policy.MaxAttempts < 1 {
+		return RetryPolicy{}, fmt.Errorf("retry policy must allow at least 1 attempt, got %d",
+			policy.MaxAttempts)
+	}
+	return policy, nil
+}
+
+// WithRetryPolicy configures `conf` so that every client created from it retries transient
+// failures according to `policy`, and fails fast when the API server is persistently unreachable.
+func WithRetryPolicy(conf *rest.Config, policy RetryPolicy) *rest.Config {
+	breaker := &circuitBreaker{host: conf.Host}
+	wrap := conf.WrapTransport
+	conf.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &retryingRoundTripper{rt: rt, policy: policy, breaker: breaker}
+	}
+	return conf
+}
+
+// Duration is a `time.Duration` that is (un)marshaled as a string like "1m30s".
+type Duration time.Duration
+
+// UnmarshalJSON implements `json.Unmarshaler`.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON implements `json.Marshaler`.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// --------------------------------------------------------------------------
+
+// Round tripper.
+
+// --------------------------------------------------------------------------
+
+type retryingRoundTripper struct {
+	rt      http.RoundTripper
+	policy  RetryPolicy
+	breaker *circuitBreaker
+}
+
+var _ http.RoundTripper = (*retryingRoundTripper)(nil)
+
+func (r *retryingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	backoff := time.Duration(r.policy.InitialBackoff)
+	for attempt := 1; ; attempt++ {
+		if err := r.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		resp, err := r.rt.RoundTrip(req)
+		r.breaker.record(err)
+
+		retryable := (err != nil && isConnectionError(err)) ||
+			(err == nil && isIdempotent(req.Method) && r.isRetryableStatus(resp.StatusCode))
+		if !retryable || attempt >= r.policy.MaxAttempts || !rewindBody(req) {
+			return resp, err
+		}
+
+		if err != nil {
+			glog.V(3).Infof("Retrying %s %s (attempt %d of %d) after error: %v",
+				req.Method, req.URL.Path, attempt+1, r.policy.MaxAttempts, err)
+		} else {
+			glog.V(3).Infof("Retrying %s %s (attempt %d of %d) after status %d",
+				req.Method, req.URL.Path, attempt+1, r.policy.MaxAttempts, resp.StatusCode)
+			resp.Body.Close()
+		}
+
+		// Back off, with jitter.
+		time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff)/5+1)))
+		backoff *= 2
+		if max := time.Duration(r.policy.MaxBackoff); max > 0 && backoff > max {
+			backoff = max
+		}
+	}
+}
+
+func (r *retryingRoundTripper) isRetryableStatus(code int) bool {
+	for _, retryable := range r.policy.RetryableStatusCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// isConnectionError returns true if `err` indicates that the request never reached the API server.
+func isConnectionError(err error) bool {
+	if opErr, isOpErr := err.(*net.OpError); isOpErr {
+		return opErr.Op == "dial"
+	}
+	if urlErr, isURLErr := err.(*url.Error); isURLErr {
+		return isConnectionError(urlErr.Err)
+	}
+	_, isDNSErr := err.(*net.DNSError)
+	return isDNSErr
+}
+
+// rewindBody resets the body of `req` so it can be sent again. Returns false if this is not
+// possible.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// --------------------------------------------------------------------------
+
+// Circuit breaker.
+
+// --------------------------------------------------------------------------
+
+type circuitBreaker struct {
+	host string
+
+	lock      sync.Mutex
+	failures  int
+	lastError error
+	openUntil time.Time
+}
+
+// allow returns an error if the circuit is open, i.e., the API server has been persistently
+// unreachable, and we should fail fast rather than issue the request.
+func (cb *circuitBreaker) allow() error {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if cb.failures < circuitBreakerThreshold || time.Now().After(cb.openUntil) {
+		return nil
+	}
+	return fmt.Errorf("Kubernetes API server at %s is unreachable (%d consecutive failures, last "+
+		"error: %v); failing fast. Check that the cluster is running and reachable from this machine",
+		cb.host, cb.failures, cb.lastError)
+}
+
+// record updates the circuit breaker with the outcome of a request.
+func (cb *circuitBreaker) record(err error) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if err == nil || !isConnectionError(err) {
+		cb.failures = 0
+		cb.lastError = nil
+		return
+	}
+
+	cb.failures++
+	cb.lastError = err
+	if cb.failures >= circuitBreakerThreshold {
+		glog.V(1).Infof("Opening circuit breaker for %s after %d consecutive failures",
+			cb.host, cb.failures)
+		cb.openUntil = time.Now().Add(circuitBreakerCooldown)
+	}
+}
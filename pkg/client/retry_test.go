@@ -0,0 +1,103 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	policy, err := ParseRetryPolicy(`{"maxAttempts": 3, "maxBackoff": "1m"}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if policy.MaxAttempts != 3 || time.Duration(policy.MaxBackoff) != time.Minute {
+		t.Errorf("Expected overridden fields to be set, got %+v", policy)
+	}
+	if time.Duration(policy.InitialBackoff) != 500*time.Millisecond || len(policy.RetryableStatusCodes) != 3 {
+		t.Errorf("Expected unspecified fields to be defaulted, got %+v", policy)
+	}
+
+	if _, err := ParseRetryPolicy(`{"maxAttempts": 0}`); err == nil {
+		t.Errorf("Expected error for zero attempts")
+	}
+	if _, err := ParseRetryPolicy(`{"initialBackoff": "soon"}`); err == nil {
+		t.Errorf("Expected error for malformed duration")
+	}
+}
+
+type failingRoundTripper struct {
+	err   error
+	calls int
+}
+
+func (f *failingRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func TestCircuitBreakerFailsFast(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	rt := &failingRoundTripper{err: dialErr}
+	retrying := &retryingRoundTripper{
+		rt:      rt,
+		policy:  RetryPolicy{MaxAttempts: circuitBreakerThreshold},
+		breaker: &circuitBreaker{host: "https://example.com"},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/api", nil)
+	if _, err := retrying.RoundTrip(req); err != dialErr {
+		t.Errorf("Expected underlying error after exhausting retries, got %v", err)
+	}
+	if rt.calls != circuitBreakerThreshold {
+		t.Errorf("Expected %d attempts, got %d", circuitBreakerThreshold, rt.calls)
+	}
+
+	// The circuit is now open, so subsequent requests fail without reaching the transport.
+	if _, err := retrying.RoundTrip(req); err == nil || err == dialErr {
+		t.Errorf("Expected circuit breaker error, got %v", err)
+	}
+	if rt.calls != circuitBreakerThreshold {
+		t.Errorf("Expected no further attempts while circuit is open, got %d", rt.calls)
+	}
+}
+
+func TestNonIdempotentRequestsNotRetriedOnStatus(t *testing.T) {
+	calls := 0
+	retrying := &retryingRoundTripper{
+		rt: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+		}),
+		policy:  DefaultRetryPolicy(),
+		breaker: &circuitBreaker{},
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/api", nil)
+	if _, err := retrying.RoundTrip(req); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected POST not to be retried, got %d attempts", calls)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
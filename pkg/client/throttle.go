@@ -0,0 +1,252 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/util/cmdutil"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// --------------------------------------------------------------------------
+
+// API server throttling.
+//
+// The API server may ask us to slow down, either by returning `429 Too Many Requests` with a
+// `Retry-After` header (e.g., when API priority and fairness or max-in-flight limits kick in), or
+// implicitly, when the client-side rate limiter blocks a request. In both cases we:
+//
+//   1. Respect the requested delay for every client of the same API server, rather than letting
+//      each request retry on its own schedule. Throttling by one cluster doesn't delay requests to
+//      another, so programs that deploy to several clusters at once aren't slowed down by the
+//      busiest one.
+//   2. Emit a single "being throttled" diagnostic per throttling episode, instead of logging every
+//      throttled request.
+//   3. Keep track of the cumulative time spent throttled, so that await logic can extend its
+//      deadlines accordingly (see `ThrottledAfter`).
+
+// --------------------------------------------------------------------------
+
+const (
+	// Minimum delay between two "being throttled" diagnostics.
+	throttleWarningInterval = time.Minute
+
+	// Client-side rate limiting that blocks for less than this is not worth reporting.
+	clientThrottleThreshold = time.Second
+
+	// Delay to use when the API server returns a 429 without a usable `Retry-After` header.
+	defaultRetryAfter = time.Second
+)
+
+type throttleState struct {
+	lock       sync.Mutex
+	until      time.Time
+	total      time.Duration
+	lastWarned time.Time
+}
+
+// throttles holds the throttling state of each API server, keyed by host.
+var throttles = struct {
+	lock   sync.Mutex
+	byHost map[string]*throttleState
+}{byHost: map[string]*throttleState{}}
+
+// throttleFor returns the throttling state of the API server at `host`.
+func throttleFor(host string) *throttleState {
+	throttles.lock.Lock()
+	defer throttles.lock.Unlock()
+
+	ts, exists := throttles.byHost[host]
+	if !exists {
+		ts = &throttleState{}
+		throttles.byHost[host] = ts
+	}
+	return ts
+}
+
+// WithThrottling configures `conf` so that every client created from it honors throttling signals
+// from its API server, and reports them as a single diagnostic.
+func WithThrottling(conf *rest.Config) *rest.Config {
+	throttle := throttleFor(conf.Host)
+	wrap := conf.WrapTransport
+	conf.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+		if wrap != nil {
+			rt = wrap(rt)
+		}
+		return &throttlingRoundTripper{rt: rt, throttle: throttle}
+	}
+
+	if conf.RateLimiter == nil {
+		qps, burst := conf.QPS, conf.Burst
+		if qps == 0 {
+			qps = rest.DefaultQPS
+		}
+		if burst == 0 {
+			burst = rest.DefaultBurst
+		}
+		conf.RateLimiter = &observedRateLimiter{
+			RateLimiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+			throttle:    throttle,
+		}
+	}
+
+	return conf
+}
+
+// ThrottledDuration returns the cumulative time this process has spent throttled by API servers.
+func ThrottledDuration() time.Duration {
+	throttles.lock.Lock()
+	defer throttles.lock.Unlock()
+
+	var total time.Duration
+	for _, ts := range throttles.byHost {
+		ts.lock.Lock()
+		total += ts.total
+		ts.lock.Unlock()
+	}
+	return total
+}
+
+// ThrottledAfter behaves like `time.After`, except that the timeout is extended by any time spent
+// throttled by the API server while waiting. This allows await logic to keep its deadlines
+// meaningful even when the API server is overloaded.
+//
+// NOTE: Timeouts are extended by throttling by any API server. That only ever makes us wait longer
+// than necessary, never give up too early.
+func ThrottledAfter(timeout time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	go func() {
+		start := ThrottledDuration()
+		remaining := timeout
+		for {
+			time.Sleep(remaining)
+			throttled := ThrottledDuration()
+			remaining = throttled - start
+			if remaining <= 0 {
+				ch <- time.Now()
+				return
+			}
+			glog.V(3).Infof("Extending timeout by %v due to API server throttling", remaining)
+			start = throttled
+		}
+	}()
+	return ch
+}
+
+// wait blocks until the API server's most recent `Retry-After` has elapsed.
+func (ts *throttleState) wait() {
+	ts.lock.Lock()
+	delay := time.Until(ts.until)
+	ts.lock.Unlock()
+
+	if delay > 0 {
+		glog.V(9).Infof("Delaying request by %v due to API server throttling", delay)
+		time.Sleep(delay)
+	}
+}
+
+// backoff records that the API server asked us to wait `delay` before issuing further requests.
+func (ts *throttleState) backoff(delay time.Duration) {
+	ts.lock.Lock()
+	now := time.Now()
+	if until := now.Add(delay); until.After(ts.until) {
+		// Only account for the portion of the delay that doesn't overlap a previous one.
+		if ts.until.After(now) {
+			ts.total += until.Sub(ts.until)
+		} else {
+			ts.total += delay
+		}
+		ts.until = until
+	}
+	ts.lock.Unlock()
+
+	ts.warn("Kubernetes API server is throttling requests; operations may take longer than usual")
+}
+
+// observe records that a request was delayed `delay` by the client-side rate limiter.
+func (ts *throttleState) observe(delay time.Duration) {
+	ts.lock.Lock()
+	ts.total += delay
+	ts.lock.Unlock()
+
+	ts.warn("Requests to the Kubernetes API server are being throttled by the client-side rate " +
+		"limiter; operations may take longer than usual")
+}
+
+func (ts *throttleState) warn(message string) {
+	ts.lock.Lock()
+	now := time.Now()
+	shouldWarn := now.Sub(ts.lastWarned) > throttleWarningInterval
+	if shouldWarn {
+		ts.lastWarned = now
+	}
+	ts.lock.Unlock()
+
+	if shouldWarn {
+		cmdutil.Diag().Warningf(diag.Message("", message))
+	} else {
+		glog.V(3).Info(message)
+	}
+}
+
+// throttlingRoundTripper delays requests while the API server has asked us to back off, and
+// records any new `429 Too Many Requests` responses.
+type throttlingRoundTripper struct {
+	rt       http.RoundTripper
+	throttle *throttleState
+}
+
+var _ http.RoundTripper = (*throttlingRoundTripper)(nil)
+
+func (t *throttlingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.throttle.wait()
+
+	resp, err := t.rt.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		t.throttle.backoff(retryAfter(resp))
+	}
+	return resp, err
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// observedRateLimiter is a client-side rate limiter that reports any time spent blocked.
+type observedRateLimiter struct {
+	flowcontrol.RateLimiter
+	throttle *throttleState
+}
+
+var _ flowcontrol.RateLimiter = (*observedRateLimiter)(nil)
+
+func (rl *observedRateLimiter) Accept() {
+	start := time.Now()
+	rl.RateLimiter.Accept()
+	if delay := time.Since(start); delay > clientThrottleThreshold {
+		rl.throttle.observe(delay)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected time.Duration
+	}{
+		{header: "", expected: defaultRetryAfter},
+		{header: "0", expected: defaultRetryAfter},
+		{header: "garbage", expected: defaultRetryAfter},
+		{header: "5", expected: 5 * time.Second},
+	}
+
+	for _, test := range tests {
+		resp := &http.Response{Header: http.Header{}}
+		if test.header != "" {
+			resp.Header.Set("Retry-After", test.header)
+		}
+		if d := retryAfter(resp); d != test.expected {
+			t.Errorf("Retry-After %q => Expected %v, got %v", test.header, test.expected, d)
+		}
+	}
+}
+
+func TestThrottleBackoffAccounting(t *testing.T) {
+	ts := &throttleState{lastWarned: time.Now()}
+
+	ts.backoff(2 * time.Second)
+	if ts.total < 2*time.Second {
+		t.Errorf("Expected at least 2s of throttling, got %v", ts.total)
+	}
+
+	// An overlapping backoff should only account for the non-overlapping portion.
+	ts.backoff(time.Second)
+	if ts.total > 3*time.Second {
+		t.Errorf("Expected overlapping backoff not to be double-counted, got %v", ts.total)
+	}
+}
+
+func TestThrottlePerHost(t *testing.T) {
+	staging := throttleFor("https://staging.example.com")
+	if throttleFor("https://staging.example.com") != staging {
+		t.Errorf("Expected clients of the same API server to share throttling state")
+	}
+
+	prod := throttleFor("https://prod.example.com")
+	if prod == staging {
+		t.Fatalf("Expected clients of different API servers not to share throttling state")
+	}
+
+	staging.lastWarned = time.Now()
+	staging.backoff(time.Second)
+	if !prod.until.IsZero() {
+		t.Errorf("Expected throttling of one API server not to delay requests to another")
+	}
+}
@@ -17,9 +17,11 @@ package client
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,6 +69,11 @@ func NamespaceOrDefault(ns string) string {
 // Client utilities.
 // --------------------------------------------------------------------------
 
+var (
+	discoveryRetryTimeout  = 15 * time.Second
+	discoveryRetryInterval = 1 * time.Second
+)
+
 // FromResource returns the ResourceClient for a given object
 func FromResource(
 	pool dynamic.ClientPool, disco discovery.ServerResourcesInterface, obj runtime.Object,
@@ -80,10 +87,25 @@ func FromResource(
 	return FromGVK(pool, disco, gvk, NamespaceOrDefault(meta.GetNamespace()))
 }
 
-// FromGVK returns the ResourceClient for a given object
+// FromGVK returns the ResourceClient for a given object. If the API server doesn't serve kind `gvk`,
+// FromGVK rediscovers the API server's resources until it does, or until `discoveryRetryTimeout`
+// elapses: the kind is often a custom resource whose CustomResourceDefinition was created moments
+// ago (e.g., earlier in the same program), and which the API server has yet to register.
 func FromGVK(
 	pool dynamic.ClientPool, disco discovery.ServerResourcesInterface, gvk schema.GroupVersionKind,
 	namespace string,
+) (dynamic.ResourceInterface, error) {
+	var rc dynamic.ResourceInterface
+	err := retryUntilServed(disco, gvk, func() (err error) {
+		rc, err = fromGVK(pool, disco, gvk, namespace)
+		return err
+	})
+	return rc, err
+}
+
+func fromGVK(
+	pool dynamic.ClientPool, disco discovery.ServerResourcesInterface, gvk schema.GroupVersionKind,
+	namespace string,
 ) (dynamic.ResourceInterface, error) {
 	client, err := pool.ClientForGroupVersionKind(gvk)
 	if err != nil {
@@ -100,11 +122,42 @@ func FromGVK(
 	return rc, nil
 }
 
+// retryUntilServed runs `op` until it no longer fails because the API server doesn't serve kind
+// `gvk`, invalidating the cached discovery information before each retry.
+func retryUntilServed(
+	disco discovery.ServerResourcesInterface, gvk schema.GroupVersionKind, op func() error,
+) error {
+	cached, isCached := disco.(discovery.CachedDiscoveryInterface)
+	deadline := time.Now().Add(discoveryRetryTimeout)
+	for {
+		err := op()
+		if err == nil || !isCached || !IsKindNotServed(err) || time.Now().After(deadline) {
+			return err
+		}
+		glog.V(3).Infof("%s is not yet served; rediscovering: %v", gvk, err)
+		cached.Invalidate()
+		time.Sleep(discoveryRetryInterval)
+	}
+}
+
+// IsNamespacedKind returns true if objects of kind `gvk` are namespaced.
+func IsNamespacedKind(
+	gvk schema.GroupVersionKind, disco discovery.ServerResourcesInterface,
+) (bool, error) {
+	resource, err := serverResourceForGVK(disco, gvk)
+	if err != nil {
+		return false, err
+	}
+	return resource.Namespaced, nil
+}
+
 func serverResourceForGVK(
 	disco discovery.ServerResourcesInterface, gvk schema.GroupVersionKind,
 ) (*metav1.APIResource, error) {
 	resources, err := disco.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
-	if err != nil {
+	if errors.IsNotFound(err) {
+		return nil, &kindNotServedError{gvk: gvk, cause: err}
+	} else if err != nil {
 		return nil, fmt.Errorf("unable to fetch resource description for %s: %v", gvk.GroupVersion(), err)
 	}
 
@@ -115,5 +168,27 @@ func serverResourceForGVK(
 		}
 	}
 
-	return nil, fmt.Errorf("Server is unable to handle %s", gvk)
+	return nil, &kindNotServedError{gvk: gvk}
+}
+
+// kindNotServedError is returned when the API server doesn't serve kind `gvk`, e.g., because its
+// group version doesn't exist.
+type kindNotServedError struct {
+	gvk   schema.GroupVersionKind
+	cause error
+}
+
+func (e *kindNotServedError) Error() string {
+	if e.cause != nil {
+		return fmt.Sprintf("unable to fetch resource description for %s: %v",
+			e.gvk.GroupVersion(), e.cause)
+	}
+	return fmt.Sprintf("Server is unable to handle %s", e.gvk)
+}
+
+// IsKindNotServed returns true if `err` means the API server doesn't serve the kind of the object
+// that was requested.
+func IsKindNotServed(err error) bool {
+	_, notServed := err.(*kindNotServedError)
+	return notServed || meta.IsNoMatchError(err)
 }
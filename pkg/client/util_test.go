@@ -0,0 +1,99 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestIsNamespacedKind(t *testing.T) {
+	disco := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+				{Name: "namespaces", Kind: "Namespace"},
+			},
+		}},
+	}}
+
+	tests := []struct {
+		kind       string
+		namespaced bool
+	}{
+		{kind: "ConfigMap", namespaced: true},
+		{kind: "Namespace", namespaced: false},
+	}
+	for _, test := range tests {
+		namespaced, err := IsNamespacedKind(schema.GroupVersionKind{Version: "v1", Kind: test.kind}, disco)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", test.kind, err)
+		}
+		if namespaced != test.namespaced {
+			t.Errorf("Expected %s namespaced=%v, got %v", test.kind, test.namespaced, namespaced)
+		}
+	}
+
+	_, err := IsNamespacedKind(schema.GroupVersionKind{Version: "v1", Kind: "Widget"}, disco)
+	if err == nil {
+		t.Errorf("Expected an error for a kind the server doesn't serve")
+	}
+}
+
+func TestRetryUntilServed(t *testing.T) {
+	interval := discoveryRetryInterval
+	discoveryRetryInterval = time.Millisecond
+	defer func() { discoveryRetryInterval = interval }()
+
+	widgets := &metav1.APIResourceList{GroupVersion: "example.com/v1"}
+	fake := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{
+		Resources: []*metav1.APIResourceList{widgets},
+	}}
+	disco := NewMemcachedDiscoveryClient(fake)
+	widget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+	// The kind is registered after the first attempt, e.g., once its CRD is established.
+	attempts := 0
+	err := retryUntilServed(disco, widget, func() error {
+		attempts++
+		_, err := serverResourceForGVK(disco, widget)
+		widgets.APIResources = []metav1.APIResource{{Name: "widgets", Kind: "Widget"}}
+		return err
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("Expected the kind to be rediscovered, got %v after %d attempts", err, attempts)
+	}
+	if disco.Fresh() {
+		t.Errorf("Expected the discovery cache not to be fresh after it was invalidated")
+	}
+
+	// Without a cache to invalidate, there's nothing to retry.
+	gadget := schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Gadget"}
+	attempts = 0
+	err = retryUntilServed(fake, gadget, func() error {
+		attempts++
+		_, err := serverResourceForGVK(fake, gadget)
+		return err
+	})
+	if !IsKindNotServed(err) || attempts != 1 {
+		t.Errorf("Expected a single attempt, got %v after %d attempts", err, attempts)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package failure defines the errors returned when an operation on a Kubernetes resource does not
+// complete successfully, e.g., because the resource never became ready, or the operation timed out.
+//
+// The errors carry stable, structured fields (the resource's URN, GVK, namespace and name, the
+// individual reasons for the failure, and recent warning events), so that programs embedding the
+// provider can branch on the cause of a failure, rather than parsing error strings:
+//
+//	if f, ok := failure.As(err); ok && f.Cause() == failure.CauseTimeout {
+//	    ...
+//	}
+package failure
+
+import (
+	"fmt"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Cause classifies why an operation failed.
+type Cause string
+
+const (
+	// CauseInitialization means the resource was created or updated, but did not become ready.
+	CauseInitialization Cause = "Initialization"
+	// CauseTimeout means the resource did not become ready before the operation timed out.
+	CauseTimeout Cause = "Timeout"
+	// CauseCancellation means the operation was cancelled by the user before it completed.
+	CauseCancellation Cause = "Cancellation"
+)
+
+// Details are the fields common to every error in this package.
+type Details struct {
+	// URN is the URN of the Pulumi resource, if known.
+	URN resource.URN
+	// GVK is the group, version, and kind of the Kubernetes object.
+	GVK schema.GroupVersionKind
+	// Namespace is the namespace of the object, or "" for cluster-scoped objects.
+	Namespace string
+	// Name is the name of the object.
+	Name string
+	// SubErrors are the individual reasons the operation failed, e.g., "Pod is not ready".
+	SubErrors []string
+	// Events are messages of recent warning events reported for the object.
+	Events []string
+}
+
+// Error is implemented by every error in this package.
+type Error interface {
+	error
+	// Cause returns the classification of the failure.
+	Cause() Cause
+	// Failure returns the structured details of the failure.
+	Failure() *Details
+}
+
+// As returns `err` as an `Error`, if it is one.
+func As(err error) (Error, bool) {
+	e, ok := err.(Error)
+	return e, ok
+}
+
+// InitializationError means the object was successfully created (or updated), but failed to
+// become ready.
+type InitializationError struct {
+	Details
+	// Object is the live object, as last observed.
+	Object *unstructured.Unstructured
+}
+
+var _ Error = (*InitializationError)(nil)
+
+func (e *InitializationError) Error() string {
+	return fmt.Sprintf("Resource '%s' was created but failed to initialize", e.Name)
+}
+
+// Cause implements `Error`.
+func (e *InitializationError) Cause() Cause { return CauseInitialization }
+
+// Failure implements `Error`.
+func (e *InitializationError) Failure() *Details { return &e.Details }
+
+// TimeoutError means the operation timed out before the object became ready.
+type TimeoutError struct {
+	Details
+}
+
+var _ Error = (*TimeoutError)(nil)
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("Timeout occurred for '%s'", e.Name)
+}
+
+// Cause implements `Error`.
+func (e *TimeoutError) Cause() Cause { return CauseTimeout }
+
+// Failure implements `Error`.
+func (e *TimeoutError) Failure() *Details { return &e.Details }
+
+// CancellationError means the user cancelled the operation before it completed.
+type CancellationError struct {
+	Details
+}
+
+var _ Error = (*CancellationError)(nil)
+
+func (e *CancellationError) Error() string {
+	return fmt.Sprintf("Resource operation was cancelled for '%s'", e.Name)
+}
+
+// Cause implements `Error`.
+func (e *CancellationError) Cause() Cause { return CauseCancellation }
+
+// Failure implements `Error`.
+func (e *CancellationError) Failure() *Details { return &e.Details }
@@ -0,0 +1,23 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package failure
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAs(t *testing.T) {
+	var err error = &TimeoutError{Details{Name: "nginx", SubErrors: []string{"Pod is not ready"}}}
+
+	f, ok := As(err)
+	assert.True(t, ok)
+	assert.Equal(t, CauseTimeout, f.Cause())
+	assert.Equal(t, []string{"Pod is not ready"}, f.Failure().SubErrors)
+	assert.Equal(t, "Timeout occurred for 'nginx'", err.Error())
+
+	_, ok = As(fmt.Errorf("some other error"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,209 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// --------------------------------------------------------------------------
+
+// Cluster codegen.
+//
+// A cluster extends the Kubernetes API with CustomResourceDefinitions, and with aggregated APIs
+// (e.g., those of OpenShift), which are served by services of their own. The kinds of custom
+// resources are generated from their CRDs (see `CRDClient`). The kinds of aggregated APIs are
+// generated from their definitions in the cluster's OpenAPI spec, which are named after the Go
+// packages that declare them (e.g., `com.github.openshift.api.route.v1.Route`), so they're renamed
+// like those of CRDs (e.g., `io.openshift.route.v1.Route`), along with the definitions they refer
+// to that the Kubernetes spec lacks.
+
+// --------------------------------------------------------------------------
+
+// ClusterClient will generate an SDK in `language` (one of `nodejs`, `python`, `dotnet`, and `go`)
+// for the kinds of the extension APIs of a cluster: those of the custom resources defined by its
+// CustomResourceDefinitions `crds`, and those of its aggregated API groups `apiGroups` (e.g.,
+// `route.openshift.io`), whose definitions are taken from the cluster's OpenAPI spec
+// `clusterSwagger`. References to Kubernetes kinds are resolved against the definitions of
+// `swagger`. The SDK is laid out like that of `CRDClient`.
+func ClusterClient(
+	language string, swagger, clusterSwagger map[string]interface{},
+	crds []*unstructured.Unstructured, apiGroups []string, templateDir string,
+) (map[string]string, error) {
+	// Unlike the CRDs an SDK is generated for explicitly, those of a cluster that have no schemas
+	// are skipped.
+	withSchemas := []*unstructured.Unstructured{}
+	for _, crd := range crds {
+		if len(openapi.CustomResourceSchemas(crd)) > 0 {
+			withSchemas = append(withSchemas, crd)
+		}
+	}
+
+	definitions := swagger["definitions"].(map[string]interface{})
+	groups := newExtensionGroups(definitions)
+	extDefs, err := crdDefinitions(withSchemas, groups)
+	if err != nil {
+		return nil, err
+	}
+	aggregatedDefs, err := aggregatedDefinitions(clusterSwagger, apiGroups, definitions, groups)
+	if err != nil {
+		return nil, err
+	}
+	for name, definition := range aggregatedDefs {
+		extDefs[name] = definition
+	}
+	if len(extDefs) == 0 {
+		return nil, fmt.Errorf("the cluster has no CustomResourceDefinitions or aggregated APIs")
+	}
+	return extensionClient(language, definitions, extDefs, templateDir)
+}
+
+// aggregatedDefinitions returns the definitions of `clusterSwagger` of the kinds of the aggregated
+// API groups `apiGroups`, and of the objects they contain that the Kubernetes `definitions` lack,
+// renamed by the names of the groups in SDKs. The objects are named after the group and version of
+// the kinds in their Go package, if any, or else of the first kind that refers to them.
+func aggregatedDefinitions(
+	clusterSwagger map[string]interface{}, apiGroups []string,
+	definitions map[string]interface{}, groups *extensionGroups,
+) (map[string]interface{}, error) {
+	clusterDefs, _ := clusterSwagger["definitions"].(map[string]interface{})
+	served := sets.NewString(apiGroups...)
+
+	// The prefix of the names of the definitions of each Go package that declares kinds of the
+	// aggregated APIs, and those kinds, in order.
+	packagePrefixes := map[string]string{}
+	kinds := []string{}
+	for name, data := range clusterDefs {
+		if _, builtin := definitions[name]; builtin {
+			continue
+		}
+		gvk, isKind := servedGVK(&definition{data: data.(map[string]interface{})})
+		if !isKind || !served.Has(gvk.Group) {
+			continue
+		}
+		prefix, err := groups.prefix(gvk.GroupVersion())
+		if err != nil {
+			return nil, fmt.Errorf("aggregated API %q: %v", gvk.GroupVersion(), err)
+		}
+		packagePrefixes[definitionPackage(name)] = prefix
+		kinds = append(kinds, name)
+	}
+	sort.Strings(kinds)
+
+	renames := map[string]string{}
+	renamedFrom := map[string]string{}
+	var rename func(name, prefix string) error
+	rename = func(name, prefix string) error {
+		if _, renamed := renames[name]; renamed {
+			return nil
+		}
+		if packagePrefix, hasKinds := packagePrefixes[definitionPackage(name)]; hasKinds {
+			prefix = packagePrefix
+		}
+		newName := fmt.Sprintf("%s.%s", prefix, gvkFromRef(name).Kind)
+		if other, exists := renamedFrom[newName]; exists {
+			return fmt.Errorf("definitions %q and %q are both named %q", other, name, newName)
+		}
+		renames[name] = newName
+		renamedFrom[newName] = name
+
+		refs := definitionRefs(clusterDefs[name])
+		sort.Strings(refs)
+		for _, ref := range refs {
+			_, builtin := definitions[ref]
+			if _, defined := clusterDefs[ref]; builtin || !defined {
+				continue
+			}
+			err := rename(ref, prefix)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, name := range kinds {
+		err := rename(name, packagePrefixes[definitionPackage(name)])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	aggregatedDefs := map[string]interface{}{}
+	for name, newName := range renames {
+		aggregatedDefs[newName] = renameRefs(clusterDefs[name], renames)
+	}
+	return aggregatedDefs, nil
+}
+
+// definitionPackage returns the Go package of the definition named `name` (e.g.,
+// `com.github.openshift.api.route.v1` for `com.github.openshift.api.route.v1.Route`).
+func definitionPackage(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+// definitionRefs returns the names of the definitions the schema `s` refers to.
+func definitionRefs(s interface{}) []string {
+	refs := []string{}
+	switch s := s.(type) {
+	case map[string]interface{}:
+		for key, value := range s {
+			if ref, isRef := value.(string); isRef && key == "$ref" {
+				refs = append(refs, stripPrefix(ref))
+				continue
+			}
+			refs = append(refs, definitionRefs(value)...)
+		}
+	case []interface{}:
+		for _, value := range s {
+			refs = append(refs, definitionRefs(value)...)
+		}
+	}
+	return refs
+}
+
+// renameRefs returns a copy of the schema `s` whose references to definitions are renamed by
+// `renames`.
+func renameRefs(s interface{}, renames map[string]string) interface{} {
+	switch s := s.(type) {
+	case map[string]interface{}:
+		renamed := map[string]interface{}{}
+		for key, value := range s {
+			if ref, isRef := value.(string); isRef && key == "$ref" {
+				if newName, exists := renames[stripPrefix(ref)]; exists {
+					value = "#/definitions/" + newName
+				}
+			}
+			renamed[key] = renameRefs(value, renames)
+		}
+		return renamed
+	case []interface{}:
+		renamed := make([]interface{}, len(s))
+		for i, value := range s {
+			renamed[i] = renameRefs(value, renames)
+		}
+		return renamed
+	default:
+		return s
+	}
+}
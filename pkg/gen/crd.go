@@ -0,0 +1,373 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// --------------------------------------------------------------------------
+
+// Custom resource codegen.
+//
+// The kinds of custom resources are turned into definitions like those of the Kubernetes OpenAPI
+// spec, from the `openAPIV3Schema` of each version of their CustomResourceDefinition: each object
+// of the schema that has properties becomes a definition of its own, named after the kind and the
+// path to the object (e.g., `CronTabSpec` for the `spec` of a `CronTab`). These are generated with
+// the same templates as the kinds of the Kubernetes SDK, but only the CRDs' groups are rendered,
+// and their references to Kubernetes kinds (e.g., `ObjectMeta`) refer to the Kubernetes SDK.
+
+// --------------------------------------------------------------------------
+
+const objectMetaRef = "#/definitions/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"
+
+var nonIdentifierChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// CRDClient will generate an SDK in `language` (one of `nodejs`, `python`, `dotnet`, and `go`)
+// for the kinds of custom resources defined by the CustomResourceDefinitions of `objs` (other
+// objects are ignored), from the templates of that language in `templateDir`. References to
+// Kubernetes kinds are resolved against the definitions of `swagger`. It returns the contents of
+// each file of the SDK, keyed by their paths relative to its root, which are laid out as in the
+// Kubernetes SDK.
+func CRDClient(
+	language string, swagger map[string]interface{}, objs []*unstructured.Unstructured,
+	templateDir string,
+) (map[string]string, error) {
+	definitions := swagger["definitions"].(map[string]interface{})
+	crdDefs, err := crdDefinitions(objs, newExtensionGroups(definitions))
+	if err != nil {
+		return nil, err
+	}
+	if len(crdDefs) == 0 {
+		return nil, fmt.Errorf("no CustomResourceDefinitions found")
+	}
+	return extensionClient(language, definitions, crdDefs, templateDir)
+}
+
+// extensionClient will generate an SDK in `language` for the kinds of the extension API groups
+// (of custom resources, or aggregated APIs) whose definitions are `extDefs`, from the templates of
+// that language in `templateDir`. References to Kubernetes kinds are resolved against the
+// Kubernetes `definitions`. See `CRDClient`.
+func extensionClient(
+	language string, definitions, extDefs map[string]interface{}, templateDir string,
+) (map[string]string, error) {
+	allDefs := map[string]interface{}{}
+	for name, definition := range definitions {
+		allDefs[name] = definition
+	}
+	extGroups := sets.NewString()
+	for name, definition := range extDefs {
+		allDefs[name] = definition
+		extGroups.Insert(gvkFromRef(name).Group)
+	}
+
+	// The extension groups, in which the versions import those of any other group from the
+	// Kubernetes SDK.
+	groups := func(generatorType gentype) []*GroupConfig {
+		groupsSlice := []*GroupConfig{}
+		for _, group := range createGroups(allDefs, generatorType) {
+			if !extGroups.Has(group.Group()) {
+				continue
+			}
+			for _, version := range group.Versions() {
+				version.imports = versionImports(version)
+				for _, imp := range version.imports {
+					imp.external = !extGroups.Has(imp.group)
+				}
+			}
+			groupsSlice = append(groupsSlice, group)
+		}
+		return groupsSlice
+	}
+
+	files := map[string]string{}
+	render := newRenderer(templateDir, files)
+	var err error
+	switch language {
+	case "nodejs":
+		importedGroups := importedKubernetesGroups(extDefs, allDefs, extGroups)
+		err = render("types/input.ts", "typesInput.ts.mustache",
+			map[string]interface{}{
+				"Groups":         groups(inputsAPI),
+				"ImportedGroups": importedGroups,
+			})
+		if err != nil {
+			return nil, err
+		}
+		err = render("types/output.ts", "typesOutput.ts.mustache",
+			map[string]interface{}{
+				"Groups":         groups(outputsAPI),
+				"ImportedGroups": importedGroups,
+			})
+		if err != nil {
+			return nil, err
+		}
+		err = render("index.ts", "crd.ts.mustache",
+			map[string]interface{}{
+				"Groups": groups(provider),
+			})
+		if err != nil {
+			return nil, err
+		}
+	case "python":
+		groupsSlice := groups(pythonAPI)
+		err = render("__init__.py", "__init__.py.mustache",
+			map[string]interface{}{
+				"Groups": groupsSlice,
+			})
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groupsSlice {
+			err = renderPythonGroup(render, "", group)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Resources translate the names of the properties of the Kubernetes kinds they contain too
+		// (e.g., of their `metadata`).
+		err = render("tables.py", "tables.py.mustache",
+			map[string]interface{}{
+				"Properties": pythonRenamedProperties(createGroups(allDefs, pythonAPI)),
+			})
+		if err != nil {
+			return nil, err
+		}
+	case "dotnet":
+		for _, group := range groups(dotnetAPI) {
+			for _, version := range group.Versions() {
+				err = renderDotnetVersion(render, version)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	case "go":
+		render = newGoRenderer(templateDir, files)
+		for _, group := range groups(goAPI) {
+			for _, version := range group.Versions() {
+				err = renderGoVersion(render, "", group, version)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unrecognized language '%s'", language)
+	}
+
+	return files, nil
+}
+
+// extensionGroups names the extension API groups in SDKs. The name of a group is its first label
+// (e.g., `stable` for `stable.example.com`), or its first two labels if that's the name of a
+// Kubernetes group (e.g., `apps_openshift` for `apps.openshift.io`).
+type extensionGroups struct {
+	kubernetes sets.String       // The names of the Kubernetes groups.
+	apiGroups  map[string]string // The API group of each extension group, by name.
+}
+
+// newExtensionGroups returns the names of extension API groups alongside the Kubernetes groups of
+// `definitions`.
+func newExtensionGroups(definitions map[string]interface{}) *extensionGroups {
+	kubernetes := sets.NewString()
+	for name := range definitions {
+		kubernetes.Insert(gvkFromRef(name).Group)
+	}
+	return &extensionGroups{kubernetes: kubernetes, apiGroups: map[string]string{}}
+}
+
+// prefix returns the prefix of the names of the definitions of `gv`, a version of an extension API
+// group. They're named like those of Kubernetes, i.e., prefixed with the reversed domain of the
+// group, so that `gvkFromRef` finds the name of the group and the version.
+func (eg *extensionGroups) prefix(gv schema.GroupVersion) (string, error) {
+	labels := strings.Split(gv.Group, ".")
+	group := nonIdentifierChars.ReplaceAllString(labels[0], "_")
+	if eg.kubernetes.Has(group) && len(labels) > 1 {
+		group = nonIdentifierChars.ReplaceAllString(labels[0]+"_"+labels[1], "_")
+	}
+	if eg.kubernetes.Has(group) {
+		return "", fmt.Errorf("group %q is named %q in SDKs, like a Kubernetes group", gv.Group,
+			group)
+	}
+	if apiGroup, exists := eg.apiGroups[group]; exists && apiGroup != gv.Group {
+		return "", fmt.Errorf("groups %q and %q are both named %q in SDKs", apiGroup, gv.Group,
+			group)
+	}
+	eg.apiGroups[group] = gv.Group
+
+	prefix := group
+	for _, label := range labels[1:] {
+		prefix = label + "." + prefix
+	}
+	return fmt.Sprintf("%s.%s", prefix, gv.Version), nil
+}
+
+// crdDefinitions returns the definitions of the kinds of custom resources defined by the
+// CustomResourceDefinitions of `objs` (and of the objects they contain), by name. Their groups are
+// named in SDKs by `groups`.
+func crdDefinitions(
+	objs []*unstructured.Unstructured, groups *extensionGroups,
+) (map[string]interface{}, error) {
+	crdDefs := map[string]interface{}{}
+	for _, crd := range objs {
+		if !openapi.IsCustomResourceDefinition(crd) {
+			continue
+		}
+
+		schemas := openapi.CustomResourceSchemas(crd)
+		if len(schemas) == 0 {
+			return nil, fmt.Errorf("CustomResourceDefinition %q has no versions with a schema",
+				crd.GetName())
+		}
+		for gvk, crSchema := range schemas {
+			prefix, err := groups.prefix(gvk.GroupVersion())
+			if err != nil {
+				return nil, fmt.Errorf("CustomResourceDefinition %q: %v", crd.GetName(), err)
+			}
+
+			definition := crdDefinition(crdDefs, prefix, gvk.Kind, crSchema.OpenAPIV3Schema)
+			properties := definition["properties"].(map[string]interface{})
+			properties["apiVersion"] = map[string]interface{}{
+				"type": "string",
+				"description": "APIVersion defines the versioned schema of this representation " +
+					"of an object.",
+			}
+			properties["kind"] = map[string]interface{}{
+				"type": "string",
+				"description": "Kind is a string value representing the REST resource this " +
+					"object represents.",
+			}
+			properties["metadata"] = map[string]interface{}{
+				"$ref":        objectMetaRef,
+				"description": "Standard object's metadata.",
+			}
+			definition["x-kubernetes-group-version-kind"] = []interface{}{
+				map[string]interface{}{
+					"group":   gvk.Group,
+					"version": gvk.Version,
+					"kind":    gvk.Kind,
+				},
+			}
+			crdDefs[fmt.Sprintf("%s.%s", prefix, gvk.Kind)] = definition
+		}
+	}
+	return crdDefs, nil
+}
+
+// crdDefinition returns the definition named `<prefix>.<name>` of the object `s` of a CRD's
+// schema, adding the definitions of the objects it contains to `crdDefs`.
+func crdDefinition(
+	crdDefs map[string]interface{}, prefix, name string, s map[string]interface{},
+) map[string]interface{} {
+	definition := map[string]interface{}{}
+	if description, exists := s["description"]; exists {
+		definition["description"] = description
+	}
+	if required, exists := s["required"]; exists {
+		definition["required"] = required
+	}
+
+	properties := map[string]interface{}{}
+	schemaProps, _ := s["properties"].(map[string]interface{})
+	for propName, propSchema := range schemaProps {
+		propSchema, _ := propSchema.(map[string]interface{})
+		properties[propName] = crdProperty(crdDefs, prefix, name+pascalCase(propName), propSchema)
+	}
+	definition["properties"] = properties
+	return definition
+}
+
+// crdProperty returns the property of the schema `s` of a CRD, in the form of the properties of
+// Kubernetes definitions. Objects with properties refer to their own definitions, named `name`
+// (see `crdDefinition`).
+func crdProperty(
+	crdDefs map[string]interface{}, prefix, name string, s map[string]interface{},
+) map[string]interface{} {
+	prop := map[string]interface{}{}
+	if description, exists := s["description"]; exists {
+		prop["description"] = description
+	}
+
+	if intOrString, _ := s["x-kubernetes-int-or-string"].(bool); intOrString {
+		prop["$ref"] = "#/definitions/io.k8s.apimachinery.pkg.util.intstr.IntOrString"
+		return prop
+	}
+
+	switch t, _ := s["type"].(string); t {
+	case "object":
+		if properties, _ := s["properties"].(map[string]interface{}); len(properties) > 0 {
+			ref := fmt.Sprintf("%s.%s", prefix, name)
+			crdDefs[ref] = crdDefinition(crdDefs, prefix, name, s)
+			prop["$ref"] = "#/definitions/" + ref
+			return prop
+		}
+		prop["type"] = "object"
+		// Like Kubernetes definitions, only maps of strings are typed.
+		if additional, _ := s["additionalProperties"].(map[string]interface{}); additional != nil &&
+			additional["type"] == "string" {
+			prop["additionalProperties"] = map[string]interface{}{"type": "string"}
+		}
+	case "array":
+		items, _ := s["items"].(map[string]interface{})
+		prop["type"] = "array"
+		prop["items"] = crdProperty(crdDefs, prefix, name, items)
+	case "boolean", "integer", "number", "string":
+		prop["type"] = t
+		if enum, hasEnum := s["enum"]; hasEnum {
+			prop["enum"] = enum
+		}
+	default:
+		// Untyped values (e.g., of `x-kubernetes-preserve-unknown-fields` fields) are opaque.
+		prop["type"] = "object"
+	}
+	return prop
+}
+
+// importedKubernetesGroups returns the names of the Kubernetes groups whose kinds the definitions
+// of the extension groups `extDefs` refer to (e.g., `meta` for `ObjectMeta`), in order.
+func importedKubernetesGroups(
+	extDefs, definitions map[string]interface{}, extGroups sets.String,
+) []string {
+	groups := sets.NewString()
+	var addRefs func(prop map[string]interface{})
+	addRefs = func(prop map[string]interface{}) {
+		if ref, isRef := prop["$ref"].(string); isRef {
+			ref = stripPrefix(ref)
+			if group := gvkFromRef(ref).Group; !extGroups.Has(group) &&
+				!isOpaqueRef(definitions, ref) {
+				groups.Insert(group)
+			}
+		}
+		if items, hasItems := prop["items"].(map[string]interface{}); hasItems {
+			addRefs(items)
+		}
+	}
+	for _, definition := range extDefs {
+		properties, _ := definition.(map[string]interface{})["properties"].(map[string]interface{})
+		for _, prop := range properties {
+			addRefs(prop.(map[string]interface{}))
+		}
+	}
+
+	return groups.List()
+}
@@ -0,0 +1,251 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const cronTabPrefix = "com.example.stable.v1"
+
+func TestCRDProperty(t *testing.T) {
+	tests := []struct {
+		description string
+		schema      map[string]interface{}
+		property    map[string]interface{}
+		definitions map[string]interface{}
+	}{
+		{
+			description: "String should keep its type and description",
+			schema:      map[string]interface{}{"type": "string", "description": "The schedule."},
+			property:    map[string]interface{}{"type": "string", "description": "The schedule."},
+		},
+		{
+			description: "String with values should keep them",
+			schema: map[string]interface{}{
+				"type": "string", "enum": []interface{}{"Allow", "Forbid"},
+			},
+			property: map[string]interface{}{
+				"type": "string", "enum": []interface{}{"Allow", "Forbid"},
+			},
+		},
+		{
+			description: "Integer should keep its type",
+			schema:      map[string]interface{}{"type": "integer", "format": "int32"},
+			property:    map[string]interface{}{"type": "integer"},
+		},
+		{
+			description: "Int-or-string should refer to IntOrString",
+			schema: map[string]interface{}{
+				"x-kubernetes-int-or-string": true,
+				"anyOf": []interface{}{
+					map[string]interface{}{"type": "integer"},
+					map[string]interface{}{"type": "string"},
+				},
+			},
+			property: map[string]interface{}{
+				"$ref": "#/definitions/io.k8s.apimachinery.pkg.util.intstr.IntOrString",
+			},
+		},
+		{
+			description: "Array should type its items",
+			schema: map[string]interface{}{
+				"type": "array", "items": map[string]interface{}{"type": "string"},
+			},
+			property: map[string]interface{}{
+				"type": "array", "items": map[string]interface{}{"type": "string"},
+			},
+		},
+		{
+			description: "Map of strings should be typed",
+			schema: map[string]interface{}{
+				"type": "object", "additionalProperties": map[string]interface{}{"type": "string"},
+			},
+			property: map[string]interface{}{
+				"type": "object", "additionalProperties": map[string]interface{}{"type": "string"},
+			},
+		},
+		{
+			description: "Map of other values should be an untyped object",
+			schema: map[string]interface{}{
+				"type": "object", "additionalProperties": map[string]interface{}{"type": "integer"},
+			},
+			property: map[string]interface{}{"type": "object"},
+		},
+		{
+			description: "Untyped value should be an untyped object",
+			schema:      map[string]interface{}{"x-kubernetes-preserve-unknown-fields": true},
+			property:    map[string]interface{}{"type": "object"},
+		},
+		{
+			description: "Object with properties should refer to its own definition",
+			schema: map[string]interface{}{
+				"type":        "object",
+				"description": "The spec.",
+				"required":    []interface{}{"cronSpec"},
+				"properties": map[string]interface{}{
+					"cronSpec": map[string]interface{}{"type": "string"},
+				},
+			},
+			property: map[string]interface{}{
+				"description": "The spec.",
+				"$ref":        "#/definitions/" + cronTabPrefix + ".CronTabSpec",
+			},
+			definitions: map[string]interface{}{
+				cronTabPrefix + ".CronTabSpec": map[string]interface{}{
+					"description": "The spec.",
+					"required":    []interface{}{"cronSpec"},
+					"properties": map[string]interface{}{
+						"cronSpec": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		definitions := map[string]interface{}{}
+		property := crdProperty(definitions, cronTabPrefix, "CronTabSpec", test.schema)
+		assert.Equal(t, test.property, property, test.description)
+		if test.definitions == nil {
+			test.definitions = map[string]interface{}{}
+		}
+		assert.Equal(t, test.definitions, definitions, test.description)
+	}
+}
+
+func TestExtensionGroupPrefix(t *testing.T) {
+	kubernetesDefinitions := map[string]interface{}{
+		"io.k8s.api.apps.v1.Deployment": map[string]interface{}{},
+		"io.k8s.api.core.v1.Pod":        map[string]interface{}{},
+	}
+
+	tests := []struct {
+		description string
+		earlier     []string
+		gv          schema.GroupVersion
+		prefix      string
+		err         string
+	}{
+		{
+			description: "Group should be named after its first label",
+			gv:          schema.GroupVersion{Group: "stable.example.com", Version: "v1"},
+			prefix:      "com.example.stable.v1",
+		},
+		{
+			description: "Group should be named with identifier characters only",
+			gv:          schema.GroupVersion{Group: "cron-jobs.example.com", Version: "v1beta1"},
+			prefix:      "com.example.cron_jobs.v1beta1",
+		},
+		{
+			description: "Group named like a Kubernetes group should add its second label",
+			gv:          schema.GroupVersion{Group: "apps.openshift.io", Version: "v1"},
+			prefix:      "io.openshift.apps_openshift.v1",
+		},
+		{
+			description: "Group should keep its name across versions",
+			earlier:     []string{"stable.example.com"},
+			gv:          schema.GroupVersion{Group: "stable.example.com", Version: "v2"},
+			prefix:      "com.example.stable.v2",
+		},
+		{
+			description: "Group named like a Kubernetes group should be an error",
+			gv:          schema.GroupVersion{Group: "core", Version: "v1"},
+			err:         `group "core" is named "core" in SDKs, like a Kubernetes group`,
+		},
+		{
+			description: "Groups with the same name should be an error",
+			earlier:     []string{"stable.example.com"},
+			gv:          schema.GroupVersion{Group: "stable.example.org", Version: "v1"},
+			err: `groups "stable.example.com" and "stable.example.org" are both named ` +
+				`"stable" in SDKs`,
+		},
+	}
+
+	for _, test := range tests {
+		groups := newExtensionGroups(kubernetesDefinitions)
+		for _, group := range test.earlier {
+			_, err := groups.prefix(schema.GroupVersion{Group: group, Version: "v1"})
+			assert.NoError(t, err, test.description)
+		}
+
+		prefix, err := groups.prefix(test.gv)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.description)
+			continue
+		}
+		assert.NoError(t, err, test.description)
+		assert.Equal(t, test.prefix, prefix, test.description)
+	}
+}
+
+func TestCRDDefinitions(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "crontabs.stable.example.com"},
+		"spec": map[string]interface{}{
+			"group": "stable.example.com",
+			"names": map[string]interface{}{"kind": "CronTab", "plural": "crontabs"},
+			"versions": []interface{}{
+				map[string]interface{}{
+					"name": "v1",
+					"schema": map[string]interface{}{
+						"openAPIV3Schema": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"type": "object",
+									"properties": map[string]interface{}{
+										"replicas": map[string]interface{}{"type": "integer"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+	configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "config"},
+	}}
+
+	definitions, err := crdDefinitions([]*unstructured.Unstructured{crd, configMap},
+		newExtensionGroups(map[string]interface{}{}))
+	assert.NoError(t, err)
+	assert.Len(t, definitions, 2)
+
+	cronTab := definitions[cronTabPrefix+".CronTab"].(map[string]interface{})
+	properties := cronTab["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/" + cronTabPrefix + ".CronTabSpec"},
+		properties["spec"])
+	assert.Equal(t, objectMetaRef, properties["metadata"].(map[string]interface{})["$ref"])
+	assert.Contains(t, properties, "apiVersion")
+	assert.Contains(t, properties, "kind")
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"group": "stable.example.com", "version": "v1", "kind": "CronTab",
+	}}, cronTab["x-kubernetes-group-version-kind"])
+
+	spec := definitions[cronTabPrefix+".CronTabSpec"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"replicas": map[string]interface{}{"type": "integer"},
+	}, spec["properties"])
+
+	_, err = crdDefinitions([]*unstructured.Unstructured{{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "crontabs.stable.example.com"},
+		"spec": map[string]interface{}{
+			"group":    "stable.example.com",
+			"names":    map[string]interface{}{"kind": "CronTab", "plural": "crontabs"},
+			"versions": []interface{}{map[string]interface{}{"name": "v1"}},
+		},
+	}}}, newExtensionGroups(map[string]interface{}{}))
+	assert.EqualError(t, err,
+		`CustomResourceDefinition "crontabs.stable.example.com" has no versions with a schema`)
+}
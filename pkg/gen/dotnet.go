@@ -0,0 +1,100 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	providerVersion "github.com/pulumi/pulumi-kubernetes/pkg/version"
+)
+
+// DotnetClient will generate a Pulumi Kubernetes provider client SDK for .NET. It returns the
+// contents of each file of the project, keyed by their paths relative to the root of the project
+// (e.g., `Apps/V1/Deployment.cs` for the `apps/v1/Deployment` resource, and
+// `Types/Inputs/Apps/V1.cs` for the input types of `apps/v1`).
+//
+// Properties keep their Kubernetes names on the wire: the generated members carry them in their
+// `[Input]` and `[Output]` attributes (and output types in the names of their constructor
+// parameters), from which the serializer maps them to and from their PascalCase .NET names.
+func DotnetClient(swagger map[string]interface{}, templateDir string) (map[string]string, error) {
+	definitions := swagger["definitions"].(map[string]interface{})
+
+	files := map[string]string{}
+	render := newRenderer(templateDir, files)
+
+	groupsSlice := createGroups(definitions, dotnetAPI)
+	for _, group := range groupsSlice {
+		for _, version := range group.Versions() {
+			err := renderDotnetVersion(render, version)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	err := render("Provider.cs", "Provider.cs.mustache", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = render("Pulumi.Kubernetes.csproj", "Pulumi.Kubernetes.csproj.mustache",
+		map[string]interface{}{
+			"ProviderVersion": providerVersion.Version,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// renderDotnetVersion renders the input and output types of `version`, the constants of its enum
+// types, and its resources.
+func renderDotnetVersion(render renderFunc, version *VersionConfig) error {
+	namespace := dotnetNamespace(*version.gv)
+	dir := strings.Replace(namespace, ".", "/", -1)
+	context := map[string]interface{}{
+		"Namespace": namespace,
+	}
+
+	err := render(fmt.Sprintf("Types/Inputs/%s.cs", dir), "Inputs.cs.mustache", context, version)
+	if err != nil {
+		return err
+	}
+
+	err = render(fmt.Sprintf("Types/Outputs/%s.cs", dir), "Outputs.cs.mustache", context, version)
+	if err != nil {
+		return err
+	}
+
+	if len(version.Enums()) > 0 {
+		err = render(fmt.Sprintf("Types/Enums/%s.cs", dir), "Enums.cs.mustache", context, version)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, kind := range version.Kinds() {
+		if !kind.IsResource() {
+			continue
+		}
+		err = render(fmt.Sprintf("%s/%s.cs", dir, kind.Kind()), "Kind.cs.mustache", context, kind)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
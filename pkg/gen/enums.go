@@ -0,0 +1,132 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Enum represents the type of a property that takes one of a fixed set of strings (e.g., the
+// `type` of a Service), for which the SDKs generate constants.
+type Enum struct {
+	name   string
+	values []*EnumValue
+}
+
+// Name returns the name of the enum type (e.g., `ServiceType`).
+func (e *Enum) Name() string { return e.name }
+
+// Values returns the values of the enum type.
+func (e *Enum) Values() []*EnumValue { return e.values }
+
+// TsType returns the TypeScript type of the enum, i.e., the union of its values (e.g.,
+// `"Always" | "IfNotPresent" | "Never"`).
+func (e *Enum) TsType() string {
+	literals := []string{}
+	for _, value := range e.values {
+		literals = append(literals, fmt.Sprintf("%q", value.value))
+	}
+	return strings.Join(literals, " | ")
+}
+
+// EnumValue represents one of the values of an enum type.
+type EnumValue struct {
+	value string
+}
+
+// Value returns the value (e.g., `ClusterIP`).
+func (ev *EnumValue) Value() string { return ev.value }
+
+// Identifier returns the PascalCase name of the value, which is appended to the name of its type
+// to name its constant in Go (e.g., `ServiceTypeClusterIP`), and names it in .NET. Values that
+// don't start with a letter are prefixed with `Value` (e.g., `Value1`).
+func (ev *EnumValue) Identifier() string {
+	identifier := pascalCase(ev.value)
+	if identifier == "" || !unicode.IsLetter([]rune(identifier)[0]) {
+		return "Value" + identifier
+	}
+	return identifier
+}
+
+// PyIdentifier returns the UPPER_SNAKE_CASE name of the value's constant in Python (e.g.,
+// `CLUSTER_IP`).
+func (ev *EnumValue) PyIdentifier() string { return strings.ToUpper(pyName(ev.Identifier())) }
+
+func newEnum(name string, values ...string) *Enum {
+	enum := &Enum{name: name}
+	for _, value := range values {
+		enum.values = append(enum.values, &EnumValue{value: value})
+	}
+	return enum
+}
+
+var (
+	protocol    = newEnum("Protocol", "SCTP", "TCP", "UDP")
+	taintEffect = newEnum("TaintEffect", "NoExecute", "NoSchedule", "PreferNoSchedule")
+
+	// wellKnownEnums maps properties that take one of a fixed set of strings, as `Kind.property`
+	// (e.g., `ServiceSpec.type`), to their enum types. The OpenAPI spec doesn't say which
+	// properties these are, so they're curated by hand.
+	wellKnownEnums = map[string]*Enum{
+		"Container.imagePullPolicy": newEnum("PullPolicy", "Always", "IfNotPresent", "Never"),
+		"Container.terminationMessagePolicy": newEnum("TerminationMessagePolicy",
+			"FallbackToLogsOnError", "File"),
+		"ContainerPort.protocol": protocol,
+		"CronJobSpec.concurrencyPolicy": newEnum("ConcurrencyPolicy",
+			"Allow", "Forbid", "Replace"),
+		"DeploymentStrategy.type": newEnum("DeploymentStrategyType", "Recreate", "RollingUpdate"),
+		"EndpointPort.protocol":   protocol,
+		"PersistentVolumeSpec.persistentVolumeReclaimPolicy": newEnum(
+			"PersistentVolumeReclaimPolicy", "Delete", "Recycle", "Retain"),
+		"PodSpec.dnsPolicy": newEnum("DNSPolicy",
+			"ClusterFirst", "ClusterFirstWithHostNet", "Default", "None"),
+		"PodSpec.restartPolicy": newEnum("RestartPolicy", "Always", "Never", "OnFailure"),
+		"ServicePort.protocol":  protocol,
+		"ServiceSpec.externalTrafficPolicy": newEnum("ServiceExternalTrafficPolicyType",
+			"Cluster", "Local"),
+		"ServiceSpec.sessionAffinity": newEnum("ServiceAffinity", "ClientIP", "None"),
+		"ServiceSpec.type": newEnum("ServiceType",
+			"ClusterIP", "ExternalName", "LoadBalancer", "NodePort"),
+		"Taint.effect":        taintEffect,
+		"Toleration.effect":   taintEffect,
+		"Toleration.operator": newEnum("TolerationOperator", "Equal", "Exists"),
+	}
+)
+
+// propertyEnum returns the enum type of the property `propName` of `kind`, whose definition is
+// `prop`, or nil if it takes any string. Besides the well-known enums, a string property whose
+// definition lists its values in `enum` (as the schemas of CRDs may) has an enum type named after
+// the kind and the property (e.g., `CronTabSpecConcurrencyPolicy`).
+func propertyEnum(kind, propName string, prop map[string]interface{}) *Enum {
+	if enum, isWellKnown := wellKnownEnums[fmt.Sprintf("%s.%s", kind, propName)]; isWellKnown {
+		return enum
+	}
+
+	values, hasValues := prop["enum"].([]interface{})
+	if !hasValues || prop["type"] != "string" {
+		return nil
+	}
+	enum := newEnum(kind + pascalCase(propName))
+	for _, value := range values {
+		str, isStr := value.(string)
+		if !isStr {
+			return nil
+		}
+		enum.values = append(enum.values, &EnumValue{value: str})
+	}
+	return enum
+}
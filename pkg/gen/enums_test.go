@@ -0,0 +1,109 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyEnum(t *testing.T) {
+	tests := []struct {
+		description string
+		kind        string
+		propName    string
+		prop        map[string]interface{}
+		name        string
+		values      []string
+	}{
+		{
+			description: "Well-known enum should be found by kind and property",
+			kind:        "ServiceSpec",
+			propName:    "type",
+			prop:        map[string]interface{}{"type": "string"},
+			name:        "ServiceType",
+			values:      []string{"ClusterIP", "ExternalName", "LoadBalancer", "NodePort"},
+		},
+		{
+			description: "Well-known enum should be shared by the properties that take it",
+			kind:        "Toleration",
+			propName:    "effect",
+			prop:        map[string]interface{}{"type": "string"},
+			name:        "TaintEffect",
+			values:      []string{"NoExecute", "NoSchedule", "PreferNoSchedule"},
+		},
+		{
+			description: "String with values should be an enum named after its kind and property",
+			kind:        "CronTabSpec",
+			propName:    "concurrencyPolicy",
+			prop: map[string]interface{}{
+				"type": "string", "enum": []interface{}{"Allow", "Forbid", "Replace"},
+			},
+			name:   "CronTabSpecConcurrencyPolicy",
+			values: []string{"Allow", "Forbid", "Replace"},
+		},
+		{
+			description: "String without values should not be an enum",
+			kind:        "ServiceSpec",
+			propName:    "clusterIP",
+			prop:        map[string]interface{}{"type": "string"},
+		},
+		{
+			description: "Integer with values should not be an enum",
+			kind:        "CronTabSpec",
+			propName:    "replicas",
+			prop:        map[string]interface{}{"type": "integer", "enum": []interface{}{1, 3}},
+		},
+		{
+			description: "String with values that aren't strings should not be an enum",
+			kind:        "CronTabSpec",
+			propName:    "mode",
+			prop: map[string]interface{}{
+				"type": "string", "enum": []interface{}{"fast", nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		enum := propertyEnum(test.kind, test.propName, test.prop)
+		if test.name == "" {
+			assert.Nil(t, enum, test.description)
+			continue
+		}
+		if !assert.NotNil(t, enum, test.description) {
+			continue
+		}
+		assert.Equal(t, test.name, enum.Name(), test.description)
+		values := []string{}
+		for _, value := range enum.Values() {
+			values = append(values, value.Value())
+		}
+		assert.Equal(t, test.values, values, test.description)
+	}
+}
+
+func TestEnumValueIdentifiers(t *testing.T) {
+	tests := []struct {
+		value        string
+		identifier   string
+		pyIdentifier string
+	}{
+		{value: "Always", identifier: "Always", pyIdentifier: "ALWAYS"},
+		{value: "IfNotPresent", identifier: "IfNotPresent", pyIdentifier: "IF_NOT_PRESENT"},
+		{value: "ClusterIP", identifier: "ClusterIP", pyIdentifier: "CLUSTER_IP"},
+		{value: "None", identifier: "None", pyIdentifier: "NONE"},
+		{value: "no-execute", identifier: "NoExecute", pyIdentifier: "NO_EXECUTE"},
+		{value: "1", identifier: "Value1", pyIdentifier: "VALUE1"},
+		{value: "", identifier: "Value", pyIdentifier: "VALUE"},
+	}
+
+	for _, test := range tests {
+		value := &EnumValue{value: test.value}
+		assert.Equal(t, test.identifier, value.Identifier(), test.value)
+		assert.Equal(t, test.pyIdentifier, value.PyIdentifier(), test.value)
+	}
+}
+
+func TestEnumTsType(t *testing.T) {
+	assert.Equal(t, `"Always" | "IfNotPresent" | "Never"`,
+		wellKnownEnums["Container.imagePullPolicy"].TsType())
+}
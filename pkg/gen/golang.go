@@ -0,0 +1,94 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"go/format"
+	"path"
+)
+
+// GoClient will generate a Pulumi Kubernetes provider client SDK for Go. It returns the contents of
+// each file of the SDK, keyed by their paths relative to the root of the SDK (e.g.,
+// `kubernetes/apps/v1/types.go` for the `Args` structs of `apps/v1`, which are in the package
+// `github.com/pulumi/pulumi-kubernetes/pack/go/kubernetes/apps/v1`).
+func GoClient(swagger map[string]interface{}, templateDir string) (map[string]string, error) {
+	definitions := swagger["definitions"].(map[string]interface{})
+
+	files := map[string]string{}
+	render := newGoRenderer(templateDir, files)
+
+	groupsSlice := createGroups(definitions, goAPI)
+	for _, group := range groupsSlice {
+		for _, version := range group.Versions() {
+			version.imports = versionImports(version)
+			err := renderGoVersion(render, "kubernetes", group, version)
+			if err != nil {
+				return nil, err
+			}
+
+			if group.Group() == "meta" && version.Version() == "v1" {
+				err = render("kubernetes/meta/v1/metadata.go", "metadata.go.mustache", version)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	err := render("kubernetes/provider.go", "provider.go.mustache", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// newGoRenderer returns a `renderFunc` like `newRenderer`'s, which also formats the rendered files
+// with gofmt.
+func newGoRenderer(templateDir string, files map[string]string) renderFunc {
+	render := newRenderer(templateDir, files)
+	return func(path, template string, context ...interface{}) error {
+		err := render(path, template, context...)
+		if err != nil {
+			return err
+		}
+		formatted, err := format.Source([]byte(files[path]))
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %v", path, err)
+		}
+		files[path] = string(formatted)
+		return nil
+	}
+}
+
+// renderGoVersion renders the package of `version` of `group` into the directory `root`.
+func renderGoVersion(
+	render renderFunc, root string, group *GroupConfig, version *VersionConfig,
+) error {
+	dir := path.Join(root, group.Group(), version.Version())
+	err := render(path.Join(dir, "types.go"), "types.go.mustache", version)
+	if err != nil {
+		return err
+	}
+
+	if version.HasResources() {
+		err = render(path.Join(dir, "resources.go"), "resources.go.mustache", version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"path"
+	"sort"
+
+	providerVersion "github.com/pulumi/pulumi-kubernetes/pkg/version"
+)
+
+// PythonClient will generate a Pulumi Kubernetes provider client SDK for Python. It returns the
+// contents of each file of the package, keyed by their paths relative to the root of the package
+// (e.g., `pulumi_kubernetes/apps/v1/__init__.py` for the `apps/v1` module).
+func PythonClient(swagger map[string]interface{}, templateDir string) (map[string]string, error) {
+	definitions := swagger["definitions"].(map[string]interface{})
+
+	groupsSlice := createGroups(definitions, pythonAPI)
+	for _, group := range groupsSlice {
+		for _, version := range group.Versions() {
+			version.imports = versionImports(version)
+		}
+	}
+
+	files := map[string]string{}
+	render := newRenderer(templateDir, files)
+
+	err := render("pulumi_kubernetes/__init__.py", "__init__.py.mustache",
+		map[string]interface{}{
+			"Groups":   groupsSlice,
+			"Provider": true,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groupsSlice {
+		err = renderPythonGroup(render, "pulumi_kubernetes", group)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = render("pulumi_kubernetes/tables.py", "tables.py.mustache",
+		map[string]interface{}{
+			"Properties": pythonRenamedProperties(groupsSlice),
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = render("pulumi_kubernetes/provider.py", "provider.py.mustache", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = render("setup.py", "setup.py.mustache",
+		map[string]interface{}{
+			"ProviderVersion": providerVersion.Version,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// renderPythonGroup renders the module of `group` (and those of its versions) into the package
+// `root`.
+func renderPythonGroup(render renderFunc, root string, group *GroupConfig) error {
+	err := render(path.Join(root, group.Group(), "__init__.py"), "group.py.mustache", group)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range group.Versions() {
+		err = render(path.Join(root, group.Group(), version.Version(), "__init__.py"),
+			"version.py.mustache", version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pythonRenamedProperties returns one property of each name that's renamed in Python (e.g.,
+// `apiVersion` to `api_version`), in order, from which the package's tables translating the names
+// of properties between Kubernetes and Python are generated.
+func pythonRenamedProperties(groups []*GroupConfig) []*Property {
+	byName := map[string]*Property{}
+	for _, group := range groups {
+		for _, version := range group.Versions() {
+			for _, kind := range version.Kinds() {
+				for _, prop := range kind.Properties() {
+					if prop.pyName != prop.name {
+						byName[prop.name] = prop
+					}
+				}
+			}
+		}
+	}
+
+	props := []*Property{}
+	for _, prop := range byName {
+		props = append(props, prop)
+	}
+	sort.Slice(props, func(i, j int) bool { return props[i].name < props[j].name })
+	return props
+}
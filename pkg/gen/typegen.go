@@ -15,11 +15,16 @@
 package gen
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode"
 
 	linq "github.com/ahmetb/go-linq"
+	"github.com/cbroglie/mustache"
 	wordwrap "github.com/mitchellh/go-wordwrap"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
@@ -53,6 +58,7 @@ type VersionConfig struct {
 	gv            *schema.GroupVersion // Used for sorting.
 	apiVersion    string
 	rawAPIVersion string
+	imports       []*Import
 }
 
 // Version returns the name of the version (e.g., `apps/v1beta1` would return `v1beta1`).
@@ -68,6 +74,55 @@ func (vc *VersionConfig) APIVersion() string { return vc.apiVersion }
 // RawAPIVersion returns the "raw" apiVersion (e.g., `v1` rather than `core/v1`).
 func (vc *VersionConfig) RawAPIVersion() string { return vc.rawAPIVersion }
 
+// Imports returns the other group/versions whose kinds the kinds of this group/version refer to,
+// which the Python module (or Go package) of this group/version has to import.
+func (vc *VersionConfig) Imports() []*Import { return vc.imports }
+
+// Enums returns the enum types of the properties of the kinds of this group/version, once each.
+// See `wellKnownEnums`.
+func (vc *VersionConfig) Enums() []*Enum {
+	enums := []*Enum{}
+	seen := sets.NewString()
+	for _, kind := range vc.kinds {
+		for _, prop := range kind.properties {
+			if prop.enum != nil && !seen.Has(prop.enum.name) {
+				seen.Insert(prop.enum.name)
+				enums = append(enums, prop.enum)
+			}
+		}
+	}
+	return enums
+}
+
+// HasResources returns true if any kind of this group/version can be managed as a resource. See
+// `KindConfig.IsResource`.
+func (vc *VersionConfig) HasResources() bool {
+	for _, kind := range vc.kinds {
+		if kind.resource {
+			return true
+		}
+	}
+	return false
+}
+
+// Import represents a group/version whose Python module (or Go package) is imported by the module
+// of another (e.g., `core/v1` is imported by `apps/v1`, for `PodTemplateSpec`).
+type Import struct {
+	group    string
+	version  string
+	external bool
+}
+
+// Group returns the name of the imported group (e.g., `core`).
+func (i *Import) Group() string { return i.group }
+
+// Version returns the name of the imported version (e.g., `v1`).
+func (i *Import) Version() string { return i.version }
+
+// External returns true if the imported group/version is one of the Kubernetes SDK, rather than of
+// the SDK being generated (i.e., the SDK of some CRDs, which refers to, e.g., `meta/v1`).
+func (i *Import) External() bool { return i.external }
+
 // KindConfig represents a Kubernetes API kind (e.g., the `Deployment` type in
 // `apps/v1beta1/Deployment`).
 type KindConfig struct {
@@ -81,6 +136,9 @@ type KindConfig struct {
 	apiVersion    string
 	rawAPIVersion string
 	typeGuard     string
+	aliases       []string
+	resource      bool
+	deprecation   string
 }
 
 // Kind returns the name of the Kubernetes API kind (e.g., `Deployment` for
@@ -113,6 +171,42 @@ func (kc *KindConfig) RawAPIVersion() string { return kc.rawAPIVersion }
 // TypeGuard returns the text of a TypeScript type guard for the given kind.
 func (kc *KindConfig) TypeGuard() string { return kc.typeGuard }
 
+// Aliases returns a TypeScript array of the type tokens of the kinds this kind is an alias of (e.g.,
+// `kubernetes:extensions/v1beta1:Deployment` for `apps/v1/Deployment`), or "" if there are none.
+// See `openapi.IsAlias`.
+func (kc *KindConfig) Aliases() string {
+	if len(kc.aliases) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`["%s"]`, strings.Join(kc.aliases, `", "`))
+}
+
+// IsResource returns true if objects of this kind can be managed as resources, i.e., if the kind
+// has an `apiVersion` and a `kind`, rather than only being part of other kinds (e.g.,
+// `PodTemplateSpec`).
+func (kc *KindConfig) IsResource() bool { return kc.resource }
+
+// AliasTypes returns the type tokens of the kinds this kind is an alias of. See `Aliases`.
+func (kc *KindConfig) AliasTypes() []string { return kc.aliases }
+
+// DotnetConstructorParams returns the parameters of the constructor of the .NET output type of
+// this kind, one per property, each on its own line.
+func (kc *KindConfig) DotnetConstructorParams() string {
+	params := []string{}
+	for _, prop := range kc.properties {
+		params = append(params, fmt.Sprintf("%s %s", prop.dotnetOutputType, prop.dotnetParamName))
+	}
+	return strings.Join(params, ",\n            ")
+}
+
+// Deprecation returns the warning that resources of the kind emit when they're created, if the
+// kind is deprecated (see `versionNotes`), or else the empty string.
+func (kc *KindConfig) Deprecation() string { return kc.deprecation }
+
+// Patchable returns true if existing objects of this kind can be managed partially with a Patch
+// resource (e.g., `ConfigMapPatch`). Lists aren't objects stored by the API server, so they can't.
+func (kc *KindConfig) Patchable() bool { return !strings.HasSuffix(kc.kind, "List") }
+
 // Property represents a property we want to expose on a Kubernetes API kind (i.e., things that we
 // will want to `.` into, like `thing.apiVersion`, `thing.kind`, `thing.metadata`, etc.).
 type Property struct {
@@ -120,6 +214,30 @@ type Property struct {
 	comment      string
 	propType     string
 	defaultValue string
+
+	// Only set for Python (i.e., `pythonAPI`).
+	pyName       string
+	pyInputType  string
+	pyOutputType string
+
+	// The group/versions of the kinds the property refers to, for the generators that import them
+	// (i.e., Python and Go).
+	refs []schema.GroupVersion
+
+	// Only set for .NET (i.e., `dotnetAPI`).
+	dotnetName       string
+	dotnetParamName  string
+	dotnetInputType  string
+	dotnetOutputType string
+
+	// Only set for Go (i.e., `goAPI`).
+	goName       string
+	goInputType  string
+	goOutputType string
+	goMarshal    goMarshalKind
+
+	// Set if the property takes one of a fixed set of strings. See `wellKnownEnums`.
+	enum *Enum
 }
 
 // Name returns the name of the property
@@ -134,12 +252,85 @@ func (p *Property) PropType() string { return p.propType }
 // DefaultValue returns the type of the property.
 func (p *Property) DefaultValue() string { return p.defaultValue }
 
+// PyName returns the name of the property in Python (e.g., `api_version` for `apiVersion`).
+func (p *Property) PyName() string { return p.pyName }
+
+// PyInputType returns the Python type annotation of the property, as an input.
+func (p *Property) PyInputType() string { return p.pyInputType }
+
+// PyOutputType returns the Python type annotation of the property, as an output.
+func (p *Property) PyOutputType() string { return p.pyOutputType }
+
+// DotnetName returns the PascalCase name of the property in .NET (e.g., `ApiVersion` for
+// `apiVersion`).
+func (p *Property) DotnetName() string { return p.dotnetName }
+
+// DotnetParamName returns the name of the parameter of the property in the constructors of .NET
+// output types (e.g., `apiVersion`, or `@namespace` for `namespace`, which is a C# keyword).
+func (p *Property) DotnetParamName() string { return p.dotnetParamName }
+
+// DotnetInputType returns the .NET type of the property, as an input (e.g.,
+// `InputList<string>`).
+func (p *Property) DotnetInputType() string { return p.dotnetInputType }
+
+// DotnetOutputType returns the .NET type of the property, as an output (e.g.,
+// `ImmutableArray<string>`), which is nullable if the property is optional.
+func (p *Property) DotnetOutputType() string { return p.dotnetOutputType }
+
+// GoName returns the name of the field of the property in Go (e.g., `ApiVersion`).
+func (p *Property) GoName() string { return p.goName }
+
+// GoInputType returns the Go type of the field of the property in `Args` structs.
+func (p *Property) GoInputType() string { return p.goInputType }
+
+// GoOutputType returns the Go type of the output of the property on resources (e.g.,
+// `*pulumi.StringOutput`).
+func (p *Property) GoOutputType() string { return p.goOutputType }
+
+// GoMarshalArgs returns true if the property is an `Args` struct, which is marshaled with its `Map`
+// method.
+func (p *Property) GoMarshalArgs() bool { return p.goMarshal == goMarshalArgs }
+
+// GoMarshalArgsList returns true if the property is a slice of `Args` structs, each of which is
+// marshaled with its `Map` method.
+func (p *Property) GoMarshalArgsList() bool { return p.goMarshal == goMarshalArgsList }
+
+// GoMarshalEnum returns true if the property is an enum, which is marshaled as a string.
+func (p *Property) GoMarshalEnum() bool { return p.goMarshal == goMarshalEnum }
+
+// GoMarshalValue returns true if the property is marshaled as is.
+func (p *Property) GoMarshalValue() bool { return p.goMarshal == goMarshalValue }
+
+// Enum returns the enum type of the property, or nil if it takes any string.
+func (p *Property) Enum() *Enum { return p.enum }
+
+// Constant returns true if the value of the property is determined by its kind, i.e., if it's
+// `apiVersion` or `kind`, which resources set themselves.
+func (p *Property) Constant() bool { return p.name == "apiVersion" || p.name == "kind" }
+
 // --------------------------------------------------------------------------
 
 // Utility functions.
 
 // --------------------------------------------------------------------------
 
+// renderFunc renders the template file `template` with the given contexts, as the file `path` of
+// an SDK.
+type renderFunc func(path, template string, context ...interface{}) error
+
+// newRenderer returns a `renderFunc` that renders the templates of `templateDir` into `files`,
+// keyed by their paths.
+func newRenderer(templateDir string, files map[string]string) renderFunc {
+	return func(path, template string, context ...interface{}) error {
+		text, err := mustache.RenderFile(fmt.Sprintf("%s/%s", templateDir, template), context...)
+		if err != nil {
+			return err
+		}
+		files[path] = text
+		return nil
+	}
+}
+
 func gvkFromRef(ref string) schema.GroupVersionKind {
 	// TODO(hausdorff): Surely there is an official k8s function somewhere for doing this.
 	split := strings.Split(ref, ".")
@@ -155,6 +346,31 @@ func stripPrefix(name string) string {
 	return strings.TrimPrefix(name, prefix)
 }
 
+// versionImports returns the other group/versions whose kinds the kinds of `version` refer to, in
+// order.
+func versionImports(version *VersionConfig) []*Import {
+	refs := map[schema.GroupVersion]bool{}
+	for _, kind := range version.Kinds() {
+		for _, prop := range kind.Properties() {
+			for _, ref := range prop.refs {
+				refs[ref] = true
+			}
+		}
+	}
+
+	imports := []*Import{}
+	for ref := range refs {
+		imports = append(imports, &Import{group: ref.Group, version: ref.Version})
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		if imports[i].group != imports[j].group {
+			return imports[i].group < imports[j].group
+		}
+		return imports[i].version < imports[j].version
+	})
+	return imports
+}
+
 func fmtComment(comment interface{}, prefix string) string {
 	if comment == nil {
 		return ""
@@ -175,6 +391,62 @@ func fmtComment(comment interface{}, prefix string) string {
 	return ""
 }
 
+// fmtPyComment formats a comment as a Python docstring whose lines (but the first) are indented
+// with `prefix`.
+func fmtPyComment(comment interface{}, prefix string) string {
+	if comment == nil {
+		return ""
+	}
+	commentstr, _ := comment.(string)
+	if len(commentstr) > 0 {
+		split := strings.Split(commentstr, "\n")
+		lines := []string{}
+		for _, line := range split {
+			escaped := strings.Replace(line, `\`, `\\`, -1)
+			escaped = strings.Replace(escaped, `"""`, `\"\"\"`, -1) // Escape docstring termination.
+			wrapped := wordwrap.WrapString(escaped, 100-uint(len(prefix)))
+			for _, wrappedLine := range strings.Split(wrapped, "\n") {
+				if wrappedLine == "" {
+					lines = append(lines, "")
+				} else {
+					lines = append(lines, prefix+wrappedLine)
+				}
+			}
+		}
+		return fmt.Sprintf("\"\"\"\n%s\n%s\"\"\"", strings.Join(lines, "\n"), prefix)
+	}
+	return ""
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// fmtCsComment formats a comment as a C# XML doc comment, whose lines (but the first) are indented
+// with `prefix`.
+func fmtCsComment(comment interface{}, prefix string) string {
+	if comment == nil {
+		return ""
+	}
+	commentstr, _ := comment.(string)
+	if len(commentstr) > 0 {
+		split := strings.Split(commentstr, "\n")
+		lines := []string{}
+		for _, line := range split {
+			escaped := xmlEscaper.Replace(line)
+			borderLen := len(prefix + "/// ")
+			wrapped := wordwrap.WrapString(escaped, 100-uint(borderLen))
+			for _, wrappedLine := range strings.Split(wrapped, "\n") {
+				if wrappedLine == "" {
+					lines = append(lines, prefix+"///")
+				} else {
+					lines = append(lines, prefix+"/// "+wrappedLine)
+				}
+			}
+		}
+		return fmt.Sprintf("/// <summary>\n%s\n%s/// </summary>", strings.Join(lines, "\n"), prefix)
+	}
+	return ""
+}
+
 type refType int
 
 const (
@@ -228,6 +500,389 @@ func makeType(prop map[string]interface{}, refPrefix string, rt refType) string
 	return fmt.Sprintf("%s.%s.%s.%s", refPrefix, gvk.Group, gvk.Version, gvk.Kind)
 }
 
+// pythonKeywords are the reserved words of Python, which can't be used as parameter names.
+var pythonKeywords = sets.NewString(
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class", "continue",
+	"def", "del", "elif", "else", "except", "finally", "for", "from", "global", "if", "import",
+	"in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise", "return", "try", "while",
+	"with", "yield")
+
+// pyName returns the snake_case Python name of the property `name` (e.g., `pod_cidr` for
+// `podCIDR`, and `external_ips` for `externalIPs`). Characters that can't appear in Python
+// identifiers (e.g., `$` in `$ref`) are dropped or replaced, and keywords get a trailing `_`.
+func pyName(name string) string {
+	runes := []rune(strings.TrimLeft(name, "$"))
+	var b bytes.Buffer
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			// The last upper-case letter of an acronym starts a word (e.g., the `S` of `IPSet`),
+			// unless only a plural `s` follows it (e.g., `IPs`).
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) &&
+				unicode.IsLower(runes[i+1]) && !(runes[i+1] == 's' && i+2 == len(runes))
+			if prevLower || nextLower {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	snake := b.String()
+	if pythonKeywords.Has(snake) {
+		return snake + "_"
+	}
+	return snake
+}
+
+// isOpaqueRef returns true if no type is generated for the definition `ref` refers to, because it
+// has no properties (e.g., `io.k8s.apiextensions-apiserver.pkg.apis.apiextensions.v1beta1.JSON`),
+// in which case values of any type are allowed.
+func isOpaqueRef(definitions map[string]interface{}, ref string) bool {
+	definition, exists := definitions[ref]
+	if !exists || strings.HasPrefix(ref, "io.k8s.kubernetes.pkg") {
+		return true
+	}
+	_, hasProperties := definition.(map[string]interface{})["properties"]
+	return !hasProperties
+}
+
+// makePythonType returns the Python type annotation of `prop`, as an input if `input` is true, or
+// else as an output. Kinds are only referred to by input types, as their `Args` classes, which are
+// qualified with the name of their module if they're not in the group/version `gv`, in which case
+// that group/version is added to `refs`.
+func makePythonType(
+	prop map[string]interface{}, definitions map[string]interface{}, gv schema.GroupVersion,
+	input bool, refs *[]schema.GroupVersion,
+) string {
+	wrap := func(t string) string {
+		if input {
+			return fmt.Sprintf("pulumi.Input[%s]", t)
+		}
+		return t
+	}
+
+	if t, exists := prop["type"]; exists {
+		switch t.(string) {
+		case "array":
+			items := prop["items"].(map[string]interface{})
+			return wrap(fmt.Sprintf("List[%s]", makePythonType(items, definitions, gv, input, refs)))
+		case "integer":
+			return wrap("int")
+		case "number":
+			return wrap("float")
+		case "boolean":
+			return wrap("bool")
+		case "string":
+			return wrap("str")
+		case "object":
+			// See `makeType` for maps.
+			if additionalProperties, exists := prop["additionalProperties"]; exists {
+				mapType := additionalProperties.(map[string]interface{})
+				if _, exists := mapType["type"]; exists && len(mapType) == 1 {
+					return wrap(fmt.Sprintf("Dict[str, %s]",
+						makePythonType(mapType, definitions, gv, input, refs)))
+				}
+			}
+			return wrap("dict")
+		}
+		return wrap("Any")
+	}
+
+	ref := stripPrefix(prop["$ref"].(string))
+	if ref == "io.k8s.apimachinery.pkg.api.resource.Quantity" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.Time" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.MicroTime" {
+		return wrap("str")
+	} else if ref == "io.k8s.apimachinery.pkg.util.intstr.IntOrString" {
+		return wrap("Union[int, str]")
+	} else if isOpaqueRef(definitions, ref) {
+		return wrap("Any")
+	} else if !input {
+		return "dict"
+	}
+
+	gvk := gvkFromRef(ref)
+	if gvk.GroupVersion() == gv {
+		return wrap(fmt.Sprintf("'%sArgs'", gvk.Kind))
+	}
+	*refs = append(*refs, gvk.GroupVersion())
+	return wrap(fmt.Sprintf("'%s_%s.%sArgs'", gvk.Group, gvk.Version, gvk.Kind))
+}
+
+// csharpKeywords are the reserved words of C#, which have to be escaped with `@` to be used as
+// parameter names.
+var csharpKeywords = sets.NewString(
+	"abstract", "as", "base", "bool", "break", "byte", "case", "catch", "char", "checked", "class",
+	"const", "continue", "decimal", "default", "delegate", "do", "double", "else", "enum", "event",
+	"explicit", "extern", "false", "finally", "fixed", "float", "for", "foreach", "goto", "if",
+	"implicit", "in", "int", "interface", "internal", "is", "lock", "long", "namespace", "new",
+	"null", "object", "operator", "out", "override", "params", "private", "protected", "public",
+	"readonly", "ref", "return", "sbyte", "sealed", "short", "sizeof", "stackalloc", "static",
+	"string", "struct", "switch", "this", "throw", "true", "try", "typeof", "uint", "ulong",
+	"unchecked", "unsafe", "ushort", "using", "virtual", "void", "volatile", "while")
+
+// pascalCase returns the PascalCase .NET name of `name`, from which Go names are also derived
+// (e.g., `ApiVersion` for `apiVersion`, and `XKubernetesEmbeddedResource` for
+// `x-kubernetes-embedded-resource`). Characters that can't appear in identifiers (e.g., `$` in
+// `$ref`) are dropped, and start a new word.
+func pascalCase(name string) string {
+	var b bytes.Buffer
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// dotnetParamName returns the camelCase name of the parameter for the .NET member `name`, escaped
+// with `@` if it's a C# keyword.
+func dotnetParamName(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	param := string(runes)
+	if csharpKeywords.Has(param) {
+		return "@" + param
+	}
+	return param
+}
+
+// dotnetNamespace returns the name of the .NET namespace of the group/version `gv`, relative to the
+// namespace of the package (e.g., `Apps.V1Beta1` for `apps/v1beta1`).
+func dotnetNamespace(gv schema.GroupVersion) string {
+	var version bytes.Buffer
+	for i, r := range gv.Version {
+		if i == 0 || unicode.IsDigit(rune(gv.Version[i-1])) {
+			r = unicode.ToUpper(r)
+		}
+		version.WriteRune(r)
+	}
+	return fmt.Sprintf("%s.%s", pascalCase(gv.Group), version.String())
+}
+
+// makeDotnetType returns the .NET type of `prop`, as used for the elements of input lists and
+// maps if `input` is true, or else as an output. Kinds are referred to by their `Args` classes as
+// inputs, and by their output types as outputs.
+func makeDotnetType(
+	prop map[string]interface{}, definitions map[string]interface{}, input bool,
+) string {
+	if t, exists := prop["type"]; exists {
+		switch t.(string) {
+		case "array":
+			items := prop["items"].(map[string]interface{})
+			return fmt.Sprintf("ImmutableArray<%s>", makeDotnetType(items, definitions, input))
+		case "integer":
+			if prop["format"] == "int64" {
+				return "long"
+			}
+			return "int"
+		case "number":
+			return "double"
+		case "boolean":
+			return "bool"
+		case "string":
+			return "string"
+		case "object":
+			// See `makeType` for maps.
+			if additionalProperties, exists := prop["additionalProperties"]; exists {
+				mapType := additionalProperties.(map[string]interface{})
+				if _, exists := mapType["type"]; exists && len(mapType) == 1 {
+					return fmt.Sprintf("ImmutableDictionary<string, %s>",
+						makeDotnetType(mapType, definitions, input))
+				}
+			}
+			return "ImmutableDictionary<string, object>"
+		}
+		return "object"
+	}
+
+	ref := stripPrefix(prop["$ref"].(string))
+	if ref == "io.k8s.apimachinery.pkg.api.resource.Quantity" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.Time" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.MicroTime" {
+		return "string"
+	} else if ref == "io.k8s.apimachinery.pkg.util.intstr.IntOrString" {
+		return "Union<int, string>"
+	} else if isOpaqueRef(definitions, ref) {
+		return "object"
+	}
+
+	gvk := gvkFromRef(ref)
+	if input {
+		return fmt.Sprintf("global::Pulumi.Kubernetes.Types.Inputs.%s.%sArgs",
+			dotnetNamespace(gvk.GroupVersion()), gvk.Kind)
+	}
+	return fmt.Sprintf("global::Pulumi.Kubernetes.Types.Outputs.%s.%s",
+		dotnetNamespace(gvk.GroupVersion()), gvk.Kind)
+}
+
+// makeDotnetInputType returns the .NET type of `prop` as an input: an `InputList` for arrays, an
+// `InputMap` for maps, and an `Input` otherwise.
+func makeDotnetInputType(prop map[string]interface{}, definitions map[string]interface{}) string {
+	if t, exists := prop["type"]; exists {
+		switch t.(string) {
+		case "array":
+			items := prop["items"].(map[string]interface{})
+			return fmt.Sprintf("InputList<%s>", makeDotnetType(items, definitions, true))
+		case "object":
+			if additionalProperties, exists := prop["additionalProperties"]; exists {
+				mapType := additionalProperties.(map[string]interface{})
+				if _, exists := mapType["type"]; exists && len(mapType) == 1 {
+					return fmt.Sprintf("InputMap<%s>", makeDotnetType(mapType, definitions, true))
+				}
+			}
+			return "InputMap<object>"
+		}
+	}
+	return fmt.Sprintf("Input<%s>", makeDotnetType(prop, definitions, true))
+}
+
+// fmtGoComment formats a comment as a Go comment, whose lines (but the first) are indented with
+// `prefix`.
+func fmtGoComment(comment interface{}, prefix string) string {
+	if comment == nil {
+		return ""
+	}
+	commentstr, _ := comment.(string)
+	if len(commentstr) > 0 {
+		split := strings.Split(commentstr, "\n")
+		lines := []string{}
+		for _, line := range split {
+			borderLen := len(prefix + "// ")
+			wrapped := wordwrap.WrapString(line, 100-uint(borderLen))
+			for _, wrappedLine := range strings.Split(wrapped, "\n") {
+				if wrappedLine == "" {
+					lines = append(lines, prefix+"//")
+				} else {
+					lines = append(lines, prefix+"// "+wrappedLine)
+				}
+			}
+		}
+		return strings.TrimPrefix(strings.Join(lines, "\n"), prefix)
+	}
+	return ""
+}
+
+// goInitialisms are the words that Go names spell in all caps (e.g., `APIVersion`, not
+// `ApiVersion`), as golint expects.
+var goInitialisms = sets.NewString(
+	"API", "CPU", "DNS", "HTTP", "HTTPS", "ID", "IP", "JSON", "TCP", "TLS", "TTL", "UDP", "UID",
+	"URI", "URL")
+
+// goName returns the exported Go name of the field for the property `name`, which is its
+// `pascalCase` name with initialisms in all caps (e.g., `APIVersion` for `apiVersion`).
+func goName(name string) string {
+	var b bytes.Buffer
+	var word []rune
+	flush := func() {
+		if upper := strings.ToUpper(string(word)); goInitialisms.Has(upper) {
+			b.WriteString(upper)
+		} else {
+			b.WriteString(string(word))
+		}
+		word = nil
+	}
+	for _, r := range pascalCase(name) {
+		if unicode.IsUpper(r) && len(word) > 0 {
+			flush()
+		}
+		word = append(word, r)
+	}
+	flush()
+	return b.String()
+}
+
+type goMarshalKind int
+
+const (
+	goMarshalValue goMarshalKind = iota
+	goMarshalArgs
+	goMarshalArgsList
+	goMarshalEnum
+)
+
+// goArgsType returns the Go type of a pointer to the `Args` struct of the kind `ref` refers to,
+// qualified with the name of its package if it's not in the group/version `gv`, in which case that
+// group/version is added to `refs`.
+func goArgsType(ref string, gv schema.GroupVersion, refs *[]schema.GroupVersion) string {
+	gvk := gvkFromRef(ref)
+	if gvk.GroupVersion() == gv {
+		return fmt.Sprintf("*%sArgs", gvk.Kind)
+	}
+	*refs = append(*refs, gvk.GroupVersion())
+	return fmt.Sprintf("*%s%s.%sArgs", gvk.Group, gvk.Version, gvk.Kind)
+}
+
+// makeGoInputType returns the Go type of the field of `prop` in `Args` structs, and how it's
+// marshaled. Kinds (and lists of kinds) are referred to by their `Args` structs, while other values
+// are `interface{}`, so that they can also be outputs of other resources. See `goArgsType`.
+func makeGoInputType(
+	prop map[string]interface{}, definitions map[string]interface{}, gv schema.GroupVersion,
+	refs *[]schema.GroupVersion,
+) (string, goMarshalKind) {
+	if t, exists := prop["type"]; exists {
+		switch t.(string) {
+		case "array":
+			items := prop["items"].(map[string]interface{})
+			if ref, isRef := items["$ref"]; isRef && !isOpaqueRef(definitions, stripPrefix(ref.(string))) {
+				return "[]" + goArgsType(stripPrefix(ref.(string)), gv, refs), goMarshalArgsList
+			}
+			return "[]interface{}", goMarshalValue
+		case "object":
+			return "map[string]interface{}", goMarshalValue
+		}
+		return "interface{}", goMarshalValue
+	}
+
+	ref := stripPrefix(prop["$ref"].(string))
+	if isOpaqueRef(definitions, ref) {
+		return "interface{}", goMarshalValue
+	}
+	return goArgsType(ref, gv, refs), goMarshalArgs
+}
+
+// makeGoOutputType returns the Go type of the output of `prop` on resources.
+func makeGoOutputType(prop map[string]interface{}, definitions map[string]interface{}) string {
+	if t, exists := prop["type"]; exists {
+		switch t.(string) {
+		case "array":
+			return "*pulumi.ArrayOutput"
+		case "integer":
+			return "*pulumi.IntOutput"
+		case "number":
+			return "*pulumi.Float64Output"
+		case "boolean":
+			return "*pulumi.BoolOutput"
+		case "string":
+			return "*pulumi.StringOutput"
+		case "object":
+			return "*pulumi.MapOutput"
+		}
+		return "*pulumi.Output"
+	}
+
+	ref := stripPrefix(prop["$ref"].(string))
+	if ref == "io.k8s.apimachinery.pkg.api.resource.Quantity" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.Time" ||
+		ref == "io.k8s.apimachinery.pkg.apis.meta.v1.MicroTime" {
+		return "*pulumi.StringOutput"
+	} else if isOpaqueRef(definitions, ref) {
+		return "*pulumi.Output"
+	}
+	return "*pulumi.MapOutput"
+}
+
 func makeTypeLiteral(prop map[string]interface{}, t refType) string {
 	return makeType(prop, "", t)
 }
@@ -254,6 +909,9 @@ const (
 	provider gentype = iota
 	inputsAPI
 	outputsAPI
+	pythonAPI
+	dotnetAPI
+	goAPI
 )
 
 func createGroups(definitionsJSON map[string]interface{}, generatorType gentype) []*GroupConfig {
@@ -277,6 +935,7 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 			}
 		}).
 		ToSlice(&definitions)
+	introducedIn := kindVersionHistory(definitions)
 
 	//
 	// Assemble a `KindConfig` for each Kubernetes kind.
@@ -320,6 +979,13 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 				fqGroupVersion = gv
 			}
 
+			reqdProps := sets.NewString()
+			if reqd, hasReqd := d.data["required"]; hasReqd {
+				for _, propName := range reqd.([]interface{}) {
+					reqdProps.Insert(propName.(string))
+				}
+			}
+
 			ps := linq.From(d.data["properties"]).
 				OrderByT(func(kv linq.KeyValue) string { return kv.Key.(string) }).
 				SelectT(func(kv linq.KeyValue) *Property {
@@ -333,6 +999,8 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 						typeLiteral = makeTypeLiteral(prop, outputRef)
 					case provider:
 						typeLiteral = makeAPITypeRef(prop)
+					case pythonAPI, dotnetAPI, goAPI:
+						// See below.
 					default:
 						panic("Unrecognized generator type")
 					}
@@ -346,12 +1014,55 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 						defaultValue = fmt.Sprintf(`"%s"`, d.gvk.Kind)
 					}
 
-					return &Property{
+					property := &Property{
 						comment:      fmtComment(prop["description"], "      "),
 						propType:     typeLiteral,
 						name:         propName,
 						defaultValue: defaultValue,
+						enum:         propertyEnum(d.gvk.Kind, propName, prop),
 					}
+					if generatorType == inputsAPI && property.enum != nil {
+						property.propType = fmt.Sprintf("%s.%s.%s", d.gvk.Group, d.gvk.Version,
+							property.enum.name)
+					}
+					if generatorType == pythonAPI {
+						property.comment = fmtPyComment(prop["description"], "    ")
+						property.pyName = pyName(propName)
+						property.pyInputType = makePythonType(prop, definitionsJSON,
+							d.gvk.GroupVersion(), true, &property.refs)
+						property.pyOutputType = makePythonType(prop, definitionsJSON,
+							d.gvk.GroupVersion(), false, &property.refs)
+						if !property.Constant() {
+							property.defaultValue = property.pyName
+						}
+					} else if generatorType == dotnetAPI {
+						property.comment = fmtCsComment(prop["description"], "        ")
+						property.dotnetName = pascalCase(propName)
+						if property.dotnetName == d.gvk.Kind {
+							// Members can't have the name of their class.
+							property.dotnetName += "Value"
+						}
+						property.dotnetParamName = dotnetParamName(property.dotnetName)
+						property.dotnetInputType = makeDotnetInputType(prop, definitionsJSON)
+						property.dotnetOutputType = makeDotnetType(prop, definitionsJSON, false)
+						if !reqdProps.Has(propName) {
+							property.dotnetOutputType += "?"
+						}
+					} else if generatorType == goAPI {
+						description, _ := prop["description"].(string)
+						if reqdProps.Has(propName) {
+							description = strings.TrimSpace(description + "\n\nRequired.")
+						}
+						property.comment = fmtGoComment(description, "\t")
+						property.goName = goName(propName)
+						property.goInputType, property.goMarshal = makeGoInputType(prop, definitionsJSON,
+							d.gvk.GroupVersion(), &property.refs)
+						property.goOutputType = makeGoOutputType(prop, definitionsJSON)
+						if property.enum != nil {
+							property.goInputType, property.goMarshal = property.enum.name, goMarshalEnum
+						}
+					}
+					return property
 				})
 
 			// All properties.
@@ -359,13 +1070,6 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 			ps.ToSlice(&properties)
 
 			// Required properties.
-			reqdProps := sets.NewString()
-			if reqd, hasReqd := d.data["required"]; hasReqd {
-				for _, propName := range reqd.([]interface{}) {
-					reqdProps.Insert(propName.(string))
-				}
-			}
-
 			requiredProperties := []*Property{}
 			ps.
 				WhereT(func(p *Property) bool {
@@ -399,12 +1103,36 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
     }`, d.gvk.Kind, d.gvk.Kind, defaultGroupVersion, d.gvk.Kind)
 			}
 
+			// The docs of the kind end with the Kubernetes versions that introduce and deprecate it,
+			// the latter tagged as editors expect in TypeScript and Go.
+			description, _ := d.data["description"].(string)
+			notes, deprecation := versionNotes(d, introducedIn)
+			if deprecation != "" {
+				switch generatorType {
+				case provider, inputsAPI, outputsAPI:
+					notes[len(notes)-1] = "@deprecated " + deprecation
+				case goAPI:
+					notes[len(notes)-1] = "Deprecated: " + deprecation
+				}
+			}
+			description = strings.TrimSpace(strings.Join(append([]string{description}, notes...),
+				"\n\n"))
+
+			// NOTE: This transformation assumes git users on Windows to set
+			// the "check in with UNIX line endings" setting.
+			comment := fmtComment(description, "    ")
+			if generatorType == pythonAPI {
+				comment = fmtPyComment(description, "    ")
+			} else if generatorType == dotnetAPI {
+				comment = fmtCsComment(description, "    ")
+			} else if generatorType == goAPI {
+				comment = fmtGoComment(description, "")
+			}
+
 			return linq.From([]*KindConfig{
 				{
-					kind: d.gvk.Kind,
-					// NOTE: This transformation assumes git users on Windows to set
-					// the "check in with UNIX line endings" setting.
-					comment:            fmtComment(d.data["description"], "    "),
+					kind:               d.gvk.Kind,
+					comment:            comment,
 					properties:         properties,
 					requiredProperties: requiredProperties,
 					optionalProperties: optionalProperties,
@@ -412,11 +1140,30 @@ func createGroups(definitionsJSON map[string]interface{}, generatorType gentype)
 					apiVersion:         fqGroupVersion,
 					rawAPIVersion:      defaultGroupVersion,
 					typeGuard:          typeGuard,
+					resource:           kindExists && apiVersionExists,
+					deprecation:        deprecation,
 				},
 			})
 		}).
 		ToSlice(&kinds)
 
+	//
+	// Alias each kind to the same kind in every other apiVersion that serves it, so that moving a
+	// resource to another apiVersion updates it in place.
+	//
+
+	for _, kind := range kinds {
+		if strings.HasSuffix(kind.kind, "List") {
+			continue
+		}
+		for _, other := range kinds {
+			if other != kind && openapi.IsAlias(*kind.gvk, *other.gvk) {
+				kind.aliases = append(kind.aliases,
+					fmt.Sprintf("kubernetes:%s:%s", other.apiVersion, other.kind))
+			}
+		}
+	}
+
 	//
 	// Assemble a `VersionConfig` for each group of kinds.
 	//
@@ -0,0 +1,143 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// --------------------------------------------------------------------------
+
+// Version history.
+//
+// The SDKs are generated from the OpenAPI spec of one Kubernetes release, which says nothing of
+// when its kinds appeared, or whether they're going away. So the kinds are tagged with the
+// Kubernetes versions that introduce and remove them, from the specs of a range of releases (the
+// schemas bundled with the provider), and with the deprecations of `openapi.DeprecationOf`. The
+// SDKs mention these in the docs of the kinds, and warn when deprecated resources are created.
+
+// --------------------------------------------------------------------------
+
+const (
+	introducedInExtension = "x-pulumi-introduced-in"
+	removedInExtension    = "x-pulumi-removed-in"
+)
+
+// AddVersionHistory tags each definition of `swagger` with the minor version of the first
+// Kubernetes 1.x release whose spec in `schemas` (the specs of consecutive releases, by minor
+// version) defines it, and of the first release after that which doesn't. Definitions that the
+// oldest release already defines aren't tagged as introduced, nor are those that the newest
+// release still defines tagged as removed.
+func AddVersionHistory(swagger map[string]interface{}, schemas map[int]map[string]interface{}) {
+	minors := []int{}
+	for minor := range schemas {
+		minors = append(minors, minor)
+	}
+	if len(minors) == 0 {
+		return
+	}
+	sort.Ints(minors)
+
+	defined := func(minor int, name string) bool {
+		definitions, _ := schemas[minor]["definitions"].(map[string]interface{})
+		_, exists := definitions[name]
+		return exists
+	}
+
+	for name, definition := range swagger["definitions"].(map[string]interface{}) {
+		data := definition.(map[string]interface{})
+		introduced := -1
+		for i, minor := range minors {
+			if !defined(minor, name) {
+				if introduced >= 0 {
+					data[removedInExtension] = minor
+					break
+				}
+				continue
+			}
+			if introduced < 0 {
+				introduced = i
+				if i > 0 {
+					data[introducedInExtension] = minor
+				}
+			}
+		}
+	}
+}
+
+// kindVersionHistory returns the Kubernetes 1.x minor version that introduced the kind of each
+// definition that is tagged with one (see `AddVersionHistory`), by the GVK of the kind.
+func kindVersionHistory(definitions []*definition) map[schema.GroupVersionKind]int {
+	introducedIn := map[schema.GroupVersionKind]int{}
+	for _, d := range definitions {
+		minor, introduced := d.data[introducedInExtension].(int)
+		if gvk, isKind := servedGVK(d); isKind && introduced {
+			introducedIn[gvk] = minor
+		}
+	}
+	return introducedIn
+}
+
+// servedGVK returns the GVK of the kind of `d` as served by the API server (e.g.,
+// `rbac.authorization.k8s.io/v1/Role`, rather than `rbac/v1/Role`), if `d` is the definition of a
+// top-level kind.
+func servedGVK(d *definition) (schema.GroupVersionKind, bool) {
+	gvks, _ := d.data["x-kubernetes-group-version-kind"].([]interface{})
+	if len(gvks) == 0 {
+		return schema.GroupVersionKind{}, false
+	}
+	gvk := gvks[0].(map[string]interface{})
+	return schema.GroupVersionKind{
+		Group:   gvk["group"].(string),
+		Version: gvk["version"].(string),
+		Kind:    gvk["kind"].(string),
+	}, true
+}
+
+// versionNotes returns the sentences about the Kubernetes versions that introduce and deprecate
+// the kind of `d` to append to its docs, and the deprecation warning of its resources, if it's a
+// deprecated top-level kind (e.g., "apps/v1beta1/Deployment is deprecated since Kubernetes 1.9,
+// and removed in Kubernetes 1.16; use apps/v1/Deployment instead.").
+func versionNotes(
+	d *definition, introducedIn map[schema.GroupVersionKind]int,
+) (notes []string, deprecation string) {
+	if minor, introduced := d.data[introducedInExtension].(int); introduced {
+		notes = append(notes, fmt.Sprintf("Available since Kubernetes 1.%d.", minor))
+	}
+
+	gvk, isKind := servedGVK(d)
+	if !isKind {
+		return notes, ""
+	}
+	name := fmt.Sprintf("%s/%s", gvk.GroupVersion(), gvk.Kind)
+	if removal, deprecated := openapi.DeprecationOf(gvk); deprecated {
+		if minor, known := introducedIn[removal.Replacement]; known {
+			deprecation = fmt.Sprintf("%s is deprecated since Kubernetes 1.%d, and %s.",
+				name, minor, removal)
+		} else {
+			deprecation = fmt.Sprintf("%s is deprecated, and %s.", name, removal)
+		}
+	} else if minor, removed := d.data[removedInExtension].(int); removed {
+		deprecation = fmt.Sprintf("%s is removed in Kubernetes 1.%d.", name, minor)
+	}
+	if deprecation != "" {
+		notes = append(notes, deprecation)
+	}
+	return notes, deprecation
+}
@@ -0,0 +1,145 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestAddVersionHistory(t *testing.T) {
+	specOf := func(names ...string) map[string]interface{} {
+		definitions := map[string]interface{}{}
+		for _, name := range names {
+			definitions[name] = map[string]interface{}{}
+		}
+		return map[string]interface{}{"definitions": definitions}
+	}
+	schemas := map[int]map[string]interface{}{
+		14: specOf("Always", "Removed"),
+		15: specOf("Always", "Removed", "Introduced", "Transient"),
+		16: specOf("Always", "Introduced"),
+	}
+
+	tests := []struct {
+		name       string
+		introduced interface{}
+		removed    interface{}
+	}{
+		{name: "Always"},
+		{name: "Removed", removed: 16},
+		{name: "Introduced", introduced: 15},
+		{name: "Transient", introduced: 15, removed: 16},
+		{name: "Unknown"},
+	}
+
+	swagger := specOf("Always", "Removed", "Introduced", "Transient", "Unknown")
+	AddVersionHistory(swagger, schemas)
+	definitions := swagger["definitions"].(map[string]interface{})
+	for _, test := range tests {
+		data := definitions[test.name].(map[string]interface{})
+		assert.Equal(t, test.introduced, data[introducedInExtension], test.name)
+		assert.Equal(t, test.removed, data[removedInExtension], test.name)
+	}
+
+	swagger = specOf("Always")
+	AddVersionHistory(swagger, map[int]map[string]interface{}{})
+	assert.Equal(t, specOf("Always"), swagger, "No schemas should tag nothing")
+}
+
+func TestKindVersionHistory(t *testing.T) {
+	definitions := []*definition{
+		versionTestDefinition("apps", "v1", "Deployment", map[string]interface{}{
+			introducedInExtension: 9,
+		}),
+		versionTestDefinition("apps", "v1", "DaemonSet", map[string]interface{}{}),
+		{name: "DeploymentSpec", data: map[string]interface{}{introducedInExtension: 9}},
+	}
+
+	assert.Equal(t, map[schema.GroupVersionKind]int{
+		{Group: "apps", Version: "v1", Kind: "Deployment"}: 9,
+	}, kindVersionHistory(definitions))
+}
+
+func TestVersionNotes(t *testing.T) {
+	introducedIn := map[schema.GroupVersionKind]int{
+		{Group: "apps", Version: "v1", Kind: "Deployment"}: 9,
+	}
+
+	tests := []struct {
+		description string
+		definition  *definition
+		notes       []string
+		deprecation string
+	}{
+		{
+			description: "Kind that is neither new nor deprecated should have no notes",
+			definition: versionTestDefinition("apps", "v1", "Deployment",
+				map[string]interface{}{}),
+		},
+		{
+			description: "Kind should note when it was introduced",
+			definition: versionTestDefinition("apps", "v1", "Deployment",
+				map[string]interface{}{introducedInExtension: 9}),
+			notes: []string{"Available since Kubernetes 1.9."},
+		},
+		{
+			description: "Deprecated kind should note when its replacement was introduced",
+			definition: versionTestDefinition("extensions", "v1beta1", "Deployment",
+				map[string]interface{}{}),
+			notes: []string{"extensions/v1beta1/Deployment is deprecated since Kubernetes 1.9, " +
+				"and removed in Kubernetes 1.16; use apps/v1/Deployment instead."},
+			deprecation: "extensions/v1beta1/Deployment is deprecated since Kubernetes 1.9, " +
+				"and removed in Kubernetes 1.16; use apps/v1/Deployment instead.",
+		},
+		{
+			description: "Deprecated kind without a replacement should say so",
+			definition: versionTestDefinition("policy", "v1beta1", "PodSecurityPolicy",
+				map[string]interface{}{}),
+			notes: []string{"policy/v1beta1/PodSecurityPolicy is deprecated, and removed in " +
+				"Kubernetes 1.25, with no replacement."},
+			deprecation: "policy/v1beta1/PodSecurityPolicy is deprecated, and removed in " +
+				"Kubernetes 1.25, with no replacement.",
+		},
+		{
+			description: "Kind missing from newer specs should note its removal",
+			definition: versionTestDefinition("example.com", "v1alpha1", "Widget",
+				map[string]interface{}{introducedInExtension: 12, removedInExtension: 14}),
+			notes: []string{
+				"Available since Kubernetes 1.12.",
+				"example.com/v1alpha1/Widget is removed in Kubernetes 1.14.",
+			},
+			deprecation: "example.com/v1alpha1/Widget is removed in Kubernetes 1.14.",
+		},
+		{
+			description: "Definition that isn't a kind should only note when it was introduced",
+			definition: &definition{name: "DeploymentSpec", data: map[string]interface{}{
+				introducedInExtension: 9, removedInExtension: 16,
+			}},
+			notes: []string{"Available since Kubernetes 1.9."},
+		},
+	}
+
+	for _, test := range tests {
+		notes, deprecation := versionNotes(test.definition, introducedIn)
+		assert.Equal(t, test.notes, notes, test.description)
+		assert.Equal(t, test.deprecation, deprecation, test.description)
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Utility constructs.
+
+// --------------------------------------------------------------------------
+
+func versionTestDefinition(group, version, kind string, data map[string]interface{}) *definition {
+	data["x-kubernetes-group-version-kind"] = []interface{}{
+		map[string]interface{}{"group": group, "version": version, "kind": kind},
+	}
+	return &definition{
+		gvk:  schema.GroupVersionKind{Group: group, Version: version, Kind: kind},
+		name: kind,
+		data: data,
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
+// --------------------------------------------------------------------------
+
+// apiVersion aliases.
+//
+// The API server serves each object under every apiVersion of its kind: a Deployment created as
+// `extensions/v1beta1` can be read and updated as `apps/v1`, and vice versa. Every version of a
+// kind within a group is equivalent in this way, and so are the groups some kinds have moved
+// between as they matured (e.g., Deployments from `extensions` to `apps`).
+//
+// The SDKs declare each resource type as an alias of its equivalents, so that when a program moves
+// a resource to another apiVersion, the engine asks the provider to update the existing object in
+// place, rather than deleting it and creating a "different" one.
+
+// --------------------------------------------------------------------------
+
+// kindMigrations maps each kind that has moved between API groups to the groups that serve it.
+var kindMigrations = map[string][]string{
+	"DaemonSet":         {"extensions", "apps"},
+	"Deployment":        {"extensions", "apps"},
+	"Ingress":           {"extensions", "networking.k8s.io"},
+	"NetworkPolicy":     {"extensions", "networking.k8s.io"},
+	"PodSecurityPolicy": {"extensions", "policy"},
+	"ReplicaSet":        {"extensions", "apps"},
+}
+
+// IsAlias returns true if `a` and `b` are the same kind served under different apiVersions, i.e.,
+// if an object of kind `a` can be read and updated as an object of kind `b`. The core group may be
+// named either "" or "core".
+func IsAlias(a, b schema.GroupVersionKind) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	groupA, groupB := canonicalGroup(a.Group), canonicalGroup(b.Group)
+	if groupA == groupB {
+		return true
+	}
+	groups := kindMigrations[a.Kind]
+	return containsGroup(groups, groupA) && containsGroup(groups, groupB)
+}
+
+func canonicalGroup(group string) string {
+	if group == "core" {
+		return ""
+	}
+	return group
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
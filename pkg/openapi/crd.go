@@ -0,0 +1,347 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kubernetes/pkg/kubectl/scheme"
+)
+
+// --------------------------------------------------------------------------
+
+// Custom resource validation.
+//
+// The OpenAPI document of the API server only describes custom resources as of Kubernetes 1.15,
+// and never those whose CustomResourceDefinition hasn't been created yet (e.g., one that the same
+// program creates). So we validate custom resources against the `openAPIV3Schema` of their
+// CustomResourceDefinition directly, with the subset of JSON schema the API server supports:
+// `type` (including `x-kubernetes-int-or-string`), `nullable`, `enum`, `properties`, `required`,
+// `items`, `additionalProperties`, and the bounds of numbers, strings, and arrays.
+//
+// Unknown fields are reported only if the schema is structural, i.e., if the API server would
+// prune them (unless `x-kubernetes-preserve-unknown-fields` is set). `metadata` is validated with
+// the rest of the object's metadata, and is skipped.
+
+// --------------------------------------------------------------------------
+
+// IsCustomResourceDefinition returns true if `obj` is a CustomResourceDefinition.
+func IsCustomResourceDefinition(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition"
+}
+
+// IsBuiltinKind returns true if `gvk` is a kind built into Kubernetes, rather than, e.g., a kind of
+// custom resource.
+func IsBuiltinKind(gvk schema.GroupVersionKind) bool {
+	return scheme.Scheme.Recognizes(gvk)
+}
+
+// CustomResourceSchema is the validation schema of a kind of custom resource.
+type CustomResourceSchema struct {
+	// OpenAPIV3Schema is the `openAPIV3Schema` of the kind.
+	OpenAPIV3Schema map[string]interface{}
+	// Prune is true if the API server prunes unknown fields of the kind.
+	Prune bool
+}
+
+// CustomResourceSchemas returns the validation schema of each kind of custom resource defined by
+// the CustomResourceDefinition `crd`, by GVK. Versions without a schema are omitted.
+func CustomResourceSchemas(
+	crd *unstructured.Unstructured,
+) map[schema.GroupVersionKind]CustomResourceSchema {
+	group, _, _ := unstructured.NestedString(crd.Object, "spec", "group")
+	kind, _, _ := unstructured.NestedString(crd.Object, "spec", "names", "kind")
+	// `apiextensions.k8s.io/v1beta1` allows a schema for all versions, which v1 doesn't.
+	common, _, _ := unstructured.NestedMap(crd.Object, "spec", "validation", "openAPIV3Schema")
+	// `apiextensions.k8s.io/v1beta1` CRDs may prune unknown fields of all versions, which v1 CRDs
+	// always do.
+	prune := crd.GetAPIVersion() != "apiextensions.k8s.io/v1beta1"
+	if preserve, exists, _ := unstructured.NestedBool(
+		crd.Object, "spec", "preserveUnknownFields"); exists {
+		prune = !preserve
+	}
+
+	var versions []interface{}
+	if version, exists, _ := unstructured.NestedString(crd.Object, "spec", "version"); exists {
+		versions = append(versions, map[string]interface{}{"name": version})
+	}
+	specVersions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	versions = append(versions, specVersions...)
+
+	schemas := map[schema.GroupVersionKind]CustomResourceSchema{}
+	for _, v := range versions {
+		version, isMap := v.(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(version, "name")
+		versionSchema, exists, _ := unstructured.NestedMap(version, "schema", "openAPIV3Schema")
+		if !exists {
+			versionSchema = common
+		}
+		if name == "" || group == "" || kind == "" || versionSchema == nil {
+			continue
+		}
+		schemas[schema.GroupVersionKind{Group: group, Version: name, Kind: kind}] =
+			CustomResourceSchema{OpenAPIV3Schema: versionSchema, Prune: prune}
+	}
+	return schemas
+}
+
+// ValidateAgainstCustomResourceSchema validates the custom resource `obj` against the schema of
+// its kind (see `CustomResourceSchemas`). If it doesn't conform, the error is a `*SchemaError`.
+func ValidateAgainstCustomResourceSchema(
+	obj *unstructured.Unstructured, crSchema CustomResourceSchema,
+) error {
+	validator := &crValidator{prune: crSchema.Prune}
+	validator.validate("", obj.Object, crSchema.OpenAPIV3Schema)
+	if len(validator.errors) == 0 {
+		return nil
+	}
+	return &SchemaError{Errors: validator.errors}
+}
+
+type crValidator struct {
+	prune  bool
+	errors []FieldError
+}
+
+func (v *crValidator) fail(field, format string, args ...interface{}) {
+	v.errors = append(v.errors, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *crValidator) validate(field string, value interface{}, s map[string]interface{}) {
+	if value == nil {
+		if nullable, _ := s["nullable"].(bool); !nullable && s["type"] != nil {
+			v.fail(field, "must not be null")
+		}
+		return
+	}
+
+	if enum, hasEnum := s["enum"].([]interface{}); hasEnum && !containsValue(enum, value) {
+		v.fail(field, "unsupported value %v, expected one of %v", value, enum)
+	}
+
+	if intOrString, _ := s["x-kubernetes-int-or-string"].(bool); intOrString {
+		if !isInteger(value) && !isString(value) {
+			v.fail(field, "expected integer or string, got %s", jsonType(value))
+		}
+		return
+	}
+
+	switch expected, _ := s["type"].(string); expected {
+	case "object":
+		obj, isObj := value.(map[string]interface{})
+		if !isObj {
+			v.fail(field, "expected object, got %s", jsonType(value))
+			return
+		}
+		v.validateObject(field, obj, s)
+	case "array":
+		arr, isArr := value.([]interface{})
+		if !isArr {
+			v.fail(field, "expected array, got %s", jsonType(value))
+			return
+		}
+		v.checkBounds(field, float64(len(arr)), s, "minItems", "maxItems", "items")
+		items, _ := s["items"].(map[string]interface{})
+		for i, item := range arr {
+			if items != nil {
+				v.validate(fmt.Sprintf("%s[%d]", field, i), item, items)
+			}
+		}
+	case "string":
+		str, isStr := value.(string)
+		if !isStr {
+			v.fail(field, "expected string, got %s", jsonType(value))
+			return
+		}
+		v.checkBounds(field, float64(len(str)), s, "minLength", "maxLength", "characters")
+		if pattern, hasPattern := s["pattern"].(string); hasPattern {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(str) {
+				v.fail(field, "%q does not match pattern %q", str, pattern)
+			}
+		}
+	case "integer":
+		if !isInteger(value) {
+			v.fail(field, "expected integer, got %s", jsonType(value))
+			return
+		}
+		v.checkRange(field, value, s)
+	case "number":
+		if !isNumber(value) {
+			v.fail(field, "expected number, got %s", jsonType(value))
+			return
+		}
+		v.checkRange(field, value, s)
+	case "boolean":
+		if _, isBool := value.(bool); !isBool {
+			v.fail(field, "expected boolean, got %s", jsonType(value))
+		}
+	}
+}
+
+func (v *crValidator) validateObject(
+	field string, obj map[string]interface{}, s map[string]interface{},
+) {
+	properties, _ := s["properties"].(map[string]interface{})
+	if required, hasRequired := s["required"].([]interface{}); hasRequired {
+		for _, name := range required {
+			if _, exists := obj[fmt.Sprint(name)]; !exists {
+				v.fail(join(field, fmt.Sprint(name)), "required field is missing")
+			}
+		}
+	}
+
+	additional, _ := s["additionalProperties"].(map[string]interface{})
+	preserve, _ := s["x-kubernetes-preserve-unknown-fields"].(bool)
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if field == "" && (name == "apiVersion" || name == "kind" || name == "metadata") {
+			continue
+		}
+		if propSchema, known := properties[name].(map[string]interface{}); known {
+			v.validate(join(field, name), obj[name], propSchema)
+		} else if additional != nil {
+			v.validate(join(field, name), obj[name], additional)
+		} else if v.prune && !preserve && properties != nil {
+			v.fail(join(field, name), "unknown field")
+		}
+	}
+}
+
+func (v *crValidator) checkBounds(
+	field string, length float64, s map[string]interface{}, minKey, maxKey, unit string,
+) {
+	if min, hasMin := s[minKey]; hasMin && isNumber(min) && length < toFloat(min) {
+		v.fail(field, "must have at least %v %s", min, unit)
+	}
+	if max, hasMax := s[maxKey]; hasMax && isNumber(max) && length > toFloat(max) {
+		v.fail(field, "must have at most %v %s", max, unit)
+	}
+}
+
+func (v *crValidator) checkRange(field string, value interface{}, s map[string]interface{}) {
+	n := toFloat(value)
+	if min, hasMin := s["minimum"]; hasMin && isNumber(min) {
+		exclusive, _ := s["exclusiveMinimum"].(bool)
+		if n < toFloat(min) || (exclusive && n == toFloat(min)) {
+			v.fail(field, "must be greater than %s%v", orEqual(!exclusive), min)
+		}
+	}
+	if max, hasMax := s["maximum"]; hasMax && isNumber(max) {
+		exclusive, _ := s["exclusiveMaximum"].(bool)
+		if n > toFloat(max) || (exclusive && n == toFloat(max)) {
+			v.fail(field, "must be less than %s%v", orEqual(!exclusive), max)
+		}
+	}
+}
+
+func orEqual(inclusive bool) string {
+	if inclusive {
+		return "or equal to "
+	}
+	return ""
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) ||
+			(isNumber(v) && isNumber(value) && toFloat(v) == toFloat(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isString(value interface{}) bool {
+	_, isStr := value.(string)
+	return isStr
+}
+
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int32, int64, float32, float64:
+		return true
+	}
+	return false
+}
+
+func isInteger(value interface{}) bool {
+	switch n := value.(type) {
+	case int, int32, int64:
+		return true
+	case float32:
+		return float32(int64(n)) == n
+	case float64:
+		return float64(int64(n)) == n
+	}
+	return false
+}
+
+func toFloat(value interface{}) float64 {
+	switch n := value.(type) {
+	case int:
+		return float64(n)
+	case int32:
+		return float64(n)
+	case int64:
+		return float64(n)
+	case float32:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
+
+// jsonType returns the JSON type of `value`, for error messages.
+func jsonType(value interface{}) string {
+	switch {
+	case isInteger(value):
+		return "integer"
+	case isNumber(value):
+		return "number"
+	}
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return strings.TrimPrefix(fmt.Sprintf("%T", value), "*")
+}
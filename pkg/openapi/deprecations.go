@@ -0,0 +1,153 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// --------------------------------------------------------------------------
+
+// Deprecated apiVersions.
+//
+// Beta (and alpha) apiVersions are served for a few releases after the kind graduates, and are then
+// removed. A program that still uses a removed apiVersion fails against newer clusters, typically
+// only once the cluster is upgraded underneath it. `removedAPIVersions` records when each of them
+// is removed, and which apiVersion replaces it, so that the provider can warn about them ahead of
+// time.
+
+// --------------------------------------------------------------------------
+
+// Deprecation describes an apiVersion of a kind that Kubernetes stops serving.
+type Deprecation struct {
+	// RemovedIn is the minor version of the first Kubernetes 1.x release that doesn't serve the
+	// apiVersion.
+	RemovedIn int
+	// Replacement is the kind to use instead, or the zero value if there is none.
+	Replacement schema.GroupVersionKind
+}
+
+// String returns a description of the deprecation, e.g., "removed in Kubernetes 1.16; use
+// apps/v1/Deployment instead".
+func (d Deprecation) String() string {
+	if d.Replacement.Empty() {
+		return fmt.Sprintf("removed in Kubernetes 1.%d, with no replacement", d.RemovedIn)
+	}
+	return fmt.Sprintf("removed in Kubernetes 1.%d; use %s/%s instead",
+		d.RemovedIn, d.Replacement.GroupVersion(), d.Replacement.Kind)
+}
+
+type removedAPIVersion struct {
+	removedIn   int
+	replacement string // The replacing group/version; the kind stays the same.
+	kinds       []string
+}
+
+// removedAPIVersions maps each deprecated group/version to the kinds it is removed for.
+var removedAPIVersions = map[string][]removedAPIVersion{
+	"extensions/v1beta1": {
+		{16, "apps/v1", []string{"DaemonSet", "Deployment", "ReplicaSet"}},
+		{16, "networking.k8s.io/v1", []string{"NetworkPolicy"}},
+		{16, "policy/v1beta1", []string{"PodSecurityPolicy"}},
+		{22, "networking.k8s.io/v1", []string{"Ingress"}},
+	},
+	"apps/v1beta1": {
+		{16, "apps/v1", []string{"ControllerRevision", "Deployment", "StatefulSet"}},
+	},
+	"apps/v1beta2": {
+		{16, "apps/v1", []string{
+			"ControllerRevision", "DaemonSet", "Deployment", "ReplicaSet", "StatefulSet"}},
+	},
+	"admissionregistration.k8s.io/v1beta1": {
+		{22, "admissionregistration.k8s.io/v1", []string{
+			"MutatingWebhookConfiguration", "ValidatingWebhookConfiguration"}},
+	},
+	"apiextensions.k8s.io/v1beta1": {
+		{22, "apiextensions.k8s.io/v1", []string{"CustomResourceDefinition"}},
+	},
+	"apiregistration.k8s.io/v1beta1": {
+		{22, "apiregistration.k8s.io/v1", []string{"APIService"}},
+	},
+	"certificates.k8s.io/v1beta1": {
+		{22, "certificates.k8s.io/v1", []string{"CertificateSigningRequest"}},
+	},
+	"coordination.k8s.io/v1beta1": {
+		{22, "coordination.k8s.io/v1", []string{"Lease"}},
+	},
+	"networking.k8s.io/v1beta1": {
+		{22, "networking.k8s.io/v1", []string{"Ingress", "IngressClass"}},
+	},
+	"rbac.authorization.k8s.io/v1alpha1": {
+		{22, "rbac.authorization.k8s.io/v1", []string{
+			"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}},
+	},
+	"rbac.authorization.k8s.io/v1beta1": {
+		{22, "rbac.authorization.k8s.io/v1", []string{
+			"ClusterRole", "ClusterRoleBinding", "Role", "RoleBinding"}},
+	},
+	"scheduling.k8s.io/v1alpha1": {
+		{22, "scheduling.k8s.io/v1", []string{"PriorityClass"}},
+	},
+	"scheduling.k8s.io/v1beta1": {
+		{22, "scheduling.k8s.io/v1", []string{"PriorityClass"}},
+	},
+	"storage.k8s.io/v1beta1": {
+		{22, "storage.k8s.io/v1", []string{
+			"CSIDriver", "CSINode", "StorageClass", "VolumeAttachment"}},
+		{27, "storage.k8s.io/v1", []string{"CSIStorageCapacity"}},
+	},
+	"autoscaling/v2beta1": {
+		{25, "autoscaling/v2", []string{"HorizontalPodAutoscaler"}},
+	},
+	"autoscaling/v2beta2": {
+		{26, "autoscaling/v2", []string{"HorizontalPodAutoscaler"}},
+	},
+	"batch/v1beta1": {
+		{25, "batch/v1", []string{"CronJob"}},
+	},
+	"discovery.k8s.io/v1beta1": {
+		{25, "discovery.k8s.io/v1", []string{"EndpointSlice"}},
+	},
+	"events.k8s.io/v1beta1": {
+		{25, "events.k8s.io/v1", []string{"Event"}},
+	},
+	"node.k8s.io/v1beta1": {
+		{25, "node.k8s.io/v1", []string{"RuntimeClass"}},
+	},
+	"policy/v1beta1": {
+		{25, "policy/v1", []string{"PodDisruptionBudget"}},
+		{25, "", []string{"PodSecurityPolicy"}},
+	},
+}
+
+// DeprecationOf returns the deprecation of the apiVersion of `gvk`, if it is deprecated.
+func DeprecationOf(gvk schema.GroupVersionKind) (Deprecation, bool) {
+	for _, removed := range removedAPIVersions[gvk.GroupVersion().String()] {
+		for _, kind := range removed.kinds {
+			if kind != gvk.Kind {
+				continue
+			}
+			deprecation := Deprecation{RemovedIn: removed.removedIn}
+			if removed.replacement != "" {
+				gv, _ := schema.ParseGroupVersion(removed.replacement)
+				deprecation.Replacement = gv.WithKind(gvk.Kind)
+			}
+			return deprecation, true
+		}
+	}
+	return Deprecation{}, false
+}
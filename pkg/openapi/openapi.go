@@ -16,15 +16,19 @@ package openapi
 
 import (
 	"fmt"
+	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/golang/glog"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/client-go/discovery"
 	"k8s.io/kube-openapi/pkg/util/proto"
+	protovalidation "k8s.io/kube-openapi/pkg/util/proto/validation"
 	"k8s.io/kubernetes/pkg/kubectl/cmd/util/openapi"
 	"k8s.io/kubernetes/pkg/kubectl/cmd/util/openapi/validation"
 	"k8s.io/kubernetes/pkg/kubectl/scheme"
@@ -40,12 +44,44 @@ import (
 //    the OpenAPI spec exposed by the API server. The OpenAPI spec would typically be obtained from
 //    the API server, and it represents not only the spec of the Kubernetes version running the API
 //    server itself, but also the flags it was started with, (e.g., RBAC enabled or not, etc.).
+//    Validation is strict: unknown fields, values of the wrong type, and missing required fields
+//    are all reported, so that programs fail at preview time rather than mid-deployment.
 // 2. Update/patch logic. Code to allow us to introspect on the OpenAPI spec to generate the patch
 //    logic required to update some Kubernetes resource.
 
 // --------------------------------------------------------------------------
 
-// ValidateAgainstSchema validates a document against the schema.
+// FieldError is a way in which an object doesn't conform to the schema of its kind, e.g., an
+// unknown field, a value of the wrong type, or a missing required field.
+type FieldError struct {
+	// Field is the path of the offending field (e.g., `spec.replicas`), or "" for the whole object.
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// SchemaError is returned by `ValidateAgainstSchema` if an object doesn't conform to the schema of
+// its kind. It reports every problem with the object, rather than just the first.
+type SchemaError struct {
+	Errors []FieldError
+}
+
+func (e *SchemaError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ValidateAgainstSchema validates a document against the schema. If the document doesn't conform
+// to it, the error is a `*SchemaError`; other errors mean it couldn't be validated at all.
 func ValidateAgainstSchema(
 	client discovery.OpenAPISchemaInterface, obj *unstructured.Unstructured,
 ) error {
@@ -66,17 +102,22 @@ func ValidateAgainstSchema(
 		return fmt.Errorf("Cluster does not support resource type '%s'", gvk.String())
 	}
 
-	// TODO(hausdorff): Come back and make sure that `ValidateBytes` actually reports a list of
-	// validation errors when there are multiple errors for usability purposes.
-
 	// Validate resource against schema.
 	specValidator := validation.NewSchemaValidation(resources)
-	return specValidator.ValidateBytes(bytes)
+	err = specValidator.ValidateBytes(bytes)
+	if err == nil {
+		return nil
+	}
+	return schemaError(resSchema, err)
 }
 
 // PatchForResourceUpdate introspects on the OpenAPI spec exposed by some client, and attempts to
 // generate a strategic merge patch for use in a resource update. If there is no specification of
 // how to generate a strategic merge patch, we fall back to JSON merge patch.
+//
+// NOTE: Custom resources publish OpenAPI schemas too (as of Kubernetes 1.15), but the API server
+// rejects strategic merge patches for them with `415 Unsupported Media Type`. Callers should retry
+// with `JSONMergePatchForResourceUpdate` in that case.
 func PatchForResourceUpdate(
 	client discovery.OpenAPISchemaInterface,
 	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
@@ -116,6 +157,74 @@ func PatchForResourceUpdate(
 	return jsonMergePatch(lastSubmittedJSON, currentSubmittedJSON, liveOldJSON)
 }
 
+// JSONMergePatchForResourceUpdate generates a three-way JSON merge patch for use in a resource
+// update, for resources that don't support strategic merge patches (i.e., custom resources).
+func JSONMergePatchForResourceUpdate(
+	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
+) ([]byte, types.PatchType, error) {
+	lastSubmittedJSON, err := lastSubmitted.MarshalJSON()
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentSubmittedJSON, err := currentSubmitted.MarshalJSON()
+	if err != nil {
+		return nil, "", err
+	}
+
+	liveOldJSON, err := liveOldObj.MarshalJSON()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return jsonMergePatch(lastSubmittedJSON, currentSubmittedJSON, liveOldJSON)
+}
+
+// PreviewResourceUpdate returns the object that would result from applying the patch generated by
+// `PatchForResourceUpdate` to `liveOldObj`. Since fields the user did not change keep their live
+// (i.e., server-defaulted) values, this approximates what the API server would store.
+func PreviewResourceUpdate(
+	client discovery.OpenAPISchemaInterface,
+	lastSubmitted, currentSubmitted, liveOldObj *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	patch, patchType, err := PatchForResourceUpdate(client, lastSubmitted, currentSubmitted, liveOldObj)
+	if err != nil {
+		return nil, err
+	}
+
+	liveOldJSON, err := liveOldObj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var previewJSON []byte
+	if patchType == types.StrategicMergePatchType {
+		resources, err := getResourceSchemasForClient(client)
+		if err != nil {
+			return nil, err
+		}
+		lookupPatchMeta := strategicpatch.PatchMetaFromOpenAPI{
+			Schema: resources.LookupResource(lastSubmitted.GroupVersionKind()),
+		}
+		previewJSON, err = strategicpatch.StrategicMergePatchUsingLookupPatchMeta(
+			liveOldJSON, patch, lookupPatchMeta)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		previewJSON, err = jsonpatch.MergePatch(liveOldJSON, patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	preview := &unstructured.Unstructured{}
+	if err := preview.UnmarshalJSON(previewJSON); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
 // Pluck obtains the property identified by the string components in `path`. For example,
 // `Pluck(foo, "bar", "baz")` returns `foo.bar.baz`.
 func Pluck(obj map[string]interface{}, path ...string) (interface{}, bool) {
@@ -152,30 +261,32 @@ func strategicMergePatch(
 	gvk schema.GroupVersionKind, resourceSchema proto.Schema,
 	lastSubmittedJSON, currentSubmittedJSON, liveOldJSON []byte,
 ) ([]byte, types.PatchType, error) {
-	// Attempt to construct patch from OpenAPI spec data.
+	// Attempt to construct patch from OpenAPI spec data. The patch metadata (i.e., the
+	// `x-kubernetes-patch-strategy` and `x-kubernetes-patch-merge-key` extensions) tells us, e.g.,
+	// to merge the `containers` of a Pod by `name`, so that containers injected by a mutating
+	// webhook are kept, rather than replaced by the list the user submitted.
 	lookupPatchMeta := strategicpatch.PatchMetaFromOpenAPI{Schema: resourceSchema}
 	patch, err := strategicpatch.CreateThreeWayMergePatch(
 		lastSubmittedJSON, currentSubmittedJSON, liveOldJSON, lookupPatchMeta, true)
-	if err != nil {
-		return nil, "", err
+	if err == nil {
+		return patch, types.StrategicMergePatchType, nil
 	}
 
-	// Fall back to constructing patch from nominal type data.
-	if patch == nil {
-		versionedObject, err := scheme.Scheme.New(gvk)
-		if err != nil {
-			return nil, "", err
-		}
+	// Fall back to constructing patch from nominal type data, if we know the type.
+	versionedObject, typeErr := scheme.Scheme.New(gvk)
+	if typeErr != nil {
+		return nil, "", err
+	}
+	glog.V(3).Infof("Falling back to nominal patch metadata for '%s': %v", gvk.String(), err)
 
-		lookupPatchMeta, err := strategicpatch.NewPatchMetaFromStruct(versionedObject)
-		if err != nil {
-			return nil, "", err
-		}
-		patch, err = strategicpatch.CreateThreeWayMergePatch(
-			lastSubmittedJSON, currentSubmittedJSON, liveOldJSON, lookupPatchMeta, true)
-		if err != nil {
-			return nil, "", err
-		}
+	structPatchMeta, err := strategicpatch.NewPatchMetaFromStruct(versionedObject)
+	if err != nil {
+		return nil, "", err
+	}
+	patch, err = strategicpatch.CreateThreeWayMergePatch(
+		lastSubmittedJSON, currentSubmittedJSON, liveOldJSON, structPatchMeta, true)
+	if err != nil {
+		return nil, "", err
 	}
 
 	return patch, types.StrategicMergePatchType, nil
@@ -203,6 +314,27 @@ func jsonMergePatch(
 	return patch, patchType, err
 }
 
+// schemaError collects the problems `ValidateBytes` reported (as an aggregate of errors) with an
+// object of the kind `resSchema` into a `SchemaError`, with field paths relative to the object.
+func schemaError(resSchema proto.Schema, err error) *SchemaError {
+	errs := []error{err}
+	if aggregate, isAggregate := err.(utilerrors.Aggregate); isAggregate {
+		errs = utilerrors.Flatten(aggregate).Errors()
+	}
+
+	prefix := resSchema.GetPath().String()
+	schemaErr := &SchemaError{}
+	for _, err := range errs {
+		fieldErr := FieldError{Message: err.Error()}
+		if validationErr, isValidationErr := err.(protovalidation.ValidationError); isValidationErr {
+			fieldErr.Field = strings.TrimPrefix(strings.TrimPrefix(validationErr.Path, prefix), ".")
+			fieldErr.Message = validationErr.Err.Error()
+		}
+		schemaErr.Errors = append(schemaErr.Errors, fieldErr)
+	}
+	return schemaErr
+}
+
 // getResourceSchemasForClient obtains the OpenAPI schemas for all Kubernetes resources supported by
 // client.
 func getResourceSchemasForClient(
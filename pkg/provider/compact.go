@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// contentHashKey is the property that replaces the payload of a compacted live object in the
+// checkpoint.
+const contentHashKey = "__contentHash"
+
+// compactedOutputFields are the paths of fields that are retained in compacted live objects, because
+// they are small, and programs commonly export or consume them as outputs (e.g., the IP address or
+// hostname allocated to a `LoadBalancer` Service, which is often only known once it is awaited).
+var compactedOutputFields = [][]string{
+	{"status", "loadBalancer"},
+}
+
+// compactLiveObject returns `live` unchanged if its serialized size is at most `threshold` bytes (or
+// `threshold` is not positive). Otherwise it returns a compacted version, which retains only the
+// identifying fields (`apiVersion`, `kind`, `metadata`) and `compactedOutputFields`, and replaces
+// the rest of the payload with a hash of its contents, so that drift can still be detected on
+// refresh.
+//
+// This is safe because `Diff` and `Update` only ever consult the old _inputs_ in the checkpoint,
+// and always retrieve the live object from the API server.
+func compactLiveObject(live *unstructured.Unstructured, threshold int) *unstructured.Unstructured {
+	if threshold <= 0 || live == nil {
+		return live
+	}
+	serialized, err := json.Marshal(live.Object)
+	if err != nil || len(serialized) <= threshold {
+		return live
+	}
+
+	compacted := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	for _, key := range []string{"apiVersion", "kind", "metadata"} {
+		if value, exists := live.Object[key]; exists {
+			compacted.Object[key] = value
+		}
+	}
+	for _, path := range compactedOutputFields {
+		if value, exists, _ := unstructured.NestedFieldCopy(live.Object, path...); exists {
+			_ = unstructured.SetNestedField(compacted.Object, value, path...)
+		}
+	}
+	compacted.Object[contentHashKey] = contentHash(live)
+	return compacted
+}
+
+// contentHash computes a hash of the contents of `obj`, ignoring `metadata` and `status`, which the
+// API server changes independently of the user-specified contents.
+func contentHash(obj *unstructured.Unstructured) string {
+	content := map[string]interface{}{}
+	for key, value := range obj.Object {
+		if key != "metadata" && key != "status" && key != contentHashKey {
+			content[key] = value
+		}
+	}
+	// NOTE: `encoding/json` serializes map keys in sorted order, so this is deterministic.
+	serialized, err := json.Marshal(content)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(serialized)
+	return hex.EncodeToString(sum[:])
+}
+
+// contentHashOf returns the content hash recorded in a compacted live object, if any.
+func contentHashOf(live *unstructured.Unstructured) (string, bool) {
+	if live == nil {
+		return "", false
+	}
+	hash, isString := live.Object[contentHashKey].(string)
+	return hash, isString && hash != ""
+}
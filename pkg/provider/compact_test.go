@@ -0,0 +1,68 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func configMapWithData(data string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "foo", "resourceVersion": "1"},
+		"data":       map[string]interface{}{"payload": data},
+	}}
+}
+
+func TestCompactLiveObject(t *testing.T) {
+	small := configMapWithData("small")
+	assert.Equal(t, small, compactLiveObject(small, 1024), "Small objects should not be compacted")
+	assert.Equal(t, small, compactLiveObject(small, 0), "Compaction should be disabled by default")
+
+	large := configMapWithData(strings.Repeat("x", 2048))
+	compacted := compactLiveObject(large, 1024)
+	assert.NotContains(t, compacted.Object, "data")
+	assert.Equal(t, "foo", compacted.GetName())
+	assert.Equal(t, "ConfigMap", compacted.GetKind())
+
+	hash, hasHash := contentHashOf(compacted)
+	assert.True(t, hasHash)
+
+	// The hash ignores metadata, but tracks content.
+	bumped := configMapWithData(strings.Repeat("x", 2048))
+	bumped.SetResourceVersion("2")
+	bumpedHash, _ := contentHashOf(compactLiveObject(bumped, 1024))
+	assert.Equal(t, hash, bumpedHash)
+
+	changedHash, _ := contentHashOf(compactLiveObject(configMapWithData(strings.Repeat("y", 2048)), 1024))
+	assert.NotEqual(t, hash, changedHash)
+}
+
+func TestCompactLiveObjectRetainsLoadBalancer(t *testing.T) {
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   map[string]interface{}{"name": "foo"},
+		"spec": map[string]interface{}{
+			"type":     "LoadBalancer",
+			"selector": map[string]interface{}{"app": strings.Repeat("x", 2048)},
+		},
+		"status": map[string]interface{}{
+			"loadBalancer": map[string]interface{}{
+				"ingress": []interface{}{
+					map[string]interface{}{"hostname": "a1b2c3.us-west-2.elb.amazonaws.com"},
+				},
+			},
+		},
+	}}
+
+	compacted := compactLiveObject(service, 1024)
+	assert.NotContains(t, compacted.Object, "spec")
+	assert.Equal(t, service.Object["status"], compacted.Object["status"])
+}
@@ -0,0 +1,90 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// rememberCustomResourceSchemas records the schemas of the kinds the CustomResourceDefinition `crd`
+// defines, so that custom resources of those kinds can be validated before it is created. Unless
+// `replace` is true, schemas that are already known are kept.
+func (k *kubeProvider) rememberCustomResourceSchemas(crd *unstructured.Unstructured, replace bool) {
+	k.crdSchemasLock.Lock()
+	defer k.crdSchemasLock.Unlock()
+	if k.crdSchemas == nil {
+		k.crdSchemas = map[schema.GroupVersionKind]openapi.CustomResourceSchema{}
+	}
+	for gvk, crSchema := range openapi.CustomResourceSchemas(crd) {
+		if _, known := k.crdSchemas[gvk]; replace || !known {
+			k.crdSchemas[gvk] = crSchema
+		}
+	}
+}
+
+// customResourceSchema returns the schema of the kind of custom resource `gvk`, from the
+// CustomResourceDefinitions the program declares, or those on the cluster. It returns false if
+// `gvk` isn't a kind of custom resource, or has no schema.
+func (k *kubeProvider) customResourceSchema(
+	gvk schema.GroupVersionKind,
+) (openapi.CustomResourceSchema, bool) {
+	if openapi.IsBuiltinKind(gvk) {
+		return openapi.CustomResourceSchema{}, false
+	}
+
+	// The schemas of the CustomResourceDefinitions on the cluster are looked up once; those the
+	// program declares are remembered as they are checked.
+	if !k.offline {
+		k.clusterCRDs.Do(k.rememberClusterCustomResourceSchemas)
+	}
+
+	k.crdSchemasLock.Lock()
+	defer k.crdSchemasLock.Unlock()
+	crSchema, known := k.crdSchemas[gvk]
+	return crSchema, known
+}
+
+// rememberClusterCustomResourceSchemas records the schemas of the CustomResourceDefinitions on the
+// cluster.
+func (k *kubeProvider) rememberClusterCustomResourceSchemas() {
+	// NOTE: Each version of the CRD API serves every CRD, so we list them with the newest one the
+	// cluster serves.
+	for _, version := range []string{"v1", "v1beta1"} {
+		gv := schema.GroupVersion{Group: "apiextensions.k8s.io", Version: version}
+		if _, err := k.client.ServerResourcesForGroupVersion(gv.String()); err != nil {
+			continue
+		}
+		crdClient, err := client.FromGVK(k.pool, k.client, gv.WithKind("CustomResourceDefinition"), "")
+		if err != nil {
+			glog.V(3).Infof("Unable to make client for CustomResourceDefinitions: %v", err)
+			return
+		}
+		crds, err := crdClient.List(metav1.ListOptions{})
+		if err != nil {
+			glog.V(3).Infof("Unable to list CustomResourceDefinitions: %v", err)
+			return
+		}
+		for i := range crds.(*unstructured.UnstructuredList).Items {
+			// The program's declarations of CRDs supersede what's on the cluster.
+			k.rememberCustomResourceSchemas(&crds.(*unstructured.UnstructuredList).Items[i], false)
+		}
+		return
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestCustomResourceSchema(t *testing.T) {
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "crontabs.stable.example.com"},
+		"spec": map[string]interface{}{
+			"group": "stable.example.com",
+			"names": map[string]interface{}{"kind": "CronTab", "plural": "crontabs"},
+			"versions": []interface{}{map[string]interface{}{
+				"name": "v1",
+				"schema": map[string]interface{}{"openAPIV3Schema": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"type":     "object",
+							"required": []interface{}{"cronSpec"},
+							"properties": map[string]interface{}{
+								"cronSpec": map[string]interface{}{"type": "string"},
+								"replicas": map[string]interface{}{
+									"type": "integer", "minimum": float64(1),
+								},
+							},
+						},
+					},
+				}},
+			}},
+		},
+	}}
+	k := &kubeProvider{offline: true}
+	k.rememberCustomResourceSchemas(crd, true)
+
+	_, isCR := k.customResourceSchema(schema.GroupVersionKind{
+		Group: "apps", Version: "v1", Kind: "Deployment"})
+	assert.False(t, isCR, "Built-in kinds should be validated against the cluster's schema")
+
+	crSchema, isCR := k.customResourceSchema(schema.GroupVersionKind{
+		Group: "stable.example.com", Version: "v1", Kind: "CronTab"})
+	assert.True(t, isCR)
+
+	cr := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "stable.example.com/v1",
+		"kind":       "CronTab",
+		"metadata":   map[string]interface{}{"name": "nightly"},
+		"spec":       map[string]interface{}{"replicas": float64(0), "image": "cron"},
+	}}
+	err := openapi.ValidateAgainstCustomResourceSchema(cr, crSchema)
+	assert.Equal(t, []openapi.FieldError{
+		{Field: "spec.cronSpec", Message: "required field is missing"},
+		{Field: "spec.image", Message: "unknown field"},
+		{Field: "spec.replicas", Message: "must be greater than or equal to 1"},
+	}, err.(*openapi.SchemaError).Errors)
+
+	cr.Object["spec"] = map[string]interface{}{"cronSpec": "0 0 * * *", "replicas": float64(2)}
+	assert.NoError(t, openapi.ValidateAgainstCustomResourceSchema(cr, crSchema))
+}
@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// deprecationWarning returns a warning about the apiVersion of `gvk` if it is deprecated (see
+// `openapi.DeprecationOf`), taking into account the version of the cluster, if known.
+func deprecationWarning(gvk schema.GroupVersionKind, cluster *client.ServerVersion) (string, bool) {
+	deprecation, deprecated := openapi.DeprecationOf(gvk)
+	if !deprecated {
+		return "", false
+	}
+
+	name := fmt.Sprintf("%s/%s", gvk.GroupVersion(), gvk.Kind)
+	if cluster != nil && cluster.Compare(1, deprecation.RemovedIn) >= 0 {
+		return fmt.Sprintf("%s is not served by this cluster (Kubernetes %s): it was %s",
+			name, cluster, deprecation), true
+	}
+	return fmt.Sprintf("%s is deprecated, and %s", name, deprecation), true
+}
+
+// clusterVersion returns the version of the cluster, or nil if it can't be determined.
+func (k *kubeProvider) clusterVersion() *client.ServerVersion {
+	k.clusterVersionOnce.Do(func() {
+		version, err := client.FetchVersion(k.client)
+		if err != nil {
+			glog.V(3).Infof("Unable to determine the version of the cluster: %v", err)
+			return
+		}
+		k.serverVersion = &version
+	})
+	return k.serverVersion
+}
@@ -0,0 +1,34 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDeprecationWarning(t *testing.T) {
+	ingress := schema.GroupVersionKind{Group: "extensions", Version: "v1beta1", Kind: "Ingress"}
+
+	warning, deprecated := deprecationWarning(ingress, &client.ServerVersion{Major: 1, Minor: 19})
+	assert.True(t, deprecated)
+	assert.Equal(t, "extensions/v1beta1/Ingress is deprecated, and removed in Kubernetes 1.22; "+
+		"use networking.k8s.io/v1/Ingress instead", warning)
+
+	warning, _ = deprecationWarning(ingress, &client.ServerVersion{Major: 1, Minor: 22})
+	assert.Equal(t, "extensions/v1beta1/Ingress is not served by this cluster (Kubernetes 1.22): "+
+		"it was removed in Kubernetes 1.22; use networking.k8s.io/v1/Ingress instead", warning)
+
+	psp := schema.GroupVersionKind{Group: "policy", Version: "v1beta1", Kind: "PodSecurityPolicy"}
+	warning, _ = deprecationWarning(psp, nil)
+	assert.Equal(t, "policy/v1beta1/PodSecurityPolicy is deprecated, and removed in Kubernetes "+
+		"1.25, with no replacement", warning)
+
+	_, deprecated = deprecationWarning(
+		schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, nil)
+	assert.False(t, deprecated)
+}
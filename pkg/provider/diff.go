@@ -15,7 +15,13 @@
 package provider
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
@@ -30,10 +36,111 @@ func forceNewProperties(
 			}
 		}
 	}
+	props = append(props, immutableObjectForceNewProperties(oldObj, newObj, gvk)...)
 
 	return openapi.PropertiesChanged(oldObj, newObj, props)
 }
 
+// apiVersionForceNewProperties returns `.apiVersion` if the object `oldObj` can't be updated in
+// place to `newObj`, because the new apiVersion doesn't serve the same kind of object (see
+// `openapi.IsAlias`).
+func apiVersionForceNewProperties(oldObj, newObj *unstructured.Unstructured) []string {
+	oldGVK, newGVK := oldObj.GroupVersionKind(), newObj.GroupVersionKind()
+	if oldGVK.Kind == "" || oldGVK == newGVK || openapi.IsAlias(oldGVK, newGVK) {
+		return nil
+	}
+	return []string{".apiVersion"}
+}
+
+// withAPIVersionOf returns `oldObj` as an object of the apiVersion of `newObj`, if it is an alias
+// of the same kind, so that an update that moves the object to another apiVersion reads and
+// patches it through the new one.
+func withAPIVersionOf(oldObj, newObj *unstructured.Unstructured) *unstructured.Unstructured {
+	if oldObj.GetAPIVersion() == newObj.GetAPIVersion() ||
+		!openapi.IsAlias(oldObj.GroupVersionKind(), newObj.GroupVersionKind()) {
+		return oldObj
+	}
+	moved := oldObj.DeepCopy()
+	moved.SetAPIVersion(newObj.GetAPIVersion())
+	return moved
+}
+
+// immutableObjectForceNewProperties returns the fields that can't be changed because the object
+// is marked `immutable` (as Secrets and ConfigMaps can be, as of Kubernetes 1.19). The contents
+// of an immutable object can't be changed even if it's marked immutable in the same update.
+func immutableObjectForceNewProperties(
+	oldObj, newObj map[string]interface{}, gvk schema.GroupVersionKind,
+) properties {
+	if gvk.Group != "core" && gvk.Group != "" {
+		return nil
+	}
+	if oldObj["immutable"] != true && newObj["immutable"] != true {
+		return nil
+	}
+
+	switch gvk.Kind {
+	case "ConfigMap":
+		return properties{".binaryData", ".data", ".immutable"}
+	case "Secret":
+		return properties{".data", ".immutable", ".stringData"}
+	default:
+		return nil
+	}
+}
+
+// immutableFieldsError explains an update of an object of kind `kind` that the API server rejected
+// because it changes immutable fields for which we didn't know to replace the object. Other errors
+// are returned unchanged.
+func immutableFieldsError(kind string, err error) error {
+	statusErr, isStatusErr := err.(*errors.StatusError)
+	if !isStatusErr || !errors.IsInvalid(err) || statusErr.ErrStatus.Details == nil {
+		return err
+	}
+
+	var fields []string
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if strings.Contains(cause.Message, "field is immutable") {
+			fields = append(fields, cause.Field)
+		}
+	}
+	if len(fields) == 0 {
+		return err
+	}
+	return fmt.Errorf("%v\n%s can't be updated in place, because the update changes immutable "+
+		"fields (%s); replace it instead, e.g., by changing its name",
+		err, kind, strings.Join(fields, ", "))
+}
+
+// replacementPaths renders the paths returned by `forceNewProperties` for display, e.g.,
+// `spec.clusterIP` rather than `.spec.clusterIP`. A path is omitted if a more specific path under it
+// also changed (e.g., `spec` is omitted if `spec.selector` changed), since it only says that
+// something under it did.
+func replacementPaths(replaces []string) []string {
+	var paths []string
+	for _, path := range replaces {
+		path = strings.TrimPrefix(path, ".")
+		specific := false
+		for _, other := range replaces {
+			if strings.HasPrefix(strings.TrimPrefix(other, "."), path+".") {
+				specific = true
+				break
+			}
+		}
+		if !specific {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// replacementMessage explains that an object of kind `kind` must be replaced, because the
+// immutable fields at `replaces` changed.
+func replacementMessage(kind string, replaces []string) string {
+	return fmt.Sprintf("%s must be replaced because these fields can't be changed in place: %s",
+		kind, strings.Join(replacementPaths(replaces), ", "))
+}
+
 type groups map[string]versions
 type versions map[string]kinds
 type kinds map[string]properties
@@ -42,8 +149,21 @@ type properties []string
 var forceNew = groups{
 	"apps": versions{
 		// NOTE: These fields do NOT trigger a replace in extensions/v1beta1 or apps/v1beta1.
-		"v1beta2": kinds{"Deployment": deployment},
-		"v1":      kinds{"Deployment": deployment},
+		"v1beta2": apps,
+		"v1":      apps,
+	},
+	"batch": versions{
+		"v1": kinds{
+			"Job": properties{
+				".spec.completions",
+				".spec.selector",
+				".spec.template",
+			},
+		},
+	},
+	"rbac.authorization.k8s.io": versions{
+		"v1beta1": rbac,
+		"v1":      rbac,
 	},
 	// List `core` under its canonical name and under it's legacy name (i.e., "", the empty string)
 	// for compatibility purposes.
@@ -54,6 +174,8 @@ var forceNew = groups{
 			"StorageClass": properties{
 				".parameters",
 				".provisioner",
+				".reclaimPolicy",
+				".volumeBindingMode",
 			},
 		},
 	},
@@ -83,13 +205,26 @@ var core = versions{
 		},
 		"Service": properties{
 			".spec.clusterIP",
+			".spec.clusterIPs",
 			".spec.type",
 		},
 	},
 }
 
-var deployment = properties{
-	".spec.selector",
+var apps = kinds{
+	"DaemonSet":  properties{".spec.selector"},
+	"Deployment": properties{".spec.selector"},
+	"StatefulSet": properties{
+		".spec.podManagementPolicy",
+		".spec.selector",
+		".spec.serviceName",
+		".spec.volumeClaimTemplates",
+	},
+}
+
+var rbac = kinds{
+	"ClusterRoleBinding": properties{".roleRef"},
+	"RoleBinding":        properties{".roleRef"},
 }
 
 func metadataForceNewProperties(prefix string) properties {
@@ -3,10 +3,16 @@
 package provider
 
 import (
+	"net/http"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 type object map[string]interface{}
@@ -39,6 +45,12 @@ func TestFieldsChanged(t *testing.T) {
 			new:      object{"spec": object{"containers": list{object{"name": "nginx", "image": "nginx:1.15-alpine"}}}},
 			expected: []string{".spec.containers[*].image"},
 		},
+		{
+			group: "apps", version: "v1", kind: "StatefulSet",
+			old:      object{"spec": object{"serviceName": "db", "replicas": 1}},
+			new:      object{"spec": object{"serviceName": "db-headless", "replicas": 3}},
+			expected: []string{".spec.serviceName"},
+		},
 	}
 
 	for _, test := range tests {
@@ -53,3 +65,103 @@ func TestFieldsChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestReplacementPaths(t *testing.T) {
+	tests := []struct {
+		replaces []string
+		expected []string
+	}{
+		{
+			replaces: []string{".spec.clusterIP", ".metadata.name"},
+			expected: []string{"metadata.name", "spec.clusterIP"},
+		},
+		{
+			replaces: []string{".spec", ".spec.accessModes", ".spec.storageClassName"},
+			expected: []string{"spec.accessModes", "spec.storageClassName"},
+		},
+		{
+			replaces: []string{".spec", ".spec.selector.matchLabels", ".spec.selectorTerms"},
+			expected: []string{"spec.selector.matchLabels", "spec.selectorTerms"},
+		},
+	}
+
+	for _, test := range tests {
+		paths := replacementPaths(test.replaces)
+		if !reflect.DeepEqual(paths, test.expected) {
+			t.Errorf("Got '%v' expected '%v'", paths, test.expected)
+		}
+	}
+}
+
+func TestImmutableObjectFieldsChanged(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "core", Version: "v1", Kind: "Secret"}
+	oldObj := object{"data": object{"password": "aHVudGVyMg=="}}
+	newObj := object{"data": object{"password": "c3dvcmRmaXNo"}}
+
+	diff, err := forceNewProperties(oldObj, newObj, gvk)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(diff) != 0 {
+		t.Errorf("Got '%v' expected no replacement of a mutable Secret", diff)
+	}
+
+	oldObj["immutable"], newObj["immutable"] = true, true
+	diff, err = forceNewProperties(oldObj, newObj, gvk)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(diff, []string{".data"}) {
+		t.Errorf("Got '%v' expected '%v'", diff, []string{".data"})
+	}
+}
+
+func TestImmutableFieldsError(t *testing.T) {
+	invalid := &errors.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   http.StatusUnprocessableEntity,
+		Reason: metav1.StatusReasonInvalid,
+		Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "Invalid value: \"sha256:1234\": field is immutable",
+			Field:   "spec.template",
+		}}},
+	}}
+	err := immutableFieldsError("Job", invalid)
+	if err == invalid || !strings.Contains(err.Error(), "immutable fields (spec.template)") {
+		t.Errorf("Expected an explanation of the immutable fields, got '%v'", err)
+	}
+
+	other := errors.NewNotFound(schema.GroupResource{Resource: "jobs"}, "migrate")
+	if immutableFieldsError("Job", other) != other {
+		t.Errorf("Expected other errors to be returned unchanged")
+	}
+}
+
+func TestAPIVersionForceNewProperties(t *testing.T) {
+	deployment := func(apiVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web"},
+		}}
+	}
+	old, moved := deployment("extensions/v1beta1"), deployment("apps/v1")
+
+	if replaces := apiVersionForceNewProperties(old, moved); len(replaces) != 0 {
+		t.Errorf("Expected no replacement for an alias, got '%v'", replaces)
+	}
+	if updated := withAPIVersionOf(old, moved); updated.GetAPIVersion() != "apps/v1" ||
+		old.GetAPIVersion() != "extensions/v1beta1" {
+		t.Errorf("Expected a copy of the old object as apps/v1, got '%s'", updated.GetAPIVersion())
+	}
+
+	other := deployment("example.com/v1")
+	if replaces := apiVersionForceNewProperties(old, other); !reflect.DeepEqual(
+		replaces, []string{".apiVersion"}) {
+		t.Errorf("Expected replacement for an unrelated group, got '%v'", replaces)
+	}
+	if withAPIVersionOf(old, other) != old {
+		t.Errorf("Expected the old object to be returned unchanged")
+	}
+}
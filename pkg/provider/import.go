@@ -0,0 +1,87 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// --------------------------------------------------------------------------
+
+// Import.
+//
+// When an existing object is imported (with `pulumi import`, or read with `Resource.get`), `Read` is
+// called with nothing but the ID of the object (i.e., `namespace/name`, or `name` for cluster-scoped
+// objects), and must synthesize the inputs that are checkpointed for it. We prefer the configuration
+// `kubectl apply` last applied to the object, since it is exactly what its previous owner asked for
+// (see `lastapplied.go`); failing that, we take the live object, minus the fields the API server
+// populates itself.
+
+// --------------------------------------------------------------------------
+
+// serverPopulatedFields are the paths of fields of live objects that are set by the API server or
+// by controllers, rather than by whoever created the object.
+var serverPopulatedFields = [][]string{
+	{"status"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionGracePeriodSeconds"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "selfLink"},
+	{"metadata", "uid"},
+}
+
+// serverPopulatedAnnotations are annotations set by controllers and tools rather than by whoever
+// created the object.
+var serverPopulatedAnnotations = []string{
+	"deployment.kubernetes.io/revision",
+	lastAppliedConfigAnnotation,
+}
+
+// importedInputs synthesizes the inputs of the imported object `live`, whose apiVersion and kind
+// are declared by the program as `gvk`, and whose ID is `id`.
+func importedInputs(
+	live *unstructured.Unstructured, gvk schema.GroupVersionKind, id string,
+) *unstructured.Unstructured {
+	if applied, exists := lastAppliedConfig(live); exists {
+		glog.V(3).Infof("Importing last-applied configuration of '%s' as its inputs",
+			client.FqObjName(live))
+		return withDeclaredIdentity(applied, gvk, id)
+	}
+
+	inputs := withoutNulls(live)
+	for _, path := range serverPopulatedFields {
+		unstructured.RemoveNestedField(inputs.Object, path...)
+	}
+
+	withoutStackLabels(inputs)
+
+	annotations := inputs.GetAnnotations()
+	for _, key := range serverPopulatedAnnotations {
+		delete(annotations, key)
+	}
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(inputs.Object, "metadata", "annotations")
+	} else {
+		inputs.SetAnnotations(annotations)
+	}
+
+	return withDeclaredIdentity(inputs, gvk, id)
+}
@@ -0,0 +1,63 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+func TestImportedInputs(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":              "settings",
+			"namespace":         "prod",
+			"labels":            map[string]interface{}{"app": "web"},
+			"creationTimestamp": "2018-08-01T00:00:00Z",
+			"resourceVersion":   "42",
+			"selfLink":          "/api/v1/namespaces/prod/configmaps/settings",
+			"uid":               "8c6e5d0a-9f2b-11e8-9a3e-42010a800002",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"data":   map[string]interface{}{"mode": "fast"},
+		"status": map[string]interface{}{},
+	}}
+
+	inputs := importedInputs(live, configMapGVK, "prod/settings")
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "settings",
+			"namespace": "prod",
+			"labels":    map[string]interface{}{"app": "web"},
+		},
+		"data": map[string]interface{}{"mode": "fast"},
+	}, inputs.Object)
+	assert.Equal(t, "42", live.GetResourceVersion(), "Live object should not be modified")
+}
+
+func TestImportedInputsPrefersLastAppliedConfig(t *testing.T) {
+	applied := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings"},
+		"data":       map[string]interface{}{"mode": "fast"},
+	}}
+	live, err := withLastAppliedConfig(applied)
+	assert.NoError(t, err)
+	live.SetNamespace("default")
+	live.SetResourceVersion("42")
+	live.SetLabels(map[string]string{"added-by": "controller"})
+
+	inputs := importedInputs(live, configMapGVK, "settings")
+	assert.Equal(t, applied, inputs)
+}
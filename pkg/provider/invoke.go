@@ -0,0 +1,112 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// Provider functions, exposed through `Invoke`.
+
+// --------------------------------------------------------------------------
+
+const (
+	// renderManifestTok renders normalized YAML manifests for a list of objects. Args:
+	//
+	//   * `objects`: the objects to render, e.g., the inputs of some resources, or the objects of a
+	//     chart or ConfigGroup. Each must have `apiVersion`, `kind`, and `metadata.name`.
+	//   * `live` (optional): if true, render the objects as they currently exist in the cluster,
+	//     rather than as given.
+	//
+	// Returns `manifest`, a multi-document YAML string, and `objects`, the normalized objects.
+	renderManifestTok = "kubernetes:kubernetes:renderManifest"
+)
+
+// renderManifest implements `renderManifestTok`.
+func (k *kubeProvider) renderManifest(args resource.PropertyMap) (resource.PropertyMap, error) {
+	rawObjects, hasObjects := args["objects"]
+	if !hasObjects || !rawObjects.IsArray() {
+		return nil, fmt.Errorf("%s requires an array of `objects`", renderManifestTok)
+	}
+	live := args["live"].IsBool() && args["live"].BoolValue()
+
+	var objects []interface{}
+	var docs []string
+	for i, raw := range rawObjects.ArrayValue() {
+		if !raw.IsObject() {
+			return nil, fmt.Errorf("objects[%d] is not an object", i)
+		}
+		obj := &unstructured.Unstructured{Object: raw.ObjectValue().Mappable()}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf(
+				"objects[%d] must specify `apiVersion`, `kind`, and `metadata.name`", i)
+		}
+
+		if live {
+			clientForResource, err := client.FromResource(k.pool, k.client, obj)
+			if err != nil {
+				return nil, err
+			}
+			obj, err = clientForResource.Get(obj.GetName(), metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		normalized := normalizeManifest(obj)
+		doc, err := yaml.Marshal(normalized.Object)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, normalized.Object)
+		docs = append(docs, string(doc))
+	}
+
+	return resource.NewPropertyMapFromMap(map[string]interface{}{
+		"manifest": strings.Join(docs, "---\n"),
+		"objects":  objects,
+	}), nil
+}
+
+// normalizeManifest returns a copy of `obj` in the form it would be written to a manifest: without
+// status or server-maintained metadata, and with no empty metadata maps.
+func normalizeManifest(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	normalized := obj.DeepCopy()
+	for _, path := range renderedDiffIgnoredFields {
+		unstructured.RemoveNestedField(normalized.Object, path...)
+	}
+	for _, field := range []string{"annotations", "labels"} {
+		if value, exists := openapi.Pluck(normalized.Object, "metadata", field); exists &&
+			isEmptyMap(value) {
+			unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+		}
+	}
+	return normalized
+}
+
+func isEmptyMap(value interface{}) bool {
+	m, isMap := value.(map[string]interface{})
+	return isMap && len(m) == 0
+}
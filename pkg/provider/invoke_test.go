@@ -0,0 +1,53 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderManifest(t *testing.T) {
+	k := &kubeProvider{}
+	result, err := k.renderManifest(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"objects": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":            "settings",
+					"labels":          map[string]interface{}{},
+					"resourceVersion": "1234",
+				},
+				"data": map[string]interface{}{"mode": "fast"},
+			},
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]interface{}{"name": "apps"},
+				"status":     map[string]interface{}{"phase": "Active"},
+			},
+		},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+data:
+  mode: fast
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+`, result["manifest"].StringValue())
+	assert.Len(t, result["objects"].ArrayValue(), 2)
+
+	_, err = k.renderManifest(resource.NewPropertyMapFromMap(map[string]interface{}{
+		"objects": []interface{}{map[string]interface{}{"kind": "ConfigMap"}},
+	}))
+	assert.Error(t, err, "objects without an apiVersion and name should be rejected")
+}
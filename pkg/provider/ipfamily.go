@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	ipv4Family = "IPv4"
+	ipv6Family = "IPv6"
+
+	singleStack      = "SingleStack"
+	preferDualStack  = "PreferDualStack"
+	requireDualStack = "RequireDualStack"
+
+	// ipFamilyMinMinorVersion is the first minor version of Kubernetes v1 with the `ipFamilies` and
+	// `ipFamilyPolicy` Service fields. Older API servers silently drop them.
+	ipFamilyMinMinorVersion = 20
+)
+
+// clusterIPFamilies describes the IP families the connected cluster can allocate addresses from.
+// If `known` is false, we could not determine this, and skip the checks that depend on it.
+// `serviceIPOnly` is set if the only evidence was the ClusterIP of the `default/kubernetes`
+// Service, which reveals the primary IP family of the cluster, but not whether it is dual-stack.
+type clusterIPFamilies struct {
+	known         bool
+	serviceIPOnly bool
+	minorVersion  int
+	ipv4          bool
+	ipv6          bool
+}
+
+func (c clusterIPFamilies) supports(family string) bool {
+	return (family == ipv4Family && c.ipv4) || (family == ipv6Family && c.ipv6)
+}
+
+func (c clusterIPFamilies) dualStack() bool {
+	return c.ipv4 && c.ipv6
+}
+
+func (c clusterIPFamilies) String() string {
+	switch {
+	case c.dualStack():
+		return "dual-stack"
+	case c.ipv6:
+		return "IPv6 single-stack"
+	default:
+		return "IPv4 single-stack"
+	}
+}
+
+// clusterIPFamilies returns the IP families supported by the cluster, discovering them on first use.
+func (k *kubeProvider) clusterIPFamilies() clusterIPFamilies {
+	k.ipFamiliesOnce.Do(func() {
+		k.ipFamilies = k.discoverClusterIPFamilies()
+	})
+	return k.ipFamilies
+}
+
+// discoverClusterIPFamilies determines which IP families the cluster supports by inspecting the Pod
+// CIDRs allocated to its Nodes, and the ClusterIP of the `default/kubernetes` Service. If the Nodes
+// have no Pod CIDRs (e.g., because the cluster's network plugin allocates Pod IPs itself), the
+// Service alone can't tell us whether the cluster is dual-stack, so the families are unknown.
+func (k *kubeProvider) discoverClusterIPFamilies() clusterIPFamilies {
+	families := clusterIPFamilies{}
+
+	if info, err := k.client.ServerVersion(); err == nil {
+		families.minorVersion, _ = strconv.Atoi(strings.TrimSuffix(info.Minor, "+"))
+	}
+
+	addIP := func(ip net.IP) {
+		families.known = true
+		if ip.To4() != nil {
+			families.ipv4 = true
+		} else {
+			families.ipv6 = true
+		}
+	}
+	addCIDR := func(cidr interface{}) {
+		if ip, _, err := net.ParseCIDR(fmt.Sprintf("%v", cidr)); err == nil {
+			addIP(ip)
+		}
+	}
+
+	nodeClient, err := client.FromGVK(k.pool, k.client, schema.GroupVersionKind{
+		Group: "", Version: "v1", Kind: "Node",
+	}, "")
+	if err == nil {
+		if nodes, err := nodeClient.List(metav1.ListOptions{}); err == nil {
+			for _, node := range nodes.(*unstructured.UnstructuredList).Items {
+				cidrs, _ := openapi.Pluck(node.Object, "spec", "podCIDRs")
+				if cidrs, isSlice := cidrs.([]interface{}); isSlice {
+					for _, cidr := range cidrs {
+						addCIDR(cidr)
+					}
+				} else if cidr, hasCIDR := openapi.Pluck(node.Object, "spec", "podCIDR"); hasCIDR {
+					addCIDR(cidr)
+				}
+			}
+		} else {
+			glog.V(3).Infof("Could not list Nodes to determine cluster IP families: %v", err)
+		}
+	}
+
+	fromNodes := families.known
+
+	serviceClient, err := client.FromGVK(k.pool, k.client, schema.GroupVersionKind{
+		Group: "", Version: "v1", Kind: "Service",
+	}, "default")
+	if err == nil {
+		if svc, err := serviceClient.Get("kubernetes", metav1.GetOptions{}); err == nil {
+			clusterIP, _ := openapi.Pluck(svc.Object, "spec", "clusterIP")
+			if ip := net.ParseIP(fmt.Sprintf("%v", clusterIP)); ip != nil {
+				addIP(ip)
+			}
+		} else {
+			glog.V(3).Infof(
+				"Could not read Service 'default/kubernetes' to determine cluster IP families: %v", err)
+		}
+	}
+
+	if families.known && !fromNodes {
+		glog.V(3).Infof("Nodes have no Pod CIDRs; cannot determine whether the cluster is dual-stack")
+		families.known = false
+		families.serviceIPOnly = true
+	}
+
+	return families
+}
+
+// ipFamilyWarnings returns human-readable warnings about the IP family fields of a Service that
+// can't be validated against the cluster, because its IP families could not be determined.
+func ipFamilyWarnings(service *unstructured.Unstructured, cluster clusterIPFamilies) []string {
+	policy, _ := openapi.Pluck(service.Object, "spec", "ipFamilyPolicy")
+	if cluster.known || !cluster.serviceIPOnly || fmt.Sprintf("%v", policy) != requireDualStack {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"`spec.ipFamilyPolicy` is %q, but could not determine whether the cluster supports "+
+			"dual-stack networking, since its Nodes have no Pod CIDRs; if it doesn't, the Service "+
+			"will be rejected", requireDualStack)}
+}
+
+// checkIPFamilies validates the `ipFamilies`, `ipFamilyPolicy`, and `clusterIPs` fields of a Service
+// against each other, and against the capabilities of the cluster. Returns a list of human-readable
+// failures.
+func checkIPFamilies(service *unstructured.Unstructured, cluster clusterIPFamilies) []string {
+	var failures []string
+
+	rawFamilies, hasFamilies := openapi.Pluck(service.Object, "spec", "ipFamilies")
+	rawPolicy, hasPolicy := openapi.Pluck(service.Object, "spec", "ipFamilyPolicy")
+	if !hasFamilies && !hasPolicy {
+		return nil
+	}
+
+	if cluster.minorVersion > 0 && cluster.minorVersion < ipFamilyMinMinorVersion {
+		return []string{fmt.Sprintf(
+			"`spec.ipFamilies` and `spec.ipFamilyPolicy` require Kubernetes v1.%d or later, but the "+
+				"cluster is running v1.%d; the API server would silently ignore them",
+			ipFamilyMinMinorVersion, cluster.minorVersion)}
+	}
+
+	var families []string
+	if list, isSlice := rawFamilies.([]interface{}); isSlice {
+		seen := map[string]bool{}
+		for _, raw := range list {
+			family := fmt.Sprintf("%v", raw)
+			if family != ipv4Family && family != ipv6Family {
+				failures = append(failures, fmt.Sprintf(
+					"`spec.ipFamilies` contains unsupported IP family %q; must be %q or %q",
+					family, ipv4Family, ipv6Family))
+				continue
+			}
+			if seen[family] {
+				failures = append(failures, fmt.Sprintf(
+					"`spec.ipFamilies` contains IP family %q more than once", family))
+				continue
+			}
+			seen[family] = true
+			families = append(families, family)
+		}
+	}
+
+	policy := ""
+	if hasPolicy {
+		policy = fmt.Sprintf("%v", rawPolicy)
+		switch policy {
+		case singleStack, preferDualStack, requireDualStack:
+		default:
+			failures = append(failures, fmt.Sprintf(
+				"`spec.ipFamilyPolicy` %q is not supported; must be one of %q, %q, or %q",
+				policy, singleStack, preferDualStack, requireDualStack))
+		}
+	}
+
+	if policy == singleStack && len(families) > 1 {
+		failures = append(failures, fmt.Sprintf(
+			"`spec.ipFamilyPolicy` is %q, but `spec.ipFamilies` lists %d IP families",
+			singleStack, len(families)))
+	}
+	if policy == "" && len(families) > 1 {
+		failures = append(failures, fmt.Sprintf(
+			"`spec.ipFamilies` lists %d IP families, which requires `spec.ipFamilyPolicy` to be %q or %q",
+			len(families), preferDualStack, requireDualStack))
+	}
+
+	// `spec.clusterIPs`, if specified, must match `spec.ipFamilies` in order.
+	if rawIPs, hasIPs := openapi.Pluck(service.Object, "spec", "clusterIPs"); hasIPs {
+		if ips, isSlice := rawIPs.([]interface{}); isSlice {
+			for i, raw := range ips {
+				ip := net.ParseIP(fmt.Sprintf("%v", raw))
+				if ip == nil || i >= len(families) {
+					continue
+				}
+				family := ipv6Family
+				if ip.To4() != nil {
+					family = ipv4Family
+				}
+				if family != families[i] {
+					failures = append(failures, fmt.Sprintf(
+						"`spec.clusterIPs[%d]` (%s) is an %s address, but `spec.ipFamilies[%d]` is %s",
+						i, ip, family, i, families[i]))
+				}
+			}
+		}
+	}
+
+	if !cluster.known {
+		return failures
+	}
+
+	if policy == requireDualStack && !cluster.dualStack() {
+		failures = append(failures, fmt.Sprintf(
+			"`spec.ipFamilyPolicy` is %q, but the cluster is %s; use %q, or enable dual-stack "+
+				"networking on the cluster", requireDualStack, cluster, preferDualStack))
+	}
+	for i, family := range families {
+		// With `PreferDualStack`, the API server ignores a secondary family it can't allocate.
+		if policy == preferDualStack && i > 0 {
+			continue
+		}
+		if !cluster.supports(family) {
+			failures = append(failures, fmt.Sprintf(
+				"`spec.ipFamilies[%d]` is %s, but the cluster is %s and cannot allocate %s addresses",
+				i, family, cluster, family))
+		}
+	}
+
+	return failures
+}
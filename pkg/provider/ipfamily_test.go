@@ -0,0 +1,79 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func serviceWithIPFamilies(policy string, families []interface{}, clusterIPs []interface{}) *unstructured.Unstructured {
+	spec := map[string]interface{}{}
+	if policy != "" {
+		spec["ipFamilyPolicy"] = policy
+	}
+	if families != nil {
+		spec["ipFamilies"] = families
+	}
+	if clusterIPs != nil {
+		spec["clusterIPs"] = clusterIPs
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+}
+
+func TestCheckIPFamilies(t *testing.T) {
+	ipv4Only := clusterIPFamilies{known: true, minorVersion: 21, ipv4: true}
+	dual := clusterIPFamilies{known: true, minorVersion: 21, ipv4: true, ipv6: true}
+	unknown := clusterIPFamilies{}
+
+	tests := []struct {
+		description string
+		service     *unstructured.Unstructured
+		cluster     clusterIPFamilies
+		failures    int
+	}{
+		{"no IP family fields", serviceWithIPFamilies("", nil, nil), ipv4Only, 0},
+		{"IPv4 on IPv4 cluster", serviceWithIPFamilies(singleStack, []interface{}{"IPv4"}, nil), ipv4Only, 0},
+		{"IPv6 on IPv4 cluster", serviceWithIPFamilies(singleStack, []interface{}{"IPv6"}, nil), ipv4Only, 1},
+		{"IPv6 on unknown cluster", serviceWithIPFamilies(singleStack, []interface{}{"IPv6"}, nil), unknown, 0},
+		{"require dual-stack on IPv4 cluster", serviceWithIPFamilies(requireDualStack, nil, nil), ipv4Only, 1},
+		{"require dual-stack on dual-stack cluster", serviceWithIPFamilies(requireDualStack, nil, nil), dual, 0},
+		{"prefer dual-stack on IPv4 cluster",
+			serviceWithIPFamilies(preferDualStack, []interface{}{"IPv4", "IPv6"}, nil), ipv4Only, 0},
+		{"single-stack with two families",
+			serviceWithIPFamilies(singleStack, []interface{}{"IPv4", "IPv6"}, nil), dual, 1},
+		{"two families without policy", serviceWithIPFamilies("", []interface{}{"IPv4", "IPv6"}, nil), dual, 1},
+		{"unsupported family", serviceWithIPFamilies("", []interface{}{"IPv5"}, nil), unknown, 1},
+		{"duplicate family", serviceWithIPFamilies(preferDualStack, []interface{}{"IPv4", "IPv4"}, nil), unknown, 1},
+		{"unsupported policy", serviceWithIPFamilies("DualStack", nil, nil), unknown, 1},
+		{"clusterIPs mismatch families",
+			serviceWithIPFamilies(singleStack, []interface{}{"IPv4"}, []interface{}{"fd00::1"}), unknown, 1},
+		{"cluster too old",
+			serviceWithIPFamilies(singleStack, []interface{}{"IPv4"}, nil),
+			clusterIPFamilies{known: true, minorVersion: 18, ipv4: true}, 1},
+	}
+
+	for _, test := range tests {
+		failures := checkIPFamilies(test.service, test.cluster)
+		assert.Len(t, failures, test.failures, test.description)
+	}
+}
+
+func TestIPFamilyWarnings(t *testing.T) {
+	// Inferred from the `kubernetes` Service IP alone, so the cluster may well be dual-stack.
+	serviceIPOnly := clusterIPFamilies{serviceIPOnly: true, minorVersion: 21, ipv4: true}
+	ipv4Only := clusterIPFamilies{known: true, minorVersion: 21, ipv4: true}
+
+	requireDual := serviceWithIPFamilies(requireDualStack, nil, nil)
+	assert.Empty(t, checkIPFamilies(requireDual, serviceIPOnly),
+		"Should not fail RequireDualStack when only the Service IP is known")
+	assert.Len(t, ipFamilyWarnings(requireDual, serviceIPOnly), 1,
+		"Should warn about RequireDualStack when only the Service IP is known")
+	assert.Empty(t, ipFamilyWarnings(requireDual, ipv4Only),
+		"Should not warn when the cluster's IP families are known")
+	assert.Empty(t, ipFamilyWarnings(serviceWithIPFamilies(preferDualStack, nil, nil), serviceIPOnly),
+		"Should not warn about PreferDualStack")
+}
@@ -0,0 +1,108 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// clientConfig returns the configuration of clients for the cluster selected by the provider
+// configuration `vars`. When the provider runs in a pod (e.g., Pulumi running in CI inside the
+// target cluster) and no kubeconfig is given or found, it uses the pod's service account.
+func clientConfig(vars map[string]string) (*rest.Config, error) {
+	if _, hasKubeconfig := vars["kubernetes:config:kubeconfig"]; !hasKubeconfig &&
+		!kubeconfigFilesExist(clientcmd.NewDefaultClientConfigLoadingRules()) {
+		if conf, err := rest.InClusterConfig(); err == nil {
+			glog.V(3).Infof("No kubeconfig found; using in-cluster configuration")
+			return conf, nil
+		}
+	}
+
+	kubeconfig, err := loadKubeconfig(vars)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := kubeconfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read kubectl config: %v", err)
+	}
+	return conf, nil
+}
+
+// kubeconfigFilesExist returns true if any of the kubeconfig files `loadingRules` would load exist.
+func kubeconfigFilesExist(loadingRules *clientcmd.ClientConfigLoadingRules) bool {
+	for _, path := range loadingRules.GetLoadingPrecedence() {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// loadKubeconfig returns the client configuration selected by the provider configuration `vars`.
+// The kubeconfig is given by the `kubeconfig` option, as either the contents of a kubeconfig file
+// or its path, or else found as `kubectl` would (i.e., in $KUBECONFIG or `~/.kube/config`). The
+// `context` and `cluster` options override the kubeconfig's current context and its cluster, so
+// that programs can target several clusters at once, with one provider each, without changing
+// $KUBECONFIG or the current context.
+func loadKubeconfig(vars map[string]string) (clientcmd.ClientConfig, error) {
+	overrides := &clientcmd.ConfigOverrides{
+		Context: clientapi.Context{
+			Cluster:   vars["kubernetes:config:cluster"],
+			Namespace: vars["kubernetes:config:namespace"],
+		},
+		CurrentContext: vars["kubernetes:config:context"],
+	}
+
+	kubeconfig, ok := vars["kubernetes:config:kubeconfig"]
+	if !ok {
+		// Use client-go to resolve the final configuration values for the client. Typically these
+		// values would would reside in the $KUBECONFIG file, but can also be altered in several
+		// places, including in env variables, client-go default values, and (if we allowed it) CLI
+		// flags.
+		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+		loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
+		return clientcmd.NewInteractiveDeferredLoadingClientConfig(
+			loadingRules, overrides, os.Stdin), nil
+	}
+
+	if isKubeconfigPath(kubeconfig) {
+		loadingRules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig}
+		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides), nil
+	}
+
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %v", err)
+	}
+	return clientcmd.NewDefaultClientConfig(*config, overrides), nil
+}
+
+// isKubeconfigPath returns true if the `kubeconfig` option names a file, rather than holding the
+// contents of one.
+func isKubeconfigPath(kubeconfig string) bool {
+	if strings.ContainsAny(kubeconfig, "\n{") {
+		return false
+	}
+	info, err := os.Stat(kubeconfig)
+	return err == nil && !info.IsDir()
+}
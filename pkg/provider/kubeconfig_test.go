@@ -0,0 +1,84 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: staging
+  cluster: {server: "https://staging.example.com"}
+- name: prod
+  cluster: {server: "https://prod.example.com"}
+users:
+- name: admin
+  user: {token: secret}
+contexts:
+- name: staging
+  context: {cluster: staging, user: admin}
+- name: prod
+  context: {cluster: prod, user: admin}
+current-context: staging
+`
+
+func kubeconfigHost(t *testing.T, vars map[string]string) string {
+	kubeconfig, err := loadKubeconfig(vars)
+	assert.NoError(t, err)
+	conf, err := kubeconfig.ClientConfig()
+	assert.NoError(t, err)
+	return conf.Host
+}
+
+func TestLoadKubeconfig(t *testing.T) {
+	vars := map[string]string{"kubernetes:config:kubeconfig": testKubeconfig}
+	assert.Equal(t, "https://staging.example.com", kubeconfigHost(t, vars))
+
+	vars["kubernetes:config:context"] = "prod"
+	assert.Equal(t, "https://prod.example.com", kubeconfigHost(t, vars))
+
+	vars["kubernetes:config:cluster"] = "staging"
+	assert.Equal(t, "https://staging.example.com", kubeconfigHost(t, vars))
+
+	_, err := loadKubeconfig(map[string]string{"kubernetes:config:kubeconfig": "clusters: ["})
+	assert.Error(t, err)
+}
+
+func TestLoadKubeconfigFromPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testKubeconfig), 0600))
+	assert.True(t, isKubeconfigPath(path))
+	assert.False(t, isKubeconfigPath(testKubeconfig))
+	assert.False(t, isKubeconfigPath(dir))
+
+	vars := map[string]string{
+		"kubernetes:config:kubeconfig": path,
+		"kubernetes:config:context":    "prod",
+	}
+	assert.Equal(t, "https://prod.example.com", kubeconfigHost(t, vars))
+}
+
+func TestKubeconfigFilesExist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config")
+	loadingRules := &clientcmd.ClientConfigLoadingRules{Precedence: []string{path}}
+	assert.False(t, kubeconfigFilesExist(loadingRules))
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte(testKubeconfig), 0600))
+	assert.True(t, kubeconfigFilesExist(loadingRules))
+}
@@ -0,0 +1,102 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// `kubectl apply` interop.
+//
+// `kubectl apply` records the configuration it last applied to an object in the
+// `kubectl.kubernetes.io/last-applied-configuration` annotation, and uses it as the "original"
+// side of the three-way merge of its next apply, so that it knows which fields to remove. We
+// maintain the annotation the same way on the objects we submit, so that `kubectl apply` can take
+// over an object from Pulumi; and when Pulumi adopts an object that was last applied by `kubectl`
+// (i.e., imports it), we take the annotation as the object's previous inputs (see `import.go`), so
+// that the first update doesn't report the whole object as changed, and correctly removes fields
+// `kubectl` set but the program doesn't.
+//
+// With server-side apply, the API server tracks field ownership itself, so we leave the annotation
+// alone.
+
+// --------------------------------------------------------------------------
+
+const lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// withLastAppliedConfig returns a copy of `obj` whose last-applied-configuration annotation records
+// `obj` itself, as `kubectl apply` would set it.
+func withLastAppliedConfig(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	applied := obj.DeepCopy()
+	annotations := applied.GetAnnotations()
+	delete(annotations, lastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(applied.Object, "metadata", "annotations")
+	} else {
+		applied.SetAnnotations(annotations)
+	}
+
+	config, err := applied.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	// NOTE: Like `kubectl`'s, the encoded configuration ends with a newline.
+	annotations[lastAppliedConfigAnnotation] = string(config)
+	applied.SetAnnotations(annotations)
+	return applied, nil
+}
+
+// submittedObject returns the object to submit to the API server for `inputs`, on behalf of the
+// resource `urn`.
+func (k *kubeProvider) submittedObject(urn resource.URN, inputs *unstructured.Unstructured) (
+	*unstructured.Unstructured, error,
+) {
+	if k.awaitOptions.ServerSideApply {
+		// Server-side apply removes the fields we stop setting, so `null`s are meaningless.
+		return withStackLabels(withoutNulls(inputs), urn), nil
+	}
+	// NOTE: The stack labels are added after the configuration is recorded, since the program didn't
+	// ask for them (see `stack.go`).
+	applied, err := withLastAppliedConfig(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return withStackLabels(applied, urn), nil
+}
+
+// lastAppliedConfig returns the configuration recorded in the last-applied-configuration annotation
+// of `live`, if any.
+func lastAppliedConfig(live *unstructured.Unstructured) (*unstructured.Unstructured, bool) {
+	config, exists := live.GetAnnotations()[lastAppliedConfigAnnotation]
+	if !exists {
+		return nil, false
+	}
+
+	applied := &unstructured.Unstructured{}
+	if err := applied.UnmarshalJSON([]byte(config)); err != nil {
+		glog.V(3).Infof("Ignoring malformed %s annotation of '%s': %v",
+			lastAppliedConfigAnnotation, live.GetName(), err)
+		return nil, false
+	}
+	return applied, true
+}
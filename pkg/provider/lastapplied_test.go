@@ -0,0 +1,40 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLastAppliedConfig(t *testing.T) {
+	inputs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "settings"},
+		"data":       map[string]interface{}{"mode": "fast"},
+	}}
+
+	submitted, err := withLastAppliedConfig(inputs)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		`{"apiVersion":"v1","data":{"mode":"fast"},"kind":"ConfigMap","metadata":{"name":"settings"}}`+
+			"\n",
+		submitted.GetAnnotations()[lastAppliedConfigAnnotation])
+	assert.Nil(t, inputs.GetAnnotations(), "Inputs should not be modified")
+
+	// Re-submitting the object should not nest the previous configuration.
+	resubmitted, err := withLastAppliedConfig(submitted)
+	assert.NoError(t, err)
+	assert.Equal(t, submitted.GetAnnotations(), resubmitted.GetAnnotations())
+
+	applied, exists := lastAppliedConfig(submitted)
+	assert.True(t, exists)
+	assert.Equal(t, inputs, applied)
+
+	_, exists = lastAppliedConfig(inputs)
+	assert.False(t, exists)
+}
@@ -1,8 +1,8 @@
 package provider
 
 import (
-	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/pulumi/pulumi/pkg/tokens"
@@ -13,18 +13,57 @@ import (
 const annotationInternalPrefix = "pulumi.com/"
 const annotationInternalAutonamed = "pulumi.com/autonamed"
 
+const (
+	// maxAutonameLength keeps generated names valid DNS-1123 labels, the most restrictive kind of
+	// object name (e.g., that of a Service).
+	maxAutonameLength    = 63
+	autonameSuffixLength = 8
+)
+
 var dns1123Alphabet = []rune("abcdefghijklmnopqrstuvwxyz0123456789")
 
-// assignName generates a name for an object. Uses DNS-1123-compliant characters. All auto-named
-// resources get the annotation `pulumi.com/autonamed` for tooling purposes.
+// assignName generates a name for an object: the `metadata.generateName` prefix of the object if it
+// has one, or else the Pulumi name of the resource, followed by a random suffix. Uses
+// DNS-1123-compliant characters. All auto-named resources get the annotation
+// `pulumi.com/autonamed` for tooling purposes.
 func assignNameIfAutonamable(obj *unstructured.Unstructured, base tokens.QName) {
 	contract.Assert(base != "")
 	if obj.GetName() == "" {
-		obj.SetName(fmt.Sprintf("%s-%s", base, randString(8)))
+		prefix := obj.GetGenerateName()
+		if prefix == "" {
+			prefix = dns1123Prefix(string(base)) + "-"
+		}
+		if maxPrefix := maxAutonameLength - autonameSuffixLength; len(prefix) > maxPrefix {
+			prefix = prefix[:maxPrefix]
+		}
+		obj.SetName(prefix + randString(autonameSuffixLength))
 		setAutonameAnnotation(obj)
 	}
 }
 
+// dns1123Prefix turns the Pulumi name of a resource (e.g., `My_ConfigMap`) into a valid prefix of
+// a DNS-1123 label (e.g., `my-configmap`).
+func dns1123Prefix(name string) string {
+	prefix := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '-'
+		}
+	}, name)
+	if maxPrefix := maxAutonameLength - autonameSuffixLength - 1; len(prefix) > maxPrefix {
+		prefix = prefix[:maxPrefix]
+	}
+	prefix = strings.Trim(prefix, "-")
+	if prefix == "" {
+		return "resource"
+	}
+	return prefix
+}
+
 // adoptOldNameIfUnnamed checks if `newObj` has a name, and if not, "adopts" the name of `oldObj`
 // instead. If `oldObj` was autonamed, then we mark `newObj` as autonamed, too.
 func adoptOldNameIfUnnamed(newObj, oldObj *unstructured.Unstructured) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pulumi/pulumi/pkg/tokens"
 	"github.com/stretchr/testify/assert"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -27,6 +28,24 @@ func TestAssignNameIfAutonamable(t *testing.T) {
 	assert.Equal(t, "bar", o2.GetName())
 }
 
+func TestAssignNameIfAutonamableSanitizesName(t *testing.T) {
+	o1 := &unstructured.Unstructured{}
+	assignNameIfAutonamable(o1, "My_Config.Map")
+	assert.True(t, strings.HasPrefix(o1.GetName(), "my-config-map-"))
+	assert.Len(t, o1.GetName(), len("my-config-map-")+autonameSuffixLength)
+
+	o2 := &unstructured.Unstructured{}
+	assignNameIfAutonamable(o2, tokens.QName(strings.Repeat("a", 100)))
+	assert.Len(t, o2.GetName(), maxAutonameLength)
+
+	// o3 has a `generateName`, which is used as the prefix instead.
+	o3 := &unstructured.Unstructured{}
+	o3.SetGenerateName("migrate-")
+	assignNameIfAutonamable(o3, "foo")
+	assert.True(t, isAutonamed(o3))
+	assert.True(t, strings.HasPrefix(o3.GetName(), "migrate-"))
+}
+
 func TestAdoptName(t *testing.T) {
 	// new1 is named and therefore DOES NOT adopt old1's name.
 	old1 := &unstructured.Unstructured{
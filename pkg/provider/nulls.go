@@ -0,0 +1,72 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// Removing fields.
+//
+// A field that is dropped from the program is removed from the live object by the three-way merge
+// of `Update`, because it appears in the last submitted inputs but not the current ones. That
+// doesn't work for fields that were never in the inputs, e.g., fields defaulted by the API server,
+// or set by `kubectl` or a controller. To remove such a field, users set it to `null`: as with
+// `kubectl apply`, an explicit `null` in the inputs becomes a deletion in the patch.
+//
+// So we keep `null`s in the inputs, including the inputs we checkpoint, so that removing the
+// `null` from the program isn't itself reported as a change. But `null`s mean nothing when an
+// object is created, or validated against its schema, so we drop them there, and we drop the
+// `null`s the API server returns in live objects (e.g., `creationTimestamp: null`) from the
+// checkpoint.
+
+// --------------------------------------------------------------------------
+
+// withoutNulls returns a copy of `obj` without any fields whose value is `null`.
+func withoutNulls(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if obj == nil {
+		return nil
+	}
+	return &unstructured.Unstructured{Object: pruneNulls(obj.Object)}
+}
+
+func pruneNulls(obj map[string]interface{}) map[string]interface{} {
+	pruned := make(map[string]interface{}, len(obj))
+	for key, value := range obj {
+		if value != nil {
+			pruned[key] = pruneNullValue(value)
+		}
+	}
+	return pruned
+}
+
+func pruneNullValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return pruneNulls(typed)
+	case []interface{}:
+		pruned := make([]interface{}, 0, len(typed))
+		for _, elem := range typed {
+			if elem != nil {
+				pruned = append(pruned, pruneNullValue(elem))
+			}
+		}
+		return pruned
+	default:
+		return value
+	}
+}
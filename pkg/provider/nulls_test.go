@@ -0,0 +1,48 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestWithoutNulls(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind": "Service",
+		"spec": map[string]interface{}{
+			"externalTrafficPolicy": nil,
+			"ports": []interface{}{
+				map[string]interface{}{"port": int64(80), "nodePort": nil},
+				nil,
+			},
+		},
+	}}
+
+	assert.Equal(t, map[string]interface{}{
+		"kind": "Service",
+		"spec": map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": int64(80)}},
+		},
+	}, withoutNulls(obj).Object)
+
+	_, hasPolicy := obj.Object["spec"].(map[string]interface{})["externalTrafficPolicy"]
+	assert.True(t, hasPolicy, "Object should not be modified")
+}
+
+func TestCheckpointObjectKeepsInputNulls(t *testing.T) {
+	inputs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"externalTrafficPolicy": nil},
+	}}
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "web", "creationTimestamp": nil},
+	}}
+
+	obj := checkpointObject(inputs, live).Mappable()
+	assert.Equal(t, map[string]interface{}{"name": "web"}, obj["metadata"])
+	assert.Equal(t, map[string]interface{}{
+		"spec": map[string]interface{}{"externalTrafficPolicy": nil},
+	}, obj["__inputs"])
+}
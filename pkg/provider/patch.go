@@ -0,0 +1,102 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// Patch resources.
+//
+// A Patch resource (e.g., `kubernetes:core/v1:ConfigMapPatch`) manages only the fields it specifies
+// of an existing object that is otherwise owned by someone else, e.g., the `aws-auth` ConfigMap
+// created by EKS, objects in `kube-system`, or CRDs installed by other tools. Patches are applied
+// with server-side apply, on behalf of a field manager unique to the Patch resource, so the API
+// server tracks exactly which fields each Patch set. Updating a Patch stops managing the fields it no
+// longer sets, and deleting it relinquishes all of its fields rather than deleting the object. As
+// with any server-side apply, relinquished fields that no other manager also set are removed.
+//
+// Since the point of a Patch is to set fields of an object someone else manages, Patches are
+// force-applied: fields another manager set are taken over by the Patch, rather than failing the
+// apply with a conflict.
+
+// --------------------------------------------------------------------------
+
+// patchQualifier is the suffix of the kind in the type token of Patch resources.
+const patchQualifier = "Patch"
+
+// isPatchURN returns true if `urn` identifies a Patch resource.
+func isPatchURN(urn resource.URN) bool {
+	return strings.HasSuffix(string(urn.Type()), patchQualifier)
+}
+
+// patchFieldManager returns the field manager on behalf of which the Patch resource `urn` is
+// applied, derived from the provider's field manager `manager`.
+func patchFieldManager(manager string, urn resource.URN) string {
+	hash := sha256.Sum256([]byte(urn))
+	return fmt.Sprintf("%s-patch-%x", manager, hash[:4])
+}
+
+// patchForceNewProperties returns the fields whose change replaces the Patch resource, i.e., those
+// that identify the patched object. Other fields are simply re-applied.
+func patchForceNewProperties(oldObj, newObj map[string]interface{}) ([]string, error) {
+	return openapi.PropertiesChanged(oldObj, newObj, metadataForceNewProperties(".metadata"))
+}
+
+// applyPatch applies `patch` to the existing object it identifies, on behalf of the Patch resource
+// `urn`, and returns the resulting live object.
+func (k *kubeProvider) applyPatch(
+	urn resource.URN, patch *unstructured.Unstructured,
+) (*unstructured.Unstructured, error) {
+	// Server-side apply creates objects that don't exist, but a Patch must only modify them.
+	if _, err := k.readLiveObject(patch); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("cannot patch '%s': the object does not exist",
+				client.FqObjName(patch))
+		}
+		return nil, err
+	}
+	return client.ForceApply(
+		k.client, patch, patchFieldManager(k.awaitOptions.FieldManagerOrDefault(), urn))
+}
+
+// relinquishPatch relinquishes all the fields of `obj` managed by the Patch resource `urn`.
+func (k *kubeProvider) relinquishPatch(urn resource.URN, obj *unstructured.Unstructured) error {
+	if _, err := k.readLiveObject(obj); err != nil {
+		if isNotFound(err) {
+			// Nothing to relinquish.
+			return nil
+		}
+		return err
+	}
+
+	// Applying only the identity of the object leaves the field manager owning no fields.
+	identity := &unstructured.Unstructured{}
+	identity.SetGroupVersionKind(obj.GroupVersionKind())
+	identity.SetNamespace(obj.GetNamespace())
+	identity.SetName(obj.GetName())
+	_, err := client.Apply(
+		k.client, identity, patchFieldManager(k.awaitOptions.FieldManagerOrDefault(), urn))
+	return err
+}
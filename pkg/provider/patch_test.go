@@ -0,0 +1,123 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/pulumi/pulumi-kubernetes/pkg/await"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func patchTestURN(typ, name string) resource.URN {
+	return resource.NewURN("dev", "proj", "", tokens.Type(typ), tokens.QName(name))
+}
+
+func TestPatchURN(t *testing.T) {
+	k := &kubeProvider{providerPrefix: "kubernetes" + gvkDelimiter}
+
+	patch := patchTestURN("kubernetes:core/v1:ConfigMapPatch", "aws-auth")
+	assert.True(t, isPatchURN(patch))
+	assert.Equal(t, schema.GroupVersionKind{Group: "core", Version: "v1", Kind: "ConfigMap"},
+		k.gvkFromURN(patch))
+
+	configMap := patchTestURN("kubernetes:core/v1:ConfigMap", "aws-auth")
+	assert.False(t, isPatchURN(configMap))
+	assert.Equal(t, k.gvkFromURN(patch), k.gvkFromURN(configMap))
+}
+
+func TestPatchFieldManager(t *testing.T) {
+	authPatch := patchFieldManager(await.FieldManager,
+		patchTestURN("kubernetes:core/v1:ConfigMapPatch", "aws-auth"))
+	assert.Regexp(t, `^pulumi-kubernetes-patch-[0-9a-f]{8}$`, authPatch)
+	assert.Equal(t, authPatch, patchFieldManager(await.FieldManager,
+		patchTestURN("kubernetes:core/v1:ConfigMapPatch", "aws-auth")))
+	assert.NotEqual(t, authPatch, patchFieldManager(await.FieldManager,
+		patchTestURN("kubernetes:core/v1:ConfigMapPatch", "coredns")))
+	assert.Regexp(t, `^staging-patch-[0-9a-f]{8}$`, patchFieldManager("staging",
+		patchTestURN("kubernetes:core/v1:ConfigMapPatch", "aws-auth")))
+}
+
+func TestPatchForceNewProperties(t *testing.T) {
+	oldObj := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "aws-auth", "namespace": "kube-system"},
+		"data":     map[string]interface{}{"mapRoles": "[]"},
+	}
+
+	newData := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "aws-auth", "namespace": "kube-system"},
+		"data":     map[string]interface{}{"mapRoles": "[{}]"},
+	}
+	replaces, err := patchForceNewProperties(oldObj, newData)
+	assert.NoError(t, err)
+	assert.Empty(t, replaces)
+
+	newName := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "coredns", "namespace": "kube-system"},
+		"data":     map[string]interface{}{"mapRoles": "[]"},
+	}
+	replaces, err = patchForceNewProperties(oldObj, newName)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{".metadata.name"}, replaces)
+}
+
+func TestApplyPatchForcesConflicts(t *testing.T) {
+	// `mapRoles` is owned by another field manager, so the API server rejects any apply that
+	// changes it without `force`.
+	var forced []string
+	k, closeServer := fakeAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/kube-system/configmaps/aws-auth" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = io.WriteString(w, `{"apiVersion": "v1", "kind": "ConfigMap",
+				"metadata": {"name": "aws-auth", "namespace": "kube-system"},
+				"data": {"mapRoles": "[]"}}`)
+		case http.MethodPatch:
+			forced = append(forced, r.URL.Query().Get("force"))
+			if r.URL.Query().Get("force") != "true" {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = io.WriteString(w, `{"kind": "Status", "apiVersion": "v1",
+					"status": "Failure", "reason": "Conflict", "code": 409,
+					"message": "Apply failed with 1 conflict: conflict with \"eks\"",
+					"details": {"causes": [{"reason": "FieldManagerConflict",
+						"message": "conflict with \"eks\"", "field": ".data.mapRoles"}]}}`)
+				return
+			}
+			body, _ := ioutil.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	})
+	defer closeServer()
+
+	patch := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "aws-auth", "namespace": "kube-system"},
+		"data":       map[string]interface{}{"mapRoles": "[{}]"},
+	}}
+
+	// A plain apply fails with the conflict.
+	_, err := client.Apply(k.client, patch, "pulumi-kubernetes")
+	assert.IsType(t, &client.ApplyConflictError{}, err)
+
+	// A Patch takes over the field.
+	urn := patchTestURN("kubernetes:core/v1:ConfigMapPatch", "aws-auth")
+	applied, err := k.applyPatch(urn, patch)
+	assert.NoError(t, err, "Should take over fields owned by other managers")
+	assert.Equal(t, []string{"", "true"}, forced)
+	data, _, _ := unstructured.NestedString(applied.Object, "data", "mapRoles")
+	assert.Equal(t, "[{}]", data)
+}
@@ -17,15 +17,20 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/golang/glog"
 	pbempty "github.com/golang/protobuf/ptypes/empty"
 	structpb "github.com/golang/protobuf/ptypes/struct"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/pulumi/pulumi-kubernetes/pkg/await"
 	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi-kubernetes/pkg/failure"
 	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
 	"github.com/pulumi/pulumi/pkg/resource"
 	"github.com/pulumi/pulumi/pkg/resource/plugin"
 	"github.com/pulumi/pulumi/pkg/resource/provider"
@@ -41,8 +46,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/client-go/tools/clientcmd"
-	clientapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/rest"
 )
 
 // --------------------------------------------------------------------------
@@ -57,6 +61,9 @@ import (
 
 const (
 	gvkDelimiter = ":"
+
+	// maxFieldManagerLength is the longest field manager name the API server accepts.
+	maxFieldManagerLength = 128
 )
 
 type cancellationContext struct {
@@ -80,6 +87,50 @@ type kubeProvider struct {
 	name           string
 	version        string
 	providerPrefix string
+
+	// defaultNamespace is the namespace of namespaced objects that don't specify one. If empty,
+	// such objects are created in the "default" namespace.
+	defaultNamespace string
+
+	// compactStateThreshold is the size (in bytes) above which live objects are checkpointed as a
+	// content hash rather than in full. Non-positive values disable compaction.
+	compactStateThreshold int
+
+	// renderYAMLDiff causes `Diff` to also report a unified YAML diff of the live object against the
+	// proposed object.
+	renderYAMLDiff bool
+
+	// enableDryRun causes the proposed object of rendered YAML diffs to be computed with a
+	// server-side dry run of the update, rather than on the client.
+	enableDryRun bool
+
+	// awaitOptions configures how the provider waits for objects to become ready.
+	awaitOptions await.Options
+
+	// enableSecrets is true if the engine accepts secret values in checkpoints.
+	enableSecrets bool
+
+	// plaintextSecretData opts out of marking the data of Secrets as secret (see `secrets.go`).
+	plaintextSecretData bool
+
+	// offline is true if the provider validates and diffs objects against a bundled schema, rather
+	// than contacting the cluster. Operations that need the cluster fail.
+	offline bool
+
+	// renderDir, if set, is the directory to which the provider writes the manifests of objects,
+	// rather than deploying them, while offline (see `render.go`).
+	renderDir string
+
+	ipFamiliesOnce sync.Once
+	ipFamilies     clusterIPFamilies
+
+	// crdSchemas are the schemas of kinds of custom resources, by GVK (see `crds.go`).
+	crdSchemasLock sync.Mutex
+	crdSchemas     map[schema.GroupVersionKind]openapi.CustomResourceSchema
+	clusterCRDs    sync.Once
+
+	clusterVersionOnce sync.Once
+	serverVersion      *client.ServerVersion
 }
 
 var _ pulumirpc.ResourceProviderServer = (*kubeProvider)(nil)
@@ -97,63 +148,206 @@ func makeKubeProvider(
 }
 
 // Configure configures the resource provider with "globals" that control its behavior.
-func (k *kubeProvider) Configure(_ context.Context, req *pulumirpc.ConfigureRequest) (*pbempty.Empty, error) {
+func (k *kubeProvider) Configure(
+	_ context.Context, req *pulumirpc.ConfigureRequest,
+) (*pulumirpc.ConfigureResponse, error) {
 	vars := req.GetVariables()
+	k.enableSecrets = req.GetAcceptSecrets()
+	k.defaultNamespace = vars["kubernetes:config:namespace"]
+
+	// Configure the discovery client. In offline mode (see `client.NewOfflineClients`), there is no
+	// cluster to configure it for.
+	offlineVersion, offline := vars["kubernetes:config:offlineKubernetesVersion"]
+	renderDir, render := vars["kubernetes:config:renderYamlToDirectory"]
+	if render {
+		if renderDir == "" {
+			return nil, fmt.Errorf("renderYamlToDirectory must not be empty")
+		}
+		k.renderDir = renderDir
+		offline = true
+	}
+	// When the cluster is reached through an in-cluster agent, which authenticates to the API server
+	// with its own credentials, there is no kubeconfig to load (and there may be none to find).
+	agentJSON, useAgent := vars["kubernetes:config:agent"]
+	conf := &rest.Config{}
+	var err error
+	if !offline && !useAgent {
+		conf, err = clientConfig(vars)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	// Compute config overrides.
-	overrides := &clientcmd.ConfigOverrides{
-		Context: clientapi.Context{
-			Cluster:   vars["kubernetes:config:cluster"],
-			Namespace: vars["kubernetes:config:namespace"],
-		},
-		CurrentContext: vars["kubernetes:config:context"],
+	// Optionally attribute the changes we make to a field manager other than the default, e.g., to
+	// distinguish stacks that manage fields of the same objects.
+	if manager, ok := vars["kubernetes:config:fieldManager"]; ok {
+		if manager == "" || len(manager) > maxFieldManagerLength {
+			return nil, fmt.Errorf(
+				"fieldManager must be between 1 and %d characters long, got %q",
+				maxFieldManagerLength, manager)
+		}
+		k.awaitOptions.FieldManager = manager
+		conf = client.WithFieldManager(conf, manager)
 	}
 
-	var kubeconfig clientcmd.ClientConfig
-	if configJSON, ok := vars["kubernetes:config:kubeconfig"]; ok {
-		config, err := clientcmd.Load([]byte(configJSON))
+	// Optionally reach the cluster through an in-cluster agent, for API servers that aren't directly
+	// reachable from where the provider runs.
+	if useAgent {
+		agent, err := client.ParseAgentConfig(agentJSON)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse kubeconfig: %v", err)
+			return nil, err
 		}
-		kubeconfig = clientcmd.NewDefaultClientConfig(*config, overrides)
-	} else {
-		// Use client-go to resolve the final configuration values for the client. Typically these
-		// values would would reside in the $KUBECONFIG file, but can also be altered in several
-		// places, including in env variables, client-go default values, and (if we allowed it) CLI
-		// flags.
-		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
-		loadingRules.DefaultClientConfig = &clientcmd.DefaultClientConfig
-		kubeconfig = clientcmd.NewInteractiveDeferredLoadingClientConfig(loadingRules, overrides, os.Stdin)
+		conf = client.WithAgent(conf, agent)
 	}
 
-	// Configure the discovery client.
-	conf, err := kubeconfig.ClientConfig()
-	if err != nil {
-		return nil, fmt.Errorf("Unable to read kubectl config: %v", err)
+	// Honor throttling signals (e.g., `429 Too Many Requests`) from the API server globally.
+	conf = client.WithThrottling(conf)
+
+	// Retry transient failures uniformly for every request, and fail fast if the API server is
+	// persistently unreachable.
+	retryPolicy := client.DefaultRetryPolicy()
+	if policyJSON, ok := vars["kubernetes:config:retryPolicy"]; ok {
+		retryPolicy, err = client.ParseRetryPolicy(policyJSON)
+		if err != nil {
+			return nil, err
+		}
 	}
+	conf = client.WithRetryPolicy(conf, retryPolicy)
 
-	disco, err := discovery.NewDiscoveryClientForConfig(conf)
-	if err != nil {
-		return nil, err
+	// Optionally keep checkpoints small by storing only a content hash for very large objects.
+	if threshold, ok := vars["kubernetes:config:compactStateThreshold"]; ok {
+		k.compactStateThreshold, err = strconv.Atoi(threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse compactStateThreshold: %v", err)
+		}
+	}
+
+	// Optionally render `kubectl diff`-style YAML diffs in previews.
+	if render, ok := vars["kubernetes:config:renderYamlDiff"]; ok {
+		k.renderYAMLDiff, err = strconv.ParseBool(render)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse renderYamlDiff: %v", err)
+		}
 	}
 
-	// Cache the discovery information (OpenAPI schema, etc.) so we don't have to retrieve it for
-	// every request.
-	discoCache := client.NewMemcachedDiscoveryClient(disco)
-	mapper := discovery.NewDeferredDiscoveryRESTMapper(discoCache, dynamic.VersionInterfaces)
-	pathresolver := dynamic.LegacyAPIPathResolverFunc
+	// Optionally compute previews with server-side dry runs.
+	if dryRun, ok := vars["kubernetes:config:enableDryRun"]; ok {
+		k.enableDryRun, err = strconv.ParseBool(dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse enableDryRun: %v", err)
+		}
+	}
 
-	// Create client pool, reusing one client per API group (e.g., one each for core, extensions,
-	// apps, etc.)
-	pool := dynamic.NewClientPool(conf, mapper, pathresolver)
+	// Optionally override how long we wait for objects of each kind to become ready.
+	if timeoutsJSON, ok := vars["kubernetes:config:timeouts"]; ok {
+		k.awaitOptions.Timeouts, err = await.ParseTimeouts(timeoutsJSON)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	k.client, k.pool = discoCache, pool
-	return &pbempty.Empty{}, nil
+	// Optionally create and update objects with server-side apply.
+	if enable, ok := vars["kubernetes:config:enableServerSideApply"]; ok {
+		k.awaitOptions.ServerSideApply, err = strconv.ParseBool(enable)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse enableServerSideApply: %v", err)
+		}
+	}
+
+	// Optionally override how deletes propagate to the dependents of objects.
+	if policy, ok := vars["kubernetes:config:deletionPropagationPolicy"]; ok {
+		k.awaitOptions.PropagationPolicy, err = await.ParsePropagationPolicy(policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deletionPropagationPolicy: %v", err)
+		}
+	}
+
+	// Optionally skip waiting for objects to become ready.
+	if suppress, ok := vars["kubernetes:config:suppressAwait"]; ok {
+		k.awaitOptions.SuppressAwait, err = strconv.ParseBool(suppress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse suppressAwait: %v", err)
+		}
+	}
+
+	// Optionally checkpoint the data of Secrets in plaintext.
+	if plaintext, ok := vars["kubernetes:config:plaintextSecretData"]; ok {
+		k.plaintextSecretData, err = strconv.ParseBool(plaintext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse plaintextSecretData: %v", err)
+		}
+	}
+
+	// Optionally override how long we wait for the endpoints of Services to settle.
+	if settle, ok := vars["kubernetes:config:endpointSettleSeconds"]; ok {
+		seconds, err := strconv.Atoi(settle)
+		if err != nil || seconds <= 0 {
+			return nil, fmt.Errorf(
+				"endpointSettleSeconds must be a positive integer number of seconds, got %q", settle)
+		}
+		k.awaitOptions.EndpointSettlePeriod = time.Duration(seconds) * time.Second
+	}
+
+	// Optionally validate and diff objects against the bundled schema of a Kubernetes version,
+	// without contacting the cluster, e.g., for previews in CI.
+	if offline {
+		// Render mode defaults to the newest bundled schema.
+		var version client.ServerVersion
+		if render && offlineVersion == "" {
+			if version, err = client.LatestBundledVersion(); err != nil {
+				return nil, err
+			}
+		} else if version, err = client.ParseOfflineVersion(offlineVersion); err != nil {
+			return nil, fmt.Errorf("failed to parse offlineKubernetesVersion: %v", err)
+		}
+		k.offline = true
+		k.client, k.pool, err = client.NewOfflineClients(version)
+		if err != nil {
+			return nil, err
+		}
+		return &pulumirpc.ConfigureResponse{AcceptSecrets: true}, nil
+	}
+
+	k.client, k.pool, err = client.NewClients(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.ConfigureResponse{AcceptSecrets: true}, nil
 }
 
 // Invoke dynamically executes a built-in function in the provider.
-func (k *kubeProvider) Invoke(context.Context, *pulumirpc.InvokeRequest) (*pulumirpc.InvokeResponse, error) {
-	panic("Invoke not implemented")
+func (k *kubeProvider) Invoke(
+	ctx context.Context, req *pulumirpc.InvokeRequest,
+) (*pulumirpc.InvokeResponse, error) {
+	tok := req.GetTok()
+	label := fmt.Sprintf("%s.Invoke(%s)", k.label(), tok)
+	glog.V(9).Infof("%s executing", label)
+
+	args, err := plugin.UnmarshalProperties(req.GetArgs(), plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.args", label), KeepUnknowns: false, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result resource.PropertyMap
+	switch tok {
+	case renderManifestTok:
+		result, err = k.renderManifest(args)
+	default:
+		return nil, fmt.Errorf("unknown Invoke type '%s'", tok)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := plugin.MarshalProperties(result, plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.returns", label), KeepUnknowns: false, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pulumirpc.InvokeResponse{Return: ret}, nil
 }
 
 // Check validates that the given property bag is valid for a resource of the given type and returns
@@ -202,7 +396,7 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 	// an update.
 	oldResInputs := req.GetOlds()
 	olds, err := plugin.UnmarshalProperties(oldResInputs, plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
@@ -213,23 +407,37 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 	// an update.
 	newResInputs := req.GetNews()
 	news, err := plugin.UnmarshalProperties(newResInputs, plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	newInputs := propMapToUnstructured(news)
+	secrets, err := secretInputPaths(newResInputs)
+	if err != nil {
+		return nil, err
+	}
 
 	var failures []*pulumirpc.CheckFailure
 
-	// If annotations with the prefix `pulumi.com/` exist, report that as error.
+	// If annotations with the prefix `pulumi.com/` exist, report that as error, unless they are among
+	// the annotations users are allowed to set.
 	for k := range newInputs.GetAnnotations() {
-		if strings.HasPrefix(k, annotationInternalPrefix) {
+		if strings.HasPrefix(k, annotationInternalPrefix) && !await.UserAnnotations[k] {
 			failures = append(failures, &pulumirpc.CheckFailure{
 				Reason: fmt.Sprintf("annotation '%s' uses illegal prefix `pulumi.com/internal`", k),
 			})
 		}
 	}
+	if _, err := await.TimeoutSeconds(newInputs); err != nil {
+		failures = append(failures, &pulumirpc.CheckFailure{Reason: err.Error()})
+	}
+	if _, _, err := await.EndpointSettleSeconds(newInputs); err != nil {
+		failures = append(failures, &pulumirpc.CheckFailure{Reason: err.Error()})
+	}
+	if _, err := await.DeletionPropagationPolicy(newInputs, k.awaitOptions); err != nil {
+		failures = append(failures, &pulumirpc.CheckFailure{Reason: err.Error()})
+	}
 
 	// Adopt name from old object if appropriate.
 	//
@@ -237,7 +445,15 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 	// autonamed in `.metadata.annotations`. This makes it easier for `Diff` to decide whether this
 	// needs to be `DeleteBeforeReplace`'d. If the resource is marked `DeleteBeforeReplace`, then
 	// `Create` will allocate it a new name later.
-	if len(oldInputs.Object) > 0 {
+	if isPatchURN(urn) {
+		// Patch resources modify existing objects, so they must name the object to patch.
+		if newInputs.GetName() == "" {
+			failures = append(failures, &pulumirpc.CheckFailure{
+				Reason: fmt.Sprintf("%s resources must specify `.metadata.name` of the object to patch",
+					urn.Type()),
+			})
+		}
+	} else if len(oldInputs.Object) > 0 {
 		// NOTE: If old inputs exist, they have a name, either provided by the user or filled in with a
 		// previous run of `Check`.
 		contract.Assert(oldInputs.GetName() != "")
@@ -248,10 +464,40 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 
 	gvk := k.gvkFromURN(urn)
 
-	// Get OpenAPI schema for the GVK.
-	err = openapi.ValidateAgainstSchema(k.client, newInputs)
-	// Validate the object according to the OpenAPI schema.
-	if err != nil {
+	// Warn about apiVersions that are deprecated, or already removed from the cluster, so that users
+	// can migrate before the cluster is upgraded underneath them.
+	if warning, deprecated := deprecationWarning(gvk, k.clusterVersion()); deprecated {
+		if k.host != nil {
+			_ = k.host.Log(ctx, diag.Warning, urn, warning)
+		}
+	}
+
+	// Place namespaced objects that don't specify a namespace in the provider's default namespace.
+	if k.defaultNamespace != "" && newInputs.GetNamespace() == "" && k.isNamespacedKind(gvk) {
+		newInputs.SetNamespace(k.defaultNamespace)
+	}
+
+	// Get OpenAPI schema for the GVK, and validate the object according to it, reporting every field
+	// that doesn't conform as a check failure. Custom resources are validated against the schema of
+	// their CustomResourceDefinition, which may not be created yet. Patches specify only some fields,
+	// so they are validated by the API server when they're applied instead.
+	if openapi.IsCustomResourceDefinition(newInputs) {
+		k.rememberCustomResourceSchemas(newInputs, true)
+	}
+	if !isPatchURN(urn) {
+		if crSchema, isCR := k.customResourceSchema(newInputs.GroupVersionKind()); isCR {
+			err = openapi.ValidateAgainstCustomResourceSchema(withoutNulls(newInputs), crSchema)
+		} else {
+			err = openapi.ValidateAgainstSchema(k.client, withoutNulls(newInputs))
+		}
+	}
+	if schemaErr, invalid := err.(*openapi.SchemaError); invalid {
+		for _, fieldErr := range schemaErr.Errors {
+			failures = append(failures, &pulumirpc.CheckFailure{
+				Property: fieldErr.Field, Reason: fieldErr.Message,
+			})
+		}
+	} else if err != nil {
 		resourceNotFound := errors.IsNotFound(err) ||
 			strings.Contains(err.Error(), "is not supported by the server")
 		if resourceNotFound && gvkExists(gvk) {
@@ -263,9 +509,35 @@ func (k *kubeProvider) Check(ctx context.Context, req *pulumirpc.CheckRequest) (
 		}
 	}
 
+	// Validate IP family settings against the cluster, so that users get an actionable error rather
+	// than an obscure rejection from the API server, or an await that never completes.
+	if schemaGroupName(gvk.Group) == "" && gvk.Kind == "Service" {
+		_, hasFamilies := openapi.Pluck(newInputs.Object, "spec", "ipFamilies")
+		_, hasPolicy := openapi.Pluck(newInputs.Object, "spec", "ipFamilyPolicy")
+		if hasFamilies || hasPolicy {
+			cluster := k.clusterIPFamilies()
+			for _, reason := range checkIPFamilies(newInputs, cluster) {
+				failures = append(failures, &pulumirpc.CheckFailure{Reason: reason})
+			}
+			for _, warning := range ipFamilyWarnings(newInputs, cluster) {
+				if k.host != nil {
+					_ = k.host.Log(ctx, diag.Warning, urn, warning)
+				}
+			}
+		}
+	}
+
+	// Mark the data of Secrets as secret too, so that it isn't exposed by the inputs in the
+	// checkpoint, or by the diff in a preview.
+	checkedInputs := markSecretPaths(resource.NewPropertyMapFromMap(newInputs.Object), secrets)
+	if k.hidesSecretData(newInputs) {
+		markSecretFields(checkedInputs)
+	}
 	autonamedInputs, err := plugin.MarshalProperties(
-		resource.NewPropertyMapFromMap(newInputs.Object), plugin.MarshalOptions{
-			Label: fmt.Sprintf("%s.autonamedInputs", label), KeepUnknowns: true, SkipNulls: true,
+		checkedInputs,
+		plugin.MarshalOptions{
+			Label: fmt.Sprintf("%s.autonamedInputs", label), KeepUnknowns: true, SkipNulls: false,
+			KeepSecrets: k.enableSecrets,
 		})
 	if err != nil {
 		return nil, err
@@ -301,33 +573,57 @@ func (k *kubeProvider) Diff(
 	// previous resource inputs supplied by the user, and `live` is the computed state of that inputs
 	// we received back from the API server.
 	oldState, err := plugin.UnmarshalProperties(req.GetOlds(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
-	oldInputs, _ := parseCheckpointObject(oldState)
+	oldInputs, oldLive := parseCheckpointObject(oldState)
 
 	// Get new resouce inputs. The user is submitting these as an update.
 	newResInputs, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	newInputs := propMapToUnstructured(newResInputs)
+	secrets, err := secretInputPaths(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
 
 	// Decide whether to replace the resource.
-	replaces, err := forceNewProperties(oldInputs.Object, newInputs.Object, k.gvkFromURN(urn))
+	var replaces []string
+	if isPatchURN(urn) {
+		replaces, err = patchForceNewProperties(oldInputs.Object, newInputs.Object)
+	} else {
+		replaces, err = forceNewProperties(oldInputs.Object, newInputs.Object, k.gvkFromURN(urn))
+		replaces = append(replaces, apiVersionForceNewProperties(oldInputs, newInputs)...)
+	}
 	if err != nil {
 		return nil, err
 	}
+	if len(replaces) > 0 && k.host != nil {
+		_ = k.host.Log(ctx, diag.Info, urn, replacementMessage(newInputs.GetKind(), replaces))
+	}
 
 	// Pack up PB, ship response back.
 	hasChanges := pulumirpc.DiffResponse_DIFF_NONE
 	diff := gojsondiff.New().CompareObjects(oldInputs.Object, newInputs.Object)
 	if len(diff.Deltas()) > 0 {
 		hasChanges = pulumirpc.DiffResponse_DIFF_SOME
+
+		if k.renderYAMLDiff || k.enableDryRun {
+			k.logYAMLDiff(ctx, urn, withAPIVersionOf(oldInputs, newInputs), newInputs, oldLive,
+				len(replaces) > 0, secrets)
+		}
+	}
+
+	// If the last await of the object was interrupted (e.g., by a cancellation), report a change even
+	// if the inputs are identical, so that `Update` runs and resumes the await.
+	if progress, exists := parseAwaitProgress(oldState); exists && !progress.complete {
+		hasChanges = pulumirpc.DiffResponse_DIFF_SOME
 	}
 
 	// Delete before replacement if we are forced to replace the old object, and the new version of
@@ -374,17 +670,46 @@ func (k *kubeProvider) Create(
 	label := fmt.Sprintf("%s.Create(%s)", k.label(), urn)
 	glog.V(9).Infof("%s executing", label)
 
+	if k.offline && k.renderDir == "" {
+		return nil, offlineError("create", urn)
+	}
+
 	// Obtain client from pool for the resource we're creating.
 	newResInputs, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.properties", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.properties", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	newInputs := propMapToUnstructured(newResInputs)
+	secrets, err := secretInputPaths(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
 
-	initialized, awaitErr := await.Creation(k.canceler.context, k.host, k.pool, k.client,
-		resource.URN(req.GetUrn()), newInputs)
+	if k.renderDir != "" {
+		rendered, err := k.renderObject(label, urn, nil, newInputs, secrets)
+		if err != nil {
+			return nil, err
+		}
+		return &pulumirpc.CreateResponse{Id: client.FqObjName(newInputs), Properties: rendered}, nil
+	}
+
+	var initialized *unstructured.Unstructured
+	var awaitErr error
+	if isPatchURN(urn) {
+		// The patched object is owned by someone else, so we don't await its readiness.
+		if initialized, err = k.applyPatch(urn, newInputs); err != nil {
+			return nil, err
+		}
+	} else {
+		submitted, err := k.submittedObject(urn, withoutNulls(newInputs))
+		if err != nil {
+			return nil, err
+		}
+		initialized, awaitErr = await.Creation(k.canceler.context, k.host, k.pool, k.client,
+			k.awaitOptions, resource.URN(req.GetUrn()), submitted)
+	}
 	if awaitErr != nil {
 		var getErr error
 		initialized, getErr = k.readLiveObject(newInputs)
@@ -397,8 +722,11 @@ func (k *kubeProvider) Create(
 	}
 
 	inputsAndComputed, err := plugin.MarshalProperties(
-		checkpointObject(newInputs, initialized), plugin.MarshalOptions{
-			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: true,
+		withAwaitProgress(
+			k.checkpointObject(newInputs, initialized, secrets), initialized, awaitErr == nil),
+		plugin.MarshalOptions{
+			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: false,
+			KeepSecrets: k.enableSecrets,
 		})
 	if err != nil {
 		return nil, err
@@ -437,41 +765,92 @@ func (k *kubeProvider) Read(ctx context.Context, req *pulumirpc.ReadRequest) (*p
 	label := fmt.Sprintf("%s.Update(%s)", k.label(), urn)
 	glog.V(9).Infof("%s executing", label)
 
+	// Rendered manifests are never changed by anyone else, so the checkpoint is up to date.
+	if k.renderDir != "" {
+		return &pulumirpc.ReadResponse{Id: req.GetId(), Properties: req.GetProperties()}, nil
+	}
+	if k.offline {
+		return nil, offlineError("read", urn)
+	}
+
 	// Obtain new properties, create a Kubernetes `unstructured.Unstructured` that we can pass to the
 	// validation routines.
 	oldState, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	// Ignore old state; we'll get it from Kubernetes later.
-	oldInputs, _ := parseCheckpointObject(oldState)
+	oldInputs, oldLive := parseCheckpointObject(oldState)
+	secrets, err := secretInputPaths(req.GetProperties())
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the object at the apiVersion declared in the program (which is encoded in the URN's type),
+	// rather than whatever version the server prefers. The API server converts between versions, so
+	// this keeps the checkpoint in the same version as the inputs it's compared with, and means
+	// programs keep working when the cluster changes its preferred version.
+	gvk := k.gvkFromURN(urn)
+	gvk.Group = schemaGroupName(gvk.Group)
+	oldInputs = withDeclaredIdentity(oldInputs, gvk, req.GetId())
 
-	liveObj, readErr := await.Read(k.canceler.context, k.host, k.pool, k.client,
-		resource.URN(req.GetUrn()), oldInputs)
+	var liveObj *unstructured.Unstructured
+	var readErr error
+	if isPatchURN(urn) {
+		liveObj, readErr = k.readLiveObject(oldInputs)
+	} else {
+		liveObj, readErr = await.Read(k.canceler.context, k.host, k.pool, k.client, k.awaitOptions,
+			resource.URN(req.GetUrn()), oldInputs)
+	}
 	if readErr != nil {
 		glog.V(3).Infof("%v", readErr)
 
-		statusErr, ok := readErr.(*errors.StatusError)
-		if ok && statusErr.ErrStatus.Code == 404 {
+		if isNotFound(readErr) {
 			// If it's a 404 error, this resource was probably deleted.
 			return &pulumirpc.ReadResponse{Id: "", Properties: nil}, nil
 		}
 
-		initErr, ok := readErr.(await.InitializationError)
-		if ok {
+		if initErr, ok := readErr.(*failure.InitializationError); ok && initErr.Object != nil {
 			glog.V(3).Infof("is init err")
-			liveObj = initErr.Object()
+			liveObj = initErr.Object
+		} else {
+			// The read logic for this kind failed without retrieving the object (e.g., it couldn't
+			// watch a dependent object), so fall back to retrieving the object itself, so that the
+			// refreshed state reflects the live object regardless.
+			var getErr error
+			liveObj, getErr = k.readLiveObject(oldInputs)
+			if isNotFound(getErr) {
+				return &pulumirpc.ReadResponse{Id: "", Properties: nil}, nil
+			} else if getErr != nil {
+				return nil, readErr
+			}
 		}
 		// If we get here, resource successfully registered with the API server, but failed to
 		// initialize.
 	}
 
+	// If we're importing the object, i.e., there is no previous state, synthesize its inputs.
+	if len(oldState) == 0 && liveObj != nil && !isPatchURN(urn) {
+		oldInputs = importedInputs(liveObj, gvk, req.GetId())
+	}
+
+	// If the object was checkpointed as a content hash, report drift by comparing hashes.
+	if oldHash, compacted := contentHashOf(oldLive); compacted && liveObj != nil {
+		if newHash := contentHash(liveObj); newHash != oldHash && k.host != nil {
+			_ = k.host.Log(ctx, diag.Info, urn, fmt.Sprintf(
+				"Contents of '%s' have changed since they were last checkpointed (content hash %s -> %s)",
+				client.FqObjName(liveObj), oldHash, newHash))
+		}
+	}
+
 	// Return a new "checkpoint object".
 	inputsAndComputed, err := plugin.MarshalProperties(
-		checkpointObject(oldInputs, liveObj), plugin.MarshalOptions{
-			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: true,
+		withAwaitProgress(k.checkpointObject(oldInputs, liveObj, secrets), liveObj, readErr == nil),
+		plugin.MarshalOptions{
+			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: false,
+			KeepSecrets: k.enableSecrets,
 		})
 	if err != nil {
 		return nil, err
@@ -520,7 +899,7 @@ func (k *kubeProvider) Update(
 	// server must decide how to apply the changes inside it, to the version of the resource that it
 	// has stored in etcd. In Kubernetes this decision is turns out to be quite complex. `kubectl`
 	// currently uses the three-way "strategic merge" and falls back to the three-way JSON merge. We
-	// currently support the second, but eventually we'll have to support the first, too.
+	// do the same.
 	//
 	// (NOTE: This comment is scoped to the question of how to patch an existing resource, rather than
 	// how to recognize when a resource needs to be re-created from scratch.)
@@ -546,20 +925,24 @@ func (k *kubeProvider) Update(
 	// So the roadmap is:
 	//
 	// - [x] Implement `Update` using the three-way JSON merge strategy.
-	// - [ ] Cause `Update` to default to the three-way JSON merge patch strategy. (This will require
-	//       plumbing, because it expects nominal types representing the API schema, but the
-	//       discovery client is completely dynamic.)
-	// - [ ] Support server-side apply, when it comes out.
+	// - [x] Cause `Update` to default to the three-way strategic merge patch strategy, using the
+	//       patch metadata in the OpenAPI spec exposed by the API server, and falling back to JSON
+	//       merge for custom resources, which don't support strategic merge.
+	// - [x] Support server-side apply (opt-in, with the provider's `enableServerSideApply` config).
 	//
 
 	urn := resource.URN(req.GetUrn())
 	label := fmt.Sprintf("%s.Update(%s)", k.label(), urn)
 	glog.V(9).Infof("%s executing", label)
 
+	if k.offline && k.renderDir == "" {
+		return nil, offlineError("update", urn)
+	}
+
 	// Obtain new properties, create a Kubernetes `unstructured.Unstructured` that we can pass to the
 	// validation routines.
 	oldState, err := plugin.UnmarshalProperties(req.GetOlds(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
@@ -570,16 +953,50 @@ func (k *kubeProvider) Update(
 	// Obtain new properties, create a Kubernetes `unstructured.Unstructured` that we can pass to the
 	// validation routines.
 	newResInputs, err := plugin.UnmarshalProperties(req.GetNews(), plugin.MarshalOptions{
-		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: true,
+		Label: fmt.Sprintf("%s.news", label), KeepUnknowns: true, SkipNulls: false,
 	})
 	if err != nil {
 		return nil, err
 	}
 	newInputs := propMapToUnstructured(newResInputs)
+	secrets, err := secretInputPaths(req.GetNews())
+	if err != nil {
+		return nil, err
+	}
+
+	if k.renderDir != "" {
+		rendered, err := k.renderObject(label, urn, oldInputs, newInputs, secrets)
+		if err != nil {
+			return nil, err
+		}
+		return &pulumirpc.UpdateResponse{Properties: rendered}, nil
+	}
 
-	// Apply update.
-	initialized, awaitErr := await.Update(k.canceler.context, k.host, k.pool, k.client,
-		resource.URN(req.GetUrn()), oldInputs, newInputs)
+	// If the program moved the object to another apiVersion that serves it (i.e., the resource is an
+	// alias of its previous type), read and patch it through the new one.
+	oldInputs = withAPIVersionOf(oldInputs, newInputs)
+
+	// Apply update. If the await for the last update of this object was interrupted, and the inputs
+	// haven't changed since, the update has already been applied, so we simply resume verifying the
+	// in-flight rollout rather than re-applying it.
+	var initialized *unstructured.Unstructured
+	var awaitErr error
+	if isPatchURN(urn) {
+		if initialized, err = k.applyPatch(urn, newInputs); err != nil {
+			return nil, err
+		}
+	} else if k.canResumeAwait(ctx, urn, oldState, oldInputs, newInputs) {
+		initialized, awaitErr = await.ResumeUpdate(k.canceler.context, k.host, k.pool, k.client,
+			k.awaitOptions, resource.URN(req.GetUrn()), newInputs)
+	} else {
+		submitted, err := k.submittedObject(urn, newInputs)
+		if err != nil {
+			return nil, err
+		}
+		initialized, awaitErr = await.Update(k.canceler.context, k.host, k.pool, k.client,
+			k.awaitOptions, resource.URN(req.GetUrn()), oldInputs, submitted)
+		awaitErr = immutableFieldsError(newInputs.GetKind(), awaitErr)
+	}
 	if awaitErr != nil {
 		var getErr error
 		initialized, getErr = k.readLiveObject(newInputs)
@@ -593,8 +1010,11 @@ func (k *kubeProvider) Update(
 
 	// Return a new "checkpoint object".
 	inputsAndComputed, err := plugin.MarshalProperties(
-		checkpointObject(newInputs, initialized), plugin.MarshalOptions{
-			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: true,
+		withAwaitProgress(
+			k.checkpointObject(newInputs, initialized, secrets), initialized, awaitErr == nil),
+		plugin.MarshalOptions{
+			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: false,
+			KeepSecrets: k.enableSecrets,
 		})
 	if err != nil {
 		return nil, err
@@ -618,14 +1038,50 @@ func (k *kubeProvider) Delete(
 	label := fmt.Sprintf("%s.Delete(%s)", k.label(), urn)
 	glog.V(9).Infof("%s executing", label)
 
+	if k.offline && k.renderDir == "" {
+		return nil, offlineError("delete", urn)
+	}
+
 	// TODO(hausdorff): Propagate other options, like grace period through flags.
 
 	gvk := k.gvkFromURN(resource.URN(req.GetUrn()))
 	gvk.Group = schemaGroupName(gvk.Group)
 
-	namespace, name := client.ParseFqName(req.GetId())
+	// Recover the annotations of the last submitted version of the object, which may configure
+	// how we await its deletion.
+	oldState, err := plugin.UnmarshalProperties(req.GetProperties(), plugin.MarshalOptions{
+		Label: fmt.Sprintf("%s.olds", label), KeepUnknowns: true, SkipNulls: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	oldInputs, _ := parseCheckpointObject(oldState)
 
-	err := await.Deletion(k.canceler.context, k.host, k.pool, k.client, gvk, namespace, name)
+	if k.renderDir != "" {
+		if err := removeRenderedManifest(k.renderDir, oldInputs); err != nil {
+			return nil, err
+		}
+		return &pbempty.Empty{}, nil
+	}
+
+	namespace, name := client.ParseFqName(req.GetId())
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	obj.SetAnnotations(oldInputs.GetAnnotations())
+
+	if isPatchURN(urn) {
+		err = k.relinquishPatch(urn, obj)
+	} else if isNamespace(gvk) {
+		// Don't take objects the stack still manages down with the Namespace.
+		if err = k.checkNamespaceUnused(urn, name, obj); err == nil {
+			err = await.Deletion(k.canceler.context, k.host, k.pool, k.client, k.awaitOptions, urn,
+				obj)
+		}
+	} else {
+		err = await.Deletion(k.canceler.context, k.host, k.pool, k.client, k.awaitOptions, urn, obj)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -656,6 +1112,12 @@ func (k *kubeProvider) Cancel(context.Context, *pbempty.Empty) (*pbempty.Empty,
 
 // --------------------------------------------------------------------------
 
+// offlineError reports that `op` can't be applied to `urn` in offline mode.
+func offlineError(op string, urn resource.URN) error {
+	return fmt.Errorf("unable to %s '%s': %v; unset offlineKubernetesVersion to deploy",
+		op, urn.Name(), client.ErrOffline)
+}
+
 func (k *kubeProvider) label() string {
 	return fmt.Sprintf("Provider[%s]", k.name)
 }
@@ -672,8 +1134,21 @@ func (k *kubeProvider) gvkFromURN(urn resource.URN) schema.GroupVersionKind {
 	return schema.GroupVersionKind{
 		Group:   gv[0],
 		Version: gv[1],
-		Kind:    gvk[1],
+		Kind:    strings.TrimSuffix(gvk[1], patchQualifier),
+	}
+}
+
+// isNamespacedKind returns true if objects of kind `gvk` are namespaced. Kinds the API server doesn't
+// know yet (e.g., those of CRDs created by the same program) are assumed to be namespaced, as most
+// are; the API server ignores the namespace of cluster-scoped objects anyway.
+func (k *kubeProvider) isNamespacedKind(gvk schema.GroupVersionKind) bool {
+	gvk.Group = schemaGroupName(gvk.Group)
+	namespaced, err := client.IsNamespacedKind(gvk, k.client)
+	if err != nil {
+		glog.V(3).Infof("Unable to determine whether %s is namespaced: %v", gvk, err)
+		return true
 	}
+	return namespaced
 }
 
 func (k *kubeProvider) readLiveObject(
@@ -690,6 +1165,11 @@ func (k *kubeProvider) readLiveObject(
 	return clientForResource.Get(obj.GetName(), metav1.GetOptions{})
 }
 
+// isNotFound returns true if `err` (or the error it wraps) reports that the object doesn't exist.
+func isNotFound(err error) bool {
+	return err != nil && errors.IsNotFound(pkgerrors.Cause(err))
+}
+
 func schemaGroupName(group string) string {
 	switch group {
 	case "core":
@@ -703,12 +1183,46 @@ func propMapToUnstructured(pm resource.PropertyMap) *unstructured.Unstructured {
 	return &unstructured.Unstructured{Object: pm.Mappable()}
 }
 
+// checkpointObject produces the checkpoint object for `live`, compacting it first if it is larger
+// than the configured threshold. The values at the paths of the secret inputs `secrets` are marked
+// secret.
+func (k *kubeProvider) checkpointObject(
+	inputs, live *unstructured.Unstructured, secrets []propertyPath,
+) resource.PropertyMap {
+	object := checkpointObject(inputs, compactLiveObject(live, k.compactStateThreshold))
+	if k.hidesSecretData(live) {
+		object = markSecretData(object)
+	}
+	if k.enableSecrets {
+		object = markSecretInputs(object, secrets)
+	}
+	return object
+}
+
 func checkpointObject(inputs, live *unstructured.Unstructured) resource.PropertyMap {
-	object := resource.NewPropertyMapFromMap(live.Object)
+	object := resource.NewPropertyMapFromMap(withoutNulls(pruneServerMetadata(live)).Object)
 	object["__inputs"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(inputs.Object))
 	return object
 }
 
+// serverMetadataFields are the paths of fields the API server maintains purely for its own
+// bookkeeping. They can be very large (e.g., `managedFields` records every field owned by every
+// manager), change on nearly every write, and are never needed by `Diff` or `Update`, so we omit
+// them from the checkpointed state. Server-side apply conflicts are detected by the API server
+// against the live object, so dropping `managedFields` from state does not affect them.
+var serverMetadataFields = [][]string{
+	{"metadata", "managedFields"},
+}
+
+// pruneServerMetadata returns a copy of `live` without `serverMetadataFields`.
+func pruneServerMetadata(live *unstructured.Unstructured) *unstructured.Unstructured {
+	pruned := live.DeepCopy()
+	for _, path := range serverMetadataFields {
+		unstructured.RemoveNestedField(pruned.Object, path...)
+	}
+	return pruned
+}
+
 func parseCheckpointObject(obj resource.PropertyMap) (oldInputs, live *unstructured.Unstructured) {
 	pm := obj.Mappable()
 
@@ -723,6 +1237,7 @@ func parseCheckpointObject(obj resource.PropertyMap) (oldInputs, live *unstructu
 		} else {
 			inputs = map[string]interface{}{}
 		}
+		delete(liveMap.(map[string]interface{}), awaitProgressKey)
 	}
 
 	oldInputs = &unstructured.Unstructured{Object: inputs.(map[string]interface{})}
@@ -730,10 +1245,75 @@ func parseCheckpointObject(obj resource.PropertyMap) (oldInputs, live *unstructu
 	return
 }
 
+// awaitProgressKey is the checkpoint property that records whether the await for the last
+// `Create`, `Update`, or `Read` of an object completed, and which generation of the object it was
+// verifying. If `pulumi up` is interrupted during a long rollout, the next `Update` uses this to
+// resume verification of the in-flight rollout, rather than re-applying the update.
+const awaitProgressKey = "__awaitProgress"
+
+type awaitProgress struct {
+	generation int64
+	complete   bool
+}
+
+func withAwaitProgress(
+	checkpoint resource.PropertyMap, live *unstructured.Unstructured, complete bool,
+) resource.PropertyMap {
+	if live == nil {
+		return checkpoint
+	}
+	checkpoint[awaitProgressKey] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(
+		map[string]interface{}{
+			"generation": float64(live.GetGeneration()),
+			"complete":   complete,
+		}))
+	return checkpoint
+}
+
+func parseAwaitProgress(checkpoint resource.PropertyMap) (awaitProgress, bool) {
+	raw, exists := checkpoint[awaitProgressKey]
+	if !exists || !raw.IsObject() {
+		return awaitProgress{}, false
+	}
+	progress := raw.ObjectValue().Mappable()
+	generation, hasGeneration := progress["generation"].(float64)
+	complete, hasComplete := progress["complete"].(bool)
+	if !hasGeneration || !hasComplete {
+		return awaitProgress{}, false
+	}
+	return awaitProgress{generation: int64(generation), complete: complete}, true
+}
+
+// canResumeAwait returns true if the last await for this object was interrupted, the inputs are
+// unchanged since, and no one else has modified the object in the meantime.
+func (k *kubeProvider) canResumeAwait(
+	ctx context.Context, urn resource.URN, oldState resource.PropertyMap,
+	oldInputs, newInputs *unstructured.Unstructured,
+) bool {
+	progress, hasProgress := parseAwaitProgress(oldState)
+	if !hasProgress || progress.complete {
+		return false
+	}
+	if len(gojsondiff.New().CompareObjects(oldInputs.Object, newInputs.Object).Deltas()) > 0 {
+		return false
+	}
+	live, err := k.readLiveObject(newInputs)
+	if err != nil || live.GetGeneration() != progress.generation {
+		return false
+	}
+
+	if k.host != nil {
+		_ = k.host.Log(ctx, diag.Info, urn, fmt.Sprintf(
+			"Resuming await of in-flight update to '%s' (generation %d)",
+			client.FqObjName(live), progress.generation))
+	}
+	return true
+}
+
 func initializationError(id string, err error, inputsAndComputed *structpb.Struct) error {
 	reasons := []string{err.Error()}
-	if aggregate, isAggregate := err.(await.AggregatedError); isAggregate {
-		reasons = append(reasons, aggregate.SubErrors()...)
+	if f, isFailure := failure.As(err); isFailure {
+		reasons = append(reasons, f.Failure().SubErrors...)
 	}
 	detail := pulumirpc.ErrorResourceInitFailed{
 		Id:         id,
@@ -743,6 +1323,23 @@ func initializationError(id string, err error, inputsAndComputed *structpb.Struc
 	return rpcerror.WithDetails(rpcerror.New(codes.Unknown, err.Error()), &detail)
 }
 
+// withDeclaredIdentity returns a copy of `inputs` with the declared GVK, and (if they are missing,
+// e.g., for state written by old versions of the provider) the name and namespace from `id`.
+func withDeclaredIdentity(
+	inputs *unstructured.Unstructured, gvk schema.GroupVersionKind, id string,
+) *unstructured.Unstructured {
+	obj := inputs.DeepCopy()
+	obj.SetGroupVersionKind(gvk)
+	if obj.GetName() == "" && id != "" {
+		namespace, name := client.ParseFqName(id)
+		obj.SetName(name)
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+	}
+	return obj
+}
+
 // canonicalNamespace will provides the canonical name for a namespace. Specifically, if the
 // namespace is "", the empty string, we report this as its canonical name, "default".
 func canonicalNamespace(ns string) string {
@@ -15,11 +15,23 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	pkgerrors "github.com/pkg/errors"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
 	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 )
 
 var (
@@ -73,6 +85,29 @@ func TestCheckpointObject(t *testing.T) {
 	assert.Equal(t, objLive, obj.Mappable())
 }
 
+func TestCheckpointObjectOmitsServerMetadata(t *testing.T) {
+	inputs := &unstructured.Unstructured{Object: objInputs}
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":            "foo",
+			"resourceVersion": "1234",
+			"managedFields": []interface{}{
+				map[string]interface{}{"manager": "kubectl", "operation": "Apply"},
+			},
+		},
+	}}
+
+	obj := checkpointObject(inputs, live)
+	metadata := obj["metadata"].Mappable().(map[string]interface{})
+	assert.Equal(t, "foo", metadata["name"])
+	assert.Equal(t, "1234", metadata["resourceVersion"])
+	assert.NotContains(t, metadata, "managedFields")
+
+	// The live object itself must not be modified.
+	_, found, _ := unstructured.NestedSlice(live.Object, "metadata", "managedFields")
+	assert.True(t, found)
+}
+
 func TestRoundtripCheckpointObject(t *testing.T) {
 	old := resource.NewPropertyMapFromMap(objLive)
 	old["__inputs"] = resource.NewObjectProperty(resource.NewPropertyMapFromMap(objInputs))
@@ -88,3 +123,150 @@ func TestRoundtripCheckpointObject(t *testing.T) {
 	assert.Equal(t, oldInputs, newInputs)
 	assert.Equal(t, oldLive, newLive)
 }
+
+func TestAwaitProgressRoundtrip(t *testing.T) {
+	inputs := &unstructured.Unstructured{Object: objInputs}
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "foo", "generation": int64(3)},
+	}}
+
+	obj := withAwaitProgress(checkpointObject(inputs, live), live, false)
+	progress, found := parseAwaitProgress(obj)
+	assert.True(t, found)
+	assert.Equal(t, awaitProgress{generation: 3, complete: false}, progress)
+
+	// Progress is not part of the live object.
+	_, parsedLive := parseCheckpointObject(obj)
+	assert.NotContains(t, parsedLive.Object, awaitProgressKey)
+
+	// Old checkpoints have no progress recorded.
+	_, found = parseAwaitProgress(checkpointObject(inputs, live))
+	assert.False(t, found)
+}
+
+func TestResumeInterruptedAwait(t *testing.T) {
+	// The await of the last update of this ConfigMap was cancelled, and the inputs haven't changed.
+	inputs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":        "foo",
+			"namespace":   "default",
+			"annotations": map[string]interface{}{"pulumi.com/skipAwait": "true"},
+		},
+		"data": map[string]interface{}{"foo": "bar"},
+	}}
+	live := inputs.DeepCopy()
+	live.SetResourceVersion("42")
+
+	var methods []string
+	k, closeServer := fakeAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/namespaces/default/configmaps/foo" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		data, _ := live.MarshalJSON()
+		_, _ = w.Write(data)
+	})
+	defer closeServer()
+
+	urn := patchTestURN("kubernetes:core/v1:ConfigMap", "foo")
+	olds, err := plugin.MarshalProperties(
+		withAwaitProgress(checkpointObject(inputs, live), live, false), plugin.MarshalOptions{})
+	assert.NoError(t, err)
+	news, err := plugin.MarshalProperties(
+		resource.NewPropertyMapFromMap(inputs.Object), plugin.MarshalOptions{})
+	assert.NoError(t, err)
+
+	// Diff reports a change, so that the engine calls Update to resume the await.
+	diff, err := k.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Urn: string(urn), Olds: olds, News: news,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_SOME, diff.GetChanges())
+	assert.Empty(t, diff.GetReplaces())
+
+	// Update resumes the await rather than re-applying the inputs.
+	update, err := k.Update(context.Background(), &pulumirpc.UpdateRequest{
+		Urn: string(urn), Olds: olds, News: news,
+	})
+	assert.NoError(t, err)
+	for _, method := range methods {
+		assert.Equal(t, http.MethodGet, method, "Should only read the object")
+	}
+
+	state, err := plugin.UnmarshalProperties(update.GetProperties(), plugin.MarshalOptions{})
+	assert.NoError(t, err)
+	progress, found := parseAwaitProgress(state)
+	assert.True(t, found)
+	assert.True(t, progress.complete, "Should record the resumed await as complete")
+
+	// Once the await has completed, identical inputs no longer report a change.
+	diff, err = k.Diff(context.Background(), &pulumirpc.DiffRequest{
+		Urn: string(urn), Olds: update.GetProperties(), News: news,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pulumirpc.DiffResponse_DIFF_NONE, diff.GetChanges())
+}
+
+func TestWithDeclaredIdentity(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1beta1", Kind: "Deployment"}
+
+	// The declared GVK wins over whatever is recorded in the inputs.
+	inputs := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "foo", "namespace": "bar"},
+	}}
+	obj := withDeclaredIdentity(inputs, gvk, "bar/foo")
+	assert.Equal(t, "apps/v1beta1", obj.GetAPIVersion())
+	assert.Equal(t, "apps/v1", inputs.GetAPIVersion(), "Expected inputs not to be modified")
+
+	// Missing names are recovered from the ID.
+	obj = withDeclaredIdentity(&unstructured.Unstructured{Object: map[string]interface{}{}}, gvk, "bar/foo")
+	assert.Equal(t, "foo", obj.GetName())
+	assert.Equal(t, "bar", obj.GetNamespace())
+	assert.Equal(t, "Deployment", obj.GetKind())
+}
+
+func TestIsNotFound(t *testing.T) {
+	notFound := errors.NewNotFound(schema.GroupResource{Resource: "services"}, "web")
+	assert.True(t, isNotFound(notFound))
+	assert.True(t, isNotFound(pkgerrors.Wrap(notFound, "Could not read Service 'web'")))
+	assert.False(t, isNotFound(fmt.Errorf("connection refused")))
+	assert.False(t, isNotFound(nil))
+}
+
+// fakeAPIServer starts an API server that serves discovery of the core `v1` API (with ConfigMaps
+// only), and hands every other request to `handle`. It returns a provider connected to it, and a
+// function that stops the server.
+func fakeAPIServer(t *testing.T, handle http.HandlerFunc) (*kubeProvider, func()) {
+	discovery := map[string]string{
+		"/api":  `{"kind": "APIVersions", "versions": ["v1"]}`,
+		"/apis": `{"kind": "APIGroupList", "apiVersion": "v1", "groups": []}`,
+		"/api/v1": `{"kind": "APIResourceList", "groupVersion": "v1", "resources": [
+			{"name": "configmaps", "namespaced": true, "kind": "ConfigMap",
+			 "verbs": ["create", "delete", "get", "list", "patch", "update", "watch"]}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if body, isDiscovery := discovery[r.URL.Path]; isDiscovery {
+			_, _ = io.WriteString(w, body)
+			return
+		}
+		handle(w, r)
+	}))
+
+	disco, pool, err := client.NewClients(&rest.Config{Host: server.URL})
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	return &kubeProvider{
+		canceler:       makeCancellationContext(),
+		client:         disco,
+		pool:           pool,
+		providerPrefix: "kubernetes" + gvkDelimiter,
+	}, server.Close
+}
@@ -0,0 +1,116 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// --------------------------------------------------------------------------
+
+// Rendering manifests.
+//
+// With `renderYamlToDirectory`, the provider doesn't deploy to a cluster: it writes the manifest of
+// each object to the directory instead, e.g., for a GitOps tool to apply. CustomResourceDefinitions
+// are written to `0-crd/`, and all other objects to `1-manifest/`, so that applying the directories
+// in order creates CRDs before their custom resources. The manifest of an object is written when
+// it's created or updated, and removed when it's deleted. Objects are validated against a bundled
+// schema, as in offline mode (see `client.NewOfflineClients`), and since no API server fills in any
+// of their fields, their inputs are checkpointed as their live state.
+//
+// Manifests contain the values of secret inputs in plaintext, like any manifest would.
+
+// --------------------------------------------------------------------------
+
+const (
+	renderedCRDDir      = "0-crd"
+	renderedManifestDir = "1-manifest"
+)
+
+// renderedManifestPath returns the path of the manifest of `obj` in the render directory `dir`.
+func renderedManifestPath(dir string, obj *unstructured.Unstructured) string {
+	subdir := renderedManifestDir
+	if openapi.IsCustomResourceDefinition(obj) {
+		subdir = renderedCRDDir
+	}
+	parts := []string{
+		strings.Replace(obj.GetAPIVersion(), "/", "_", -1), strings.ToLower(obj.GetKind()),
+	}
+	if namespace := obj.GetNamespace(); namespace != "" {
+		parts = append(parts, namespace)
+	}
+	parts = append(parts, obj.GetName())
+	return filepath.Join(dir, subdir, strings.Join(parts, "-")+".yaml")
+}
+
+// writeRenderedManifest writes the manifest of `obj` to the render directory `dir`.
+func writeRenderedManifest(dir string, obj *unstructured.Unstructured) error {
+	path := renderedManifestPath(dir, obj)
+	text, err := yaml.Marshal(normalizeManifest(withoutNulls(obj)).Object)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, text, 0644)
+}
+
+// removeRenderedManifest removes the manifest of `obj` from the render directory `dir`, if it
+// exists.
+func removeRenderedManifest(dir string, obj *unstructured.Unstructured) error {
+	err := os.Remove(renderedManifestPath(dir, obj))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// renderObject writes the manifest of `obj` in render mode, in place of creating or updating it
+// (and removes the manifest of `oldObj`, if it's a different file), and returns its checkpoint.
+func (k *kubeProvider) renderObject(
+	label string, urn resource.URN, oldObj, obj *unstructured.Unstructured, secrets []propertyPath,
+) (*structpb.Struct, error) {
+	if isPatchURN(urn) {
+		return nil, fmt.Errorf(
+			"unable to render '%s': patches of existing objects can't be rendered to manifests", urn)
+	}
+	if err := writeRenderedManifest(k.renderDir, obj); err != nil {
+		return nil, err
+	}
+	if oldObj != nil &&
+		renderedManifestPath(k.renderDir, oldObj) != renderedManifestPath(k.renderDir, obj) {
+		if err := removeRenderedManifest(k.renderDir, oldObj); err != nil {
+			return nil, err
+		}
+	}
+
+	return plugin.MarshalProperties(
+		k.checkpointObject(obj, obj, secrets), plugin.MarshalOptions{
+			Label: fmt.Sprintf("%s.inputsAndComputed", label), KeepUnknowns: true, SkipNulls: false,
+			KeepSecrets: k.enableSecrets,
+		})
+}
@@ -0,0 +1,57 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRenderedManifestPath(t *testing.T) {
+	deployment := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "nginx", "namespace": "web"},
+	}}
+	assert.Equal(t, filepath.Join("out", "1-manifest", "apps_v1-deployment-web-nginx.yaml"),
+		renderedManifestPath("out", deployment))
+
+	crd := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "crontabs.stable.example.com"},
+	}}
+	assert.Equal(t,
+		filepath.Join("out", "0-crd",
+			"apiextensions.k8s.io_v1beta1-customresourcedefinition-crontabs.stable.example.com.yaml"),
+		renderedManifestPath("out", crd))
+}
+
+func TestWriteRenderedManifest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifests")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "config", "annotations": map[string]interface{}{}},
+		"data":       map[string]interface{}{"key": "value", "unset": nil},
+	}}
+	assert.NoError(t, writeRenderedManifest(dir, obj))
+	text, err := ioutil.ReadFile(renderedManifestPath(dir, obj))
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"apiVersion: v1\ndata:\n  key: value\nkind: ConfigMap\nmetadata:\n  name: config\n",
+		string(text))
+
+	assert.NoError(t, removeRenderedManifest(dir, obj))
+	_, err = os.Stat(renderedManifestPath(dir, obj))
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, removeRenderedManifest(dir, obj), "Removing a missing manifest is a no-op")
+}
@@ -0,0 +1,245 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	structpb "github.com/golang/protobuf/ptypes/struct"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// --------------------------------------------------------------------------
+
+// Secret data.
+//
+// The `data` and `stringData` of a Secret are credentials, so (unless the provider is configured
+// with `plaintextSecretData`) we mark them as Pulumi secrets in the checkpoint, which makes the
+// engine encrypt them in state and mask them in the CLI, and we redact them from rendered YAML
+// diffs. The last-applied-configuration annotation (see `lastapplied.go`) contains the same data,
+// so we treat it the same way.
+
+// --------------------------------------------------------------------------
+
+// secretDataFields are the fields of a Secret that hold its data.
+var secretDataFields = []string{"data", "stringData"}
+
+// redactedSecretValue replaces the values of Secret data in rendered YAML diffs.
+const redactedSecretValue = "[secret]"
+
+// isSecretKind returns true if `gvk` is the kind of core/v1 Secrets.
+func isSecretKind(gvk schema.GroupVersionKind) bool {
+	return (gvk.Group == "" || gvk.Group == "core") && gvk.Kind == "Secret"
+}
+
+// hidesSecretData returns true if the data of `obj` must be kept secret.
+func (k *kubeProvider) hidesSecretData(obj *unstructured.Unstructured) bool {
+	return k.enableSecrets && !k.plaintextSecretData && obj != nil &&
+		isSecretKind(obj.GroupVersionKind())
+}
+
+// markSecretData marks the Secret data of the checkpoint object `checkpoint`, and of its inputs, as
+// secret.
+func markSecretData(checkpoint resource.PropertyMap) resource.PropertyMap {
+	markSecretFields(checkpoint)
+	if inputs, hasInputs := checkpoint["__inputs"]; hasInputs && inputs.IsObject() {
+		markSecretFields(inputs.ObjectValue())
+	}
+
+	markLastAppliedConfigSecret(checkpoint)
+	return checkpoint
+}
+
+// markLastAppliedConfigSecret marks the last-applied-configuration annotation of `obj` as secret.
+func markLastAppliedConfigSecret(obj resource.PropertyMap) {
+	if metadata, hasMetadata := obj["metadata"]; hasMetadata && metadata.IsObject() {
+		annotations, hasAnnotations := metadata.ObjectValue()["annotations"]
+		if hasAnnotations && annotations.IsObject() {
+			key := resource.PropertyKey(lastAppliedConfigAnnotation)
+			if config, exists := annotations.ObjectValue()[key]; exists && !config.IsSecret() {
+				annotations.ObjectValue()[key] = resource.MakeSecret(config)
+			}
+		}
+	}
+}
+
+func markSecretFields(obj resource.PropertyMap) {
+	for _, field := range secretDataFields {
+		key := resource.PropertyKey(field)
+		if value, exists := obj[key]; exists && !value.IsNull() && !value.IsSecret() {
+			obj[key] = resource.MakeSecret(value)
+		}
+	}
+}
+
+// redactSecretData returns a copy of the Secret `obj` whose data values (though not keys) are
+// replaced with `redactedSecretValue`.
+func redactSecretData(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	redacted := obj.DeepCopy()
+	for _, field := range secretDataFields {
+		data, isMap := redacted.Object[field].(map[string]interface{})
+		if !isMap {
+			continue
+		}
+		for key := range data {
+			data[key] = redactedSecretValue
+		}
+	}
+
+	redactLastAppliedConfig(redacted)
+	return redacted
+}
+
+func redactLastAppliedConfig(obj *unstructured.Unstructured) {
+	annotations := obj.GetAnnotations()
+	if _, exists := annotations[lastAppliedConfigAnnotation]; exists {
+		annotations[lastAppliedConfigAnnotation] = redactedSecretValue
+		obj.SetAnnotations(annotations)
+	}
+}
+
+// --------------------------------------------------------------------------
+
+// Secret inputs.
+//
+// Any input value can be a Pulumi secret, e.g., a Helm chart value read from secret config.
+// Secrets are unwrapped when inputs are unmarshaled, so we record where they were, and mark the
+// values at the same paths as secret in the inputs `Check` returns and in the checkpoint (along
+// with the last-applied-configuration annotation, which contains them too), and redact them from
+// rendered YAML diffs.
+
+// --------------------------------------------------------------------------
+
+// propertyPath is the path of a value in an object: a string for each key of a map, and an int for
+// each index of a list.
+type propertyPath []interface{}
+
+// secretInputPaths returns the paths of the secret values of `props`, which are either the inputs
+// of a resource or its checkpoint object, in which case the paths are those of its inputs.
+func secretInputPaths(props *structpb.Struct) ([]propertyPath, error) {
+	pm, err := plugin.UnmarshalProperties(props, plugin.MarshalOptions{
+		KeepUnknowns: true, SkipNulls: true, KeepSecrets: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if inputs, hasInputs := pm["__inputs"]; hasInputs {
+		if inputs.IsSecret() {
+			inputs = inputs.SecretValue().Element
+		}
+		if inputs.IsObject() {
+			pm = inputs.ObjectValue()
+		}
+	}
+	return secretPaths(resource.NewObjectProperty(pm), nil), nil
+}
+
+func secretPaths(value resource.PropertyValue, path propertyPath) []propertyPath {
+	var paths []propertyPath
+	switch {
+	case value.IsSecret():
+		paths = append(paths, append(propertyPath{}, path...))
+	case value.IsObject():
+		for key, elem := range value.ObjectValue() {
+			paths = append(paths, secretPaths(elem, append(path, string(key)))...)
+		}
+	case value.IsArray():
+		for i, elem := range value.ArrayValue() {
+			paths = append(paths, secretPaths(elem, append(path, i))...)
+		}
+	}
+	return paths
+}
+
+// markSecretInputs marks the values of the checkpoint object `checkpoint`, and of its inputs, at
+// `paths` as secret.
+func markSecretInputs(checkpoint resource.PropertyMap, paths []propertyPath) resource.PropertyMap {
+	if len(paths) == 0 {
+		return checkpoint
+	}
+	markSecretPaths(checkpoint, paths)
+	if inputs, hasInputs := checkpoint["__inputs"]; hasInputs && inputs.IsObject() {
+		markSecretPaths(inputs.ObjectValue(), paths)
+	}
+	markLastAppliedConfigSecret(checkpoint)
+	return checkpoint
+}
+
+// markSecretPaths marks the values of `obj` at `paths`, where they exist, as secret.
+func markSecretPaths(obj resource.PropertyMap, paths []propertyPath) resource.PropertyMap {
+	for _, path := range paths {
+		markSecretPath(resource.NewObjectProperty(obj), path)
+	}
+	return obj
+}
+
+func markSecretPath(value resource.PropertyValue, path propertyPath) resource.PropertyValue {
+	if value.IsSecret() {
+		return value
+	}
+	if len(path) == 0 {
+		return resource.MakeSecret(value)
+	}
+	switch key := path[0].(type) {
+	case string:
+		if value.IsObject() {
+			if elem, exists := value.ObjectValue()[resource.PropertyKey(key)]; exists {
+				value.ObjectValue()[resource.PropertyKey(key)] = markSecretPath(elem, path[1:])
+			}
+		}
+	case int:
+		if value.IsArray() && key < len(value.ArrayValue()) {
+			elems := value.ArrayValue()
+			elems[key] = markSecretPath(elems[key], path[1:])
+		}
+	}
+	return value
+}
+
+// redactSecretPaths returns a copy of `obj` whose values at `paths` are replaced with
+// `redactedSecretValue`.
+func redactSecretPaths(
+	obj *unstructured.Unstructured, paths []propertyPath,
+) *unstructured.Unstructured {
+	if len(paths) == 0 {
+		return obj
+	}
+	redacted := obj.DeepCopy()
+	for _, path := range paths {
+		redactSecretPath(redacted.Object, path)
+	}
+	redactLastAppliedConfig(redacted)
+	return redacted
+}
+
+func redactSecretPath(value interface{}, path propertyPath) interface{} {
+	if len(path) == 0 {
+		return redactedSecretValue
+	}
+	switch key := path[0].(type) {
+	case string:
+		if obj, isMap := value.(map[string]interface{}); isMap {
+			if elem, exists := obj[key]; exists {
+				obj[key] = redactSecretPath(elem, path[1:])
+			}
+		}
+	case int:
+		if elems, isList := value.([]interface{}); isList && key < len(elems) {
+			elems[key] = redactSecretPath(elems[key], path[1:])
+		}
+	}
+	return value
+}
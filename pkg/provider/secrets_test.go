@@ -0,0 +1,191 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/googleapis/gnostic/OpenAPIv2"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/resource/plugin"
+	pulumirpc "github.com/pulumi/pulumi/sdk/proto/go"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+)
+
+func secretTestObject() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name": "creds",
+			"annotations": map[string]interface{}{
+				lastAppliedConfigAnnotation: `{"data":{"password":"aHVudGVyMg=="}}`,
+			},
+		},
+		"data": map[string]interface{}{"password": "aHVudGVyMg=="},
+		"type": "Opaque",
+	}}
+}
+
+func TestMarkSecretData(t *testing.T) {
+	obj := secretTestObject()
+	checkpoint := markSecretData(checkpointObject(obj, obj))
+
+	assert.True(t, checkpoint["data"].IsSecret())
+	assert.False(t, checkpoint["type"].IsSecret())
+	assert.True(t, checkpoint["__inputs"].ObjectValue()["data"].IsSecret())
+	annotations := checkpoint["metadata"].ObjectValue()["annotations"].ObjectValue()
+	assert.True(t, annotations[resource.PropertyKey(lastAppliedConfigAnnotation)].IsSecret())
+}
+
+func TestHidesSecretData(t *testing.T) {
+	obj := secretTestObject()
+	assert.True(t, (&kubeProvider{enableSecrets: true}).hidesSecretData(obj))
+	assert.False(t, (&kubeProvider{}).hidesSecretData(obj))
+	assert.False(t,
+		(&kubeProvider{enableSecrets: true, plaintextSecretData: true}).hidesSecretData(obj))
+
+	configMap := obj.DeepCopy()
+	configMap.SetKind("ConfigMap")
+	assert.False(t, (&kubeProvider{enableSecrets: true}).hidesSecretData(configMap))
+}
+
+// checkTestDiscovery is a discovery client for `Check` that serves Secrets, but no OpenAPI schema.
+type checkTestDiscovery struct {
+	discovery.CachedDiscoveryInterface
+}
+
+func (checkTestDiscovery) ServerVersion() (*version.Info, error) {
+	return &version.Info{Major: "1", Minor: "16", GitVersion: "v1.16.0"}, nil
+}
+
+func (checkTestDiscovery) OpenAPISchema() (*openapi_v2.Document, error) {
+	return nil, errors.NewNotFound(schema.GroupResource{}, "openapi/v2")
+}
+
+func (checkTestDiscovery) ServerResourcesForGroupVersion(
+	groupVersion string,
+) (*metav1.APIResourceList, error) {
+	return &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: []metav1.APIResource{
+		{Name: "secrets", Namespaced: true, Kind: "Secret"},
+	}}, nil
+}
+
+func TestCheckMarksSecretData(t *testing.T) {
+	news := resource.NewPropertyMapFromMap(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "creds"},
+		"data":       map[string]interface{}{"password": "aHVudGVyMg=="},
+		"stringData": map[string]interface{}{"username": "admin"},
+	})
+	props, err := plugin.MarshalProperties(news, plugin.MarshalOptions{})
+	assert.NoError(t, err)
+
+	k := &kubeProvider{
+		client:         checkTestDiscovery{},
+		enableSecrets:  true,
+		providerPrefix: "kubernetes" + gvkDelimiter,
+	}
+	resp, err := k.Check(context.Background(), &pulumirpc.CheckRequest{
+		Urn:  string(patchTestURN("kubernetes:core/v1:Secret", "creds")),
+		News: props,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	inputs, err := plugin.UnmarshalProperties(resp.GetInputs(), plugin.MarshalOptions{
+		KeepSecrets: true,
+	})
+	assert.NoError(t, err)
+	assert.True(t, inputs["data"].IsSecret())
+	assert.True(t, inputs["stringData"].IsSecret())
+	assert.False(t, inputs["metadata"].IsSecret())
+}
+
+func TestRedactSecretData(t *testing.T) {
+	obj := secretTestObject()
+	redacted := redactSecretData(obj)
+
+	assert.Equal(t, map[string]interface{}{"password": redactedSecretValue}, redacted.Object["data"])
+	assert.Equal(t, redactedSecretValue, redacted.GetAnnotations()[lastAppliedConfigAnnotation])
+	assert.Equal(t, "aHVudGVyMg==",
+		obj.Object["data"].(map[string]interface{})["password"], "Object should not be modified")
+}
+
+func secretInputsTestObject() resource.PropertyMap {
+	return resource.PropertyMap{
+		"apiVersion": resource.NewStringProperty("v1"),
+		"kind":       resource.NewStringProperty("ConfigMap"),
+		"metadata": resource.NewObjectProperty(resource.PropertyMap{
+			"name": resource.NewStringProperty("config"),
+		}),
+		"data": resource.NewObjectProperty(resource.PropertyMap{
+			"token": resource.MakeSecret(resource.NewStringProperty("hunter2")),
+			"users": resource.NewArrayProperty([]resource.PropertyValue{
+				resource.NewStringProperty("alice"),
+				resource.MakeSecret(resource.NewStringProperty("bob")),
+			}),
+		}),
+	}
+}
+
+func TestSecretInputPaths(t *testing.T) {
+	props, err := plugin.MarshalProperties(secretInputsTestObject(), plugin.MarshalOptions{
+		KeepSecrets: true,
+	})
+	assert.NoError(t, err)
+	paths, err := secretInputPaths(props)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.Contains(t, paths, propertyPath{"data", "token"})
+	assert.Contains(t, paths, propertyPath{"data", "users", 1})
+
+	checkpoint := secretInputsTestObject()
+	checkpoint["__inputs"] = resource.NewObjectProperty(secretInputsTestObject())
+	props, err = plugin.MarshalProperties(checkpoint, plugin.MarshalOptions{KeepSecrets: true})
+	assert.NoError(t, err)
+	paths, err = secretInputPaths(props)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 2)
+	assert.Contains(t, paths, propertyPath{"data", "token"})
+	assert.Contains(t, paths, propertyPath{"data", "users", 1})
+}
+
+func TestMarkSecretInputs(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "config"},
+		"data": map[string]interface{}{
+			"token": "hunter2",
+			"users": []interface{}{"alice", "bob"},
+		},
+	}}
+	paths := []propertyPath{{"data", "token"}, {"data", "users", 1}, {"data", "missing"}}
+	checkpoint := markSecretInputs(checkpointObject(obj, obj), paths)
+
+	for _, pm := range []resource.PropertyMap{checkpoint, checkpoint["__inputs"].ObjectValue()} {
+		data := pm["data"].ObjectValue()
+		assert.True(t, data["token"].IsSecret())
+		assert.False(t, data["users"].ArrayValue()[0].IsSecret())
+		assert.True(t, data["users"].ArrayValue()[1].IsSecret())
+		assert.NotContains(t, data, resource.PropertyKey("missing"))
+		assert.False(t, pm["kind"].IsSecret())
+	}
+
+	redacted := redactSecretPaths(obj, paths)
+	assert.Equal(t, map[string]interface{}{
+		"token": redactedSecretValue,
+		"users": []interface{}{"alice", redactedSecretValue},
+	}, redacted.Object["data"])
+	assert.Equal(t, "hunter2",
+		obj.Object["data"].(map[string]interface{})["token"], "Object should not be modified")
+}
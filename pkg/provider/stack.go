@@ -0,0 +1,203 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/await"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
+	"github.com/pulumi/pulumi/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// --------------------------------------------------------------------------
+
+// Stack labels and Namespace deletion protection.
+//
+// We label every object we create or update with the project and stack that manage it, so that we
+// can find the objects a stack manages in the cluster. Labels (unlike inputs) aren't part of the
+// checkpoint the engine diffs, so adding them doesn't cause spurious updates; and they are left out
+// of the last-applied configuration, which records only what the program asked for.
+//
+// Deleting a Namespace deletes everything in it. When a program stops declaring a Namespace, but
+// still declares objects in it, that would silently delete objects the stack still manages (and
+// which it would then recreate, or fail to update). So, before deleting a Namespace, we look for
+// objects labeled as managed by the same stack that remain in it, and refuse to delete it if there
+// are any, unless it has the `pulumi.com/forceDelete` annotation. Since the engine may delete a
+// Namespace concurrently with the objects in it (e.g., during `pulumi destroy`), objects that are
+// already being deleted don't count, and we give the others `namespaceContentsTimeout` to go away.
+
+// --------------------------------------------------------------------------
+
+const (
+	projectLabel = "pulumi.com/project"
+	stackLabel   = "pulumi.com/stack"
+)
+
+var (
+	namespaceContentsTimeout  = 30 * time.Second
+	namespaceContentsInterval = 2 * time.Second
+)
+
+// stackLabels returns the labels that identify the objects managed by the stack of `urn`.
+func stackLabels(urn resource.URN) map[string]string {
+	return map[string]string{
+		projectLabel: labelValue(string(urn.Project())),
+		stackLabel:   labelValue(string(urn.Stack())),
+	}
+}
+
+// labelValue returns `s`, modified as little as possible to be a valid label value.
+func labelValue(s string) string {
+	value := strings.Map(func(r rune) rune {
+		if isAlphanumeric(r) || r == '-' || r == '_' || r == '.' {
+			return r
+		}
+		return '_'
+	}, s)
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	return strings.TrimFunc(value, func(r rune) bool { return !isAlphanumeric(r) })
+}
+
+func isAlphanumeric(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// withStackLabels returns a copy of `obj` labeled as managed by the stack of `urn`.
+func withStackLabels(obj *unstructured.Unstructured, urn resource.URN) *unstructured.Unstructured {
+	labeled := obj.DeepCopy()
+	objLabels := labeled.GetLabels()
+	if objLabels == nil {
+		objLabels = map[string]string{}
+	}
+	for key, value := range stackLabels(urn) {
+		objLabels[key] = value
+	}
+	labeled.SetLabels(objLabels)
+	return labeled
+}
+
+// withoutStackLabels removes the stack labels from `obj`, e.g., so that they don't become part of
+// the inputs of an imported object.
+func withoutStackLabels(obj *unstructured.Unstructured) {
+	objLabels := obj.GetLabels()
+	delete(objLabels, projectLabel)
+	delete(objLabels, stackLabel)
+	if len(objLabels) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "labels")
+	} else {
+		obj.SetLabels(objLabels)
+	}
+}
+
+// isNamespace returns true if `gvk` is the kind of Namespaces.
+func isNamespace(gvk schema.GroupVersionKind) bool {
+	return gvk.Group == "" && gvk.Kind == "Namespace"
+}
+
+// checkNamespaceUnused returns an error if objects managed by the stack of `urn` remain in the
+// Namespace `name`, and won't be deleted, unless `ns` has the `pulumi.com/forceDelete` annotation.
+func (k *kubeProvider) checkNamespaceUnused(
+	urn resource.URN, name string, ns *unstructured.Unstructured,
+) error {
+	if ns.GetAnnotations()[await.AnnotationForceDelete] == "true" {
+		return nil
+	}
+
+	deadline := time.Now().Add(namespaceContentsTimeout)
+	for {
+		remaining := k.stackObjectsInNamespace(urn, name)
+		if len(remaining) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf(
+				"refusing to delete Namespace '%s', which still contains objects managed by this "+
+					"stack: %s. Delete these objects (or move them to another Namespace) first, or set "+
+					"the '%s' annotation of the Namespace to \"true\" to delete it and everything in it",
+				name, strings.Join(remaining, ", "), await.AnnotationForceDelete)
+		}
+
+		select {
+		case <-k.canceler.context.Done():
+			return fmt.Errorf("deletion of Namespace '%s' was cancelled", name)
+		case <-time.After(namespaceContentsInterval):
+		}
+	}
+}
+
+// stackObjectsInNamespace returns the kinds and names of the objects managed by the stack of `urn`
+// that remain in the Namespace `namespace`, and aren't being deleted. Kinds we can't list are
+// skipped.
+func (k *kubeProvider) stackObjectsInNamespace(urn resource.URN, namespace string) []string {
+	// NOTE: Discovery fails partially if an aggregated API is unavailable; we can still check the
+	// rest.
+	resourceLists, err := k.client.ServerPreferredNamespacedResources()
+	if err != nil {
+		glog.V(3).Infof("Unable to discover all namespaced kinds: %v", err)
+	}
+
+	selector := labels.SelectorFromSet(stackLabels(urn)).String()
+	var remaining []string
+	for _, resourceList := range resourceLists {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			if !hasVerb(apiResource, "list") {
+				continue
+			}
+			clientForResource, err := client.FromGVK(k.pool, k.client, gv.WithKind(apiResource.Kind),
+				namespace)
+			if err != nil {
+				glog.V(3).Infof("Unable to make client for %s: %v", apiResource.Kind, err)
+				continue
+			}
+			list, err := clientForResource.List(metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				glog.V(3).Infof("Unable to list %s in '%s': %v", apiResource.Name, namespace, err)
+				continue
+			}
+			for _, obj := range list.(*unstructured.UnstructuredList).Items {
+				if obj.GetDeletionTimestamp() == nil {
+					remaining = append(remaining, fmt.Sprintf("%s '%s'", obj.GetKind(), obj.GetName()))
+				}
+			}
+		}
+	}
+	sort.Strings(remaining)
+	return remaining
+}
+
+func hasVerb(apiResource metav1.APIResource, verb string) bool {
+	for _, v := range apiResource.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
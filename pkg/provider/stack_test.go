@@ -0,0 +1,50 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/resource"
+	"github.com/pulumi/pulumi/pkg/tokens"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestLabelValue(t *testing.T) {
+	assert.Equal(t, "my-stack_1.0", labelValue("my-stack_1.0"))
+	assert.Equal(t, "acme_prod", labelValue("acme/prod"))
+	assert.Equal(t, "prod", labelValue("-prod-"))
+	assert.Len(t, labelValue(strings.Repeat("a", 100)), 63)
+}
+
+func TestStackLabels(t *testing.T) {
+	urn := resource.NewURN("dev", "web", "", tokens.Type("kubernetes:core/v1:ConfigMap"),
+		tokens.QName("settings"))
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":   "settings",
+			"labels": map[string]interface{}{"app": "web"},
+		},
+	}}
+
+	labeled := withStackLabels(obj, urn)
+	assert.Equal(t,
+		map[string]string{"app": "web", projectLabel: "web", stackLabel: "dev"},
+		labeled.GetLabels())
+	assert.Equal(t, map[string]string{"app": "web"}, obj.GetLabels(), "Inputs should not be modified")
+
+	withoutStackLabels(labeled)
+	assert.Equal(t, obj, labeled)
+
+	unlabeled := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "settings"},
+	}}
+	labeled = withStackLabels(unlabeled, urn)
+	withoutStackLabels(labeled)
+	assert.Equal(t, unlabeled, labeled)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/pulumi/pulumi-kubernetes/pkg/await"
+	"github.com/pulumi/pulumi-kubernetes/pkg/openapi"
+	"github.com/pulumi/pulumi/pkg/diag"
+	"github.com/pulumi/pulumi/pkg/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// renderedDiffIgnoredFields are the paths of fields omitted from rendered YAML diffs, because the
+// API server changes them on every write (or they are not part of the desired state at all), so
+// they would only add noise. This mirrors what `kubectl diff` hides.
+var renderedDiffIgnoredFields = [][]string{
+	{"status"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "generation"},
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "selfLink"},
+	{"metadata", "uid"},
+}
+
+// renderYAMLDiff renders a unified diff between the YAML representations of the `live` object and
+// the `proposed` object, e.g., for display in `pulumi preview --diff`. Returns "" if the two are
+// identical, ignoring `renderedDiffIgnoredFields`.
+func renderYAMLDiff(live, proposed *unstructured.Unstructured) (string, error) {
+	liveYAML, err := renderedDiffYAML(live)
+	if err != nil {
+		return "", err
+	}
+	proposedYAML, err := renderedDiffYAML(proposed)
+	if err != nil {
+		return "", err
+	}
+
+	name := live.GetName()
+	if ns := live.GetNamespace(); ns != "" {
+		name = ns + "/" + name
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(proposedYAML),
+		FromFile: fmt.Sprintf("%s %s (live)", live.GetKind(), name),
+		ToFile:   fmt.Sprintf("%s %s (proposed)", proposed.GetKind(), name),
+		Context:  3,
+	})
+}
+
+func renderedDiffYAML(obj *unstructured.Unstructured) (string, error) {
+	pruned := obj.DeepCopy()
+	for _, path := range renderedDiffIgnoredFields {
+		unstructured.RemoveNestedField(pruned.Object, path...)
+	}
+	text, err := yaml.Marshal(pruned.Object)
+	if err != nil {
+		return "", err
+	}
+	return string(text), nil
+}
+
+// logYAMLDiff reports the rendered YAML diff between the live object and the object the API server
+// would store after the update, with the values of the secret inputs `secrets` redacted. Failure to
+// render the diff is not fatal to the preview.
+func (k *kubeProvider) logYAMLDiff(
+	ctx context.Context, urn resource.URN,
+	oldInputs, newInputs, oldLive *unstructured.Unstructured, replace bool, secrets []propertyPath,
+) {
+	if k.host == nil {
+		return
+	}
+
+	if !replace && k.enableDryRun && k.client != nil && !k.offline {
+		if live, proposed, ok := k.dryRunUpdate(ctx, urn, oldInputs, newInputs); ok {
+			k.logRenderedYAMLDiff(ctx, urn, live, proposed, secrets)
+			return
+		}
+	}
+
+	if _, compacted := oldLive.Object[contentHashKey]; compacted {
+		return
+	}
+
+	proposed := newInputs
+	if !replace && k.client != nil {
+		preview, err := openapi.PreviewResourceUpdate(k.client, oldInputs, newInputs, oldLive)
+		if err != nil {
+			glog.V(3).Infof("Unable to preview update of %s: %v", urn, err)
+		} else {
+			proposed = preview
+		}
+	}
+	k.logRenderedYAMLDiff(ctx, urn, oldLive, proposed, secrets)
+}
+
+// dryRunUpdate returns the current live object, and the object the API server would store after
+// the update, as computed with a server-side dry run. If the dry run fails, e.g., because an
+// admission webhook would reject the update, it reports why, and returns false, so that the diff
+// is rendered from a client-side preview instead.
+func (k *kubeProvider) dryRunUpdate(
+	ctx context.Context, urn resource.URN, oldInputs, newInputs *unstructured.Unstructured,
+) (live, proposed *unstructured.Unstructured, ok bool) {
+	live, err := k.readLiveObject(oldInputs)
+	if err != nil {
+		glog.V(3).Infof("Unable to read live object of %s for dry run: %v", urn, err)
+		return nil, nil, false
+	}
+
+	submitted, err := k.submittedObject(urn, newInputs)
+	if err != nil {
+		glog.V(3).Infof("Unable to prepare %s for dry run: %v", urn, err)
+		return nil, nil, false
+	}
+
+	proposed, err = await.DryRunUpdate(k.client, k.awaitOptions, oldInputs, submitted, live)
+	if err != nil {
+		_ = k.host.Log(ctx, diag.Warning, urn,
+			fmt.Sprintf("Server-side dry run of the update failed: %v", err))
+		return nil, nil, false
+	}
+	return live, proposed, true
+}
+
+// logRenderedYAMLDiff reports the rendered YAML diff between `live` and `proposed`, if any.
+func (k *kubeProvider) logRenderedYAMLDiff(
+	ctx context.Context, urn resource.URN, live, proposed *unstructured.Unstructured,
+	secrets []propertyPath,
+) {
+	if k.hidesSecretData(proposed) {
+		live, proposed = redactSecretData(live), redactSecretData(proposed)
+	}
+	live, proposed = redactSecretPaths(live, secrets), redactSecretPaths(proposed, secrets)
+	rendered, err := renderYAMLDiff(live, proposed)
+	if err != nil {
+		glog.V(3).Infof("Unable to render YAML diff of %s: %v", urn, err)
+		return
+	}
+	if rendered != "" {
+		_ = k.host.Log(ctx, diag.Info, urn, rendered)
+	}
+}
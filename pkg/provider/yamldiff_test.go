@@ -0,0 +1,43 @@
+// Copyright 2016-2018, Pulumi Corporation.  All rights reserved.
+
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRenderYAMLDiff(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "settings",
+			"namespace":       "default",
+			"resourceVersion": "1234",
+			"uid":             "5e3a4c1e",
+		},
+		"data": map[string]interface{}{"mode": "fast", "level": "1"},
+	}}
+
+	proposed := live.DeepCopy()
+	proposed.Object["data"].(map[string]interface{})["mode"] = "safe"
+	proposed.Object["metadata"].(map[string]interface{})["resourceVersion"] = "1235"
+
+	rendered, err := renderYAMLDiff(live, proposed)
+	assert.NoError(t, err)
+	assert.Contains(t, rendered, "--- ConfigMap default/settings (live)")
+	assert.Contains(t, rendered, "+++ ConfigMap default/settings (proposed)")
+	assert.Contains(t, rendered, "-  mode: fast")
+	assert.Contains(t, rendered, "+  mode: safe")
+	assert.False(t, strings.Contains(rendered, "resourceVersion"),
+		"server-managed fields should not be rendered")
+
+	unchanged, err := renderYAMLDiff(live, live.DeepCopy())
+	assert.NoError(t, err)
+	assert.Equal(t, "", unchanged)
+}
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/pulumi/pulumi-kubernetes/pkg/client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
@@ -51,7 +52,11 @@ func ForObject(
 		ctx:     ctx,
 		objName: name,
 		pollFunc: func() (*unstructured.Unstructured, error) {
-			obj, err := clientForResource.Get(name, metav1.GetOptions{})
+			var obj *unstructured.Unstructured
+			err := client.RetryUnauthorized(func() (err error) {
+				obj, err = clientForResource.Get(name, metav1.GetOptions{})
+				return err
+			})
 			if err != nil {
 				// Log the error.
 				glog.V(3).Infof("Received error polling for '%s': %#v", name, err)
@@ -105,11 +110,8 @@ func (ow *ObjectWatcher) RetryUntil(r Retry, timeout time.Duration) error {
 func (ow *ObjectWatcher) watch(
 	until func(*unstructured.Unstructured, error) (bool, error), timeout time.Duration,
 ) error {
-	timeoutCh := make(chan struct{})
-	go func() {
-		time.Sleep(timeout)
-		timeoutCh <- struct{}{}
-	}()
+	// NOTE: The timeout is extended by any time spent throttled by the API server.
+	timeoutCh := client.ThrottledAfter(timeout)
 
 	results := make(chan result)
 	poll := func() {
@@ -21,6 +21,9 @@ go build \
    -o "${WORK_PATH}/pulumi-resource-kubernetes${BIN_SUFFIX}" \
    "${ROOT}/cmd/pulumi-resource-kubernetes"
 
+# Bundle the schemas of offline mode next to the plugin.
+cp -R "${ROOT}/schemas" "${WORK_PATH}/schemas"
+
 # Tar up the plugin
 tar -czf ${PLUGIN_PACKAGE_PATH} -C ${WORK_PATH} .
 